@@ -142,6 +142,13 @@ var (
 		Args:  cobra.MinimumNArgs(3),
 		RunE:  cmdValueAttribution,
 	}
+	bucketTagUsageCmd = &cobra.Command{
+		Use:   "bucket-tag-usage [tag key] [start] [end]",
+		Short: "Generate a usage report grouped by the value of a bucket tag",
+		Long:  "Generate a usage report of the buckets with the tag, grouped by the tag value, e.g. by team or cost centre. Format dates using YYYY-MM-DD. The end date is exclusive.",
+		Args:  cobra.MinimumNArgs(3),
+		RunE:  cmdBucketTagUsage,
+	}
 	reportsGracefulExitCmd = &cobra.Command{
 		Use:   "graceful-exit [start] [end]",
 		Short: "Generate a graceful exit report",
@@ -277,6 +284,11 @@ var (
 		Database string `help:"satellite database connection string" releaseDefault:"postgres://" devDefault:"postgres://"`
 		Output   string `help:"destination of report output" default:""`
 	}
+	bucketTagUsageCfg struct {
+		Database         string `help:"satellite database connection string" releaseDefault:"postgres://" devDefault:"postgres://"`
+		MetabaseDatabase string `help:"satellite metabase connection string" releaseDefault:"postgres://" devDefault:"postgres://"`
+		Output           string `help:"destination of report output" default:""`
+	}
 	reportsGracefulExitCfg struct {
 		Database  string `help:"satellite database connection string" releaseDefault:"postgres://" devDefault:"postgres://"`
 		Output    string `help:"destination of report output" default:""`
@@ -314,6 +326,7 @@ func init() {
 	rootCmd.AddCommand(restoreTrashCmd)
 	reportsCmd.AddCommand(nodeUsageCmd)
 	reportsCmd.AddCommand(partnerAttributionCmd)
+	reportsCmd.AddCommand(bucketTagUsageCmd)
 	reportsCmd.AddCommand(reportsGracefulExitCmd)
 	reportsCmd.AddCommand(reportsVerifyGEReceiptCmd)
 	compensationCmd.AddCommand(generateInvoicesCmd)
@@ -343,6 +356,7 @@ func init() {
 	process.Bind(reportsGracefulExitCmd, &reportsGracefulExitCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(reportsVerifyGEReceiptCmd, &reportsVerifyGracefulExitReceiptCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(partnerAttributionCmd, &partnerAttribtionCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(bucketTagUsageCmd, &bucketTagUsageCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(prepareCustomerInvoiceRecordsCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(createCustomerInvoiceItemsCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(createCustomerInvoiceCouponsCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
@@ -685,6 +699,39 @@ func cmdValueAttribution(cmd *cobra.Command, args []string) (err error) {
 	return reports.GenerateAttributionCSV(ctx, partnerAttribtionCfg.Database, partnerID, start, end, file)
 }
 
+func cmdBucketTagUsage(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+	log := zap.L().Named("satellite-cli")
+
+	start, end, err := reports.ParseRange(args[1], args[2])
+	if err != nil {
+		return err
+	}
+
+	// send output to stdout
+	if bucketTagUsageCfg.Output == "" {
+		return reports.GenerateTagUsageCSV(ctx, bucketTagUsageCfg.Database, bucketTagUsageCfg.MetabaseDatabase, args[0], start, end, os.Stdout)
+	}
+
+	// send output to file
+	file, err := os.Create(bucketTagUsageCfg.Output)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = errs.Combine(err, file.Close())
+		if err != nil {
+			log.Error("Error closing the output file after retrieving bucket tag usage.",
+				zap.String("Output File", bucketTagUsageCfg.Output),
+				zap.Error(err),
+			)
+		}
+	}()
+
+	return reports.GenerateTagUsageCSV(ctx, bucketTagUsageCfg.Database, bucketTagUsageCfg.MetabaseDatabase, args[0], start, end, file)
+}
+
 func cmdPrepareCustomerInvoiceRecords(cmd *cobra.Command, args []string) (err error) {
 	ctx, _ := process.Ctx(cmd)
 
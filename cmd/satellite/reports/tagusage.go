@@ -0,0 +1,154 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reports
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/accounting"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/satellitedb"
+)
+
+var tagUsageHeaders = []string{
+	"tagValue",
+	"buckets",
+	"gbHours:Storage",
+	"gb:GetEgress",
+	"objectHours",
+}
+
+// TagUsage contains the usage of all buckets with the same value of a bucket tag.
+type TagUsage struct {
+	Value   string
+	Buckets int
+
+	TotalStoredData float64
+	GetEgress       float64
+	ObjectCount     float64
+}
+
+// GroupUsageByTag sums bucket usage rollups by the value of the tag key. Buckets
+// without the tag are skipped.
+func GroupUsageByTag(key string, tags []metabase.BucketTags, rollups []accounting.BucketUsageRollup) []TagUsage {
+	values := make(map[metabase.BucketLocation]string, len(tags))
+	for _, bucket := range tags {
+		if value, ok := bucket.Tags[key]; ok {
+			values[bucket.BucketLocation] = value
+		}
+	}
+
+	byValue := make(map[string]*TagUsage)
+	for _, rollup := range rollups {
+		value, ok := values[metabase.BucketLocation{
+			ProjectID:  rollup.ProjectID,
+			BucketName: string(rollup.BucketName),
+		}]
+		if !ok {
+			continue
+		}
+
+		usage, ok := byValue[value]
+		if !ok {
+			usage = &TagUsage{Value: value}
+			byValue[value] = usage
+		}
+		usage.Buckets++
+		usage.TotalStoredData += rollup.TotalStoredData
+		usage.GetEgress += rollup.GetEgress
+		usage.ObjectCount += rollup.ObjectCount
+	}
+
+	usages := make([]TagUsage, 0, len(byValue))
+	for _, usage := range byValue {
+		usages = append(usages, *usage)
+	}
+	sort.Slice(usages, func(i, k int) bool {
+		return usages[i].Value < usages[k].Value
+	})
+	return usages
+}
+
+// GenerateTagUsageCSV creates a report of the usage of the tagged buckets grouped
+// by the value of a bucket tag.
+func GenerateTagUsageCSV(ctx context.Context, database, metabaseDatabase, key string, start time.Time, end time.Time, output io.Writer) (err error) {
+	log := zap.L().Named("db")
+	db, err := satellitedb.Open(ctx, log, database, satellitedb.Options{ApplicationName: "satellite-tag-usage"})
+	if err != nil {
+		return errs.New("error connecting to master database on satellite: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	metabaseDB, err := metabase.Open(ctx, log.Named("metabase"), metabaseDatabase)
+	if err != nil {
+		return errs.New("error connecting to metabase on satellite: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, metabaseDB.Close())
+	}()
+
+	tags, err := metabaseDB.ListBucketTags(ctx, metabase.ListBucketTags{})
+	if err != nil {
+		return errs.Wrap(err)
+	}
+
+	projects := make(map[uuid.UUID]struct{})
+	for _, bucket := range tags {
+		if _, ok := bucket.Tags[key]; ok {
+			projects[bucket.ProjectID] = struct{}{}
+		}
+	}
+
+	var rollups []accounting.BucketUsageRollup
+	for projectID := range projects {
+		projectRollups, err := db.ProjectAccounting().GetBucketUsageRollups(ctx, projectID, start, end)
+		if err != nil {
+			return errs.Wrap(err)
+		}
+		rollups = append(rollups, projectRollups...)
+	}
+
+	w := csv.NewWriter(output)
+	defer func() {
+		w.Flush()
+	}()
+
+	if err := w.Write(tagUsageHeaders); err != nil {
+		return errs.Wrap(err)
+	}
+
+	for _, usage := range GroupUsageByTag(key, tags, rollups) {
+		record := []string{
+			usage.Value,
+			strconv.Itoa(usage.Buckets),
+			strconv.FormatFloat(usage.TotalStoredData, 'f', 4, 64),
+			strconv.FormatFloat(usage.GetEgress, 'f', 4, 64),
+			strconv.FormatFloat(usage.ObjectCount, 'f', 4, 64),
+		}
+		if err := w.Write(record); err != nil {
+			return errs.Wrap(err)
+		}
+	}
+	if err := w.Error(); err != nil {
+		return errs.Wrap(err)
+	}
+
+	if output != os.Stdout {
+		fmt.Println("Generated report for bucket tag", key)
+	}
+	return nil
+}
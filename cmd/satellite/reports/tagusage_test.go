@@ -0,0 +1,52 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reports_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testrand"
+	"storj.io/storj/cmd/satellite/reports"
+	"storj.io/storj/satellite/accounting"
+	"storj.io/storj/satellite/metabase"
+)
+
+func TestGroupUsageByTag(t *testing.T) {
+	projectID := testrand.UUID()
+	bucket := func(name string) metabase.BucketLocation {
+		return metabase.BucketLocation{ProjectID: projectID, BucketName: name}
+	}
+	rollup := func(name string, stored, egress float64) accounting.BucketUsageRollup {
+		return accounting.BucketUsageRollup{
+			ProjectID:       projectID,
+			BucketName:      []byte(name),
+			TotalStoredData: stored,
+			GetEgress:       egress,
+			ObjectCount:     1,
+		}
+	}
+
+	tags := []metabase.BucketTags{
+		{BucketLocation: bucket("logs"), Tags: metabase.Tags{"team": "ops"}},
+		{BucketLocation: bucket("backups"), Tags: metabase.Tags{"team": "ops"}},
+		{BucketLocation: bucket("photos"), Tags: metabase.Tags{"team": "media"}},
+		{BucketLocation: bucket("other"), Tags: metabase.Tags{"env": "prod"}},
+	}
+	rollups := []accounting.BucketUsageRollup{
+		rollup("logs", 1, 2),
+		rollup("backups", 10, 20),
+		rollup("photos", 100, 200),
+		rollup("other", 1000, 2000),
+		rollup("untagged", 1000, 2000),
+	}
+
+	require.Equal(t, []reports.TagUsage{
+		{Value: "media", Buckets: 1, TotalStoredData: 100, GetEgress: 200, ObjectCount: 1},
+		{Value: "ops", Buckets: 2, TotalStoredData: 11, GetEgress: 22, ObjectCount: 2},
+	}, reports.GroupUsageByTag("team", tags, rollups))
+
+	require.Empty(t, reports.GroupUsageByTag("missing", tags, rollups))
+}
@@ -8,6 +8,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -20,6 +21,7 @@ import (
 
 	"storj.io/common/sync2"
 	"storj.io/private/version"
+	"storj.io/storj/private/version/release"
 )
 
 func binaryVersion(location string) (version.SemVer, error) {
@@ -41,7 +43,9 @@ func binaryVersion(location string) (version.SemVer, error) {
 	return version.SemVer{}, errs.New("unable to determine binary version")
 }
 
-func downloadBinary(ctx context.Context, url, target string) error {
+// downloadBinary downloads the archive at url and unpacks its binary to target.
+// When there is a release manifest, the archive must have its hash.
+func downloadBinary(ctx context.Context, url, target string, manifest *release.Manifest) error {
 	f, err := ioutil.TempFile("", createPattern(url))
 	if err != nil {
 		return errs.New("cannot create temporary archive: %v", err)
@@ -55,9 +59,15 @@ func downloadBinary(ctx context.Context, url, target string) error {
 
 	zap.L().Info("Download started.", zap.String("From", url), zap.String("To", f.Name()))
 
-	if err = downloadArchive(ctx, f, url); err != nil {
+	hash := sha256.New()
+	if err = downloadArchive(ctx, io.MultiWriter(f, hash), url); err != nil {
 		return errs.Wrap(err)
 	}
+	if manifest != nil {
+		if err = manifest.CheckHash(hash.Sum(nil)); err != nil {
+			return errs.Wrap(err)
+		}
+	}
 	if err = unpackBinary(ctx, f.Name(), target); err != nil {
 		return errs.Wrap(err)
 	}
@@ -21,6 +21,7 @@ import (
 	"storj.io/private/process"
 	"storj.io/private/version"
 	_ "storj.io/storj/private/version" // This attaches version information during release builds.
+	"storj.io/storj/private/version/release"
 	"storj.io/storj/storagenode"
 )
 
@@ -55,8 +56,10 @@ var (
 	runCfg struct {
 		storagenode.Config
 
-		BinaryLocation string `help:"the storage node executable binary location" default:"storagenode"`
-		ServiceName    string `help:"storage node OS service name" default:"storagenode"`
+		BinaryLocation string        `help:"the storage node executable binary location" default:"storagenode"`
+		ServiceName    string        `help:"storage node OS service name" default:"storagenode"`
+		ReleaseKey     string        `help:"hex-encoded ed25519 public key of the release manifests; when set, only binaries of verified releases are installed" default:""`
+		StartTimeout   time.Duration `help:"how long the updated storage node has to keep running, otherwise the previous binary is restored" default:"1m0s"`
 		// deprecated
 		Log string `help:"deprecated, use --log.output" default:""`
 	}
@@ -104,6 +107,12 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 		zap.L().Fatal("Empty node ID.")
 	}
 
+	if runCfg.ReleaseKey != "" {
+		if _, err := release.ParsePublicKey(runCfg.ReleaseKey); err != nil {
+			zap.L().Fatal("Invalid release key.", zap.Error(err))
+		}
+	}
+
 	zap.L().Info("Running on version",
 		zap.String("Service", updaterServiceName),
 		zap.String("Version", version.Build.Version.String()),
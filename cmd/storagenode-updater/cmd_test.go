@@ -6,6 +6,9 @@ package main_test
 import (
 	"archive/zip"
 	"compress/flate"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -14,6 +17,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"testing"
 	"time"
@@ -28,6 +32,7 @@ import (
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
 	"storj.io/private/version"
+	"storj.io/storj/private/version/release"
 	"storj.io/storj/versioncontrol"
 )
 
@@ -37,6 +42,18 @@ const (
 )
 
 func TestAutoUpdater(t *testing.T) {
+	testAutoUpdater(t, false, false)
+}
+
+func TestAutoUpdater_SignedRelease(t *testing.T) {
+	t.Run("valid", func(t *testing.T) { testAutoUpdater(t, true, false) })
+	t.Run("hash mismatch", func(t *testing.T) { testAutoUpdater(t, true, true) })
+}
+
+// testAutoUpdater runs the updater against a versioncontrol server. When
+// signed is set, the updater only accepts verified releases. When
+// corruptHash is set, the hashes in the release manifests are wrong.
+func testAutoUpdater(t *testing.T, signed, corruptHash bool) {
 	// TODO cleanup `.exe` extension for different OS
 
 	ctx := testcontext.New(t)
@@ -78,7 +95,14 @@ func TestAutoUpdater(t *testing.T) {
 	}
 
 	// run versioncontrol and update zips http servers
-	versionControlPeer, cleanupVersionControl := testVersionControlWithUpdates(ctx, t, updateBins)
+	var signingKey string
+	var releaseKey ed25519.PublicKey
+	if signed {
+		signingKey = ctx.File("release.key")
+		releaseKey, err = release.GenerateKey(signingKey)
+		require.NoError(t, err)
+	}
+	versionControlPeer, cleanupVersionControl := testVersionControlWithUpdates(ctx, t, updateBins, signingKey, corruptHash)
 	defer cleanupVersionControl()
 
 	logPath := ctx.File("storagenode-updater.log")
@@ -96,11 +120,25 @@ func TestAutoUpdater(t *testing.T) {
 		"--identity.key-path", identConfig.KeyPath,
 		"--log", logPath,
 	}
+	if signed {
+		args = append(args, "--release-key", hex.EncodeToString(releaseKey))
+	}
 
 	// NB: updater currently uses `log.SetOutput` so all output after that call
 	// only goes to the log file.
 	out, err := exec.Command(updaterPath, args...).CombinedOutput()
 	logData, logErr := ioutil.ReadFile(logPath)
+	if corruptHash {
+		require.NoError(t, err, string(out))
+		require.NoError(t, logErr)
+		require.Contains(t, string(logData), "hash mismatch")
+		require.NotContains(t, string(logData), "Service restarted successfully.")
+
+		versionOut, err := exec.Command(storagenodePath, "version").CombinedOutput()
+		require.NoError(t, err)
+		require.Contains(t, string(versionOut), oldVersion)
+		return
+	}
 	if assert.NoError(t, logErr) {
 		logStr := string(logData)
 		t.Log(logStr)
@@ -110,6 +148,9 @@ func TestAutoUpdater(t *testing.T) {
 		if !assert.Contains(t, logStr, `Service restarted successfully.	{"Service": "storagenode-updater"}`) {
 			t.Log(logStr)
 		}
+		if signed {
+			assert.Contains(t, logStr, "Release manifest verified.")
+		}
 	} else {
 		t.Log(string(out))
 	}
@@ -184,16 +225,23 @@ func testIdentityFiles(ctx *testcontext.Context, t *testing.T) identity.Config {
 	return identConfig
 }
 
-func testVersionControlWithUpdates(ctx *testcontext.Context, t *testing.T, updateBins map[string]string) (peer *versioncontrol.Peer, cleanup func()) {
+func testVersionControlWithUpdates(ctx *testcontext.Context, t *testing.T, updateBins map[string]string, signingKey string, corruptHash bool) (peer *versioncontrol.Peer, cleanup func()) {
 	t.Helper()
 
 	var mux http.ServeMux
+	hashes := map[string][]string{}
 	for name, src := range updateBins {
 		dst := ctx.File("updates", name+".zip")
 		zipBin(ctx, t, dst, src)
 		zipData, err := ioutil.ReadFile(dst)
 		require.NoError(t, err)
 
+		hash := sha256.Sum256(zipData)
+		if corruptHash {
+			hash[0]++
+		}
+		hashes[name] = []string{runtime.GOOS + "_" + runtime.GOARCH + ":" + hex.EncodeToString(hash[:])}
+
 		mux.HandleFunc("/"+name, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			_, err := w.Write(zipData)
 			require.NoError(t, err)
@@ -228,6 +276,7 @@ func testVersionControlWithUpdates(ctx *testcontext.Context, t *testing.T, updat
 				Suggested: versioncontrol.VersionConfig{
 					Version: newVersion,
 					URL:     ts.URL + "/storagenode",
+					Hashes:  hashes["storagenode"],
 				},
 				Rollout: versioncontrol.RolloutConfig{
 					Seed:   storagenodeSeed,
@@ -242,6 +291,7 @@ func testVersionControlWithUpdates(ctx *testcontext.Context, t *testing.T, updat
 				Suggested: versioncontrol.VersionConfig{
 					Version: newVersion,
 					URL:     ts.URL + "/storagenode-updater",
+					Hashes:  hashes["storagenode-updater"],
 				},
 				Rollout: versioncontrol.RolloutConfig{
 					Seed:   updaterSeed,
@@ -249,6 +299,7 @@ func testVersionControlWithUpdates(ctx *testcontext.Context, t *testing.T, updat
 				},
 			},
 		},
+		SigningKey: signingKey,
 	}
 	peer, err := versioncontrol.New(zaptest.NewLogger(t), config)
 	require.NoError(t, err)
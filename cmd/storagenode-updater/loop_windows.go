@@ -7,7 +7,6 @@ package main
 
 import (
 	"context"
-	"os"
 	"os/exec"
 
 	"github.com/zeebo/errs"
@@ -41,11 +40,6 @@ func loopFunc(ctx context.Context) error {
 }
 
 func updateSelf(ctx context.Context, binaryLocation string, ver version.Process) error {
-	suggestedVersion, err := ver.Suggested.SemVer()
-	if err != nil {
-		return errs.Wrap(err)
-	}
-
 	currentVersion, err := binaryVersion(binaryLocation)
 	if err != nil {
 		return errs.Wrap(err)
@@ -68,23 +62,10 @@ func updateSelf(ctx context.Context, binaryLocation string, ver version.Process)
 
 	newVersionPath := prependExtension(binaryLocation, ver.Suggested.Version)
 
-	if err = downloadBinary(ctx, parseDownloadURL(ver.Suggested.URL), newVersionPath); err != nil {
+	if err = downloadRelease(ctx, updaterServiceName, ver, newVersionPath); err != nil {
 		return errs.Wrap(err)
 	}
 
-	downloadedVersion, err := binaryVersion(newVersionPath)
-	if err != nil {
-		return errs.Combine(errs.Wrap(err), os.Remove(newVersionPath))
-	}
-
-	if suggestedVersion.Compare(downloadedVersion) != 0 {
-		err := errs.New("invalid version downloaded: wants %s got %s",
-			suggestedVersion.String(),
-			downloadedVersion.String(),
-		)
-		return errs.Combine(err, os.Remove(newVersionPath))
-	}
-
 	zap.L().Info("Restarting service.", zap.String("Service", updaterServiceName))
 	return restartSelf(binaryLocation, newVersionPath)
 }
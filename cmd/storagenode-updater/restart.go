@@ -8,6 +8,7 @@ package main
 import (
 	"context"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/zeebo/errs"
@@ -28,3 +29,13 @@ func restartService(ctx context.Context, service, binaryLocation, newVersionPath
 
 	return nil
 }
+
+// waitForStart waits until the restarted service keeps running. The updater
+// doesn't manage the service here, so there is nothing to wait for.
+func waitForStart(ctx context.Context, service string, timeout time.Duration) error {
+	return nil
+}
+
+func rollbackService(ctx context.Context, service, binaryLocation, backupPath string) error {
+	return errs.Wrap(os.Rename(backupPath, binaryLocation))
+}
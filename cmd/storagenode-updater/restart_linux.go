@@ -11,11 +11,18 @@ import (
 	"os/exec"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/zeebo/errs"
+
+	"storj.io/common/sync2"
 )
 
+// stopTimeout is how long a stopped service has to exit.
+const stopTimeout = 5 * time.Minute
+
 func cmdRestart(cmd *cobra.Command, args []string) error {
 	return nil
 }
@@ -33,7 +40,7 @@ func restartService(ctx context.Context, service, binaryLocation, newVersionPath
 		os.Exit(1)
 	}
 
-	if err := stopProcess(service); err != nil {
+	if err := stopProcess(ctx, service); err != nil {
 		err = errs.New("error stopping %s service: %v", service, err)
 		return errs.Combine(err, os.Rename(backupPath, binaryLocation))
 	}
@@ -41,17 +48,51 @@ func restartService(ctx context.Context, service, binaryLocation, newVersionPath
 	return nil
 }
 
-func stopProcess(service string) (err error) {
+// waitForStart waits until the service, which systemd starts again after it
+// was stopped, keeps running.
+func waitForStart(ctx context.Context, service string, timeout time.Duration) error {
+	return waitForProcess(ctx, service, timeout, func() (int, error) {
+		return getServicePID(service)
+	})
+}
+
+// rollbackService restores the previous binary and stops the service if it
+// runs, so that systemd starts the previous binary.
+func rollbackService(ctx context.Context, service, binaryLocation, backupPath string) error {
+	if err := os.Rename(backupPath, binaryLocation); err != nil {
+		return errs.Wrap(err)
+	}
+	return stopProcess(ctx, service)
+}
+
+// stopProcess stops the process of the service and waits until it exits.
+func stopProcess(ctx context.Context, service string) (err error) {
 	pid, err := getServicePID(service)
 	if err != nil {
 		return err
 	}
+	if pid == 0 {
+		// the service isn't running.
+		return nil
+	}
 	p, err := os.FindProcess(pid)
 	if err != nil {
 		return err
 	}
-	return p.Signal(os.Interrupt)
+	if err := p.Signal(os.Interrupt); err != nil {
+		return err
+	}
 
+	deadline := time.Now().Add(stopTimeout)
+	for p.Signal(syscall.Signal(0)) == nil {
+		if time.Now().After(deadline) {
+			return errs.New("process %d didn't exit", pid)
+		}
+		if !sync2.Sleep(ctx, time.Second) {
+			return ctx.Err()
+		}
+	}
+	return nil
 }
 
 func getServicePID(service string) (int, error) {
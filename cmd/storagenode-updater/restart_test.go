@@ -0,0 +1,44 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// +build !service !windows,!linux,service
+
+package main
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+)
+
+func TestRollbackService(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	binaryLocation := ctx.File("storagenode")
+	newVersionPath := prependExtension(binaryLocation, "v1.2.3")
+	backupPath := prependExtension(binaryLocation, "old")
+
+	require.NoError(t, ioutil.WriteFile(binaryLocation, []byte("old"), 0755))
+	require.NoError(t, ioutil.WriteFile(newVersionPath, []byte("new"), 0755))
+
+	require.NoError(t, restartService(ctx, "storagenode", binaryLocation, newVersionPath, backupPath))
+	// the service isn't managed by the updater, so there is nothing to wait for.
+	require.NoError(t, waitForStart(ctx, "storagenode", time.Minute))
+
+	data, err := ioutil.ReadFile(binaryLocation)
+	require.NoError(t, err)
+	require.Equal(t, "new", string(data))
+
+	require.NoError(t, rollbackService(ctx, "storagenode", binaryLocation, backupPath))
+
+	data, err = ioutil.ReadFile(binaryLocation)
+	require.NoError(t, err)
+	require.Equal(t, "old", string(data))
+
+	require.Error(t, rollbackService(ctx, "storagenode", binaryLocation, backupPath))
+}
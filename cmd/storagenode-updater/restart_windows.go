@@ -113,6 +113,60 @@ func restartService(ctx context.Context, service, binaryLocation, newVersionPath
 	return nil
 }
 
+// waitForStart waits until the started service keeps running.
+func waitForStart(ctx context.Context, service string, timeout time.Duration) (err error) {
+	srvc, err := openService(service)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	defer func() {
+		err = errs.Combine(err, errs.Wrap(srvc.Close()))
+	}()
+
+	return waitForProcess(ctx, service, timeout, func() (int, error) {
+		status, err := srvc.Query()
+		if err != nil {
+			return 0, errs.Wrap(err)
+		}
+		if status.State != svc.Running {
+			return 0, nil
+		}
+		return int(status.ProcessId), nil
+	})
+}
+
+// rollbackService stops the service, restores the previous binary and starts
+// the service again.
+func rollbackService(ctx context.Context, service, binaryLocation, backupPath string) (err error) {
+	srvc, err := openService(service)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	defer func() {
+		err = errs.Combine(err, errs.Wrap(srvc.Close()))
+	}()
+
+	status, err := srvc.Query()
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	if status.State != svc.Stopped && status.State != svc.StopPending {
+		if err = serviceControl(ctx, srvc, svc.Stop, svc.Stopped, 10*time.Second); err != nil {
+			return errs.Wrap(err)
+		}
+	} else if status.State == svc.StopPending {
+		if err = serviceWaitForState(ctx, srvc, svc.Stopped, 10*time.Second); err != nil {
+			return errs.Wrap(err)
+		}
+	}
+
+	if err := os.Rename(backupPath, binaryLocation); err != nil {
+		return unrecoverableErr.Wrap(err)
+	}
+
+	return errs.Wrap(srvc.Start())
+}
+
 func openService(name string) (_ *mgr.Service, err error) {
 	manager, err := mgr.Connect()
 	if err != nil {
@@ -0,0 +1,50 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/sync2"
+)
+
+// startPollInterval is how often the process of a restarted service is checked.
+var startPollInterval = time.Second
+
+// waitForProcess waits until the service runs in the same process for the
+// whole timeout. servicePID returns the process id of the service, or 0 when
+// it isn't running.
+func waitForProcess(ctx context.Context, service string, timeout time.Duration, servicePID func() (int, error)) error {
+	var started int
+	deadline := time.Now().Add(timeout)
+	for {
+		pid, err := servicePID()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case pid == 0 && started != 0:
+			return errs.New("%s service stopped after it started", service)
+		case pid != 0 && started == 0:
+			started = pid
+		case pid != started:
+			return errs.New("%s service restarted after it started", service)
+		}
+
+		if time.Now().After(deadline) {
+			if started == 0 {
+				return errs.New("%s service didn't start", service)
+			}
+			return nil
+		}
+
+		if !sync2.Sleep(ctx, startPollInterval) {
+			return ctx.Err()
+		}
+	}
+}
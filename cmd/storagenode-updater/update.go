@@ -5,20 +5,38 @@ package main
 
 import (
 	"context"
+	"io/ioutil"
 	"os"
+	"runtime"
+	"strings"
+	"time"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
 	"storj.io/private/version"
+	"storj.io/storj/private/version/checker"
+	"storj.io/storj/private/version/release"
 )
 
+// failedVersionSuffix is appended to the binary location for the file that
+// records the version of the service that failed to start.
+const failedVersionSuffix = ".failed-version"
+
 func update(ctx context.Context, serviceName, binaryLocation string, ver version.Process) error {
 	suggestedVersion, err := ver.Suggested.SemVer()
 	if err != nil {
 		return errs.Wrap(err)
 	}
 
+	if failed, ok := loadFailedVersion(binaryLocation); ok && failed.Compare(suggestedVersion) == 0 {
+		zap.L().Info("Suggested version failed to start before, skipping it.",
+			zap.String("Service", serviceName),
+			zap.String("Version", suggestedVersion.String()),
+		)
+		return nil
+	}
+
 	currentVersion, err := binaryVersion(binaryLocation)
 	if err != nil {
 		return errs.Wrap(err)
@@ -41,13 +59,119 @@ func update(ctx context.Context, serviceName, binaryLocation string, ver version
 
 	newVersionPath := prependExtension(binaryLocation, ver.Suggested.Version)
 
-	if err = downloadBinary(ctx, parseDownloadURL(ver.Suggested.URL), newVersionPath); err != nil {
+	if err = downloadRelease(ctx, serviceName, ver, newVersionPath); err != nil {
+		return errs.Wrap(err)
+	}
+
+	var backupPath string
+	if serviceName == updaterServiceName {
+		// NB: don't include old version number for updater binary backup
+		backupPath = prependExtension(binaryLocation, "old")
+	} else {
+		backupPath = prependExtension(binaryLocation, "old."+currentVersion.String())
+	}
+
+	return installRelease(ctx, platformServiceManager, serviceName, binaryLocation, newVersionPath, backupPath, suggestedVersion)
+}
+
+// serviceManager restarts services with new binaries and restores their
+// previous binaries.
+type serviceManager struct {
+	restart      func(ctx context.Context, service, binaryLocation, newVersionPath, backupPath string) error
+	waitForStart func(ctx context.Context, service string, timeout time.Duration) error
+	rollback     func(ctx context.Context, service, binaryLocation, backupPath string) error
+}
+
+// platformServiceManager manages the services of the platform the updater
+// runs on.
+var platformServiceManager = serviceManager{
+	restart:      restartService,
+	waitForStart: waitForStart,
+	rollback:     rollbackService,
+}
+
+// installRelease restarts the service with the binary at newVersionPath. When
+// the service doesn't keep running, the previous binary is restored and the
+// version is recorded, so that it isn't installed again.
+func installRelease(ctx context.Context, manager serviceManager, serviceName, binaryLocation, newVersionPath, backupPath string, newVersion version.SemVer) error {
+	zap.L().Info("Restarting service.", zap.String("Service", serviceName))
+
+	if err := manager.restart(ctx, serviceName, binaryLocation, newVersionPath, backupPath); err != nil {
+		return errs.Wrap(err)
+	}
+
+	if serviceName != updaterServiceName {
+		if err := manager.waitForStart(ctx, serviceName, runCfg.StartTimeout); err != nil {
+			zap.L().Error("Service failed to start, restoring previous binary.", zap.String("Service", serviceName), zap.Error(err))
+
+			if saveErr := saveFailedVersion(binaryLocation, newVersion); saveErr != nil {
+				zap.L().Error("Unable to record failed version.", zap.String("Service", serviceName), zap.Error(saveErr))
+			}
+
+			if rollbackErr := manager.rollback(ctx, serviceName, binaryLocation, backupPath); rollbackErr != nil {
+				return errs.Combine(errs.Wrap(err), errs.Wrap(rollbackErr))
+			}
+			zap.L().Info("Service restored to previous binary.", zap.String("Service", serviceName))
+			return errs.Wrap(err)
+		}
+	}
+
+	zap.L().Info("Service restarted successfully.", zap.String("Service", serviceName))
+	return nil
+}
+
+// loadFailedVersion returns the version of the service with the binary that
+// failed to start, if there is one.
+func loadFailedVersion(binaryLocation string) (_ version.SemVer, ok bool) {
+	data, err := ioutil.ReadFile(binaryLocation + failedVersionSuffix)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			zap.L().Warn("Unable to read failed version.", zap.Error(err))
+		}
+		return version.SemVer{}, false
+	}
+	failed, err := version.NewSemVer(strings.TrimSpace(string(data)))
+	if err != nil {
+		zap.L().Warn("Invalid failed version.", zap.Error(err))
+		return version.SemVer{}, false
+	}
+	return failed, true
+}
+
+// saveFailedVersion records the version of the service with the binary that
+// failed to start next to the binary, so that it is kept when the updater
+// restarts.
+func saveFailedVersion(binaryLocation string, failed version.SemVer) error {
+	return errs.Wrap(ioutil.WriteFile(binaryLocation+failedVersionSuffix, []byte(failed.String()+"\n"), 0644))
+}
+
+// downloadRelease downloads the suggested version of the service to target and
+// checks that it is the release it should be.
+func downloadRelease(ctx context.Context, serviceName string, ver version.Process, target string) (err error) {
+	suggestedVersion, err := ver.Suggested.SemVer()
+	if err != nil {
 		return errs.Wrap(err)
 	}
 
-	downloadedVersion, err := binaryVersion(newVersionPath)
+	url := parseDownloadURL(ver.Suggested.URL)
+
+	var manifest *release.Manifest
+	if runCfg.ReleaseKey != "" {
+		verified, err := releaseManifest(ctx, serviceName, ver)
+		if err != nil {
+			return errs.Wrap(err)
+		}
+		manifest = &verified
+		url = manifest.URL
+	}
+
+	if err = downloadBinary(ctx, url, target, manifest); err != nil {
+		return errs.Wrap(err)
+	}
+
+	downloadedVersion, err := binaryVersion(target)
 	if err != nil {
-		return errs.Combine(errs.Wrap(err), os.Remove(newVersionPath))
+		return errs.Combine(errs.Wrap(err), os.Remove(target))
 	}
 
 	if suggestedVersion.Compare(downloadedVersion) != 0 {
@@ -55,23 +179,48 @@ func update(ctx context.Context, serviceName, binaryLocation string, ver version
 			suggestedVersion.String(),
 			downloadedVersion.String(),
 		)
-		return errs.Combine(err, os.Remove(newVersionPath))
+		return errs.Combine(err, os.Remove(target))
 	}
 
-	var backupPath string
+	return nil
+}
+
+// releaseManifest returns the signed release manifest of the suggested
+// version of the service after verifying it.
+func releaseManifest(ctx context.Context, serviceName string, ver version.Process) (_ release.Manifest, err error) {
+	key, err := release.ParsePublicKey(runCfg.ReleaseKey)
+	if err != nil {
+		return release.Manifest{}, err
+	}
+
+	// the storage node may run under another OS service name.
+	process := "storagenode"
 	if serviceName == updaterServiceName {
-		// NB: don't include old version number for updater binary backup
-		backupPath = prependExtension(binaryLocation, "old")
-	} else {
-		backupPath = prependExtension(binaryLocation, "old."+currentVersion.String())
+		process = updaterServiceName
 	}
 
-	zap.L().Info("Restarting service.", zap.String("Service", serviceName))
+	signed, err := checker.New(runCfg.Version.ClientConfig).Manifest(ctx, process, "suggested", runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return release.Manifest{}, err
+	}
 
-	if err = restartService(ctx, serviceName, binaryLocation, newVersionPath, backupPath); err != nil {
-		return errs.Wrap(err)
+	manifest, err := signed.Verify(key)
+	if err != nil {
+		return release.Manifest{}, err
 	}
 
-	zap.L().Info("Service restarted successfully.", zap.String("Service", serviceName))
-	return nil
+	if manifest.Service != process || manifest.Version != ver.Suggested.Version ||
+		manifest.OS != runtime.GOOS || manifest.Arch != runtime.GOARCH {
+		return release.Manifest{}, errs.New("release manifest is for %s %s %s_%s, wants %s %s %s_%s",
+			manifest.Service, manifest.Version, manifest.OS, manifest.Arch,
+			process, ver.Suggested.Version, runtime.GOOS, runtime.GOARCH,
+		)
+	}
+
+	zap.L().Info("Release manifest verified.",
+		zap.String("Service", serviceName),
+		zap.String("Version", manifest.Version),
+		zap.String("Hash", manifest.SHA256),
+	)
+	return manifest, nil
 }
@@ -0,0 +1,150 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/testcontext"
+	"storj.io/private/version"
+)
+
+func TestWaitForProcess(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	defer func(interval time.Duration) { startPollInterval = interval }(startPollInterval)
+	startPollInterval = time.Millisecond
+
+	// pids returns a servicePID func that returns the pids in order and then
+	// keeps returning the last one.
+	pids := func(pids ...int) func() (int, error) {
+		return func() (int, error) {
+			pid := pids[0]
+			if len(pids) > 1 {
+				pids = pids[1:]
+			}
+			return pid, nil
+		}
+	}
+
+	const timeout = 50 * time.Millisecond
+
+	require.NoError(t, waitForProcess(ctx, "storagenode", timeout, pids(0, 0, 10)))
+
+	err := waitForProcess(ctx, "storagenode", timeout, pids(0))
+	require.EqualError(t, err, "storagenode service didn't start")
+
+	err = waitForProcess(ctx, "storagenode", timeout, pids(10, 10, 0))
+	require.EqualError(t, err, "storagenode service stopped after it started")
+
+	err = waitForProcess(ctx, "storagenode", timeout, pids(10, 10, 11))
+	require.EqualError(t, err, "storagenode service restarted after it started")
+
+	err = waitForProcess(ctx, "storagenode", timeout, func() (int, error) {
+		return 0, errs.New("systemctl failed")
+	})
+	require.EqualError(t, err, "systemctl failed")
+}
+
+func TestInstallRelease(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	newVersion, err := version.NewSemVer("v1.2.3")
+	require.NoError(t, err)
+
+	binaryLocation := ctx.File("storagenode")
+	newVersionPath := prependExtension(binaryLocation, newVersion.String())
+	backupPath := prependExtension(binaryLocation, "old")
+
+	writeBinaries := func() {
+		require.NoError(t, ioutil.WriteFile(binaryLocation, []byte("old"), 0755))
+		require.NoError(t, ioutil.WriteFile(newVersionPath, []byte("new"), 0755))
+	}
+	requireBinary := func(expected string) {
+		data, err := ioutil.ReadFile(binaryLocation)
+		require.NoError(t, err)
+		require.Equal(t, expected, string(data))
+	}
+
+	var started, rolledBack int
+	manager := serviceManager{
+		restart: func(ctx context.Context, service, binaryLocation, newVersionPath, backupPath string) error {
+			require.NoError(t, os.Rename(binaryLocation, backupPath))
+			require.NoError(t, os.Rename(newVersionPath, binaryLocation))
+			return nil
+		},
+		waitForStart: func(ctx context.Context, service string, timeout time.Duration) error {
+			started++
+			return errs.New("%s service didn't start", service)
+		},
+		rollback: func(ctx context.Context, service, binaryLocation, backupPath string) error {
+			rolledBack++
+			return os.Rename(backupPath, binaryLocation)
+		},
+	}
+
+	t.Run("failed start", func(t *testing.T) {
+		writeBinaries()
+
+		err := installRelease(ctx, manager, "storagenode", binaryLocation, newVersionPath, backupPath, newVersion)
+		require.EqualError(t, err, "storagenode service didn't start")
+		require.Equal(t, 1, started)
+		require.Equal(t, 1, rolledBack)
+		requireBinary("old")
+
+		// the failed version is kept next to the binary.
+		failed, ok := loadFailedVersion(binaryLocation)
+		require.True(t, ok)
+		require.Equal(t, newVersion, failed)
+		_, err = os.Stat(filepath.Join(filepath.Dir(binaryLocation), "storagenode"+failedVersionSuffix))
+		require.NoError(t, err)
+	})
+
+	t.Run("successful start", func(t *testing.T) {
+		require.NoError(t, os.Remove(binaryLocation+failedVersionSuffix))
+		writeBinaries()
+
+		manager.waitForStart = func(ctx context.Context, service string, timeout time.Duration) error {
+			return nil
+		}
+		require.NoError(t, installRelease(ctx, manager, "storagenode", binaryLocation, newVersionPath, backupPath, newVersion))
+		require.Equal(t, 1, rolledBack)
+		requireBinary("new")
+
+		_, ok := loadFailedVersion(binaryLocation)
+		require.False(t, ok)
+	})
+}
+
+func TestFailedVersion(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	binaryLocation := ctx.File("storagenode")
+
+	_, ok := loadFailedVersion(binaryLocation)
+	require.False(t, ok)
+
+	failed, err := version.NewSemVer("v1.2.3")
+	require.NoError(t, err)
+	require.NoError(t, saveFailedVersion(binaryLocation, failed))
+
+	loaded, ok := loadFailedVersion(binaryLocation)
+	require.True(t, ok)
+	require.Equal(t, failed, loaded)
+
+	require.NoError(t, ioutil.WriteFile(binaryLocation+failedVersionSuffix, []byte("invalid"), 0644))
+	_, ok = loadFailedVersion(binaryLocation)
+	require.False(t, ok)
+}
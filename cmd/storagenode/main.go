@@ -91,6 +91,49 @@ var (
 		Short: "Issue apikey for mnd",
 		RunE:  cmdIssue,
 	}
+	migratePiecesCmd = &cobra.Command{
+		Use:   "migrate-pieces",
+		Short: "Move pieces to the configured piece backend",
+		Long: "Moves every piece, including the trash, from the other piece backend to the one " +
+			"configured with storage2.piece-backend.\n" +
+			"The storage node must be stopped while the pieces are moved, the command refuses " +
+			"to run while the node answers on its private address. An interrupted " +
+			"migration continues where it stopped when the command is run again.",
+		RunE:        cmdMigratePieces,
+		Annotations: map[string]string{"type": "helper"},
+	}
+	piecesCmd = &cobra.Command{
+		Use:   "pieces",
+		Short: "Inspect and repair the stored pieces",
+		Long: "Inspects and repairs the stored pieces and the databases about them.\n" +
+			"The storage node must be stopped while these commands are used, they refuse to run " +
+			"while the node answers on its private address.",
+		Annotations: map[string]string{"type": "helper"},
+	}
+	piecesListCmd = &cobra.Command{
+		Use:   "list [satellite-id...]",
+		Short: "List the pieces of every or the given satellites",
+		RunE:  cmdPiecesList,
+	}
+	piecesVerifyCmd = &cobra.Command{
+		Use:   "verify [satellite-id...]",
+		Short: "Verify the headers and hashes of the pieces of every or the given satellites",
+		RunE:  cmdPiecesVerify,
+	}
+	piecesRebuildCmd = &cobra.Command{
+		Use:   "rebuild",
+		Short: "Rebuild the piece expirations and the space used from the pieces on disk",
+		Long: "Rebuilds the piece_expirations and piece_space_used tables from the pieces on disk. " +
+			"The expirations of pieces in the trash are left alone.",
+		RunE: cmdPiecesRebuild,
+	}
+	piecesOrphansCmd = &cobra.Command{
+		Use:   "orphans",
+		Short: "Find leftover temporary files and orphaned V0 pieces",
+		Long: "Finds temporary files left behind by interrupted uploads, V0 pieces without " +
+			"piece information and piece information without V0 pieces.",
+		RunE: cmdPiecesOrphans,
+	}
 
 	runCfg       StorageNodeFlags
 	setupCfg     StorageNodeFlags
@@ -102,6 +145,8 @@ var (
 	confDir        string
 	identityDir    string
 	useColor       bool
+
+	piecesOrphansDelete bool
 )
 
 const (
@@ -126,6 +171,12 @@ func init() {
 	rootCmd.AddCommand(gracefulExitInitCmd)
 	rootCmd.AddCommand(gracefulExitStatusCmd)
 	rootCmd.AddCommand(issueAPITokenCmd)
+	rootCmd.AddCommand(migratePiecesCmd)
+	rootCmd.AddCommand(piecesCmd)
+	piecesCmd.AddCommand(piecesListCmd)
+	piecesCmd.AddCommand(piecesVerifyCmd)
+	piecesCmd.AddCommand(piecesRebuildCmd)
+	piecesCmd.AddCommand(piecesOrphansCmd)
 	process.Bind(runCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(setupCmd, &setupCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir), cfgstruct.SetupMode())
 	process.Bind(configCmd, &setupCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir), cfgstruct.SetupMode())
@@ -134,6 +185,12 @@ func init() {
 	process.Bind(gracefulExitInitCmd, &diagCfg, defaults, cfgstruct.ConfDir(defaultDiagDir))
 	process.Bind(gracefulExitStatusCmd, &diagCfg, defaults, cfgstruct.ConfDir(defaultDiagDir))
 	process.Bind(issueAPITokenCmd, &diagCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(migratePiecesCmd, &diagCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(piecesListCmd, &diagCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(piecesVerifyCmd, &diagCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(piecesRebuildCmd, &diagCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(piecesOrphansCmd, &diagCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	piecesOrphansCmd.Flags().BoolVar(&piecesOrphansDelete, "delete", false, "delete the orphans that are found")
 }
 
 func cmdRun(cmd *cobra.Command, args []string) (err error) {
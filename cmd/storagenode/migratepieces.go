@@ -0,0 +1,77 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/private/process"
+	"storj.io/storj/storage/logstore"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+func cmdMigratePieces(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+	log := zap.L()
+
+	if err := ensureNodeStopped(ctx); err != nil {
+		return err
+	}
+
+	config := diagCfg.DatabaseConfig()
+	to := config.Backend
+	if to == "" {
+		to = storagenodedb.FilestoreBackend
+	}
+	from := storagenodedb.OtherBackend(to)
+
+	for _, dir := range config.PieceDirs() {
+		config.Pieces = dir
+		if err := migratePieces(ctx, log, config, from, to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migratePieces moves the pieces in a single piece directory.
+func migratePieces(ctx context.Context, log *zap.Logger, config storagenodedb.Config, from, to string) (err error) {
+	if from == storagenodedb.LogstoreBackend {
+		inUse, err := logstore.InUse(config.Pieces)
+		if err != nil {
+			return err
+		}
+		if !inUse {
+			fmt.Printf("No pieces are stored with the %s backend in %s.\n", from, config.Pieces)
+			return nil
+		}
+	}
+
+	source, err := storagenodedb.OpenBlobs(log.Named(from), config, from)
+	if err != nil {
+		return errs.New("Error opening the %s backend in %s: %v", from, config.Pieces, err)
+	}
+	defer func() { err = errs.Combine(err, source.Close()) }()
+
+	destination, err := storagenodedb.OpenBlobs(log.Named(to), config, to)
+	if err != nil {
+		return errs.New("Error opening the %s backend in %s: %v", to, config.Pieces, err)
+	}
+	defer func() { err = errs.Combine(err, destination.Close()) }()
+
+	stats, err := logstore.Migrate(ctx, log.Named("migrate"), source, destination)
+	if err != nil {
+		return errs.New("Error moving pieces in %s: %v", config.Pieces, err)
+	}
+
+	fmt.Printf("Moved %d pieces (%s) of %d satellites in %s from the %s backend to the %s backend, %d of them in the trash.\n",
+		stats.Blobs, memory.Size(stats.Bytes), stats.Namespaces, config.Pieces, from, to, stats.Trashed)
+	return nil
+}
@@ -0,0 +1,451 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/private/process"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+// openPieceStore opens the databases and the piece store.
+func openPieceStore(ctx context.Context, log *zap.Logger) (*storagenodedb.DB, *pieces.Store, error) {
+	db, err := storagenodedb.OpenExisting(ctx, log.Named("db"), diagCfg.DatabaseConfig())
+	if err != nil {
+		return nil, nil, errs.New("Error opening the storage node databases: %v", err)
+	}
+	store := pieces.NewStore(log.Named("pieces"), db.Pieces(), db.V0PieceInfo(), db.PieceExpirationDB(), db.PieceSpaceUsedDB(), diagCfg.Pieces)
+	return db, store, nil
+}
+
+// ensureNodeStopped returns an error when the storage node answers on its
+// private address. Opening the piece backends changes them, for example the
+// logstore truncates its index and removes logs, so every command working on
+// the pieces would race with a running node.
+func ensureNodeStopped(ctx context.Context) error {
+	address := diagCfg.Server.PrivateAddress
+	if address == "" {
+		return nil
+	}
+
+	dialer := net.Dialer{Timeout: time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil //nolint: nilerr // nothing is listening, so the node is stopped
+	}
+	_ = conn.Close()
+	return errs.New("The storage node is running on %s. Stop it before using this command.", address)
+}
+
+// satellitesToInspect returns the satellites in args, or every satellite
+// that pieces are stored for.
+func satellitesToInspect(ctx context.Context, db *storagenodedb.DB, args []string) (satellites storj.NodeIDList, err error) {
+	if len(args) > 0 {
+		for _, arg := range args {
+			satellite, err := storj.NodeIDFromString(arg)
+			if err != nil {
+				return nil, errs.New("invalid satellite ID %q: %v", arg, err)
+			}
+			satellites = append(satellites, satellite)
+		}
+		return satellites, nil
+	}
+
+	namespaces, err := db.Pieces().ListNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, namespace := range namespaces {
+		satellite, err := storj.NodeIDFromBytes(namespace)
+		if err != nil {
+			continue
+		}
+		satellites = append(satellites, satellite)
+	}
+	return satellites, nil
+}
+
+func cmdPiecesList(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	if err := ensureNodeStopped(ctx); err != nil {
+		return err
+	}
+
+	db, store, err := openPieceStore(ctx, zap.L())
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, db.Close()) }()
+
+	satellites, err := satellitesToInspect(ctx, db, args)
+	if err != nil {
+		return err
+	}
+
+	for _, satellite := range satellites {
+		expirations, err := db.PieceExpirationDB().GetExpirations(ctx, satellite)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Satellite %s\n", satellite)
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprint(w, "Piece ID\tFormat\tSize\tModified\tExpires\n")
+
+		var count, total int64
+		err = store.WalkSatellitePieces(ctx, satellite, func(access pieces.StoredPieceAccess) error {
+			_, size, err := access.Size(ctx)
+			if err != nil {
+				return err
+			}
+			modified, err := access.ModTime(ctx)
+			if err != nil {
+				return err
+			}
+
+			expires, ok := expirations[access.PieceID()]
+			if access.StorageFormatVersion() == filestore.FormatV0 {
+				info, err := store.GetV0PieceInfo(ctx, satellite, access.PieceID())
+				if err != nil {
+					return err
+				}
+				expires, ok = info.PieceExpiration, !info.PieceExpiration.IsZero()
+			}
+			expiresText := "never"
+			if ok {
+				expiresText = expires.Format(time.RFC3339)
+			}
+
+			fmt.Fprintf(w, "%s\tV%d\t%s\t%s\t%s\n",
+				access.PieceID(), access.StorageFormatVersion(), memory.Size(size),
+				modified.Format(time.RFC3339), expiresText)
+			count++
+			total += size
+			return nil
+		})
+		if err := errs.Combine(err, w.Flush()); err != nil {
+			return err
+		}
+		fmt.Printf("%d pieces, %s\n\n", count, memory.Size(total))
+	}
+	return nil
+}
+
+func cmdPiecesVerify(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+	log := zap.L()
+
+	if err := ensureNodeStopped(ctx); err != nil {
+		return err
+	}
+
+	db, store, err := openPieceStore(ctx, log)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, db.Close()) }()
+
+	satellites, err := satellitesToInspect(ctx, db, args)
+	if err != nil {
+		return err
+	}
+
+	// the read rate is not limited, because the node is not running.
+	scrubber := pieces.NewScrubber(log.Named("scrubber"), pieces.ScrubberConfig{}, store)
+	defer func() { err = errs.Combine(err, scrubber.Close()) }()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprint(w, "Satellite\tPiece ID\tProblem\n")
+
+	var checked, corrupt, bytes int64
+	for _, satellite := range satellites {
+		err := store.WalkSatellitePieces(ctx, satellite, func(access pieces.StoredPieceAccess) error {
+			size, reason, err := scrubber.CheckPiece(ctx, satellite, access)
+			if err != nil {
+				return err
+			}
+			checked++
+			bytes += size
+			if reason != "" {
+				corrupt++
+				fmt.Fprintf(w, "%s\t%s\t%s\n", satellite, access.PieceID(), reason)
+			}
+			return nil
+		})
+		if err != nil {
+			return errs.Combine(err, w.Flush())
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Verified %d pieces (%s), %d of them are corrupt.\n", checked, memory.Size(bytes), corrupt)
+	if corrupt > 0 {
+		return errs.New("found %d corrupt pieces", corrupt)
+	}
+	return nil
+}
+
+func cmdPiecesRebuild(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	if err := ensureNodeStopped(ctx); err != nil {
+		return err
+	}
+
+	db, store, err := openPieceStore(ctx, zap.L())
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, db.Close()) }()
+
+	satellites, err := satellitesToInspect(ctx, db, nil)
+	if err != nil {
+		return err
+	}
+
+	var added, updated, removed int64
+	for _, satellite := range satellites {
+		a, u, r, err := rebuildExpirations(ctx, db, store, satellite)
+		if err != nil {
+			return errs.New("Error rebuilding the piece expirations of %s: %v", satellite, err)
+		}
+		added, updated, removed = added+a, updated+u, removed+r
+	}
+	fmt.Printf("Piece expirations: %d added, %d updated, %d removed.\n", added, updated, removed)
+
+	total, contentSize, bySatellite, err := store.SpaceUsedTotalAndBySatellite(ctx)
+	if err != nil {
+		return errs.New("Error calculating the space used: %v", err)
+	}
+	trash, err := store.SpaceUsedForTrash(ctx)
+	if err != nil {
+		return errs.New("Error calculating the space used by the trash: %v", err)
+	}
+
+	spaceUsedDB := db.PieceSpaceUsedDB()
+	if err := spaceUsedDB.Init(ctx); err != nil {
+		return err
+	}
+	// satellites without pieces are set to zero, which removes them.
+	previous, err := spaceUsedDB.GetPieceTotalsForAllSatellites(ctx)
+	if err != nil {
+		return err
+	}
+	for satellite := range previous {
+		if _, ok := bySatellite[satellite]; !ok {
+			bySatellite[satellite] = pieces.SatelliteUsage{}
+		}
+	}
+	err = errs.Combine(
+		spaceUsedDB.UpdatePieceTotals(ctx, total, contentSize),
+		spaceUsedDB.UpdatePieceTotalsForAllSatellites(ctx, bySatellite),
+		spaceUsedDB.UpdateTrashTotal(ctx, trash),
+	)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Space used: %s by pieces (%s of content), %s by the trash.\n",
+		memory.Size(total), memory.Size(contentSize), memory.Size(trash))
+	return nil
+}
+
+// rebuildExpirations makes the piece expirations of the satellite match the
+// expirations in the headers of its pieces.
+func rebuildExpirations(ctx context.Context, db *storagenodedb.DB, store *pieces.Store, satellite storj.NodeID) (added, updated, removed int64, err error) {
+	expirationDB := db.PieceExpirationDB()
+	expirations, err := expirationDB.GetExpirations(ctx, satellite)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	err = store.WalkSatellitePieces(ctx, satellite, func(access pieces.StoredPieceAccess) error {
+		// V0 pieces keep their expiration in the piece information.
+		if access.StorageFormatVersion() == filestore.FormatV0 {
+			return nil
+		}
+
+		pieceID := access.PieceID()
+		reader, err := store.ReaderWithStorageFormat(ctx, satellite, pieceID, access.StorageFormatVersion())
+		if err != nil {
+			return err
+		}
+		header, err := reader.GetPieceHeader()
+		if err := errs.Combine(err, reader.Close()); err != nil {
+			fmt.Printf("Skipping piece %s of %s with an unreadable header: %v\n", pieceID, satellite, err)
+			return nil
+		}
+
+		current, ok := expirations[pieceID]
+		delete(expirations, pieceID)
+		expiresAt := header.OrderLimit.PieceExpiration
+
+		switch {
+		case expiresAt.IsZero() && !ok:
+			return nil
+		case expiresAt.IsZero():
+			removed++
+			_, err := expirationDB.DeleteExpiration(ctx, satellite, pieceID)
+			return err
+		case !ok:
+			added++
+			return expirationDB.SetExpiration(ctx, satellite, pieceID, expiresAt)
+		case !current.Equal(expiresAt):
+			updated++
+			if _, err := expirationDB.DeleteExpiration(ctx, satellite, pieceID); err != nil {
+				return err
+			}
+			return expirationDB.SetExpiration(ctx, satellite, pieceID, expiresAt)
+		}
+		return nil
+	})
+	if err != nil {
+		return added, updated, removed, err
+	}
+
+	// the remaining expirations belong to pieces that are gone.
+	for pieceID := range expirations {
+		if _, err := expirationDB.DeleteExpiration(ctx, satellite, pieceID); err != nil {
+			return added, updated, removed, err
+		}
+		removed++
+	}
+	return added, updated, removed, nil
+}
+
+func cmdPiecesOrphans(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+	log := zap.L()
+
+	if err := ensureNodeStopped(ctx); err != nil {
+		return err
+	}
+
+	db, store, err := openPieceStore(ctx, log)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, db.Close()) }()
+
+	var found int64
+	for _, path := range diagCfg.DatabaseConfig().PieceDirs() {
+		dir, err := filestore.OpenDir(log, path)
+		if err != nil {
+			return errs.New("Error opening %s: %v", path, err)
+		}
+		temporary, err := dir.ListTemporary()
+		if err != nil {
+			return err
+		}
+		for _, file := range temporary {
+			found++
+			fmt.Printf("Temporary file %s\n", file)
+			if piecesOrphansDelete {
+				if err := os.Remove(file); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	satellites, err := satellitesToInspect(ctx, db, nil)
+	if err != nil {
+		return err
+	}
+	for _, satellite := range satellites {
+		n, err := findOrphanedV0Pieces(ctx, db, store, satellite)
+		if err != nil {
+			return err
+		}
+		found += n
+	}
+
+	switch {
+	case found == 0:
+		fmt.Println("No orphans found.")
+	case piecesOrphansDelete:
+		fmt.Printf("Deleted %d orphans.\n", found)
+	default:
+		fmt.Printf("Found %d orphans. Use --delete to delete them.\n", found)
+	}
+	return nil
+}
+
+// findOrphanedV0Pieces finds the V0 pieces of the satellite without piece
+// information and the piece information without a V0 piece.
+func findOrphanedV0Pieces(ctx context.Context, db *storagenodedb.DB, store *pieces.Store, satellite storj.NodeID) (found int64, err error) {
+	blobs := db.Pieces()
+
+	var orphans []storj.PieceID
+	err = blobs.WalkNamespace(ctx, satellite.Bytes(), func(info storage.BlobInfo) error {
+		if info.StorageFormatVersion() != filestore.FormatV0 {
+			return nil
+		}
+		pieceID, err := storj.PieceIDFromBytes(info.BlobRef().Key)
+		if err != nil {
+			return nil //nolint: nilerr // not a piece
+		}
+		_, err = store.GetV0PieceInfo(ctx, satellite, pieceID)
+		if errors.Is(err, sql.ErrNoRows) {
+			orphans = append(orphans, pieceID)
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, pieceID := range orphans {
+		found++
+		fmt.Printf("V0 piece %s of %s without piece information\n", pieceID, satellite)
+		if piecesOrphansDelete {
+			ref := storage.BlobRef{Namespace: satellite.Bytes(), Key: pieceID.Bytes()}
+			if err := blobs.DeleteWithStorageFormat(ctx, ref, filestore.FormatV0); err != nil {
+				return found, err
+			}
+		}
+	}
+
+	var missing []storj.PieceID
+	err = db.V0PieceInfo().WalkSatelliteV0Pieces(ctx, blobs, satellite, func(access pieces.StoredPieceAccess) error {
+		_, err := blobs.StatWithStorageFormat(ctx, access.BlobRef(), filestore.FormatV0)
+		if errs.IsFunc(err, os.IsNotExist) {
+			missing = append(missing, access.PieceID())
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return found, err
+	}
+	for _, pieceID := range missing {
+		found++
+		fmt.Printf("Piece information of %s of %s without a V0 piece\n", pieceID, satellite)
+		if piecesOrphansDelete {
+			if err := db.V0PieceInfo().Delete(ctx, satellite, pieceID); err != nil {
+				return found, err
+			}
+		}
+	}
+	return found, nil
+}
@@ -0,0 +1,178 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+func TestPiecesCommands(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	defer func(config storagenode.Config, orphansDelete bool) {
+		diagCfg, piecesOrphansDelete = config, orphansDelete
+	}(diagCfg, piecesOrphansDelete)
+
+	// nothing listens on the private address of a stopped node.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	stoppedAddress := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	diagCfg = storagenode.Config{}
+	diagCfg.Storage.Path = ctx.Dir("storage")
+	diagCfg.Filestore = filestore.DefaultConfig
+	diagCfg.Pieces = pieces.DefaultConfig
+	diagCfg.Server.PrivateAddress = stoppedAddress
+
+	log := zaptest.NewLogger(t)
+	db, err := storagenodedb.OpenNew(ctx, log, diagCfg.DatabaseConfig())
+	require.NoError(t, err)
+	defer ctx.Check(db.Close)
+	require.NoError(t, db.MigrateToLatest(ctx))
+
+	store := pieces.NewStore(log, db.Pieces(), db.V0PieceInfo(), db.PieceExpirationDB(), db.PieceSpaceUsedDB(), diagCfg.Pieces)
+
+	satellite := testrand.NodeID()
+	expiration := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	write := func(expiration time.Time) storj.PieceID {
+		pieceID := testrand.PieceID()
+		writer, err := store.Writer(ctx, satellite, pieceID)
+		require.NoError(t, err)
+		_, err = io.Copy(writer, bytes.NewReader(testrand.BytesInt(10*memory.KiB.Int())))
+		require.NoError(t, err)
+		require.NoError(t, writer.Commit(ctx, &pb.PieceHeader{
+			Hash:         writer.Hash(),
+			CreationTime: time.Now(),
+			OrderLimit:   pb.OrderLimit{PieceExpiration: expiration},
+		}))
+		return pieceID
+	}
+	expiring := write(expiration)
+	permanent := write(time.Time{})
+
+	t.Run("list", func(t *testing.T) {
+		require.NoError(t, cmdPiecesList(piecesListCmd, nil))
+		require.NoError(t, cmdPiecesList(piecesListCmd, []string{satellite.String()}))
+		require.Error(t, cmdPiecesList(piecesListCmd, []string{"invalid"}))
+	})
+
+	t.Run("rebuild", func(t *testing.T) {
+		// an expiration of a piece that is gone is removed.
+		require.NoError(t, db.PieceExpirationDB().SetExpiration(ctx, satellite, testrand.PieceID(), expiration))
+
+		require.NoError(t, cmdPiecesRebuild(piecesRebuildCmd, nil))
+
+		expirations, err := db.PieceExpirationDB().GetExpirations(ctx, satellite)
+		require.NoError(t, err)
+		require.Len(t, expirations, 1)
+		require.True(t, expirations[expiring].Equal(expiration), expirations[expiring])
+		require.NotContains(t, expirations, permanent)
+
+		totals, err := db.PieceSpaceUsedDB().GetPieceTotalsForAllSatellites(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 20*memory.KiB.Int64(), totals[satellite].ContentSize)
+	})
+
+	t.Run("verify", func(t *testing.T) {
+		require.NoError(t, cmdPiecesVerify(piecesVerifyCmd, nil))
+
+		// flip a byte of the content of one of the pieces.
+		info, err := db.Pieces().Stat(ctx, storage.BlobRef{Namespace: satellite.Bytes(), Key: permanent.Bytes()})
+		require.NoError(t, err)
+		path, err := info.FullPath(ctx)
+		require.NoError(t, err)
+		file, err := os.OpenFile(path, os.O_RDWR, 0)
+		require.NoError(t, err)
+		b := make([]byte, 1)
+		_, err = file.ReadAt(b, pieces.V1PieceHeaderReservedArea+100)
+		require.NoError(t, err)
+		b[0] ^= 0xff
+		_, err = file.WriteAt(b, pieces.V1PieceHeaderReservedArea+100)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		require.Error(t, cmdPiecesVerify(piecesVerifyCmd, nil))
+		require.NoError(t, cmdPiecesVerify(piecesVerifyCmd, []string{testrand.NodeID().String()}))
+	})
+
+	t.Run("orphans", func(t *testing.T) {
+		dir, err := filestore.OpenDir(log, diagCfg.Storage.Path)
+		require.NoError(t, err)
+		temporary, err := dir.CreateTemporaryFile(ctx, 0)
+		require.NoError(t, err)
+		require.NoError(t, temporary.Close())
+
+		// piece information without a V0 piece.
+		missing := testrand.PieceID()
+		err = db.V0PieceInfo().(pieces.V0PieceInfoDBForTest).Add(ctx, &pieces.Info{
+			SatelliteID:     satellite,
+			PieceID:         missing,
+			PieceSize:       100,
+			PieceCreation:   time.Now(),
+			OrderLimit:      &pb.OrderLimit{},
+			UplinkPieceHash: &pb.PieceHash{},
+		})
+		require.NoError(t, err)
+
+		// orphans are only reported without --delete.
+		piecesOrphansDelete = false
+		require.NoError(t, cmdPiecesOrphans(piecesOrphansCmd, nil))
+		_, err = os.Stat(temporary.Name())
+		require.NoError(t, err)
+		_, err = store.GetV0PieceInfo(ctx, satellite, missing)
+		require.NoError(t, err)
+
+		piecesOrphansDelete = true
+		require.NoError(t, cmdPiecesOrphans(piecesOrphansCmd, nil))
+		_, err = os.Stat(temporary.Name())
+		require.True(t, os.IsNotExist(err), err)
+		_, err = store.GetV0PieceInfo(ctx, satellite, missing)
+		require.True(t, errors.Is(err, sql.ErrNoRows), err)
+	})
+
+	t.Run("running node", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer ctx.Check(listener.Close)
+		diagCfg.Server.PrivateAddress = listener.Addr().String()
+		defer func() { diagCfg.Server.PrivateAddress = stoppedAddress }()
+
+		// opening the piece backends changes them, so even inspecting needs a
+		// stopped node.
+		for name, run := range map[string]func(*cobra.Command, []string) error{
+			"list":    cmdPiecesList,
+			"verify":  cmdPiecesVerify,
+			"rebuild": cmdPiecesRebuild,
+			"orphans": cmdPiecesOrphans,
+		} {
+			err := run(piecesCmd, nil)
+			require.Error(t, err, name)
+			require.Contains(t, err.Error(), "The storage node is running", name)
+		}
+	})
+}
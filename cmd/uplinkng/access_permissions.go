@@ -29,6 +29,8 @@ type accessPermissions struct {
 
 	notBefore time.Time
 	notAfter  time.Time
+
+	defaultReadonly bool // defaultReadonly is the default value of the readonly flag
 }
 
 func (ap *accessPermissions) Setup(params clingy.Parameters) {
@@ -49,7 +51,7 @@ func (ap *accessPermissions) Setup(params clingy.Parameters) {
 		clingy.Repeated,
 	).([]uplink.SharePrefix)
 
-	ap.readonly = params.Flag("readonly", "Implies --disallow-writes and --disallow-deletes", false,
+	ap.readonly = params.Flag("readonly", "Implies --disallow-writes and --disallow-deletes", ap.defaultReadonly,
 		clingy.Transform(strconv.ParseBool)).(bool)
 	ap.writeonly = params.Flag("writeonly", "Implies --disallow-reads and --disallow-lists", false,
 		clingy.Transform(strconv.ParseBool)).(bool)
@@ -87,8 +89,9 @@ func (ap *accessPermissions) Setup(params clingy.Parameters) {
 		time.Time{}, transformHumanDate, clingy.Type("relative_date")).(time.Time)
 }
 
-func (ap *accessPermissions) Apply(access *uplink.Access) (*uplink.Access, error) {
-	permission := uplink.Permission{
+// permission returns the permission described by the flags.
+func (ap *accessPermissions) permission() uplink.Permission {
+	return uplink.Permission{
 		AllowDelete:   !ap.disallowDeletes && !ap.readonly,
 		AllowList:     !ap.disallowLists && !ap.writeonly,
 		AllowDownload: !ap.disallowReads && !ap.writeonly,
@@ -96,8 +99,16 @@ func (ap *accessPermissions) Apply(access *uplink.Access) (*uplink.Access, error
 		NotBefore:     ap.notBefore,
 		NotAfter:      ap.notAfter,
 	}
+}
 
-	access, err := access.Share(permission, ap.prefixes...)
+// canWrite returns true if the access allows uploads or deletes.
+func (ap *accessPermissions) canWrite() bool {
+	permission := ap.permission()
+	return permission.AllowUpload || permission.AllowDelete
+}
+
+func (ap *accessPermissions) Apply(access *uplink.Access) (*uplink.Access, error) {
+	access, err := access.Share(ap.permission(), ap.prefixes...)
 	if err != nil {
 		return nil, errs.Wrap(err)
 	}
@@ -0,0 +1,103 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/zeebo/clingy"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/cmd/uplinkng/ulfs"
+	"storj.io/storj/cmd/uplinkng/ulloc"
+	"storj.io/uplink"
+)
+
+// checksumMetadataKey is the custom metadata entry that holds the hex encoded
+// sha256 of the object contents. It is set by cp when uploading, and is
+// consulted before falling back to reading the contents to compute it.
+const checksumMetadataKey = "sha256"
+
+// errChecksumMismatch is the class of errors for contents that do not match
+// their expected checksum.
+var errChecksumMismatch = errs.Class("checksum mismatch")
+
+// checksumWriter computes the sha256 of everything written through it.
+type checksumWriter struct {
+	w io.Writer
+	h hash.Hash
+}
+
+func newChecksumWriter(w io.Writer) *checksumWriter {
+	return &checksumWriter{w: w, h: sha256.New()}
+}
+
+func (c *checksumWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	_, _ = c.h.Write(p[:n])
+	return n, err
+}
+
+// Sum returns the hex encoded sha256 of the written data.
+func (c *checksumWriter) Sum() string {
+	return hex.EncodeToString(c.h.Sum(nil))
+}
+
+// objectChecksum returns the hex encoded sha256 of the contents at the location. It
+// uses the value stored in the object metadata if there is one.
+func objectChecksum(ctx clingy.Context, fs ulfs.Filesystem, loc ulloc.Location) (string, error) {
+	info, err := fs.Stat(ctx, loc)
+	if err != nil {
+		return "", err
+	}
+	if sum, ok := info.Metadata[checksumMetadataKey]; ok {
+		return sum, nil
+	}
+	return contentChecksum(ctx, fs, loc)
+}
+
+// contentChecksum returns the hex encoded sha256 of the contents at the
+// location by reading all of them.
+func contentChecksum(ctx clingy.Context, fs ulfs.Filesystem, loc ulloc.Location) (string, error) {
+	rh, err := fs.Open(ctx, loc)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = rh.Close() }()
+
+	cw := newChecksumWriter(ioutil.Discard)
+	if _, err := io.Copy(cw, rh); err != nil {
+		return "", errs.Wrap(err)
+	}
+	return cw.Sum(), nil
+}
+
+// verifyCopy checks that the contents of dest match the contents of source.
+// The contents of dest are always read so that what was actually stored is
+// checked.
+func verifyCopy(ctx clingy.Context, fs ulfs.Filesystem, source, dest ulloc.Location) error {
+	expected, err := objectChecksum(ctx, fs, source)
+	if err != nil {
+		return err
+	}
+	actual, err := contentChecksum(ctx, fs, dest)
+	if err != nil {
+		return err
+	}
+	if expected != actual {
+		return errChecksumMismatch.New("%q does not match %q", dest, source)
+	}
+	return nil
+}
+
+// isNotExist returns true if err means that a file or object does not exist.
+func isNotExist(err error) bool {
+	return errors.Is(err, os.ErrNotExist) || errors.Is(err, uplink.ErrObjectNotFound)
+}
@@ -14,6 +14,14 @@ import (
 	"storj.io/uplink"
 )
 
+// accessRecord is the machine readable form of a saved access.
+type accessRecord struct {
+	Name      string `json:"name"`
+	Current   bool   `json:"current"`
+	Satellite string `json:"satellite"`
+	Value     string `json:"value,omitempty"`
+}
+
 type cmdAccessList struct {
 	ex ulext.External
 
@@ -37,13 +45,13 @@ func (c *cmdAccessList) Execute(ctx clingy.Context) error {
 		return err
 	}
 
-	var tw *tabbedWriter
+	var rw *recordWriter
 	if c.verbose {
-		tw = newTabbedWriter(ctx.Stdout(), "CURRENT", "NAME", "SATELLITE", "VALUE")
+		rw = newRecordWriter(ctx.Stdout(), c.ex.OutputFormat(), "CURRENT", "NAME", "SATELLITE", "VALUE")
 	} else {
-		tw = newTabbedWriter(ctx.Stdout(), "CURRENT", "NAME", "SATELLITE")
+		rw = newRecordWriter(ctx.Stdout(), c.ex.OutputFormat(), "CURRENT", "NAME", "SATELLITE")
 	}
-	defer tw.Done()
+	defer rw.Done()
 
 	var names []string
 	for name := range accesses {
@@ -66,10 +74,16 @@ func (c *cmdAccessList) Execute(ctx clingy.Context) error {
 			inUse = '*'
 		}
 
+		record := accessRecord{
+			Name:      name,
+			Current:   name == defaultName,
+			Satellite: address,
+		}
 		if c.verbose {
-			tw.WriteLine(inUse, name, address, accesses[name])
+			record.Value = accesses[name]
+			rw.Write(record, inUse, name, address, accesses[name])
 		} else {
-			tw.WriteLine(inUse, name, address)
+			rw.Write(record, inUse, name, address)
 		}
 	}
 
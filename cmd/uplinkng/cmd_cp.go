@@ -13,12 +13,33 @@ import (
 	"github.com/zeebo/clingy"
 	"github.com/zeebo/errs"
 
+	"storj.io/common/memory"
 	"storj.io/common/sync2"
 	"storj.io/storj/cmd/uplinkng/ulext"
 	"storj.io/storj/cmd/uplinkng/ulfs"
 	"storj.io/storj/cmd/uplinkng/ulloc"
 )
 
+// cpRecord is the machine readable result of copying a single file.
+type cpRecord struct {
+	Type   string `json:"type"`
+	Action string `json:"action"`
+	Source string `json:"source"`
+	Dest   string `json:"dest"`
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// cpSummaryRecord is the machine readable summary of a copy.
+type cpSummaryRecord struct {
+	Type    string `json:"type"`
+	Copied  int    `json:"copied"`
+	Failed  int    `json:"failed"`
+	Skipped int    `json:"skipped"`
+	DryRun  int    `json:"dry_run,omitempty"` // files that a dry run would copy
+}
+
 type cmdCp struct {
 	ex ulext.External
 
@@ -27,6 +48,13 @@ type cmdCp struct {
 	parallelism int
 	dryrun      bool
 	progress    bool
+	resume      bool
+	verify      bool
+	partSize    int64
+	rangeSize   int64
+	version     int64
+	filter      filterFlags
+	filtered    int // number of entries skipped by the filter
 
 	source ulloc.Location
 	dest   ulloc.Location
@@ -42,7 +70,7 @@ func (c *cmdCp) Setup(params clingy.Parameters) {
 		clingy.Short('r'),
 		clingy.Transform(strconv.ParseBool),
 	).(bool)
-	c.parallelism = params.Flag("parallelism", "Controls how many uploads/downloads to perform in parallel", 1,
+	c.parallelism = params.Flag("parallelism", "Controls how many uploads/downloads to perform in parallel, or how many ranges of a single large file", 1,
 		clingy.Short('p'),
 		clingy.Transform(strconv.Atoi),
 		clingy.Transform(func(n int) (int, error) {
@@ -58,13 +86,92 @@ func (c *cmdCp) Setup(params clingy.Parameters) {
 	c.progress = params.Flag("progress", "Show a progress bar when possible", true,
 		clingy.Transform(strconv.ParseBool),
 	).(bool)
-
-	c.source = params.Arg("source", "Source to copy", clingy.Transform(ulloc.Parse)).(ulloc.Location)
+	c.resume = params.Flag("resume", "Continue an interrupted upload from the last committed part", false,
+		clingy.Transform(strconv.ParseBool),
+	).(bool)
+	c.verify = params.Flag("verify", "Read back every copied file and compare it against the source", false,
+		clingy.Transform(strconv.ParseBool),
+	).(bool)
+	c.partSize = params.Flag("part-size", "Uploads larger than this are uploaded in parts of this size", 64*memory.MiB.Int64(),
+		clingy.Transform(memory.ParseString),
+		clingy.Transform(func(n int64) (int64, error) {
+			if n <= 0 {
+				return 0, errs.New("part size must be positive")
+			}
+			return n, nil
+		}),
+	).(int64)
+	c.rangeSize = params.Flag("range-size", "Downloads larger than this are downloaded in ranges of at most this size when parallelism is above 1. "+
+		"Ranges are aligned to the segments of the object", 64*memory.MiB.Int64(),
+		clingy.Transform(memory.ParseString),
+		clingy.Transform(func(n int64) (int64, error) {
+			if n <= 0 {
+				return 0, errs.New("range size must be positive")
+			}
+			return n, nil
+		}),
+	).(int64)
+	c.version = params.Flag("version", "Version of the remote source to copy (defaults to the latest version)", int64(0),
+		clingy.Transform(parseVersionFlag),
+	).(int64)
+	c.filter.Setup(params)
+
+	c.source = params.Arg("source", "Source to copy",
+		clingy.Transform(ulloc.Parse),
+	).(ulloc.Location)
 	c.dest = params.Arg("dest", "Desination to copy", clingy.Transform(ulloc.Parse)).(ulloc.Location)
 }
 
 func (c *cmdCp) Execute(ctx clingy.Context) error {
-	fs, err := c.ex.OpenFilesystem(ctx, c.access)
+	if c.verify && (c.source.Std() || c.dest.Std()) {
+		return errs.New("cannot verify copies to or from stdin/stdout")
+	}
+	if c.version > 0 {
+		switch {
+		case !c.source.Remote():
+			return errs.New("a version can only be copied from a remote source")
+		case c.recursive:
+			return errs.New("cannot copy a version recursively")
+		case c.verify:
+			return errs.New("cannot verify copies of object versions")
+		}
+	}
+
+	// results are only written as records when they do not mix with the
+	// copied data.
+	format := c.ex.OutputFormat()
+	if c.dest.Std() {
+		format = ulext.OutputTable
+	}
+	if format != ulext.OutputTable {
+		// the progress bar is not machine readable.
+		c.progress = false
+	}
+
+	rw := newRecordWriter(ctx.Stdout(), format)
+	defer rw.Done()
+
+	var skipped func(loc ulloc.Location, reason string)
+	if c.dryrun {
+		skipped = func(loc ulloc.Location, reason string) {
+			c.filtered++
+
+			dest := c.dest
+			if rel, err := c.source.RelativeTo(loc); err == nil {
+				dest = joinDestWith(c.dest, rel)
+			}
+			rw.Write(cpRecord{
+				Type:   "file",
+				Action: copyVerb(loc, dest),
+				Source: loc.String(),
+				Dest:   dest.String(),
+				Status: "skipped",
+				Reason: reason,
+			}, "skip", loc, "("+reason+")")
+		}
+	}
+
+	fs, err := c.ex.OpenFilesystem(ctx, c.access, c.filter.option(skipped))
 	if err != nil {
 		return err
 	}
@@ -81,7 +188,7 @@ func (c *cmdCp) Execute(ctx clingy.Context) error {
 	}
 
 	if c.recursive {
-		return c.copyRecursive(ctx, fs)
+		return c.copyRecursive(ctx, fs, rw, format)
 	}
 
 	// if the destination is directoryish, we add the basename of the source
@@ -98,14 +205,54 @@ func (c *cmdCp) Execute(ctx clingy.Context) error {
 	}
 	c.dest = joinDestWith(c.dest, base)
 
-	if !c.source.Std() && !c.dest.Std() {
+	if !c.source.Std() && !c.dest.Std() && format == ulext.OutputTable {
 		fmt.Fprintln(ctx.Stdout(), copyVerb(c.source, c.dest), c.source, "to", c.dest)
 	}
 
-	return c.copyFile(ctx, fs, c.source, c.dest, c.progress)
+	if c.dryrun {
+		if format != ulext.OutputTable {
+			rw.Write(newCpRecord(c.source, c.dest, nil, true))
+			rw.Write(cpSummaryRecord{Type: "summary", DryRun: 1})
+		}
+		return nil
+	}
+
+	if c.version > 0 {
+		err = copyVersion(ctx, fs, c.source, c.version, c.dest, c.progress)
+	} else {
+		err = c.copyFile(ctx, fs, c.source, c.dest, c.parallelism, c.progress)
+	}
+	if format != ulext.OutputTable {
+		summary := cpSummaryRecord{Type: "summary", Copied: 1}
+		if err != nil {
+			summary = cpSummaryRecord{Type: "summary", Failed: 1}
+		}
+		rw.Write(newCpRecord(c.source, c.dest, err, false))
+		rw.Write(summary)
+	}
+	return err
 }
 
-func (c *cmdCp) copyRecursive(ctx clingy.Context, fs ulfs.Filesystem) error {
+// newCpRecord returns the record of copying source to dest.
+func newCpRecord(source, dest ulloc.Location, err error, dryrun bool) cpRecord {
+	record := cpRecord{
+		Type:   "file",
+		Action: copyVerb(source, dest),
+		Source: source.String(),
+		Dest:   dest.String(),
+		Status: "copied",
+	}
+	switch {
+	case dryrun:
+		record.Status = "dry-run"
+	case err != nil:
+		record.Status = "failed"
+		record.Error = err.Error()
+	}
+	return record
+}
+
+func (c *cmdCp) copyRecursive(ctx clingy.Context, fs ulfs.Filesystem, rw *recordWriter, format string) error {
 	if c.source.Std() || c.dest.Std() {
 		return errs.New("cannot recursively copy to stdin/stdout")
 	}
@@ -119,6 +266,7 @@ func (c *cmdCp) copyRecursive(ctx clingy.Context, fs ulfs.Filesystem) error {
 		limiter = sync2.NewLimiter(c.parallelism)
 		es      errs.Group
 		mu      sync.Mutex
+		summary = cpSummaryRecord{Type: "summary"}
 	)
 
 	fprintln := func(w io.Writer, args ...interface{}) {
@@ -128,11 +276,22 @@ func (c *cmdCp) copyRecursive(ctx clingy.Context, fs ulfs.Filesystem) error {
 		fmt.Fprintln(w, args...)
 	}
 
-	addError := func(err error) {
+	addResult := func(source, dest ulloc.Location, err error) {
 		mu.Lock()
 		defer mu.Unlock()
 
-		es.Add(err)
+		switch {
+		case c.dryrun:
+			summary.DryRun++
+		case err != nil:
+			summary.Failed++
+			es.Add(err)
+		default:
+			summary.Copied++
+		}
+		if format != ulext.OutputTable {
+			rw.Write(newCpRecord(source, dest, err, c.dryrun))
+		}
 	}
 
 	for iter.Next() {
@@ -143,13 +302,26 @@ func (c *cmdCp) copyRecursive(ctx clingy.Context, fs ulfs.Filesystem) error {
 		}
 		dest := joinDestWith(c.dest, rel)
 
+		// dry runs are reported in the order of the listing, which mixes
+		// them with the entries that the filter skips.
+		if c.dryrun {
+			if format == ulext.OutputTable {
+				fprintln(ctx.Stdout(), copyVerb(source, dest), source, "to", dest)
+			}
+			addResult(source, dest, nil)
+			continue
+		}
+
 		ok := limiter.Go(ctx, func() {
-			fprintln(ctx.Stdout(), copyVerb(source, dest), source, "to", dest)
+			if format == ulext.OutputTable {
+				fprintln(ctx.Stdout(), copyVerb(source, dest), source, "to", dest)
+			}
 
-			if err := c.copyFile(ctx, fs, source, dest, false); err != nil {
+			err := c.copyFile(ctx, fs, source, dest, 1, false)
+			if err != nil && format == ulext.OutputTable {
 				fprintln(ctx.Stderr(), copyVerb(source, dest), "failed:", err.Error())
-				addError(err)
 			}
+			addResult(source, dest, err)
 		})
 		if !ok {
 			break
@@ -158,6 +330,11 @@ func (c *cmdCp) copyRecursive(ctx clingy.Context, fs ulfs.Filesystem) error {
 
 	limiter.Wait()
 
+	if format != ulext.OutputTable {
+		summary.Skipped += c.filtered
+		rw.Write(summary)
+	}
+
 	if err := iter.Err(); err != nil {
 		return errs.Wrap(err)
 	} else if len(es) > 0 {
@@ -166,17 +343,296 @@ func (c *cmdCp) copyRecursive(ctx clingy.Context, fs ulfs.Filesystem) error {
 	return nil
 }
 
-func (c *cmdCp) copyFile(ctx clingy.Context, fs ulfs.Filesystem, source, dest ulloc.Location, progress bool) error {
-	if c.dryrun {
-		return nil
+// copyFile copies source into dest. Local files larger than the part size are
+// uploaded as multipart uploads which can be resumed, and when parallelism is
+// above one, remote objects larger than the range size are downloaded as
+// concurrent byte ranges and the parts of uploads are uploaded concurrently.
+// Remote objects are copied to remote locations on the satellite without
+// transferring their data. With --verify the copy is read back and compared
+// against the source.
+func (c *cmdCp) copyFile(ctx clingy.Context, fs ulfs.Filesystem, source, dest ulloc.Location, parallelism int, progress bool) error {
+	var err error
+	switch {
+	case source.Remote() && dest.Remote():
+		err = fs.Copy(ctx, source, dest)
+	case source.Local() && dest.Remote():
+		err = c.uploadFile(ctx, fs, source, dest, parallelism, progress)
+	case source.Remote() && dest.Local() && parallelism > 1:
+		err = c.downloadFile(ctx, fs, source, dest, parallelism, progress)
+	default:
+		err = copyFile(ctx, fs, source, dest, progress)
+	}
+	if err != nil || !c.verify {
+		return err
+	}
+	return verifyCopy(ctx, fs, source, dest)
+}
+
+// uploadFile uploads the local source to the remote dest, as a multipart
+// upload if it is larger than the part size.
+func (c *cmdCp) uploadFile(ctx clingy.Context, fs ulfs.Filesystem, source, dest ulloc.Location, parallelism int, progress bool) error {
+	info, err := fs.Stat(ctx, source)
+	if err != nil {
+		return err
+	}
+
+	statePath := uploadStatePath(c.ex.UploadStateDir(), source, dest)
+	state, err := loadUploadState(statePath)
+	if err != nil {
+		return err
+	}
+
+	if state == nil && info.ContentLength <= c.partSize {
+		return copyFile(ctx, fs, source, dest, progress)
 	}
 
+	var upload ulfs.MultipartUpload
+	if state != nil && c.resume {
+		if state.Size != info.ContentLength || !state.Modified.Equal(info.Created) {
+			return errs.New("%q changed since the upload was started, abort the upload with 'uploads abort' and copy again", source)
+		}
+		upload, err = fs.ResumeMultipart(ctx, dest, state.UploadID)
+		if err != nil {
+			return err
+		}
+	} else {
+		if state != nil {
+			// the previous upload was not resumed, so nothing will ever
+			// complete it.
+			_ = fs.AbortMultipart(ctx, dest, state.UploadID)
+		}
+
+		upload, err = fs.BeginMultipart(ctx, dest)
+		if err != nil {
+			return err
+		}
+		state = &uploadState{
+			Source:   source.String(),
+			Dest:     dest.String(),
+			UploadID: upload.UploadID(),
+			PartSize: c.partSize,
+			Size:     info.ContentLength,
+			Modified: info.Created,
+		}
+		if err := state.save(statePath); err != nil {
+			return errs.Combine(err, upload.Abort(ctx))
+		}
+	}
+
+	if err := uploadParts(ctx, fs, source, upload, state, statePath, parallelism, progress); err != nil {
+		fmt.Fprintln(ctx.Stderr(), "upload to", dest, "interrupted, run cp again with --resume to continue")
+		return err
+	}
+
+	// the parts may have been uploaded by an earlier run, so the checksum
+	// is computed from the whole file.
+	sum, err := contentChecksum(ctx, fs, source)
+	if err != nil {
+		return err
+	}
+	if err := upload.SetMetadata(ctx, map[string]string{checksumMetadataKey: sum}); err != nil {
+		return err
+	}
+
+	if err := upload.Commit(ctx); err != nil {
+		return err
+	}
+	return removeUploadState(statePath)
+}
+
+// uploadParts uploads the parts of source which are not yet committed
+// according to state, and records each committed part in the state file.
+func uploadParts(ctx clingy.Context, fs ulfs.Filesystem, source ulloc.Location, upload ulfs.MultipartUpload, state *uploadState, statePath string, parallelism int, progress bool) error {
+	committed := make(map[uint32]bool, len(state.Parts))
+	for _, number := range state.Parts {
+		committed[number] = true
+	}
+
+	var pending []byteRange
+	var done int64
+	for _, r := range splitRanges(state.Size, state.PartSize) {
+		if committed[partNumber(r)] {
+			done += r.Length
+			continue
+		}
+		pending = append(pending, r)
+	}
+
+	var bar *progressbar.ProgressBar
+	if progress {
+		bar = progressbar.New64(state.Size).SetWriter(ctx.Stdout())
+		bar.SetCurrent(done)
+		bar.Start()
+		defer bar.Finish()
+	}
+
+	var mu sync.Mutex
+	return processRanges(ctx, pending, parallelism, func(ctx clingy.Context, r byteRange) error {
+		rh, err := fs.OpenRange(ctx, source, r.Offset, r.Length)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = rh.Close() }()
+
+		reader := &progressReader{r: rh, bar: bar}
+		if err := upload.UploadPart(ctx, partNumber(r), reader); err != nil {
+			if bar != nil {
+				// the part is uploaded again from the start.
+				bar.Add64(-reader.read)
+			}
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		state.Parts = append(state.Parts, partNumber(r))
+		return state.save(statePath)
+	})
+}
+
+// partNumber returns the number of the multipart upload part of a range.
+func partNumber(r byteRange) uint32 {
+	return uint32(r.Index) + 1
+}
+
+// downloadFile downloads the remote source into the local dest, as
+// concurrent byte ranges if it is larger than the range size. The ranges are
+// aligned to the segments of the source, so that no segment is downloaded
+// partially by two ranges unless it is larger than the range size.
+func (c *cmdCp) downloadFile(ctx clingy.Context, fs ulfs.Filesystem, source, dest ulloc.Location, parallelism int, progress bool) error {
+	info, err := fs.Stat(ctx, source)
+	if err != nil {
+		return err
+	}
+	if info.ContentLength <= c.rangeSize {
+		return copyFile(ctx, fs, source, dest, progress)
+	}
+
+	offsets, err := fs.SegmentOffsets(ctx, source)
+	if err != nil {
+		return err
+	}
+	ranges := splitSegmentRanges(info.ContentLength, c.rangeSize, offsets)
+	if len(ranges) <= 1 {
+		return copyFile(ctx, fs, source, dest, progress)
+	}
+
+	wh, err := fs.CreateWriterAt(ctx, dest, info.ContentLength)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = wh.Abort() }()
+
+	var bar *progressbar.ProgressBar
+	if progress {
+		bar = progressbar.New64(info.ContentLength).SetWriter(ctx.Stdout())
+		bar.Start()
+		defer bar.Finish()
+	}
+
+	err = processRanges(ctx, ranges, parallelism, func(ctx clingy.Context, r byteRange) error {
+		rh, err := fs.OpenRange(ctx, source, r.Offset, r.Length)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = rh.Close() }()
+
+		w := &offsetWriter{w: wh, offset: r.Offset, bar: bar}
+		_, err = io.Copy(w, rh)
+		if err == nil && w.offset != r.Offset+r.Length {
+			err = errs.New("short read: %d of %d bytes", w.offset-r.Offset, r.Length)
+		}
+		if err != nil && bar != nil {
+			// the range is downloaded again from the start.
+			bar.Add64(r.Offset - w.offset)
+		}
+		return err
+	})
+	if err != nil {
+		return errs.Combine(err, wh.Abort())
+	}
+	if err := wh.Commit(); err != nil {
+		return errs.Wrap(err)
+	}
+
+	// the ranges are written out of order, so the checksum can only be
+	// computed once the whole file is written.
+	if expected, ok := info.Metadata[checksumMetadataKey]; ok {
+		sum, err := contentChecksum(ctx, fs, dest)
+		if err != nil {
+			return err
+		}
+		if sum != expected {
+			return errs.Combine(
+				errChecksumMismatch.New("%q does not match the checksum stored with %q", dest, source),
+				fs.Remove(ctx, dest),
+			)
+		}
+	}
+	return nil
+}
+
+// progressReader counts the bytes read from r on the progress bar, when there is one.
+type progressReader struct {
+	r    io.Reader
+	read int64
+	bar  *progressbar.ProgressBar
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+	if p.bar != nil {
+		p.bar.Add(n)
+	}
+	return n, err
+}
+
+// offsetWriter writes sequentially to an io.WriterAt starting at offset.
+type offsetWriter struct {
+	w      io.WriterAt
+	offset int64
+	bar    *progressbar.ProgressBar
+}
+
+func (o *offsetWriter) Write(p []byte) (int, error) {
+	n, err := o.w.WriteAt(p, o.offset)
+	o.offset += int64(n)
+	if o.bar != nil {
+		o.bar.Add(n)
+	}
+	return n, err
+}
+
+// copyFile copies the contents of source into dest, optionally displaying a
+// progress bar if the size of the source is known. The sha256 of the contents
+// is stored in the metadata of remote destinations, and the copy fails and is
+// removed when the contents do not match the sha256 stored with the source.
+func copyFile(ctx clingy.Context, fs ulfs.Filesystem, source, dest ulloc.Location, progress bool) error {
 	rh, err := fs.Open(ctx, source)
 	if err != nil {
 		return err
 	}
 	defer func() { _ = rh.Close() }()
 
+	return copyHandle(ctx, fs, rh, source, dest, progress)
+}
+
+// copyVersion copies the contents of the version of the remote source into dest
+// like copyFile.
+func copyVersion(ctx clingy.Context, fs ulfs.Filesystem, source ulloc.Location, version int64, dest ulloc.Location, progress bool) error {
+	rh, err := fs.OpenVersion(ctx, source, version)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rh.Close() }()
+
+	return copyHandle(ctx, fs, rh, source, dest, progress)
+}
+
+// copyHandle copies the contents of rh, which was opened from source, into dest.
+func copyHandle(ctx clingy.Context, fs ulfs.Filesystem, rh ulfs.ReadHandle, source, dest ulloc.Location, progress bool) error {
 	wh, err := fs.Create(ctx, dest)
 	if err != nil {
 		return err
@@ -186,16 +642,30 @@ func (c *cmdCp) copyFile(ctx clingy.Context, fs ulfs.Filesystem, source, dest ul
 	var bar *progressbar.ProgressBar
 	var writer io.Writer = wh
 
-	if length := rh.Info().ContentLength; progress && length >= 0 && !c.dest.Std() {
+	if length := rh.Info().ContentLength; progress && length >= 0 && !dest.Std() {
 		bar = progressbar.New64(length).SetWriter(ctx.Stdout())
 		writer = bar.NewProxyWriter(writer)
 		bar.Start()
 		defer bar.Finish()
 	}
 
-	if _, err := io.Copy(writer, rh); err != nil {
+	cw := newChecksumWriter(writer)
+	if _, err := io.Copy(cw, rh); err != nil {
 		return errs.Combine(err, wh.Abort())
 	}
+
+	sum := cw.Sum()
+	if expected, ok := rh.Info().Metadata[checksumMetadataKey]; ok && sum != expected {
+		return errs.Combine(
+			errChecksumMismatch.New("%q does not match the checksum stored with %q", dest, source),
+			wh.Abort(),
+		)
+	}
+	if dest.Remote() {
+		if err := wh.SetMetadata(ctx, map[string]string{checksumMetadataKey: sum}); err != nil {
+			return errs.Combine(err, wh.Abort())
+		}
+	}
 	return errs.Wrap(wh.Commit())
 }
 
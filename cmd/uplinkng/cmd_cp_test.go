@@ -211,6 +211,22 @@ func TestCpRemoteToRemote(t *testing.T) {
 		)
 	})
 
+	t.Run("Existing", func(t *testing.T) {
+		state.With(ultest.WithFile("sj://b2/ends-slash", "existing")).
+			Succeed(t, "cp", "sj://b1/ends-slash", "sj://b2/ends-slash").RequireRemoteFiles(t,
+			ultest.File{Loc: "sj://b1/dot-dot/../../../../../foo", Contents: "data1"},
+			ultest.File{Loc: "sj://b1/dot-dot/../../foo", Contents: "data2"},
+			ultest.File{Loc: "sj://b1/dot-dot/../foo", Contents: "data3"},
+			ultest.File{Loc: "sj://b1//starts-slash", Contents: "data4"},
+			ultest.File{Loc: "sj://b1/ends-slash", Contents: "data5"},
+			ultest.File{Loc: "sj://b1/ends-slash/", Contents: "data6"},
+			ultest.File{Loc: "sj://b1/ends-slash//", Contents: "data7"},
+			ultest.File{Loc: "sj://b1/mid-slash//file", Contents: "data8"},
+
+			ultest.File{Loc: "sj://b2/ends-slash", Contents: "data5"},
+		)
+	})
+
 	t.Run("BucketToPrefix", func(t *testing.T) {
 		state.Succeed(t, "cp", "sj://b1", "sj://b2/pre", "--recursive").RequireFiles(t,
 			ultest.File{Loc: "sj://b1/dot-dot/../../../../../foo", Contents: "data1"},
@@ -416,3 +432,238 @@ func TestCpStandard(t *testing.T) {
 		)
 	})
 }
+
+func TestCpMultipart(t *testing.T) {
+	state := ultest.Setup(commands,
+		ultest.WithFile("/home/user/big", "0123456789"),
+		ultest.WithBucket("user"),
+	)
+
+	t.Run("Upload", func(t *testing.T) {
+		state.Succeed(t, "cp", "/home/user/big", "sj://user/big", "--part-size", "4", "--progress=false").RequireFiles(t,
+			ultest.File{Loc: "/home/user/big", Contents: "0123456789"},
+			ultest.File{Loc: "sj://user/big", Contents: "0123456789"},
+		)
+	})
+
+	t.Run("Small", func(t *testing.T) {
+		state.Succeed(t, "cp", "/home/user/big", "sj://user/big", "--part-size", "10", "--progress=false").RequireFiles(t,
+			ultest.File{Loc: "/home/user/big", Contents: "0123456789"},
+			ultest.File{Loc: "sj://user/big", Contents: "0123456789"},
+		)
+	})
+
+	t.Run("ParallelParts", func(t *testing.T) {
+		state.Succeed(t, "cp", "/home/user/big", "sj://user/big", "--part-size", "3", "--parallelism", "3", "--progress=false").RequireFiles(t,
+			ultest.File{Loc: "/home/user/big", Contents: "0123456789"},
+			ultest.File{Loc: "sj://user/big", Contents: "0123456789"},
+		)
+	})
+
+	t.Run("InvalidPartSize", func(t *testing.T) {
+		state.Fail(t, "cp", "/home/user/big", "sj://user/big", "--part-size", "0")
+	})
+}
+
+func TestUploadsAbort(t *testing.T) {
+	state := ultest.Setup(commands,
+		ultest.WithPendingMultipart("sj://user/foo"),
+		ultest.WithPendingMultipart("sj://user/dir/bar"),
+		ultest.WithPendingMultipart("sj://user/dir/baz"),
+	)
+
+	t.Run("Exact", func(t *testing.T) {
+		state.Succeed(t, "uploads", "abort", "sj://user/foo").RequireStdout(t, `
+			aborted sj://user/foo upload-1
+		`)
+	})
+
+	t.Run("UploadID", func(t *testing.T) {
+		state.Succeed(t, "uploads", "abort", "sj://user/dir", "--recursive", "--upload-id", "upload-3").RequireStdout(t, `
+			aborted sj://user/dir/baz upload-3
+		`)
+	})
+
+	t.Run("Recursive", func(t *testing.T) {
+		state.Succeed(t, "uploads", "abort", "sj://user/dir", "--recursive").RequireStdout(t, `
+			aborted sj://user/dir/bar upload-2
+			aborted sj://user/dir/baz upload-3
+		`)
+	})
+
+	t.Run("Local", func(t *testing.T) {
+		state.Fail(t, "uploads", "abort", "/home/user/foo")
+	})
+}
+
+func TestCpRangedDownload(t *testing.T) {
+	state := ultest.Setup(commands,
+		ultest.WithFile("sj://user/big", "0123456789"),
+	)
+
+	t.Run("Ranges", func(t *testing.T) {
+		state.Succeed(t, "cp", "sj://user/big", "/home/user/big", "--range-size", "3", "--parallelism", "3", "--progress=false").RequireLocalFiles(t,
+			ultest.File{Loc: "/home/user/big", Contents: "0123456789"},
+		)
+	})
+
+	t.Run("SingleRange", func(t *testing.T) {
+		state.Succeed(t, "cp", "sj://user/big", "/home/user/big", "--range-size", "10", "--parallelism", "3", "--progress=false").RequireLocalFiles(t,
+			ultest.File{Loc: "/home/user/big", Contents: "0123456789"},
+		)
+	})
+
+	t.Run("ToStdout", func(t *testing.T) {
+		state.Succeed(t, "cp", "sj://user/big", "-", "--range-size", "3", "--parallelism", "3")
+	})
+}
+
+func TestCpVersion(t *testing.T) {
+	state := ultest.Setup(commands,
+		ultest.WithFile("sj://user/file", "first"),
+		ultest.WithFile("sj://user/file", "second"),
+		ultest.WithFile("sj://user/at@home", "at"),
+	)
+
+	t.Run("Latest", func(t *testing.T) {
+		state.Succeed(t, "cp", "sj://user/file", "/home/user/file", "--progress=false").RequireLocalFiles(t,
+			ultest.File{Loc: "/home/user/file", Contents: "second"},
+		)
+	})
+
+	t.Run("Older", func(t *testing.T) {
+		state.Succeed(t, "cp", "sj://user/file", "/home/user/file", "--version", "1", "--progress=false").RequireLocalFiles(t,
+			ultest.File{Loc: "/home/user/file", Contents: "first"},
+		)
+	})
+
+	t.Run("IntoDirectory", func(t *testing.T) {
+		state.Succeed(t, "cp", "sj://user/file", "sj://user/dir/", "--version", "1", "--progress=false").RequireStdout(t, `
+			upload sj://user/file to sj://user/dir/file
+		`).RequireRemoteFiles(t,
+			ultest.File{Loc: "sj://user/at@home", Contents: "at"},
+			ultest.File{Loc: "sj://user/dir/file", Contents: "first"},
+			ultest.File{Loc: "sj://user/file", Contents: "second"},
+		)
+	})
+
+	t.Run("KeyWithAt", func(t *testing.T) {
+		state.Succeed(t, "cp", "sj://user/at@home", "/home/user/at", "--progress=false").RequireLocalFiles(t,
+			ultest.File{Loc: "/home/user/at", Contents: "at"},
+		)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		state.Fail(t, "cp", "sj://user/file", "/home/user/file", "--version", "3", "--progress=false")
+	})
+
+	t.Run("Verify", func(t *testing.T) {
+		state.Fail(t, "cp", "sj://user/file", "/home/user/file", "--version", "1", "--verify")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		state.Fail(t, "cp", "sj://user/file", "/home/user/file", "--version", "0")
+		state.Fail(t, "cp", "/home/user/file", "sj://user/file", "--version", "1")
+		state.Fail(t, "cp", "sj://user/", "/home/user/", "--version", "1", "--recursive")
+	})
+}
+
+func TestCpChecksum(t *testing.T) {
+	// the checksum stored with sj://user/bad is the checksum of "good".
+	state := ultest.Setup(commands,
+		ultest.WithBucket("user"),
+		withChecksummedFile("sj://user/good", "good", "770e607624d689265ca6c44884d0807d9b054d23c473c106c72be9de08b7376c"),
+		withChecksummedFile("sj://user/bad", "bad", "770e607624d689265ca6c44884d0807d9b054d23c473c106c72be9de08b7376c"),
+	)
+
+	t.Run("Match", func(t *testing.T) {
+		state.Succeed(t, "cp", "sj://user/good", "/home/user/good", "--progress=false").RequireLocalFiles(t,
+			ultest.File{Loc: "/home/user/good", Contents: "good"},
+		)
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		state.Fail(t, "cp", "sj://user/bad", "/home/user/bad", "--progress=false").RequireLocalFiles(t)
+	})
+
+	t.Run("RangedMismatch", func(t *testing.T) {
+		state.Fail(t, "cp", "sj://user/bad", "/home/user/bad", "--range-size", "1", "--parallelism", "2", "--progress=false").RequireLocalFiles(t)
+	})
+
+	t.Run("Verify", func(t *testing.T) {
+		state.Succeed(t, "cp", "sj://user/good", "/home/user/good", "--verify", "--progress=false")
+		state.Succeed(t, "cp", "sj://user/good", "sj://user/copy", "--verify", "--progress=false")
+		state.Fail(t, "cp", "sj://user/good", "-", "--verify")
+	})
+}
+
+func TestCpFilter(t *testing.T) {
+	state := ultest.Setup(commands,
+		ultest.WithFile("sj://user/files/.storjignore", "*.log\nbuild/\n"),
+		ultest.WithFile("sj://user/files/a.txt"),
+		ultest.WithFile("sj://user/files/b.log"),
+		ultest.WithFile("sj://user/files/build/out"),
+		ultest.WithFile("sj://user/files/docs/c.txt"),
+		ultest.WithFile("sj://user/files/docs/d.md"),
+	)
+
+	t.Run("StorjIgnore", func(t *testing.T) {
+		state.Succeed(t, "cp", "sj://user/files", "/home/user/dest", "--recursive").RequireLocalFiles(t,
+			ultest.File{Loc: "/home/user/dest/files/.storjignore", Contents: "*.log\nbuild/\n"},
+			ultest.File{Loc: "/home/user/dest/files/a.txt", Contents: "sj://user/files/a.txt"},
+			ultest.File{Loc: "/home/user/dest/files/docs/c.txt", Contents: "sj://user/files/docs/c.txt"},
+			ultest.File{Loc: "/home/user/dest/files/docs/d.md", Contents: "sj://user/files/docs/d.md"},
+		)
+	})
+
+	t.Run("IncludeExclude", func(t *testing.T) {
+		state.Succeed(t, "cp", "sj://user/files", "/home/user/dest", "--recursive", "--include", "*.txt", "--exclude", "docs/").RequireLocalFiles(t,
+			ultest.File{Loc: "/home/user/dest/files/a.txt", Contents: "sj://user/files/a.txt"},
+		)
+	})
+
+	t.Run("DryRun", func(t *testing.T) {
+		state.Succeed(t, "cp", "sj://user/files", "/home/user/dest", "--recursive", "--dryrun", "--exclude", "d.*").RequireStdout(t, `
+			download sj://user/files/.storjignore to /home/user/dest/files/.storjignore
+			download sj://user/files/a.txt to /home/user/dest/files/a.txt
+			skip sj://user/files/b.log (ignored by "*.log" in .storjignore)
+			skip sj://user/files/build/ (ignored by "build/" in .storjignore)
+			download sj://user/files/docs/c.txt to /home/user/dest/files/docs/c.txt
+			skip sj://user/files/docs/d.md (excluded by "d.*")
+		`)
+	})
+
+	t.Run("DryRunJSONL", func(t *testing.T) {
+		state.Succeed(t, "cp", "sj://user/files/docs", "/home/user/dest", "--recursive", "--dryrun", "--exclude", "*.md", "--output", "jsonl").RequireStdout(t, `
+			{"type":"file","action":"download","source":"sj://user/files/docs/c.txt","dest":"/home/user/dest/docs/c.txt","status":"dry-run"}
+			{"type":"file","action":"download","source":"sj://user/files/docs/d.md","dest":"/home/user/dest/docs/d.md","status":"skipped","reason":"excluded by \"*.md\""}
+			{"type":"summary","copied":0,"failed":0,"skipped":1,"dry_run":1}
+		`)
+	})
+
+	t.Run("InvalidPattern", func(t *testing.T) {
+		state.Fail(t, "cp", "sj://user/files", "/home/user/dest", "--recursive", "--exclude", "[a-")
+	})
+}
+
+func TestCpFilterNestedStorjIgnore(t *testing.T) {
+	state := ultest.Setup(commands,
+		ultest.WithFile("sj://user/files/.storjignore", "*.log\n"),
+		ultest.WithFile("sj://user/files/a.log"),
+		ultest.WithFile("sj://user/files/logs/.storjignore", "!keep.log\ntmp/\n"),
+		ultest.WithFile("sj://user/files/logs/b.log"),
+		ultest.WithFile("sj://user/files/logs/keep.log"),
+		ultest.WithFile("sj://user/files/logs/tmp/c.txt"),
+		ultest.WithFile("sj://user/files/tmp/d.txt"),
+	)
+
+	state.Succeed(t, "cp", "sj://user/files", "/home/user/dest", "--recursive", "--dryrun").RequireStdout(t, `
+		download sj://user/files/.storjignore to /home/user/dest/files/.storjignore
+		skip sj://user/files/a.log (ignored by "*.log" in .storjignore)
+		download sj://user/files/logs/.storjignore to /home/user/dest/files/logs/.storjignore
+		skip sj://user/files/logs/b.log (ignored by "*.log" in .storjignore)
+		download sj://user/files/logs/keep.log to /home/user/dest/files/logs/keep.log
+		skip sj://user/files/logs/tmp/ (ignored by "tmp/" in logs/.storjignore)
+		download sj://user/files/tmp/d.txt to /home/user/dest/files/tmp/d.txt
+	`)
+}
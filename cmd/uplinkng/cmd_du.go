@@ -0,0 +1,151 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/zeebo/clingy"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/cmd/uplinkng/ulext"
+	"storj.io/storj/cmd/uplinkng/ulfs"
+	"storj.io/storj/cmd/uplinkng/ulloc"
+)
+
+// prefixUsage is the total size and number of objects beneath a prefix.
+type prefixUsage struct {
+	Prefix  string `json:"prefix"`
+	Size    int64  `json:"size"`
+	Objects int64  `json:"objects"`
+}
+
+type cmdDu struct {
+	ex ulext.External
+
+	access    string
+	encrypted bool
+	depth     int
+
+	prefix ulloc.Location
+}
+
+func newCmdDu(ex ulext.External) *cmdDu {
+	return &cmdDu{ex: ex}
+}
+
+func (c *cmdDu) Setup(params clingy.Parameters) {
+	c.access = params.Flag("access", "Access name or value to use", "").(string)
+	c.encrypted = params.Flag("encrypted", "Shows keys base64 encoded without decrypting", false,
+		clingy.Transform(strconv.ParseBool),
+	).(bool)
+	c.depth = params.Flag("depth", "Only show totals for prefixes at most this deep (-1 for no limit)", -1,
+		clingy.Transform(strconv.Atoi),
+	).(int)
+
+	c.prefix = params.Arg("prefix", "Prefix to summarize (sj://BUCKET[/KEY])",
+		clingy.Transform(ulloc.Parse),
+	).(ulloc.Location)
+}
+
+func (c *cmdDu) Execute(ctx clingy.Context) error {
+	if !c.prefix.Remote() {
+		return errs.New("prefix must be remote")
+	}
+
+	fs, err := c.ex.OpenFilesystem(ctx, c.access, ulext.BypassEncryption(c.encrypted))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fs.Close() }()
+
+	iter, err := fs.ListObjects(ctx, c.prefix, true)
+	if err != nil {
+		return err
+	}
+
+	usage, err := summarizePrefixes(c.prefix, iter, c.depth)
+	if err != nil {
+		return err
+	}
+
+	rw := newRecordWriter(ctx.Stdout(), c.ex.OutputFormat(), "SIZE", "OBJECTS", "PREFIX")
+	defer rw.Done()
+
+	for _, u := range usage {
+		rw.Write(u, u.Size, u.Objects, u.Prefix)
+	}
+	return nil
+}
+
+// summarizePrefixes totals the objects from the iterator for the prefix and
+// every prefix beneath it that is at most depth levels deep. A negative depth
+// means that there is no limit. The totals are sorted by prefix with the total
+// of the prefix itself last.
+func summarizePrefixes(prefix ulloc.Location, iter ulfs.ObjectIterator, depth int) ([]prefixUsage, error) {
+	base := prefix
+	if _, key, _ := prefix.RemoteParts(); key != "" {
+		base = prefix.AsDirectoryish()
+	}
+	_, baseKey, _ := base.RemoteParts()
+
+	total := &prefixUsage{Prefix: base.String()}
+	children := make(map[string]*prefixUsage)
+
+	for iter.Next() {
+		item := iter.Item()
+		if item.IsPrefix {
+			continue
+		}
+		_, key, _ := item.Loc.RemoteParts()
+		if !strings.HasPrefix(key, baseKey) {
+			continue
+		}
+
+		total.Size += item.ContentLength
+		total.Objects++
+
+		rel := key[len(baseKey):]
+		for level := 1; depth < 0 || level <= depth; level++ {
+			idx := nthIndexByte(rel, '/', level)
+			if idx < 0 {
+				break
+			}
+			child := base.String() + rel[:idx+1]
+			u, ok := children[child]
+			if !ok {
+				u = &prefixUsage{Prefix: child}
+				children[child] = u
+			}
+			u.Size += item.ContentLength
+			u.Objects++
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, errs.Wrap(err)
+	}
+
+	usage := make([]prefixUsage, 0, len(children)+1)
+	for _, u := range children {
+		usage = append(usage, *u)
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Prefix < usage[j].Prefix })
+
+	return append(usage, *total), nil
+}
+
+// nthIndexByte returns the index of the nth occurrence of c in s or -1.
+func nthIndexByte(s string, c byte, n int) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] == c {
+			n--
+			if n == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
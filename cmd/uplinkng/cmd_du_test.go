@@ -0,0 +1,102 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/cmd/uplinkng/ulfs"
+	"storj.io/storj/cmd/uplinkng/ulloc"
+	"storj.io/storj/cmd/uplinkng/ultest"
+)
+
+type sliceObjectIterator struct {
+	infos   []ulfs.ObjectInfo
+	current ulfs.ObjectInfo
+}
+
+func (it *sliceObjectIterator) Next() bool {
+	if len(it.infos) == 0 {
+		return false
+	}
+	it.current, it.infos = it.infos[0], it.infos[1:]
+	return true
+}
+
+func (it *sliceObjectIterator) Err() error            { return nil }
+func (it *sliceObjectIterator) Item() ulfs.ObjectInfo { return it.current }
+
+func TestSummarizePrefixes(t *testing.T) {
+	object := func(key string, size int64) ulfs.ObjectInfo {
+		return ulfs.ObjectInfo{Loc: ulloc.NewRemote("bucket", key), ContentLength: size}
+	}
+	objects := []ulfs.ObjectInfo{
+		object("a/1", 1),
+		object("a/b/2", 2),
+		object("a/b/c/3", 4),
+		object("a/d/4", 8),
+		object("a2/5", 16),
+		object("6", 32),
+	}
+
+	summarize := func(location string, depth int) []prefixUsage {
+		prefix, err := ulloc.Parse(location)
+		require.NoError(t, err)
+		usage, err := summarizePrefixes(prefix, &sliceObjectIterator{infos: objects}, depth)
+		require.NoError(t, err)
+		return usage
+	}
+
+	require.Equal(t, []prefixUsage{
+		{Prefix: "sj://bucket/", Size: 63, Objects: 6},
+	}, summarize("sj://bucket", 0))
+
+	require.Equal(t, []prefixUsage{
+		{Prefix: "sj://bucket/a/", Size: 15, Objects: 4},
+		{Prefix: "sj://bucket/a2/", Size: 16, Objects: 1},
+		{Prefix: "sj://bucket/", Size: 63, Objects: 6},
+	}, summarize("sj://bucket", 1))
+
+	require.Equal(t, []prefixUsage{
+		{Prefix: "sj://bucket/a/b/", Size: 6, Objects: 2},
+		{Prefix: "sj://bucket/a/b/c/", Size: 4, Objects: 1},
+		{Prefix: "sj://bucket/a/d/", Size: 8, Objects: 1},
+		{Prefix: "sj://bucket/a/", Size: 15, Objects: 4},
+	}, summarize("sj://bucket/a", -1))
+}
+
+func TestDu(t *testing.T) {
+	state := ultest.Setup(commands,
+		ultest.WithFile("sj://user/a/1"),
+		ultest.WithFile("sj://user/a/b/2"),
+		ultest.WithFile("sj://user/c/3"),
+	)
+
+	state.Fail(t, "du", "/local/path")
+	state.Fail(t, "du", "sj://user", "--output", "xml")
+
+	state.Succeed(t, "du", "sj://user", "--depth", "1").RequireStdout(t, `
+		SIZE    OBJECTS    PREFIX
+		0       2          sj://user/a/
+		0       1          sj://user/c/
+		0       3          sj://user/
+	`)
+
+	state.Succeed(t, "du", "sj://user/a", "--output", "json").RequireStdout(t, `
+		[
+		  {
+		    "prefix": "sj://user/a/b/",
+		    "size": 0,
+		    "objects": 1
+		  },
+		  {
+		    "prefix": "sj://user/a/",
+		    "size": 0,
+		    "objects": 2
+		  }
+		]
+	`)
+}
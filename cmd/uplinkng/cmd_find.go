@@ -0,0 +1,173 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/zeebo/clingy"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/memory"
+	"storj.io/storj/cmd/uplinkng/ulext"
+	"storj.io/storj/cmd/uplinkng/ulfs"
+	"storj.io/storj/cmd/uplinkng/ulloc"
+)
+
+// findFilter selects the objects printed by find.
+type findFilter struct {
+	name          string
+	minSize       int64
+	maxSize       int64
+	createdAfter  time.Time
+	createdBefore time.Time
+}
+
+// match returns true if the object passes all of the filters.
+func (f findFilter) match(info ulfs.ObjectInfo) (bool, error) {
+	if info.IsPrefix {
+		return false, nil
+	}
+	if f.name != "" {
+		base, _ := info.Loc.Base()
+		ok, err := path.Match(f.name, base)
+		if err != nil {
+			return false, errs.Wrap(err)
+		} else if !ok {
+			return false, nil
+		}
+	}
+	if info.ContentLength < f.minSize || (f.maxSize > 0 && info.ContentLength > f.maxSize) {
+		return false, nil
+	}
+	if !f.createdAfter.IsZero() && !info.Created.After(f.createdAfter) {
+		return false, nil
+	}
+	if !f.createdBefore.IsZero() && !info.Created.Before(f.createdBefore) {
+		return false, nil
+	}
+	return true, nil
+}
+
+type cmdFind struct {
+	ex ulext.External
+
+	access    string
+	encrypted bool
+	pending   bool
+	utc       bool
+	filter    findFilter
+
+	prefix ulloc.Location
+}
+
+func newCmdFind(ex ulext.External) *cmdFind {
+	return &cmdFind{ex: ex}
+}
+
+func (c *cmdFind) Setup(params clingy.Parameters) {
+	c.access = params.Flag("access", "Access name or value to use", "").(string)
+	c.encrypted = params.Flag("encrypted", "Shows keys base64 encoded without decrypting", false,
+		clingy.Transform(strconv.ParseBool),
+	).(bool)
+	c.pending = params.Flag("pending", "Find pending object uploads instead", false,
+		clingy.Transform(strconv.ParseBool),
+	).(bool)
+	c.utc = params.Flag("utc", "Show all timestamps in UTC instead of local time", false,
+		clingy.Transform(strconv.ParseBool),
+	).(bool)
+
+	c.filter.name = params.Flag("name", "Only find objects whose last key component matches the glob (e.g. '*.jpg')", "",
+		clingy.Transform(func(pattern string) (string, error) {
+			_, err := path.Match(pattern, "")
+			return pattern, errs.Wrap(err)
+		}),
+	).(string)
+
+	transformSize := clingy.Transform(parseSize)
+	c.filter.minSize = params.Flag("min-size", "Only find objects of at least this size (e.g. '10MiB')", int64(0),
+		transformSize).(int64)
+	c.filter.maxSize = params.Flag("max-size", "Only find objects of at most this size, 0 for no limit (e.g. '1GB')", int64(0),
+		transformSize).(int64)
+
+	now := time.Now()
+	transformTime := clingy.Transform(func(date string) (time.Time, error) {
+		return parseFindTime(now, date)
+	})
+	c.filter.createdAfter = params.Flag("created-after",
+		"Only find objects created after this time (e.g. '-24h', '2021-01-02', '2021-01-02T15:04:05Z')",
+		time.Time{}, transformTime, clingy.Type("relative_date")).(time.Time)
+	c.filter.createdBefore = params.Flag("created-before",
+		"Only find objects created before this time (e.g. '-24h', '2021-01-02', '2021-01-02T15:04:05Z')",
+		time.Time{}, transformTime, clingy.Type("relative_date")).(time.Time)
+
+	c.prefix = params.Arg("prefix", "Prefix to search (sj://BUCKET[/KEY])",
+		clingy.Transform(ulloc.Parse),
+	).(ulloc.Location)
+}
+
+func (c *cmdFind) Execute(ctx clingy.Context) error {
+	if !c.prefix.Remote() {
+		return errs.New("prefix must be remote")
+	}
+
+	fs, err := c.ex.OpenFilesystem(ctx, c.access, ulext.BypassEncryption(c.encrypted))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fs.Close() }()
+
+	var iter ulfs.ObjectIterator
+	if c.pending {
+		iter, err = fs.ListUploads(ctx, c.prefix, true)
+	} else {
+		iter, err = fs.ListObjects(ctx, c.prefix, true)
+	}
+	if err != nil {
+		return err
+	}
+
+	rw := newRecordWriter(ctx.Stdout(), c.ex.OutputFormat(), "CREATED", "SIZE", "LOCATION")
+	defer rw.Done()
+
+	for iter.Next() {
+		item := iter.Item()
+		ok, err := c.filter.match(item)
+		if err != nil {
+			return err
+		} else if ok {
+			rw.Write(newObjectRecord(item), formatTime(c.utc, item.Created), item.ContentLength, item.Loc)
+		}
+	}
+	return errs.Wrap(iter.Err())
+}
+
+// parseSize parses a size like '10MiB'. It guards against sizes without any
+// digits, which memory.ParseString does not handle.
+func parseSize(size string) (int64, error) {
+	if size == "" || (size[0] < '0' || size[0] > '9') && size[0] != '.' {
+		return 0, errs.New("invalid size: %q", size)
+	}
+	return memory.ParseString(size)
+}
+
+// parseFindTime parses an absolute date or a duration relative to now.
+func parseFindTime(now time.Time, date string) (time.Time, error) {
+	switch {
+	case date == "":
+		return time.Time{}, nil
+	case date == "now":
+		return now, nil
+	case date[0] == '+' || date[0] == '-':
+		d, err := time.ParseDuration(date)
+		return now.Add(d), errs.Wrap(err)
+	}
+	if t, err := time.Parse("2006-01-02", date); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, date)
+	return t, errs.Wrap(err)
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/cmd/uplinkng/ultest"
+)
+
+func TestFind(t *testing.T) {
+	state := ultest.Setup(commands,
+		ultest.WithFile("sj://user/photos/1.jpg"),
+		ultest.WithFile("sj://user/photos/2.png"),
+		ultest.WithFile("sj://user/photos/deep/3.jpg"),
+		ultest.WithFile("sj://user/docs/4.txt"),
+
+		ultest.WithPendingMultipart("sj://user/photos/5.jpg"),
+	)
+
+	t.Run("Errors", func(t *testing.T) {
+		state.Fail(t, "find", "/local/path")
+		state.Fail(t, "find", "sj://user", "--name", "[")
+		state.Fail(t, "find", "sj://user", "--min-size", "lots")
+		state.Fail(t, "find", "sj://user", "--created-after", "yesterday")
+	})
+
+	t.Run("Name", func(t *testing.T) {
+		state.Succeed(t, "find", "sj://user", "--name", "*.jpg", "--utc").RequireStdout(t, `
+			CREATED                SIZE    LOCATION
+			1970-01-01 00:00:01    0       sj://user/photos/1.jpg
+			1970-01-01 00:00:03    0       sj://user/photos/deep/3.jpg
+		`)
+	})
+
+	t.Run("Created", func(t *testing.T) {
+		state.Succeed(t, "find", "sj://user/photos",
+			"--created-after", "1970-01-01T00:00:01Z",
+			"--created-before", "1970-01-01T00:00:04Z",
+			"--utc",
+		).RequireStdout(t, `
+			CREATED                SIZE    LOCATION
+			1970-01-01 00:00:02    0       sj://user/photos/2.png
+			1970-01-01 00:00:03    0       sj://user/photos/deep/3.jpg
+		`)
+	})
+
+	t.Run("Size", func(t *testing.T) {
+		state.Succeed(t, "find", "sj://user", "--min-size", "1B").RequireStdout(t, ``)
+	})
+
+	t.Run("Pending", func(t *testing.T) {
+		state.Succeed(t, "find", "sj://user", "--pending", "--output", "json").RequireStdout(t, `
+			[
+			  {
+			    "location": "sj://user/photos/5.jpg",
+			    "kind": "pending",
+			    "size": 0,
+			    "created": "1970-01-01T00:00:05Z",
+			    "uploadId": "upload-5"
+			  }
+			]
+		`)
+	})
+}
+
+func TestParseFindTime(t *testing.T) {
+	now := time.Date(2021, 5, 6, 7, 8, 9, 0, time.UTC)
+
+	for _, tt := range []struct {
+		date     string
+		expected time.Time
+	}{
+		{"", time.Time{}},
+		{"now", now},
+		{"-1h", now.Add(-time.Hour)},
+		{"2021-01-02", time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"2021-01-02T03:04:05Z", time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)},
+	} {
+		parsed, err := parseFindTime(now, tt.date)
+		require.NoError(t, err, tt.date)
+		require.True(t, tt.expected.Equal(parsed), tt.date)
+	}
+
+	_, err := parseFindTime(now, "tomorrow")
+	require.Error(t, err)
+}
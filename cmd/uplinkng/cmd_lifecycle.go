@@ -0,0 +1,174 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"strconv"
+
+	"github.com/zeebo/clingy"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/cmd/uplinkng/ulext"
+	"storj.io/storj/cmd/uplinkng/ulloc"
+	"storj.io/storj/cmd/uplinkng/ulmetainfo"
+)
+
+// lifecycleRecord is the machine readable form of a bucket lifecycle rule.
+type lifecycleRecord struct {
+	Bucket  string `json:"bucket"`
+	Name    string `json:"name"`
+	Prefix  string `json:"prefix"`
+	Action  string `json:"action"`
+	AgeDays int    `json:"ageDays"`
+}
+
+type cmdLifecycleSet struct {
+	ex ulext.External
+
+	access string
+
+	location ulloc.Location
+	name     string
+	action   ulmetainfo.LifecycleAction
+	days     int
+}
+
+func newCmdLifecycleSet(ex ulext.External) *cmdLifecycleSet {
+	return &cmdLifecycleSet{ex: ex}
+}
+
+func (c *cmdLifecycleSet) Setup(params clingy.Parameters) {
+	c.access = params.Flag("access", "Access name or value to use", "").(string)
+
+	c.location = params.Arg("location", "Bucket and prefix the rule applies to (sj://BUCKET[/PREFIX/]). "+
+		"The prefix must end with a / because the satellite only sees it encrypted",
+		clingy.Transform(ulloc.Parse),
+	).(ulloc.Location)
+	c.name = params.Arg("name", "Name of the rule, replaces the rule of the bucket with the same name").(string)
+	c.action = params.Arg("action", "What to do with the matching objects (expire-objects, abort-uploads)",
+		clingy.Transform(ulmetainfo.ParseLifecycleAction),
+	).(ulmetainfo.LifecycleAction)
+	c.days = params.Arg("days", "Age in days after which the matching objects are deleted",
+		clingy.Transform(strconv.Atoi),
+		clingy.Transform(func(days int) (int, error) {
+			if days <= 0 {
+				return 0, errs.New("days must be positive: %d", days)
+			}
+			return days, nil
+		}),
+	).(int)
+}
+
+func (c *cmdLifecycleSet) Execute(ctx clingy.Context) error {
+	bucket, prefix, ok := c.location.RemoteParts()
+	if !ok {
+		return errs.New("location must be remote")
+	}
+
+	client, err := c.ex.OpenMetainfo(ctx, c.access)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	return client.SetBucketLifecycleRule(ctx, bucket, ulmetainfo.LifecycleRule{
+		Name:    c.name,
+		Prefix:  prefix,
+		Action:  c.action,
+		AgeDays: c.days,
+	})
+}
+
+type cmdLifecycleDelete struct {
+	ex ulext.External
+
+	access string
+
+	location ulloc.Location
+	name     *string
+}
+
+func newCmdLifecycleDelete(ex ulext.External) *cmdLifecycleDelete {
+	return &cmdLifecycleDelete{ex: ex}
+}
+
+func (c *cmdLifecycleDelete) Setup(params clingy.Parameters) {
+	c.access = params.Flag("access", "Access name or value to use", "").(string)
+
+	c.location = params.Arg("bucket", "Bucket to delete the rules of (sj://BUCKET)",
+		clingy.Transform(ulloc.Parse),
+	).(ulloc.Location)
+	c.name = params.Arg("name", "Name of the rule to delete, all rules are deleted when missing", clingy.Optional).(*string)
+}
+
+func (c *cmdLifecycleDelete) Execute(ctx clingy.Context) error {
+	bucket, key, ok := c.location.RemoteParts()
+	if !ok || key != "" {
+		return errs.New("location must be a bucket: %q", c.location)
+	}
+
+	client, err := c.ex.OpenMetainfo(ctx, c.access)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	var name string
+	if c.name != nil {
+		name = *c.name
+	}
+	return client.DeleteBucketLifecycleRules(ctx, bucket, name)
+}
+
+type cmdLifecycleList struct {
+	ex ulext.External
+
+	access string
+
+	location ulloc.Location
+}
+
+func newCmdLifecycleList(ex ulext.External) *cmdLifecycleList {
+	return &cmdLifecycleList{ex: ex}
+}
+
+func (c *cmdLifecycleList) Setup(params clingy.Parameters) {
+	c.access = params.Flag("access", "Access name or value to use", "").(string)
+
+	c.location = params.Arg("bucket", "Bucket to list the rules of (sj://BUCKET)",
+		clingy.Transform(ulloc.Parse),
+	).(ulloc.Location)
+}
+
+func (c *cmdLifecycleList) Execute(ctx clingy.Context) error {
+	bucket, key, ok := c.location.RemoteParts()
+	if !ok || key != "" {
+		return errs.New("location must be a bucket: %q", c.location)
+	}
+
+	client, err := c.ex.OpenMetainfo(ctx, c.access)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	rules, err := client.ListBucketLifecycleRules(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	rw := newRecordWriter(ctx.Stdout(), c.ex.OutputFormat(), "NAME", "ACTION", "DAYS", "PREFIX")
+	defer rw.Done()
+
+	for _, rule := range rules {
+		rw.Write(lifecycleRecord{
+			Bucket:  bucket,
+			Name:    rule.Name,
+			Prefix:  rule.Prefix,
+			Action:  rule.Action.String(),
+			AgeDays: rule.AgeDays,
+		}, rule.Name, rule.Action, rule.AgeDays, rule.Prefix)
+	}
+	return nil
+}
@@ -8,20 +8,34 @@ import (
 	"time"
 
 	"github.com/zeebo/clingy"
+	"github.com/zeebo/errs"
 
 	"storj.io/storj/cmd/uplinkng/ulext"
 	"storj.io/storj/cmd/uplinkng/ulfs"
 	"storj.io/storj/cmd/uplinkng/ulloc"
 )
 
+// lsRecord is the machine readable form of a listed bucket, prefix or object.
+type lsRecord struct {
+	Kind     string     `json:"kind"`
+	Name     string     `json:"name,omitempty"`
+	Key      string     `json:"key,omitempty"`
+	Created  *time.Time `json:"created,omitempty"`
+	Size     *int64     `json:"size,omitempty"`
+	UploadID string     `json:"uploadId,omitempty"`
+	Version  int64      `json:"version,omitempty"`
+}
+
 type cmdLs struct {
 	ex ulext.External
 
-	access    string
-	recursive bool
-	encrypted bool
-	pending   bool
-	utc       bool
+	access      string
+	recursive   bool
+	encrypted   bool
+	pending     bool
+	allVersions bool
+	utc         bool
+	tags        []string
 
 	prefix *ulloc.Location
 }
@@ -42,9 +56,16 @@ func (c *cmdLs) Setup(params clingy.Parameters) {
 	c.pending = params.Flag("pending", "List pending object uploads instead", false,
 		clingy.Transform(strconv.ParseBool),
 	).(bool)
+	c.allVersions = params.Flag("all-versions", "List every version and delete marker of the objects", false,
+		clingy.Transform(strconv.ParseBool),
+	).(bool)
 	c.utc = params.Flag("utc", "Show all timestamps in UTC instead of local time", false,
 		clingy.Transform(strconv.ParseBool),
 	).(bool)
+	c.tags = params.Flag("tag", "Only list objects that have the tag (KEY=VALUE). May be given multiple times", []string{},
+		clingy.Transform(checkTag),
+		clingy.Repeated,
+	).([]string)
 
 	c.prefix = params.Arg("prefix", "Prefix to list (sj://BUCKET[/KEY])", clingy.Optional,
 		clingy.Transform(ulloc.Parse),
@@ -53,6 +74,9 @@ func (c *cmdLs) Setup(params clingy.Parameters) {
 
 func (c *cmdLs) Execute(ctx clingy.Context) error {
 	if c.prefix == nil {
+		if len(c.tags) > 0 {
+			return errs.New("--tag requires a prefix")
+		}
 		return c.listBuckets(ctx)
 	}
 	return c.listLocation(ctx, *c.prefix)
@@ -65,18 +89,33 @@ func (c *cmdLs) listBuckets(ctx clingy.Context) error {
 	}
 	defer func() { _ = project.Close() }()
 
-	tw := newTabbedWriter(ctx.Stdout(), "CREATED", "NAME")
-	defer tw.Done()
+	rw := newRecordWriter(ctx.Stdout(), c.ex.OutputFormat(), "CREATED", "NAME")
+	defer rw.Done()
 
 	iter := project.ListBuckets(ctx, nil)
 	for iter.Next() {
 		item := iter.Item()
-		tw.WriteLine(formatTime(c.utc, item.Created), item.Name)
+		rw.Write(lsRecord{
+			Kind:    "bucket",
+			Name:    item.Name,
+			Created: optionalTime(item.Created),
+		}, formatTime(c.utc, item.Created), item.Name)
 	}
 	return iter.Err()
 }
 
 func (c *cmdLs) listLocation(ctx clingy.Context, prefix ulloc.Location) error {
+	if c.pending && c.allVersions {
+		return errs.New("--pending and --all-versions cannot be used together")
+	}
+	if len(c.tags) > 0 && (c.pending || c.allVersions) {
+		return errs.New("--tag cannot be used with --pending or --all-versions")
+	}
+	tags, err := parseTags(c.tags)
+	if err != nil {
+		return err
+	}
+
 	fs, err := c.ex.OpenFilesystem(ctx, c.access, ulext.BypassEncryption(c.encrypted))
 	if err != nil {
 		return err
@@ -87,14 +126,21 @@ func (c *cmdLs) listLocation(ctx clingy.Context, prefix ulloc.Location) error {
 		prefix = prefix.AsDirectoryish()
 	}
 
-	tw := newTabbedWriter(ctx.Stdout(), "KIND", "CREATED", "SIZE", "KEY")
-	defer tw.Done()
+	if c.allVersions {
+		return c.listVersions(ctx, fs, prefix)
+	}
+
+	rw := newRecordWriter(ctx.Stdout(), c.ex.OutputFormat(), "KIND", "CREATED", "SIZE", "KEY")
+	defer rw.Done()
 
-	// create the object iterator of either existing objects or pending multipart uploads
+	// create the object iterator of either existing objects, objects with the tags or pending multipart uploads
 	var iter ulfs.ObjectIterator
-	if c.pending {
+	switch {
+	case c.pending:
 		iter, err = fs.ListUploads(ctx, prefix, c.recursive)
-	} else {
+	case len(tags) > 0:
+		iter, err = fs.ListTaggedObjects(ctx, prefix, c.recursive, tags)
+	default:
 		iter, err = fs.ListObjects(ctx, prefix, c.recursive)
 	}
 	if err != nil {
@@ -105,9 +151,55 @@ func (c *cmdLs) listLocation(ctx clingy.Context, prefix ulloc.Location) error {
 	for iter.Next() {
 		obj := iter.Item()
 		if obj.IsPrefix {
-			tw.WriteLine("PRE", "", "", obj.Loc.Loc())
+			rw.Write(lsRecord{Kind: "prefix", Key: obj.Loc.Loc()},
+				"PRE", "", "", obj.Loc.Loc())
 		} else {
-			tw.WriteLine("OBJ", formatTime(c.utc, obj.Created), obj.ContentLength, obj.Loc.Loc())
+			size := obj.ContentLength
+			rw.Write(lsRecord{
+				Kind:     "object",
+				Key:      obj.Loc.Loc(),
+				Created:  optionalTime(obj.Created),
+				Size:     &size,
+				UploadID: obj.UploadID,
+			}, "OBJ", formatTime(c.utc, obj.Created), obj.ContentLength, obj.Loc.Loc())
+		}
+	}
+	return iter.Err()
+}
+
+// listVersions lists every version and delete marker of the objects under the
+// prefix, newest first.
+func (c *cmdLs) listVersions(ctx clingy.Context, fs ulfs.Filesystem, prefix ulloc.Location) error {
+	iter, err := fs.ListObjectVersions(ctx, prefix, c.recursive)
+	if err != nil {
+		return err
+	}
+
+	rw := newRecordWriter(ctx.Stdout(), c.ex.OutputFormat(), "KIND", "CREATED", "SIZE", "VERSION", "KEY")
+	defer rw.Done()
+
+	for iter.Next() {
+		obj := iter.Item()
+		switch {
+		case obj.IsPrefix:
+			rw.Write(lsRecord{Kind: "prefix", Key: obj.Loc.Loc()},
+				"PRE", "", "", "", obj.Loc.Loc())
+		case obj.DeleteMarker:
+			rw.Write(lsRecord{
+				Kind:    "delete-marker",
+				Key:     obj.Loc.Loc(),
+				Created: optionalTime(obj.Created),
+				Version: obj.Version,
+			}, "DEL", formatTime(c.utc, obj.Created), "", obj.Version, obj.Loc.Loc())
+		default:
+			size := obj.ContentLength
+			rw.Write(lsRecord{
+				Kind:    "object",
+				Key:     obj.Loc.Loc(),
+				Created: optionalTime(obj.Created),
+				Size:    &size,
+				Version: obj.Version,
+			}, "OBJ", formatTime(c.utc, obj.Created), obj.ContentLength, obj.Version, obj.Loc.Loc())
 		}
 	}
 	return iter.Err()
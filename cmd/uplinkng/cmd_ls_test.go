@@ -6,6 +6,11 @@ package main
 import (
 	"testing"
 
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/clingy"
+
+	"storj.io/storj/cmd/uplinkng/ulfs"
+	"storj.io/storj/cmd/uplinkng/ulloc"
 	"storj.io/storj/cmd/uplinkng/ultest"
 )
 
@@ -392,3 +397,87 @@ func TestLsRelative(t *testing.T) {
 	})
 
 }
+
+func TestLsAllVersions(t *testing.T) {
+	state := ultest.Setup(commands,
+		ultest.WithFile("sj://user/dir/file", "v1"),
+		ultest.WithFile("sj://user/dir/file", "v2-"),
+		ultest.WithFile("sj://user/other", "other"),
+		ultest.WithFile("sj://user/deleted", "gone"),
+		ultest.WithFilesystem(func(t *testing.T, ctx clingy.Context, fs ulfs.Filesystem) {
+			require.NoError(t, fs.Remove(ctx, ulloc.NewRemote("user", "deleted")))
+		}),
+	)
+
+	t.Run("Recursive", func(t *testing.T) {
+		state.Succeed(t, "ls", "sj://user", "--all-versions", "--recursive", "--utc").RequireStdout(t, `
+			KIND    CREATED                SIZE    VERSION    KEY
+			DEL     1970-01-01 00:00:05            2          deleted
+			OBJ     1970-01-01 00:00:04    4       1          deleted
+			OBJ     1970-01-01 00:00:02    3       2          dir/file
+			OBJ     1970-01-01 00:00:01    2       1          dir/file
+			OBJ     1970-01-01 00:00:03    5       1          other
+		`)
+	})
+
+	t.Run("Prefix", func(t *testing.T) {
+		state.Succeed(t, "ls", "sj://user/", "--all-versions", "--utc").RequireStdout(t, `
+			KIND    CREATED                SIZE    VERSION    KEY
+			DEL     1970-01-01 00:00:05            2          deleted
+			OBJ     1970-01-01 00:00:04    4       1          deleted
+			PRE                                               dir/
+			OBJ     1970-01-01 00:00:03    5       1          other
+		`)
+	})
+
+	t.Run("Pending", func(t *testing.T) {
+		state.Fail(t, "ls", "sj://user/", "--all-versions", "--pending")
+	})
+
+	t.Run("Local", func(t *testing.T) {
+		state.Fail(t, "ls", "/home/user/", "--all-versions")
+	})
+}
+
+func TestLsTags(t *testing.T) {
+	state := ultest.Setup(commands,
+		ultest.WithFile("sj://user/dir/red"),
+		ultest.WithTags("sj://user/dir/red", map[string]string{"color": "red", "size": "big"}),
+		ultest.WithFile("sj://user/dir/blue"),
+		ultest.WithTags("sj://user/dir/blue", map[string]string{"color": "blue"}),
+		ultest.WithFile("sj://user/other/red"),
+		ultest.WithTags("sj://user/other/red", map[string]string{"color": "red"}),
+		ultest.WithFile("sj://user/plain"),
+	)
+
+	t.Run("Recursive", func(t *testing.T) {
+		state.Succeed(t, "ls", "sj://user", "--tag", "color=red", "--recursive", "--utc").RequireStdout(t, `
+			KIND    CREATED                SIZE    KEY
+			OBJ     1970-01-01 00:00:01    0       dir/red
+			OBJ     1970-01-01 00:00:03    0       other/red
+		`)
+	})
+
+	t.Run("MultipleTags", func(t *testing.T) {
+		state.Succeed(t, "ls", "sj://user", "--tag", "color=red", "--tag", "size=big", "--recursive", "--utc").RequireStdout(t, `
+			KIND    CREATED                SIZE    KEY
+			OBJ     1970-01-01 00:00:01    0       dir/red
+		`)
+	})
+
+	t.Run("Prefix", func(t *testing.T) {
+		state.Succeed(t, "ls", "sj://user/", "--tag", "color=blue").RequireStdout(t, `
+			KIND    CREATED    SIZE    KEY
+			PRE                        dir/
+		`)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		state.Fail(t, "ls", "--tag", "color=red")
+		state.Fail(t, "ls", "sj://user", "--tag", "color")
+		state.Fail(t, "ls", "sj://user", "--tag", "color=red", "--tag", "color=blue")
+		state.Fail(t, "ls", "sj://user", "--tag", "color=red", "--pending")
+		state.Fail(t, "ls", "sj://user", "--tag", "color=red", "--all-versions")
+		state.Fail(t, "ls", "/home/user/", "--tag", "color=red")
+	})
+}
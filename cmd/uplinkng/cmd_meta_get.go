@@ -4,7 +4,6 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"strconv"
 
@@ -58,26 +57,26 @@ func (c *cmdMetaGet) Execute(ctx clingy.Context) error {
 		return err
 	}
 
+	format := c.ex.OutputFormat()
+
 	if c.entry != nil {
 		value, ok := object.Custom[*c.entry]
 		if !ok {
 			return errs.New("entry %q does not exist", *c.entry)
 		}
 
+		if format != ulext.OutputTable {
+			return writeRecord(ctx.Stdout(), format, map[string]string{*c.entry: value})
+		}
 		fmt.Fprintln(ctx.Stdout(), value)
 		return nil
 	}
 
-	if object.Custom == nil {
-		fmt.Fprintln(ctx.Stdout(), "{}")
-		return nil
-	}
-
-	data, err := json.MarshalIndent(object.Custom, "", "  ")
-	if err != nil {
-		return errs.Wrap(err)
+	custom := map[string]string(object.Custom)
+	if custom == nil {
+		custom = map[string]string{}
 	}
 
-	fmt.Fprintln(ctx.Stdout(), string(data))
-	return nil
+	// the metadata is written as json even for tables.
+	return writeRecord(ctx.Stdout(), format, custom)
 }
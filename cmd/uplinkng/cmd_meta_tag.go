@@ -0,0 +1,133 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"strings"
+
+	"github.com/zeebo/clingy"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/cmd/uplinkng/ulext"
+	"storj.io/storj/cmd/uplinkng/ulloc"
+)
+
+type cmdMetaTagSet struct {
+	ex ulext.External
+
+	access string
+
+	location ulloc.Location
+	tags     []string
+}
+
+func newCmdMetaTagSet(ex ulext.External) *cmdMetaTagSet {
+	return &cmdMetaTagSet{ex: ex}
+}
+
+func (c *cmdMetaTagSet) Setup(params clingy.Parameters) {
+	c.access = params.Flag("access", "Access name or value to use", "").(string)
+
+	c.location = params.Arg("location", "Location of bucket or object (sj://BUCKET[/KEY])",
+		clingy.Transform(ulloc.Parse),
+	).(ulloc.Location)
+	c.tags = params.Arg("tag", "Tags to set (KEY=VALUE), replacing all existing tags. "+
+		"Tags are not encrypted",
+		clingy.Transform(checkTag),
+		clingy.Repeated,
+	).([]string)
+}
+
+func (c *cmdMetaTagSet) Execute(ctx clingy.Context) error {
+	bucket, key, ok := c.location.RemoteParts()
+	if !ok {
+		return errs.New("location must be remote")
+	}
+
+	tags, err := parseTags(c.tags)
+	if err != nil {
+		return err
+	}
+
+	client, err := c.ex.OpenMetainfo(ctx, c.access)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	if key == "" {
+		return client.SetBucketTags(ctx, bucket, tags)
+	}
+	return client.SetObjectTags(ctx, bucket, key, tags)
+}
+
+type cmdMetaTagGet struct {
+	ex ulext.External
+
+	access string
+
+	location ulloc.Location
+}
+
+func newCmdMetaTagGet(ex ulext.External) *cmdMetaTagGet {
+	return &cmdMetaTagGet{ex: ex}
+}
+
+func (c *cmdMetaTagGet) Setup(params clingy.Parameters) {
+	c.access = params.Flag("access", "Access name or value to use", "").(string)
+
+	c.location = params.Arg("location", "Location of bucket or object (sj://BUCKET[/KEY])",
+		clingy.Transform(ulloc.Parse),
+	).(ulloc.Location)
+}
+
+func (c *cmdMetaTagGet) Execute(ctx clingy.Context) error {
+	bucket, key, ok := c.location.RemoteParts()
+	if !ok {
+		return errs.New("location must be remote")
+	}
+
+	client, err := c.ex.OpenMetainfo(ctx, c.access)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	var tags map[string]string
+	if key == "" {
+		tags, err = client.GetBucketTags(ctx, bucket)
+	} else {
+		tags, err = client.GetObjectTags(ctx, bucket, key)
+	}
+	if err != nil {
+		return err
+	}
+	if tags == nil {
+		tags = map[string]string{}
+	}
+
+	// the tags are written as json even for tables.
+	return writeRecord(ctx.Stdout(), c.ex.OutputFormat(), tags)
+}
+
+// checkTag checks that the tag is of the form KEY=VALUE.
+func checkTag(tag string) (string, error) {
+	if strings.IndexByte(tag, '=') <= 0 {
+		return "", errs.New("invalid tag %q: must be of the form KEY=VALUE", tag)
+	}
+	return tag, nil
+}
+
+// parseTags parses tags checked by checkTag into a map.
+func parseTags(tags []string) (map[string]string, error) {
+	parsed := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		i := strings.IndexByte(tag, '=')
+		if _, ok := parsed[tag[:i]]; ok {
+			return nil, errs.New("duplicate tag %q", tag[:i])
+		}
+		parsed[tag[:i]] = tag[i+1:]
+	}
+	return parsed, nil
+}
@@ -0,0 +1,192 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/zeebo/clingy"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/sync2"
+	"storj.io/storj/cmd/uplinkng/ulext"
+	"storj.io/storj/cmd/uplinkng/ulfs"
+	"storj.io/storj/cmd/uplinkng/ulloc"
+)
+
+type cmdMv struct {
+	ex ulext.External
+
+	access      string
+	recursive   bool
+	parallelism int
+	dryrun      bool
+	progress    bool
+
+	source ulloc.Location
+	dest   ulloc.Location
+}
+
+func newCmdMv(ex ulext.External) *cmdMv {
+	return &cmdMv{ex: ex}
+}
+
+func (c *cmdMv) Setup(params clingy.Parameters) {
+	c.access = params.Flag("access", "Access name or value to use", "").(string)
+	c.recursive = params.Flag("recursive", "Move all objects or files under the specified prefix or directory", false,
+		clingy.Short('r'),
+		clingy.Transform(strconv.ParseBool),
+	).(bool)
+	c.parallelism = params.Flag("parallelism", "Controls how many moves to perform in parallel", 1,
+		clingy.Short('p'),
+		clingy.Transform(strconv.Atoi),
+		clingy.Transform(func(n int) (int, error) {
+			if n <= 0 {
+				return 0, errs.New("parallelism must be at least 1")
+			}
+			return n, nil
+		}),
+	).(int)
+	c.dryrun = params.Flag("dryrun", "Print what operations would happen but don't execute them", false,
+		clingy.Transform(strconv.ParseBool),
+	).(bool)
+	c.progress = params.Flag("progress", "Show a progress bar when possible", true,
+		clingy.Transform(strconv.ParseBool),
+	).(bool)
+
+	c.source = params.Arg("source", "Source to move", clingy.Transform(ulloc.Parse)).(ulloc.Location)
+	c.dest = params.Arg("dest", "Destination to move to", clingy.Transform(ulloc.Parse)).(ulloc.Location)
+}
+
+func (c *cmdMv) Execute(ctx clingy.Context) error {
+	if c.source.Std() || c.dest.Std() {
+		return errs.New("cannot move to or from stdin/stdout")
+	}
+
+	fs, err := c.ex.OpenFilesystem(ctx, c.access)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fs.Close() }()
+
+	// we ensure the source and destination are lexically directoryish
+	// if they map to directories. the destination is always converted to be
+	// directoryish if the move is recursive.
+	if fs.IsLocalDir(ctx, c.source) {
+		c.source = c.source.AsDirectoryish()
+	}
+	if c.recursive || fs.IsLocalDir(ctx, c.dest) {
+		c.dest = c.dest.AsDirectoryish()
+	}
+
+	if c.recursive {
+		return c.moveRecursive(ctx, fs)
+	}
+
+	// if the destination is directoryish, we add the basename of the source
+	// to the end of the destination to pick a filename.
+	var base string
+	if c.dest.Directoryish() {
+		var ok bool
+		base, ok = c.source.Undirectoryish().Base()
+		if !ok {
+			return errs.New("destination is a directory and cannot find base name for source %q", c.source)
+		}
+	}
+	c.dest = joinDestWith(c.dest, base)
+
+	fmt.Fprintln(ctx.Stdout(), "move", c.source, "to", c.dest)
+	if c.dryrun {
+		return nil
+	}
+
+	return moveFile(ctx, fs, c.source, c.dest, c.progress)
+}
+
+func (c *cmdMv) moveRecursive(ctx clingy.Context, fs ulfs.Filesystem) error {
+	iter, err := fs.ListObjects(ctx, c.source, true)
+	if err != nil {
+		return err
+	}
+
+	// the listing is collected before anything is moved, otherwise objects
+	// moved to a destination inside of the source are listed and moved again.
+	var sources []ulloc.Location
+	for iter.Next() {
+		sources = append(sources, iter.Item().Loc)
+	}
+	if err := iter.Err(); err != nil {
+		return errs.Wrap(err)
+	}
+
+	var (
+		limiter = sync2.NewLimiter(c.parallelism)
+		es      errs.Group
+		mu      sync.Mutex
+	)
+
+	fprintln := func(w io.Writer, args ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		fmt.Fprintln(w, args...)
+	}
+
+	addError := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		es.Add(err)
+	}
+
+	for _, source := range sources {
+		source := source
+		rel, err := c.source.RelativeTo(source)
+		if err != nil {
+			return err
+		}
+		dest := joinDestWith(c.dest, rel)
+
+		ok := limiter.Go(ctx, func() {
+			fprintln(ctx.Stdout(), "move", source, "to", dest)
+			if c.dryrun {
+				return
+			}
+
+			if err := moveFile(ctx, fs, source, dest, false); err != nil {
+				fprintln(ctx.Stderr(), "move", source, "failed:", err.Error())
+				addError(err)
+			}
+		})
+		if !ok {
+			break
+		}
+	}
+
+	limiter.Wait()
+
+	if len(es) > 0 {
+		return es.Err()
+	}
+	return nil
+}
+
+// moveFile moves the source to dest. Remote objects are moved on the satellite
+// without transferring their data. Otherwise the source is copied into dest and
+// removed once the copy has been committed.
+func moveFile(ctx clingy.Context, fs ulfs.Filesystem, source, dest ulloc.Location, progress bool) error {
+	if source == dest {
+		return errs.New("source and destination are the same: %q", source)
+	}
+	if source.Remote() && dest.Remote() {
+		return fs.Move(ctx, source, dest)
+	}
+	if err := copyFile(ctx, fs, source, dest, progress); err != nil {
+		return err
+	}
+	return fs.Remove(ctx, source)
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"testing"
+
+	"storj.io/storj/cmd/uplinkng/ultest"
+)
+
+func TestMv(t *testing.T) {
+	state := ultest.Setup(commands,
+		ultest.WithFile("/home/user/file1.txt", "local"),
+		ultest.WithFile("sj://user/file1.txt", "remote"),
+	)
+
+	t.Run("Upload", func(t *testing.T) {
+		state.Succeed(t, "mv", "/home/user/file1.txt", "sj://user/file2.txt").RequireFiles(t,
+			ultest.File{Loc: "sj://user/file1.txt", Contents: "remote"},
+			ultest.File{Loc: "sj://user/file2.txt", Contents: "local"},
+		)
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		state.Succeed(t, "mv", "sj://user/file1.txt", "/home/user/file2.txt").RequireFiles(t,
+			ultest.File{Loc: "/home/user/file1.txt", Contents: "local"},
+			ultest.File{Loc: "/home/user/file2.txt", Contents: "remote"},
+		)
+	})
+
+	t.Run("Remote", func(t *testing.T) {
+		state.Succeed(t, "mv", "sj://user/file1.txt", "sj://user/folder/", "--progress=false").RequireStdout(t, `
+			move sj://user/file1.txt to sj://user/folder/file1.txt
+		`).RequireFiles(t,
+			ultest.File{Loc: "sj://user/folder/file1.txt", Contents: "remote"},
+			ultest.File{Loc: "/home/user/file1.txt", Contents: "local"},
+		)
+	})
+
+	t.Run("RemoteExisting", func(t *testing.T) {
+		// remote objects are moved on the satellite, which does not overwrite
+		// existing objects.
+		state.With(ultest.WithFile("sj://user/file2.txt", "existing")).
+			Fail(t, "mv", "sj://user/file1.txt", "sj://user/file2.txt").RequireFiles(t,
+			ultest.File{Loc: "sj://user/file1.txt", Contents: "remote"},
+			ultest.File{Loc: "sj://user/file2.txt", Contents: "existing"},
+			ultest.File{Loc: "/home/user/file1.txt", Contents: "local"},
+		)
+	})
+
+	t.Run("Same", func(t *testing.T) {
+		state.Fail(t, "mv", "sj://user/file1.txt", "sj://user/file1.txt")
+	})
+
+	t.Run("Dryrun", func(t *testing.T) {
+		state.Succeed(t, "mv", "sj://user/file1.txt", "sj://user/file2.txt", "--dryrun").RequireFiles(t,
+			ultest.File{Loc: "sj://user/file1.txt", Contents: "remote"},
+			ultest.File{Loc: "/home/user/file1.txt", Contents: "local"},
+		)
+	})
+}
+
+func TestMvRecursive(t *testing.T) {
+	state := ultest.Setup(commands,
+		ultest.WithFile("sj://user/folder1/file1.txt", "data1"),
+		ultest.WithFile("sj://user/folder1/folder2/file2.txt", "data2"),
+		ultest.WithFile("sj://user/other.txt", "other"),
+	)
+
+	state.Succeed(t, "mv", "sj://user/folder1/", "sj://user/moved/", "--recursive").RequireFiles(t,
+		ultest.File{Loc: "sj://user/moved/file1.txt", Contents: "data1"},
+		ultest.File{Loc: "sj://user/moved/folder2/file2.txt", Contents: "data2"},
+		ultest.File{Loc: "sj://user/other.txt", Contents: "other"},
+	)
+
+	state.Succeed(t, "mv", "sj://user/folder1", "/home/user/dest", "--recursive").RequireFiles(t,
+		ultest.File{Loc: "/home/user/dest/folder1/file1.txt", Contents: "data1"},
+		ultest.File{Loc: "/home/user/dest/folder1/folder2/file2.txt", Contents: "data2"},
+		ultest.File{Loc: "sj://user/other.txt", Contents: "other"},
+	)
+	t.Run("Into Source", func(t *testing.T) {
+		state := ultest.Setup(commands,
+			ultest.WithFile("sj://user/folder1/file1.txt", "data1"),
+			ultest.WithFile("sj://user/folder1/folder2/file2.txt", "data2"),
+		)
+
+		state.Succeed(t, "mv", "sj://user/folder1/", "sj://user/folder1/sub/", "--recursive").RequireFiles(t,
+			ultest.File{Loc: "sj://user/folder1/sub/file1.txt", Contents: "data1"},
+			ultest.File{Loc: "sj://user/folder1/sub/folder2/file2.txt", Contents: "data2"},
+		)
+	})
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/zeebo/clingy"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/cmd/uplinkng/ulext"
+	"storj.io/storj/cmd/uplinkng/ulloc"
+)
+
+// restoreRecord is the machine readable result of restoring an object version.
+type restoreRecord struct {
+	Bucket          string `json:"bucket"`
+	Key             string `json:"key"`
+	Version         int64  `json:"version"`
+	RestoredVersion int64  `json:"restoredVersion"`
+}
+
+type cmdRestore struct {
+	ex ulext.External
+
+	access string
+
+	location ulloc.Location
+	version  int64
+}
+
+func newCmdRestore(ex ulext.External) *cmdRestore {
+	return &cmdRestore{ex: ex}
+}
+
+func (c *cmdRestore) Setup(params clingy.Parameters) {
+	c.access = params.Flag("access", "Access name or value to use", "").(string)
+
+	c.location = params.Arg("location", "Object to restore a version of (sj://BUCKET/KEY)",
+		clingy.Transform(ulloc.Parse),
+	).(ulloc.Location)
+	c.version = params.Arg("version", "Version to make the latest version again, as listed by ls --all-versions",
+		clingy.Transform(parseVersionFlag),
+	).(int64)
+}
+
+func (c *cmdRestore) Execute(ctx clingy.Context) error {
+	bucket, key, ok := c.location.RemoteParts()
+	if !ok || key == "" {
+		return errs.New("location must be a remote object: %q", c.location)
+	}
+
+	client, err := c.ex.OpenMetainfo(ctx, c.access)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	restored, err := client.RestoreObjectVersion(ctx, bucket, key, c.version)
+	if err != nil {
+		return err
+	}
+
+	format := c.ex.OutputFormat()
+	if format != ulext.OutputTable {
+		return writeRecord(ctx.Stdout(), format, restoreRecord{
+			Bucket:          bucket,
+			Key:             key,
+			Version:         c.version,
+			RestoredVersion: restored,
+		})
+	}
+	fmt.Fprintln(ctx.Stdout(), "restored version", c.version, "of", c.location, "as version", restored)
+	return nil
+}
@@ -0,0 +1,272 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/zeebo/clingy"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/cmd/uplinkng/ulext"
+	"storj.io/storj/cmd/uplinkng/ulloc"
+)
+
+// bucketRetentionRecord is the machine readable default retention of a bucket.
+type bucketRetentionRecord struct {
+	Bucket string `json:"bucket"`
+	Days   int    `json:"days"`
+}
+
+// objectLockRecord is the machine readable retention and legal hold of an object.
+type objectLockRecord struct {
+	Bucket      string     `json:"bucket"`
+	Key         string     `json:"key"`
+	Version     int64      `json:"version,omitempty"`
+	RetainUntil *time.Time `json:"retainUntil,omitempty"`
+	LegalHold   bool       `json:"legalHold"`
+}
+
+type cmdRetentionDefault struct {
+	ex ulext.External
+
+	access string
+
+	location ulloc.Location
+	days     *int
+}
+
+func newCmdRetentionDefault(ex ulext.External) *cmdRetentionDefault {
+	return &cmdRetentionDefault{ex: ex}
+}
+
+func (c *cmdRetentionDefault) Setup(params clingy.Parameters) {
+	c.access = params.Flag("access", "Access name or value to use", "").(string)
+
+	c.location = params.Arg("bucket", "Bucket to show or change the default retention of (sj://BUCKET)",
+		clingy.Transform(ulloc.Parse),
+	).(ulloc.Location)
+	c.days = params.Arg("days", "Days that new objects can't be deleted or overwritten, 0 disables the default retention",
+		clingy.Optional,
+		clingy.Transform(strconv.Atoi),
+		clingy.Transform(func(days int) (int, error) {
+			if days < 0 {
+				return 0, errs.New("days must not be negative: %d", days)
+			}
+			return days, nil
+		}),
+	).(*int)
+}
+
+func (c *cmdRetentionDefault) Execute(ctx clingy.Context) error {
+	bucket, key, ok := c.location.RemoteParts()
+	if !ok || key != "" {
+		return errs.New("location must be a bucket: %q", c.location)
+	}
+
+	client, err := c.ex.OpenMetainfo(ctx, c.access)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	if c.days != nil {
+		if err := client.SetBucketRetention(ctx, bucket, *c.days); err != nil {
+			return err
+		}
+	}
+
+	days, err := client.GetBucketRetention(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	format := c.ex.OutputFormat()
+	if format != ulext.OutputTable {
+		return writeRecord(ctx.Stdout(), format, bucketRetentionRecord{Bucket: bucket, Days: days})
+	}
+	if days == 0 {
+		fmt.Fprintln(ctx.Stdout(), c.location, "has no default retention")
+		return nil
+	}
+	fmt.Fprintln(ctx.Stdout(), "new objects of", c.location, "are retained for", days, "days")
+	return nil
+}
+
+type cmdRetentionSet struct {
+	ex ulext.External
+
+	access  string
+	version int64
+
+	location    ulloc.Location
+	retainUntil time.Time
+}
+
+func newCmdRetentionSet(ex ulext.External) *cmdRetentionSet {
+	return &cmdRetentionSet{ex: ex}
+}
+
+func (c *cmdRetentionSet) Setup(params clingy.Parameters) {
+	c.access = params.Flag("access", "Access name or value to use", "").(string)
+	c.version = params.Flag("version", "Version of the object (defaults to the latest version)", int64(0),
+		clingy.Transform(parseVersionFlag),
+	).(int64)
+
+	now := time.Now()
+	c.location = params.Arg("location", "Object to protect from deletion (sj://BUCKET/KEY)",
+		clingy.Transform(ulloc.Parse),
+	).(ulloc.Location)
+	c.retainUntil = params.Arg("until", "Date until the object can't be deleted or overwritten "+
+		"(YYYY-MM-DD, RFC3339 or a duration relative to now like +720h). "+
+		"An existing retention can only be extended",
+		clingy.Transform(func(date string) (time.Time, error) { return parseFindTime(now, date) }),
+	).(time.Time)
+}
+
+func (c *cmdRetentionSet) Execute(ctx clingy.Context) error {
+	bucket, key, ok := c.location.RemoteParts()
+	if !ok || key == "" {
+		return errs.New("location must be a remote object: %q", c.location)
+	}
+
+	client, err := c.ex.OpenMetainfo(ctx, c.access)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	return client.SetObjectRetention(ctx, bucket, key, c.version, c.retainUntil)
+}
+
+type cmdRetentionLegalHold struct {
+	ex ulext.External
+
+	access  string
+	version int64
+
+	location ulloc.Location
+	enabled  bool
+}
+
+func newCmdRetentionLegalHold(ex ulext.External) *cmdRetentionLegalHold {
+	return &cmdRetentionLegalHold{ex: ex}
+}
+
+func (c *cmdRetentionLegalHold) Setup(params clingy.Parameters) {
+	c.access = params.Flag("access", "Access name or value to use", "").(string)
+	c.version = params.Flag("version", "Version of the object (defaults to the latest version)", int64(0),
+		clingy.Transform(parseVersionFlag),
+	).(int64)
+
+	c.location = params.Arg("location", "Object to place or remove the legal hold on (sj://BUCKET/KEY)",
+		clingy.Transform(ulloc.Parse),
+	).(ulloc.Location)
+	c.enabled = params.Arg("state", "Places or removes the legal hold (on, off). "+
+		"Removing it needs the permission to delete the object",
+		clingy.Transform(func(state string) (bool, error) {
+			switch state {
+			case "on":
+				return true, nil
+			case "off":
+				return false, nil
+			default:
+				return false, errs.New("invalid legal hold state: %q", state)
+			}
+		}),
+	).(bool)
+}
+
+func (c *cmdRetentionLegalHold) Execute(ctx clingy.Context) error {
+	bucket, key, ok := c.location.RemoteParts()
+	if !ok || key == "" {
+		return errs.New("location must be a remote object: %q", c.location)
+	}
+
+	client, err := c.ex.OpenMetainfo(ctx, c.access)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	return client.SetObjectLegalHold(ctx, bucket, key, c.version, c.enabled)
+}
+
+type cmdRetentionGet struct {
+	ex ulext.External
+
+	access  string
+	version int64
+	utc     bool
+
+	location ulloc.Location
+}
+
+func newCmdRetentionGet(ex ulext.External) *cmdRetentionGet {
+	return &cmdRetentionGet{ex: ex}
+}
+
+func (c *cmdRetentionGet) Setup(params clingy.Parameters) {
+	c.access = params.Flag("access", "Access name or value to use", "").(string)
+	c.version = params.Flag("version", "Version of the object (defaults to the latest version)", int64(0),
+		clingy.Transform(parseVersionFlag),
+	).(int64)
+	c.utc = params.Flag("utc", "Show all timestamps in UTC instead of local time", false,
+		clingy.Transform(strconv.ParseBool),
+	).(bool)
+
+	c.location = params.Arg("location", "Object to show the retention and legal hold of (sj://BUCKET/KEY)",
+		clingy.Transform(ulloc.Parse),
+	).(ulloc.Location)
+}
+
+func (c *cmdRetentionGet) Execute(ctx clingy.Context) error {
+	bucket, key, ok := c.location.RemoteParts()
+	if !ok || key == "" {
+		return errs.New("location must be a remote object: %q", c.location)
+	}
+
+	client, err := c.ex.OpenMetainfo(ctx, c.access)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	lock, err := client.GetObjectLock(ctx, bucket, key, c.version)
+	if err != nil {
+		return err
+	}
+
+	format := c.ex.OutputFormat()
+	if format != ulext.OutputTable {
+		return writeRecord(ctx.Stdout(), format, objectLockRecord{
+			Bucket:      bucket,
+			Key:         key,
+			Version:     c.version,
+			RetainUntil: lock.RetainUntil,
+			LegalHold:   lock.LegalHold,
+		})
+	}
+
+	if lock.RetainUntil != nil {
+		fmt.Fprintln(ctx.Stdout(), c.location, "is retained until", formatTime(c.utc, *lock.RetainUntil))
+	} else {
+		fmt.Fprintln(ctx.Stdout(), c.location, "has no retention")
+	}
+	if lock.LegalHold {
+		fmt.Fprintln(ctx.Stdout(), c.location, "is under legal hold")
+	}
+	return nil
+}
+
+// parseVersionFlag parses the version of an object, which must be positive.
+func parseVersionFlag(version string) (int64, error) {
+	v, err := strconv.ParseInt(version, 10, 64)
+	if err != nil || v <= 0 {
+		return 0, errs.New("invalid version %q: must be a positive number", version)
+	}
+	return v, nil
+}
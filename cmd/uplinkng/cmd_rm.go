@@ -14,12 +14,20 @@ import (
 	"storj.io/storj/cmd/uplinkng/ulloc"
 )
 
+// rmRecord is the machine readable result of removing a location.
+type rmRecord struct {
+	Location string `json:"location"`
+	Removed  bool   `json:"removed"`
+	Error    string `json:"error,omitempty"`
+}
+
 type cmdRm struct {
 	ex ulext.External
 
 	access    string
 	recursive bool
 	encrypted bool
+	filter    filterFlags
 
 	location ulloc.Location
 }
@@ -37,6 +45,7 @@ func (c *cmdRm) Setup(params clingy.Parameters) {
 	c.encrypted = params.Flag("encrypted", "Interprets keys base64 encoded without decrypting", false,
 		clingy.Transform(strconv.ParseBool),
 	).(bool)
+	c.filter.Setup(params)
 
 	c.location = params.Arg("location", "Location to remove (sj://BUCKET[/KEY])",
 		clingy.Transform(ulloc.Parse),
@@ -44,18 +53,21 @@ func (c *cmdRm) Setup(params clingy.Parameters) {
 }
 
 func (c *cmdRm) Execute(ctx clingy.Context) error {
-	fs, err := c.ex.OpenFilesystem(ctx, c.access, ulext.BypassEncryption(c.encrypted))
+	fs, err := c.ex.OpenFilesystem(ctx, c.access, ulext.BypassEncryption(c.encrypted), c.filter.option(nil))
 	if err != nil {
 		return err
 	}
 	defer func() { _ = fs.Close() }()
 
+	rw := newRecordWriter(ctx.Stdout(), c.ex.OutputFormat())
+	defer rw.Done()
+
 	if !c.recursive {
 		if err := fs.Remove(ctx, c.location); err != nil {
 			return err
 		}
 
-		fmt.Fprintln(ctx.Stdout(), "removed", c.location)
+		rw.Write(rmRecord{Location: c.location.String(), Removed: true}, "removed", c.location)
 		return nil
 	}
 
@@ -69,10 +81,14 @@ func (c *cmdRm) Execute(ctx clingy.Context) error {
 		loc := iter.Item().Loc
 
 		if err := fs.Remove(ctx, loc); err != nil {
-			fmt.Fprintln(ctx.Stderr(), "remove", loc, "failed:", err.Error())
+			if c.ex.OutputFormat() == ulext.OutputTable {
+				fmt.Fprintln(ctx.Stderr(), "remove", loc, "failed:", err.Error())
+			} else {
+				rw.Write(rmRecord{Location: loc.String(), Error: err.Error()})
+			}
 			anyFailed = true
 		} else {
-			fmt.Fprintln(ctx.Stdout(), "removed", loc)
+			rw.Write(rmRecord{Location: loc.String(), Removed: true}, "removed", loc)
 		}
 	}
 
@@ -40,6 +40,21 @@ func TestRmRemote(t *testing.T) {
 			ultest.File{Loc: "/home/user/files/file2.txt"},
 		)
 	})
+
+	t.Run("Filter", func(t *testing.T) {
+		state := ultest.Setup(commands,
+			ultest.WithFile("sj://user/files/.storjignore", "keep/\n"),
+			ultest.WithFile("sj://user/files/file1.txt"),
+			ultest.WithFile("sj://user/files/file2.log"),
+			ultest.WithFile("sj://user/files/keep/file3.txt"),
+		)
+
+		state.Succeed(t, "rm", "sj://user/files", "-r", "--exclude", "*.log", "--exclude", ".storjignore").RequireFiles(t,
+			ultest.File{Loc: "sj://user/files/.storjignore", Contents: "keep/\n"},
+			ultest.File{Loc: "sj://user/files/file2.log"},
+			ultest.File{Loc: "sj://user/files/keep/file3.txt"},
+		)
+	})
 }
 
 func TestRmLocal(t *testing.T) {
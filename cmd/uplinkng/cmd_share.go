@@ -4,15 +4,43 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/zeebo/clingy"
+	"github.com/zeebo/errs"
 
 	"storj.io/storj/cmd/uplinkng/ulext"
+	"storj.io/storj/cmd/uplinkng/ulloc"
+	"storj.io/uplink"
 )
 
+// registerTimeout is how long registering an access with the auth service may take.
+const registerTimeout = 15 * time.Second
+
 type cmdShare struct {
 	ex ulext.External
+	ap accessPermissions
 
-	access string
+	access      string
+	register    bool
+	url         bool
+	public      bool
+	authService string
+	baseURL     string
+	exportTo    string
+
+	locations []ulloc.Location
 }
 
 func newCmdShare(ex ulext.External) *cmdShare {
@@ -20,9 +48,190 @@ func newCmdShare(ex ulext.External) *cmdShare {
 }
 
 func (c *cmdShare) Setup(params clingy.Parameters) {
-	c.access = params.Flag("access", "Access name or value to use", "").(string)
+	c.access = params.Flag("access", "Access name or value to share", "").(string)
+	c.register = params.Flag("register", "Register the access with the auth service", false,
+		clingy.Transform(strconv.ParseBool),
+	).(bool)
+	c.url = params.Flag("url", "Print a link sharing URL for the shared prefix. Implies --register and --public", false,
+		clingy.Transform(strconv.ParseBool),
+	).(bool)
+	c.public = params.Flag("public", "Register the access as public, so it can be used without a secret key", false,
+		clingy.Transform(strconv.ParseBool),
+	).(bool)
+	c.authService = params.Flag("auth-service", "URL of the auth service used to register the access",
+		"https://auth.us1.storjshare.io").(string)
+	c.baseURL = params.Flag("base-url", "Base URL for link sharing", "https://link.us1.storjshare.io").(string)
+	c.exportTo = params.Flag("export-to", "Path to export the shared access to", "").(string)
+
+	params.Break()
+	c.ap.defaultReadonly = true
+	c.ap.Setup(params)
+
+	c.locations = params.Arg("prefix", "Key prefixes to share (sj://BUCKET[/KEY])",
+		clingy.Transform(ulloc.Parse),
+		clingy.Repeated,
+	).([]ulloc.Location)
 }
 
 func (c *cmdShare) Execute(ctx clingy.Context) error {
+	for _, loc := range c.locations {
+		bucket, key, ok := loc.RemoteParts()
+		if !ok {
+			return errs.New("invalid prefix: must be remote: %q", loc)
+		}
+		c.ap.prefixes = append(c.ap.prefixes, uplink.SharePrefix{
+			Bucket: bucket,
+			Prefix: key,
+		})
+	}
+
+	access, err := c.ex.OpenAccess(c.access)
+	if err != nil {
+		return err
+	}
+
+	newAccess, err := c.ap.Apply(access)
+	if err != nil {
+		return err
+	}
+
+	accessValue, err := newAccess.Serialize()
+	if err != nil {
+		return errs.Wrap(err)
+	}
+
+	c.printRestrictions(ctx.Stdout(), newAccess.SatelliteAddress(), accessValue)
+
+	// the link is made either from the access key of the registered access or
+	// from the access grant itself.
+	linkAccess := accessValue
+	if c.register || c.url {
+		accessKey, secretKey, endpoint, err := registerAccess(ctx, c.authService, accessValue, c.public || c.url)
+		if err != nil {
+			return err
+		}
+		linkAccess = accessKey
+
+		fmt.Fprintln(ctx.Stdout(), "=========== CREDENTIALS ===================================================================")
+		fmt.Fprintln(ctx.Stdout(), "Access Key ID:", accessKey)
+		fmt.Fprintln(ctx.Stdout(), "Secret Key   :", secretKey)
+		fmt.Fprintln(ctx.Stdout(), "Endpoint     :", endpoint)
+		fmt.Fprintln(ctx.Stdout(), "Public Access:", c.public || c.url)
+	}
+
+	if len(c.ap.prefixes) == 1 && !c.ap.canWrite() {
+		prefix := c.ap.prefixes[0]
+		fmt.Fprintln(ctx.Stdout(), "=========== BROWSER URL ==================================================================")
+		fmt.Fprintln(ctx.Stdout(), "REMINDER  : Object key must end in '/' when trying to share recursively")
+		fmt.Fprintf(ctx.Stdout(), "URL       : %s/s/%s/%s/%s\n", strings.TrimSuffix(c.baseURL, "/"),
+			url.PathEscape(linkAccess), prefix.Bucket, prefix.Prefix)
+	}
+
+	if c.exportTo != "" {
+		// convert to an absolute path, mostly for output purposes.
+		exportTo, err := filepath.Abs(c.exportTo)
+		if err != nil {
+			return errs.Wrap(err)
+		}
+		if err := ioutil.WriteFile(exportTo, []byte(accessValue+"\n"), 0600); err != nil {
+			return errs.Wrap(err)
+		}
+		fmt.Fprintln(ctx.Stdout(), "Exported to:", exportTo)
+	}
+
 	return nil
 }
+
+func (c *cmdShare) printRestrictions(w io.Writer, satelliteAddress, accessValue string) {
+	permission := c.ap.permission()
+
+	formatPermission := func(allowed bool) string {
+		if allowed {
+			return "Allowed"
+		}
+		return "Disallowed"
+	}
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return "No restriction"
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	paths := "WARNING! The entire project is shared!"
+	if len(c.ap.prefixes) > 0 {
+		var locations []string
+		for _, prefix := range c.ap.prefixes {
+			if prefix.Prefix == "" {
+				locations = append(locations, "sj://"+prefix.Bucket+"/ (entire bucket)")
+			} else {
+				locations = append(locations, "sj://"+prefix.Bucket+"/"+prefix.Prefix)
+			}
+		}
+		paths = strings.Join(locations, "\n            ")
+	}
+
+	fmt.Fprintln(w, "Sharing access to satellite", satelliteAddress)
+	fmt.Fprintln(w, "=========== ACCESS RESTRICTIONS ==========================================================")
+	fmt.Fprintln(w, "Download  :", formatPermission(permission.AllowDownload))
+	fmt.Fprintln(w, "Upload    :", formatPermission(permission.AllowUpload))
+	fmt.Fprintln(w, "Lists     :", formatPermission(permission.AllowList))
+	fmt.Fprintln(w, "Deletes   :", formatPermission(permission.AllowDelete))
+	fmt.Fprintln(w, "NotBefore :", formatTime(permission.NotBefore))
+	fmt.Fprintln(w, "NotAfter  :", formatTime(permission.NotAfter))
+	fmt.Fprintln(w, "Paths     :", paths)
+	fmt.Fprintln(w, "=========== SERIALIZED ACCESS WITH THE ABOVE RESTRICTIONS TO SHARE WITH OTHERS ===========")
+	fmt.Fprintln(w, "Access    :", accessValue)
+}
+
+// registerAccess registers the access grant with the auth service and returns
+// the S3 compatible credentials for it.
+func registerAccess(ctx context.Context, authService, accessValue string, public bool) (accessKey, secretKey, endpoint string, err error) {
+	if authService == "" {
+		return "", "", "", errs.New("no auth service address provided")
+	}
+
+	postData, err := json.Marshal(map[string]interface{}{
+		"access_grant": accessValue,
+		"public":       public,
+	})
+	if err != nil {
+		return "", "", "", errs.Wrap(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, registerTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		strings.TrimSuffix(authService, "/")+"/v1/access", bytes.NewReader(postData))
+	if err != nil {
+		return "", "", "", errs.Wrap(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", "", "", errs.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, resp.Body.Close()) }()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", "", "", errs.Wrap(err)
+	}
+
+	var respBody map[string]string
+	if err := json.Unmarshal(body, &respBody); err != nil {
+		return "", "", "", errs.New("unexpected response from auth service: %s", string(body))
+	}
+
+	accessKey, ok := respBody["access_key_id"]
+	if !ok {
+		return "", "", "", errs.New("access_key_id missing in response")
+	}
+	secretKey, ok = respBody["secret_key"]
+	if !ok {
+		return "", "", "", errs.New("secret_key missing in response")
+	}
+	return accessKey, secretKey, respBody["endpoint"], nil
+}
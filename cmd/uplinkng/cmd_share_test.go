@@ -0,0 +1,137 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/macaroon"
+	"storj.io/common/pb"
+	"storj.io/common/testrand"
+	"storj.io/storj/cmd/uplinkng/ultest"
+	"storj.io/uplink"
+)
+
+// testAccessGrant builds a serialized access grant without contacting a satellite.
+func testAccessGrant(t *testing.T) string {
+	apiKey, err := macaroon.NewAPIKey(testrand.Bytes(32))
+	require.NoError(t, err)
+
+	data, err := pb.Marshal(&pb.Scope{
+		SatelliteAddr: "12whfK1EDvHJtajBiAUeajQLYcWqxcQmdYQU5zX5cCf6bAxfgu4@us1.storj.io:7777",
+		ApiKey:        apiKey.SerializeRaw(),
+		EncryptionAccess: &pb.EncryptionAccess{
+			DefaultKey:        testrand.Bytes(32),
+			DefaultPathCipher: pb.CipherSuite_ENC_AESGCM,
+		},
+	})
+	require.NoError(t, err)
+
+	return base58.CheckEncode(data, 0)
+}
+
+// shareOutputValue returns the value of the first line of the share output
+// starting with the label.
+func shareOutputValue(t *testing.T, stdout, label string) string {
+	for _, line := range strings.Split(stdout, "\n") {
+		if strings.HasPrefix(line, label) {
+			return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(line, label)), ":"))
+		}
+	}
+	t.Fatalf("%q not found in output:\n%s", label, stdout)
+	return ""
+}
+
+func TestShare(t *testing.T) {
+	state := ultest.Setup(commands)
+	grant := testAccessGrant(t)
+
+	t.Run("Readonly By Default", func(t *testing.T) {
+		result := state.Succeed(t, "share", "--access", grant, "sj://bucket/prefix/")
+		stdout := result.Stdout
+
+		require.Equal(t, "Allowed", shareOutputValue(t, stdout, "Download"))
+		require.Equal(t, "Disallowed", shareOutputValue(t, stdout, "Upload"))
+		require.Equal(t, "Allowed", shareOutputValue(t, stdout, "Lists"))
+		require.Equal(t, "Disallowed", shareOutputValue(t, stdout, "Deletes"))
+		require.Equal(t, "sj://bucket/prefix/", shareOutputValue(t, stdout, "Paths"))
+
+		shared := shareOutputValue(t, stdout, "Access    ")
+		_, err := uplink.ParseAccess(shared)
+		require.NoError(t, err)
+		require.NotEqual(t, grant, shared)
+
+		require.Equal(t,
+			"https://link.us1.storjshare.io/s/"+shared+"/bucket/prefix/",
+			shareOutputValue(t, stdout, "URL"))
+	})
+
+	t.Run("Writable And Expiring", func(t *testing.T) {
+		result := state.Succeed(t, "share", "--access", grant,
+			"--readonly=false", "--disallow-deletes",
+			"--not-after", "2030-01-02T15:04:05Z",
+			"--prefix", "sj://bucket")
+		stdout := result.Stdout
+
+		require.Equal(t, "Allowed", shareOutputValue(t, stdout, "Upload"))
+		require.Equal(t, "Disallowed", shareOutputValue(t, stdout, "Deletes"))
+		require.Equal(t, "2030-01-02T15:04:05Z", shareOutputValue(t, stdout, "NotAfter"))
+		require.Equal(t, "sj://bucket/ (entire bucket)", shareOutputValue(t, stdout, "Paths"))
+		require.NotContains(t, stdout, "URL")
+	})
+
+	t.Run("Export", func(t *testing.T) {
+		exportTo := filepath.Join(t.TempDir(), "access")
+		result := state.Succeed(t, "share", "--access", grant, "--export-to", exportTo, "sj://bucket")
+		require.Equal(t, exportTo, shareOutputValue(t, result.Stdout, "Exported to"))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		state.Fail(t, "share", "--access", grant, "/local/path")
+		state.Fail(t, "share", "--access", "invalid", "sj://bucket")
+	})
+}
+
+func TestShareRegister(t *testing.T) {
+	var registered struct {
+		AccessGrant string `json:"access_grant"`
+		Public      bool   `json:"public"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/access", r.URL.Path)
+		require.Equal(t, http.MethodPost, r.Method)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&registered))
+
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"access_key_id": "accesskey",
+			"secret_key":    "secretkey",
+			"endpoint":      "https://gateway.example.test",
+		})
+	}))
+	defer server.Close()
+
+	state := ultest.Setup(commands)
+	grant := testAccessGrant(t)
+
+	result := state.Succeed(t, "share", "--access", grant,
+		"--url", "--auth-service", server.URL, "--base-url", "https://link.example.test",
+		"sj://bucket/file.txt")
+	stdout := result.Stdout
+
+	require.True(t, registered.Public)
+	require.Equal(t, shareOutputValue(t, stdout, "Access    "), registered.AccessGrant)
+
+	require.Equal(t, "accesskey", shareOutputValue(t, stdout, "Access Key ID"))
+	require.Equal(t, "secretkey", shareOutputValue(t, stdout, "Secret Key"))
+	require.Equal(t, "https://gateway.example.test", shareOutputValue(t, stdout, "Endpoint"))
+	require.Equal(t, "https://link.example.test/s/accesskey/bucket/file.txt", shareOutputValue(t, stdout, "URL"))
+}
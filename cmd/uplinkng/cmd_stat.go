@@ -0,0 +1,115 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/zeebo/clingy"
+
+	"storj.io/storj/cmd/uplinkng/ulext"
+	"storj.io/storj/cmd/uplinkng/ulloc"
+	"storj.io/uplink"
+	"storj.io/uplink/private/object"
+)
+
+type cmdStat struct {
+	ex ulext.External
+
+	access    string
+	encrypted bool
+	utc       bool
+
+	location ulloc.Location
+}
+
+func newCmdStat(ex ulext.External) *cmdStat {
+	return &cmdStat{ex: ex}
+}
+
+func (c *cmdStat) Setup(params clingy.Parameters) {
+	c.access = params.Flag("access", "Access name or value to use", "").(string)
+	c.encrypted = params.Flag("encrypted", "Shows keys base64 encoded without decrypting", false,
+		clingy.Transform(strconv.ParseBool),
+	).(bool)
+	c.utc = params.Flag("utc", "Show all timestamps in UTC instead of local time", false,
+		clingy.Transform(strconv.ParseBool),
+	).(bool)
+
+	c.location = params.Arg("location", "Location of the object or file (sj://BUCKET/KEY or /path)",
+		clingy.Transform(ulloc.Parse),
+	).(ulloc.Location)
+}
+
+func (c *cmdStat) Execute(ctx clingy.Context) error {
+	fs, err := c.ex.OpenFilesystem(ctx, c.access, ulext.BypassEncryption(c.encrypted))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fs.Close() }()
+
+	info, err := fs.Stat(ctx, c.location)
+	if err != nil {
+		return err
+	}
+
+	record := newObjectRecord(*info)
+	if bucket, key, ok := c.location.RemoteParts(); ok && !c.encrypted {
+		if segments, ok := c.segmentCount(ctx, bucket, key); ok {
+			record.Segments = &segments
+		}
+	}
+
+	if format := c.ex.OutputFormat(); format != ulext.OutputTable {
+		return writeRecord(ctx.Stdout(), format, record)
+	}
+
+	expires, segments := "never", "unknown"
+	if !info.Expires.IsZero() {
+		expires = formatTime(c.utc, info.Expires)
+	}
+	if record.Segments != nil {
+		segments = strconv.FormatInt(*record.Segments, 10)
+	}
+
+	fmt.Fprintln(ctx.Stdout(), "Location :", record.Location)
+	fmt.Fprintln(ctx.Stdout(), "Kind     :", record.Kind)
+	fmt.Fprintln(ctx.Stdout(), "Size     :", record.Size)
+	fmt.Fprintln(ctx.Stdout(), "Created  :", formatTime(c.utc, info.Created))
+	fmt.Fprintln(ctx.Stdout(), "Expires  :", expires)
+	fmt.Fprintln(ctx.Stdout(), "Segments :", segments)
+
+	if len(info.Metadata) > 0 {
+		keys := make([]string, 0, len(info.Metadata))
+		for key := range info.Metadata {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		fmt.Fprintln(ctx.Stdout(), "Metadata :")
+		tw := newTabbedWriter(ctx.Stdout(), "    KEY", "VALUE")
+		defer tw.Done()
+		for _, key := range keys {
+			tw.WriteLine("    "+key, info.Metadata[key])
+		}
+	}
+	return nil
+}
+
+// segmentCount asks the satellite for the number of segments of the object.
+// The count is informational, so it is left out when it can not be determined.
+func (c *cmdStat) segmentCount(ctx context.Context, bucket, key string) (int64, bool) {
+	access, err := c.ex.OpenAccess(c.access)
+	if err != nil {
+		return 0, false
+	}
+	summary, err := object.GetObjectIPSummary(ctx, uplink.Config{}, access, bucket, key)
+	if err != nil {
+		return 0, false
+	}
+	return summary.SegmentCount, true
+}
@@ -0,0 +1,37 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"testing"
+
+	"storj.io/storj/cmd/uplinkng/ultest"
+)
+
+func TestStat(t *testing.T) {
+	state := ultest.Setup(commands,
+		ultest.WithFile("sj://user/file.txt", "hello"),
+	)
+
+	state.Fail(t, "stat", "sj://user/missing")
+	state.Fail(t, "stat", "sj://user/file.txt", "--output", "xml")
+
+	state.Succeed(t, "stat", "sj://user/file.txt", "--utc").RequireStdout(t, `
+		Location : sj://user/file.txt
+		Kind     : object
+		Size     : 5
+		Created  : 1970-01-01 00:00:01
+		Expires  : never
+		Segments : unknown
+	`)
+
+	state.Succeed(t, "stat", "sj://user/file.txt", "--output", "json").RequireStdout(t, `
+		{
+		  "location": "sj://user/file.txt",
+		  "kind": "object",
+		  "size": 5,
+		  "created": "1970-01-01T00:00:01Z"
+		}
+	`)
+}
@@ -0,0 +1,216 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/zeebo/clingy"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/sync2"
+	"storj.io/storj/cmd/uplinkng/ulext"
+	"storj.io/storj/cmd/uplinkng/ulfs"
+	"storj.io/storj/cmd/uplinkng/ulloc"
+)
+
+type cmdSync struct {
+	ex ulext.External
+
+	access      string
+	delete      bool
+	checksum    bool
+	parallelism int
+	dryrun      bool
+
+	source ulloc.Location
+	dest   ulloc.Location
+}
+
+func newCmdSync(ex ulext.External) *cmdSync {
+	return &cmdSync{ex: ex}
+}
+
+func (c *cmdSync) Setup(params clingy.Parameters) {
+	c.access = params.Flag("access", "Access name or value to use", "").(string)
+	c.delete = params.Flag("delete", "Remove destination objects that do not exist in the source", false,
+		clingy.Transform(strconv.ParseBool),
+	).(bool)
+	c.checksum = params.Flag("checksum", "Compare contents by sha256 checksum instead of size and time", false,
+		clingy.Transform(strconv.ParseBool),
+	).(bool)
+	c.parallelism = params.Flag("parallelism", "Controls how many uploads/downloads to perform in parallel", 1,
+		clingy.Short('p'),
+		clingy.Transform(strconv.Atoi),
+		clingy.Transform(func(n int) (int, error) {
+			if n <= 0 {
+				return 0, errs.New("parallelism must be at least 1")
+			}
+			return n, nil
+		}),
+	).(int)
+	c.dryrun = params.Flag("dryrun", "Print what operations would happen but don't execute them", false,
+		clingy.Transform(strconv.ParseBool),
+	).(bool)
+
+	c.source = params.Arg("source", "Source to sync from", clingy.Transform(ulloc.Parse)).(ulloc.Location)
+	c.dest = params.Arg("dest", "Destination to sync to", clingy.Transform(ulloc.Parse)).(ulloc.Location)
+}
+
+func (c *cmdSync) Execute(ctx clingy.Context) error {
+	if c.source.Std() || c.dest.Std() {
+		return errs.New("cannot sync to or from stdin/stdout")
+	}
+
+	fs, err := c.ex.OpenFilesystem(ctx, c.access)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fs.Close() }()
+
+	// a sync always operates on everything under the source and destination,
+	// so they are both treated as directories.
+	c.source = c.source.AsDirectoryish()
+	c.dest = c.dest.AsDirectoryish()
+
+	sources, err := c.listRelative(ctx, fs, c.source)
+	if err != nil {
+		return err
+	}
+	dests, err := c.listRelative(ctx, fs, c.dest)
+	if err != nil {
+		return err
+	}
+
+	var (
+		limiter = sync2.NewLimiter(c.parallelism)
+		es      errs.Group
+		mu      sync.Mutex
+	)
+
+	fprintln := func(w io.Writer, args ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		fmt.Fprintln(w, args...)
+	}
+
+	addError := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		es.Add(err)
+	}
+
+	for _, rel := range sortedKeys(sources) {
+		src := sources[rel]
+		dst, exists := dests[rel]
+		if exists {
+			changed, err := c.changed(ctx, fs, src, dst)
+			if err != nil {
+				fprintln(ctx.Stderr(), "compare", src.Loc, "failed:", err.Error())
+				addError(err)
+				continue
+			} else if !changed {
+				continue
+			}
+		}
+
+		source, dest := src.Loc, joinDestWith(c.dest, rel)
+		ok := limiter.Go(ctx, func() {
+			fprintln(ctx.Stdout(), copyVerb(source, dest), source, "to", dest)
+			if c.dryrun {
+				return
+			}
+
+			if err := copyFile(ctx, fs, source, dest, false); err != nil {
+				fprintln(ctx.Stderr(), copyVerb(source, dest), "failed:", err.Error())
+				addError(err)
+			}
+		})
+		if !ok {
+			break
+		}
+	}
+
+	limiter.Wait()
+
+	if c.delete {
+		for _, rel := range sortedKeys(dests) {
+			if _, ok := sources[rel]; ok {
+				continue
+			}
+
+			loc := dests[rel].Loc
+			fmt.Fprintln(ctx.Stdout(), "remove", loc)
+			if c.dryrun {
+				continue
+			}
+
+			if err := fs.Remove(ctx, loc); err != nil {
+				fmt.Fprintln(ctx.Stderr(), "remove", loc, "failed:", err.Error())
+				es.Add(err)
+			}
+		}
+	}
+
+	return es.Err()
+}
+
+// listRelative recursively lists all of the objects under the prefix and returns
+// them keyed by their path relative to the prefix.
+func (c *cmdSync) listRelative(ctx clingy.Context, fs ulfs.Filesystem, prefix ulloc.Location) (map[string]ulfs.ObjectInfo, error) {
+	iter, err := fs.ListObjects(ctx, prefix, true)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make(map[string]ulfs.ObjectInfo)
+	for iter.Next() {
+		item := iter.Item()
+		if item.IsPrefix {
+			continue
+		}
+		rel, err := prefix.RelativeTo(item.Loc)
+		if err != nil {
+			return nil, err
+		}
+		infos[rel] = item
+	}
+	return infos, errs.Wrap(iter.Err())
+}
+
+// changed returns true if the source object should be copied over the existing
+// destination object.
+func (c *cmdSync) changed(ctx clingy.Context, fs ulfs.Filesystem, src, dst ulfs.ObjectInfo) (bool, error) {
+	if src.ContentLength != dst.ContentLength {
+		return true, nil
+	}
+	if !c.checksum {
+		return src.Created.After(dst.Created), nil
+	}
+
+	srcSum, err := objectChecksum(ctx, fs, src.Loc)
+	if err != nil {
+		return false, err
+	}
+	dstSum, err := objectChecksum(ctx, fs, dst.Loc)
+	if err != nil {
+		return false, err
+	}
+	return srcSum != dstSum, nil
+}
+
+func sortedKeys(infos map[string]ulfs.ObjectInfo) []string {
+	keys := make([]string, 0, len(infos))
+	for key := range infos {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
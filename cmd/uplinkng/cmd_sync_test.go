@@ -0,0 +1,111 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"testing"
+
+	"storj.io/storj/cmd/uplinkng/ultest"
+)
+
+func TestSyncUpload(t *testing.T) {
+	t.Run("New", func(t *testing.T) {
+		state := ultest.Setup(commands,
+			ultest.WithBucket("user"),
+			ultest.WithFile("/home/user/file1.txt", "data1"),
+			ultest.WithFile("/home/user/folder/file2.txt", "data2"),
+		)
+
+		state.Succeed(t, "sync", "/home/user", "sj://user/backup").RequireRemoteFiles(t,
+			ultest.File{Loc: "sj://user/backup/file1.txt", Contents: "data1"},
+			ultest.File{Loc: "sj://user/backup/folder/file2.txt", Contents: "data2"},
+		)
+	})
+
+	t.Run("Unchanged", func(t *testing.T) {
+		state := ultest.Setup(commands,
+			ultest.WithFile("/home/user/file1.txt", "new"),
+			ultest.WithFile("sj://user/backup/file1.txt", "old"),
+		)
+
+		state.Succeed(t, "sync", "/home/user", "sj://user/backup").RequireStdout(t, ``).RequireRemoteFiles(t,
+			ultest.File{Loc: "sj://user/backup/file1.txt", Contents: "old"},
+		)
+	})
+
+	t.Run("Changed", func(t *testing.T) {
+		state := ultest.Setup(commands,
+			ultest.WithFile("sj://user/backup/file1.txt", "old"),
+			ultest.WithFile("/home/user/file1.txt", "new"),
+		)
+
+		state.Succeed(t, "sync", "/home/user", "sj://user/backup").RequireStdout(t, `
+			upload /home/user/file1.txt to sj://user/backup/file1.txt
+		`).RequireRemoteFiles(t,
+			ultest.File{Loc: "sj://user/backup/file1.txt", Contents: "new"},
+		)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		state := ultest.Setup(commands,
+			ultest.WithFile("sj://user/backup/file1.txt", "data1"),
+			ultest.WithFile("sj://user/backup/file2.txt", "data2"),
+			ultest.WithFile("/home/user/file1.txt", "data1"),
+		)
+
+		state.Succeed(t, "sync", "/home/user", "sj://user/backup").RequireRemoteFiles(t,
+			ultest.File{Loc: "sj://user/backup/file1.txt", Contents: "data1"},
+			ultest.File{Loc: "sj://user/backup/file2.txt", Contents: "data2"},
+		)
+
+		state.Succeed(t, "sync", "/home/user", "sj://user/backup", "--delete").RequireStdout(t, `
+			upload /home/user/file1.txt to sj://user/backup/file1.txt
+			remove sj://user/backup/file2.txt
+		`).RequireRemoteFiles(t,
+			ultest.File{Loc: "sj://user/backup/file1.txt", Contents: "data1"},
+		)
+	})
+
+	t.Run("Dryrun", func(t *testing.T) {
+		state := ultest.Setup(commands,
+			ultest.WithFile("sj://user/backup/file2.txt", "data2"),
+			ultest.WithFile("/home/user/file1.txt", "data1"),
+		)
+
+		state.Succeed(t, "sync", "/home/user", "sj://user/backup", "--delete", "--dryrun").RequireStdout(t, `
+			upload /home/user/file1.txt to sj://user/backup/file1.txt
+			remove sj://user/backup/file2.txt
+		`).RequireRemoteFiles(t,
+			ultest.File{Loc: "sj://user/backup/file2.txt", Contents: "data2"},
+		)
+	})
+
+	t.Run("Checksum", func(t *testing.T) {
+		state := ultest.Setup(commands,
+			ultest.WithFile("sj://user/backup/file1.txt", "same"),
+			ultest.WithFile("sj://user/backup/file2.txt", "old"),
+			ultest.WithFile("/home/user/file1.txt", "same"),
+			ultest.WithFile("/home/user/file2.txt", "new"),
+		)
+
+		state.Succeed(t, "sync", "/home/user", "sj://user/backup", "--checksum").RequireStdout(t, `
+			upload /home/user/file2.txt to sj://user/backup/file2.txt
+		`)
+	})
+}
+
+func TestSyncDownload(t *testing.T) {
+	state := ultest.Setup(commands,
+		ultest.WithFile("sj://user/file1.txt", "data1"),
+		ultest.WithFile("sj://user/folder/file2.txt", "data2"),
+		ultest.WithFile("/home/user/dest/file1.txt", "data1"),
+	)
+
+	state.Succeed(t, "sync", "sj://user", "/home/user/dest").RequireStdout(t, `
+		download sj://user/folder/file2.txt to /home/user/dest/folder/file2.txt
+	`).RequireLocalFiles(t,
+		ultest.File{Loc: "/home/user/dest/file1.txt", Contents: "data1"},
+		ultest.File{Loc: "/home/user/dest/folder/file2.txt", Contents: "data2"},
+	)
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/zeebo/clingy"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/cmd/uplinkng/ulext"
+	"storj.io/storj/cmd/uplinkng/ulloc"
+)
+
+type cmdUploadsAbort struct {
+	ex ulext.External
+
+	access    string
+	recursive bool
+	uploadID  string
+
+	location ulloc.Location
+}
+
+func newCmdUploadsAbort(ex ulext.External) *cmdUploadsAbort {
+	return &cmdUploadsAbort{ex: ex}
+}
+
+func (c *cmdUploadsAbort) Setup(params clingy.Parameters) {
+	c.access = params.Flag("access", "Access name or value to use", "").(string)
+	c.recursive = params.Flag("recursive", "Abort all pending uploads with the key prefix", false,
+		clingy.Short('r'),
+		clingy.Transform(strconv.ParseBool),
+	).(bool)
+	c.uploadID = params.Flag("upload-id", "Only abort the upload with this ID", "").(string)
+
+	c.location = params.Arg("location", "Location of the pending uploads (sj://BUCKET[/KEY])",
+		clingy.Transform(ulloc.Parse),
+	).(ulloc.Location)
+}
+
+func (c *cmdUploadsAbort) Execute(ctx clingy.Context) error {
+	if !c.location.Remote() {
+		return errs.New("location must be remote")
+	}
+
+	fs, err := c.ex.OpenFilesystem(ctx, c.access)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fs.Close() }()
+
+	statePaths, states, err := loadUploadStates(c.ex.UploadStateDir())
+	if err != nil {
+		return err
+	}
+
+	iter, err := fs.ListUploads(ctx, c.location, true)
+	if err != nil {
+		return err
+	}
+
+	anyFailed := false
+	for iter.Next() {
+		item := iter.Item()
+		if !c.recursive && item.Loc != c.location {
+			continue
+		}
+		if item.UploadID == "" || (c.uploadID != "" && item.UploadID != c.uploadID) {
+			continue
+		}
+
+		if err := fs.AbortMultipart(ctx, item.Loc, item.UploadID); err != nil {
+			fmt.Fprintln(ctx.Stderr(), "abort", item.Loc, "failed:", err.Error())
+			anyFailed = true
+			continue
+		}
+		fmt.Fprintln(ctx.Stdout(), "aborted", item.Loc, item.UploadID)
+
+		// the upload can not be resumed anymore, so forget its progress.
+		for i, state := range states {
+			if state.UploadID == item.UploadID && state.Dest == item.Loc.String() {
+				if err := removeUploadState(statePaths[i]); err != nil {
+					fmt.Fprintln(ctx.Stderr(), "removing upload state failed:", err.Error())
+				}
+			}
+		}
+	}
+
+	if err := iter.Err(); err != nil {
+		return errs.Wrap(err)
+	} else if anyFailed {
+		return errs.New("some aborts failed")
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/zeebo/clingy"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/storj/cmd/uplinkng/ulext"
+)
+
+// bucketUsage is the usage of a single bucket as returned by the satellite console.
+type bucketUsage struct {
+	BucketName string `json:"bucketName"`
+
+	StorageGBHours float64 `json:"storageGbHours"`
+	EgressGB       float64 `json:"egressGb"`
+	ObjectHours    float64 `json:"objectHours"`
+	SegmentHours   float64 `json:"segmentHours"`
+
+	Since  time.Time `json:"since"`
+	Before time.Time `json:"before"`
+}
+
+type cmdUsage struct {
+	ex ulext.External
+
+	access  string
+	console string
+	csv     bool
+	since   time.Time
+	before  time.Time
+}
+
+func newCmdUsage(ex ulext.External) *cmdUsage {
+	return &cmdUsage{ex: ex}
+}
+
+func (c *cmdUsage) Setup(params clingy.Parameters) {
+	c.access = params.Flag("access", "Access name or value to use", "").(string)
+	c.console = params.Flag("console-address", "Address of the satellite console (defaults to the satellite host)", "").(string)
+	c.csv = params.Flag("csv", "Write the usage as csv instead of the selected output format", false,
+		clingy.Transform(strconv.ParseBool),
+	).(bool)
+
+	now := time.Now().UTC()
+	transformDate := clingy.Transform(func(date string) (time.Time, error) {
+		if t, err := time.Parse("2006-01-02", date); err == nil {
+			return t, nil
+		}
+		t, err := time.Parse(time.RFC3339, date)
+		return t, errs.Wrap(err)
+	})
+
+	c.since = params.Flag("since", "Start of the period as YYYY-MM-DD or RFC3339 (defaults to the start of the month)",
+		time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC), transformDate).(time.Time)
+	c.before = params.Flag("before", "End of the period as YYYY-MM-DD or RFC3339 (defaults to now)",
+		now, transformDate).(time.Time)
+}
+
+func (c *cmdUsage) Execute(ctx clingy.Context) error {
+	if !c.since.Before(c.before) {
+		return errs.New("--since must be earlier than --before")
+	}
+
+	access, err := c.ex.OpenAccess(c.access)
+	if err != nil {
+		return err
+	}
+
+	serialized, err := access.Serialize()
+	if err != nil {
+		return errs.Wrap(err)
+	}
+
+	// the api key is not exposed by the access, so it is taken from the
+	// serialized access grant instead.
+	data, version, err := base58.CheckDecode(serialized)
+	if err != nil || version != 0 {
+		return errs.New("invalid access grant format: %v", err)
+	}
+	var scope pb.Scope
+	if err := pb.Unmarshal(data, &scope); err != nil {
+		return errs.Wrap(err)
+	}
+	apiKey := base58.CheckEncode(scope.ApiKey, 0)
+
+	address := c.console
+	if address == "" {
+		address, err = consoleAddress(access.SatelliteAddress())
+		if err != nil {
+			return err
+		}
+	}
+
+	usage, err := fetchBucketUsage(ctx, address, apiKey, c.since, c.before)
+	if err != nil {
+		return err
+	}
+
+	format := c.ex.OutputFormat()
+	if c.csv {
+		format = "csv"
+	}
+	return writeBucketUsage(ctx.Stdout(), format, usage)
+}
+
+// consoleAddress returns the default console address for the satellite,
+// which is served over https on the satellite host.
+func consoleAddress(satelliteAddress string) (string, error) {
+	nodeURL, err := storj.ParseNodeURL(satelliteAddress)
+	if err != nil {
+		return "", errs.Wrap(err)
+	}
+	host, _, err := net.SplitHostPort(nodeURL.Address)
+	if err != nil {
+		return "", errs.Wrap(err)
+	}
+	return "https://" + host, nil
+}
+
+// fetchBucketUsage queries the satellite console for the per-bucket usage.
+func fetchBucketUsage(ctx context.Context, address, apiKey string, since, before time.Time) (_ []bucketUsage, err error) {
+	query := url.Values{}
+	query.Set("since", strconv.FormatInt(since.Unix(), 10))
+	query.Set("before", strconv.FormatInt(before.Unix(), 10))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		strings.TrimSuffix(address, "/")+"/api/v0/usage/buckets?"+query.Encode(), nil)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, resp.Body.Close()) }()
+
+	if resp.StatusCode != http.StatusOK {
+		var response struct {
+			Error string `json:"error"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&response); err != nil || response.Error == "" {
+			return nil, errs.New("unexpected status: %s", resp.Status)
+		}
+		return nil, errs.New("%s: %s", resp.Status, response.Error)
+	}
+
+	var usage []bucketUsage
+	if err := json.NewDecoder(resp.Body).Decode(&usage); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return usage, nil
+}
+
+// writeBucketUsage writes the usage to w in the requested output format or as csv.
+func writeBucketUsage(w io.Writer, format string, usage []bucketUsage) error {
+	formatFloat := func(v float64) string { return strconv.FormatFloat(v, 'f', 4, 64) }
+
+	if format == "csv" {
+		cw := csv.NewWriter(w)
+		_ = cw.Write([]string{"bucket", "storageGbHours", "egressGb", "objectHours", "segmentHours", "since", "before"})
+		for _, bucket := range usage {
+			_ = cw.Write([]string{
+				bucket.BucketName,
+				formatFloat(bucket.StorageGBHours),
+				formatFloat(bucket.EgressGB),
+				formatFloat(bucket.ObjectHours),
+				formatFloat(bucket.SegmentHours),
+				bucket.Since.Format(time.RFC3339),
+				bucket.Before.Format(time.RFC3339),
+			})
+		}
+		cw.Flush()
+		return errs.Wrap(cw.Error())
+	}
+
+	rw := newRecordWriter(w, format, "BUCKET", "STORAGE (GB-HOURS)", "EGRESS (GB)", "OBJECT-HOURS", "SEGMENT-HOURS")
+	defer rw.Done()
+
+	for _, bucket := range usage {
+		rw.Write(bucket,
+			bucket.BucketName,
+			formatFloat(bucket.StorageGBHours),
+			formatFloat(bucket.EgressGB),
+			formatFloat(bucket.ObjectHours),
+			formatFloat(bucket.SegmentHours))
+	}
+	return nil
+}
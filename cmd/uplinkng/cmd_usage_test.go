@@ -0,0 +1,87 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/cmd/uplinkng/ultest"
+)
+
+func TestUsageErrors(t *testing.T) {
+	state := ultest.Setup(commands)
+
+	state.Fail(t, "usage", "--output", "xml")
+	state.Fail(t, "usage", "--since", "yesterday")
+	state.Fail(t, "usage", "--since", "2021-02-01", "--before", "2021-01-01")
+}
+
+func TestUsageFetchAndWrite(t *testing.T) {
+	since := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "unauthorized"})
+			return
+		}
+		require.Equal(t, "/api/v0/usage/buckets", r.URL.Path)
+		require.Equal(t, "1609459200", r.URL.Query().Get("since"))
+		require.Equal(t, "1612137600", r.URL.Query().Get("before"))
+
+		_ = json.NewEncoder(w).Encode([]bucketUsage{{
+			BucketName:     "photos",
+			StorageGBHours: 1.5,
+			EgressGB:       0.25,
+			ObjectHours:    744,
+			SegmentHours:   1488,
+			Since:          since,
+			Before:         before,
+		}})
+	}))
+	defer server.Close()
+
+	_, err := fetchBucketUsage(context.Background(), server.URL, "bad", since, before)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unauthorized")
+
+	usage, err := fetchBucketUsage(context.Background(), server.URL+"/", "key", since, before)
+	require.NoError(t, err)
+	require.Len(t, usage, 1)
+
+	var out bytes.Buffer
+	require.NoError(t, writeBucketUsage(&out, "table", usage))
+	require.Equal(t, ""+
+		"BUCKET    STORAGE (GB-HOURS)    EGRESS (GB)    OBJECT-HOURS    SEGMENT-HOURS\n"+
+		"photos    1.5000                0.2500         744.0000        1488.0000\n",
+		out.String())
+
+	out.Reset()
+	require.NoError(t, writeBucketUsage(&out, "csv", usage))
+	require.Equal(t, ""+
+		"bucket,storageGbHours,egressGb,objectHours,segmentHours,since,before\n"+
+		"photos,1.5000,0.2500,744.0000,1488.0000,2021-01-01T00:00:00Z,2021-02-01T00:00:00Z\n",
+		out.String())
+
+	out.Reset()
+	require.NoError(t, writeBucketUsage(&out, "json", usage))
+	var decoded []bucketUsage
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	require.Equal(t, usage, decoded)
+}
+
+func TestConsoleAddress(t *testing.T) {
+	address, err := consoleAddress("12whfK1EDvHJtajBiAUeajQLYcWqxcQmdYQU5zX5cCf6bAxfgu4@us1.storj.io:7777")
+	require.NoError(t, err)
+	require.Equal(t, "https://us1.storj.io", address)
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"strconv"
+
+	"github.com/zeebo/clingy"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/cmd/uplinkng/ulext"
+	"storj.io/storj/cmd/uplinkng/ulfs"
+	"storj.io/storj/cmd/uplinkng/ulloc"
+)
+
+// verifyRecord is the machine readable result of verifying a single file.
+type verifyRecord struct {
+	Source string `json:"source"`
+	Dest   string `json:"dest"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type cmdVerify struct {
+	ex ulext.External
+
+	access    string
+	recursive bool
+
+	source ulloc.Location
+	dest   ulloc.Location
+}
+
+func newCmdVerify(ex ulext.External) *cmdVerify {
+	return &cmdVerify{ex: ex}
+}
+
+func (c *cmdVerify) Setup(params clingy.Parameters) {
+	c.access = params.Flag("access", "Access name or value to use", "").(string)
+	c.recursive = params.Flag("recursive", "Verify every file beneath the source", false,
+		clingy.Short('r'),
+		clingy.Transform(strconv.ParseBool),
+	).(bool)
+
+	c.source = params.Arg("source", "Files or objects to verify", clingy.Transform(ulloc.Parse)).(ulloc.Location)
+	c.dest = params.Arg("dest", "Copies to verify them against", clingy.Transform(ulloc.Parse)).(ulloc.Location)
+}
+
+func (c *cmdVerify) Execute(ctx clingy.Context) error {
+	if c.source.Std() || c.dest.Std() {
+		return errs.New("cannot verify stdin/stdout")
+	}
+
+	fs, err := c.ex.OpenFilesystem(ctx, c.access)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fs.Close() }()
+
+	rw := newRecordWriter(ctx.Stdout(), c.ex.OutputFormat(), "STATUS", "SOURCE", "DEST")
+	defer rw.Done()
+
+	if !c.recursive {
+		record := verifyFile(ctx, fs, c.source, c.dest)
+		rw.Write(record, record.Status, record.Source, record.Dest)
+		if record.Status != "ok" {
+			return errs.New("%s does not match %s", c.dest, c.source)
+		}
+		return nil
+	}
+
+	if fs.IsLocalDir(ctx, c.source) {
+		c.source = c.source.AsDirectoryish()
+	}
+	c.dest = c.dest.AsDirectoryish()
+
+	iter, err := fs.ListObjects(ctx, c.source, true)
+	if err != nil {
+		return err
+	}
+
+	var total, failed int
+	for iter.Next() {
+		source := iter.Item().Loc
+		rel, err := c.source.RelativeTo(source)
+		if err != nil {
+			return err
+		}
+
+		record := verifyFile(ctx, fs, source, joinDestWith(c.dest, rel))
+		rw.Write(record, record.Status, record.Source, record.Dest)
+
+		total++
+		if record.Status != "ok" {
+			failed++
+		}
+	}
+
+	if err := iter.Err(); err != nil {
+		return errs.Wrap(err)
+	} else if failed > 0 {
+		return errs.New("%d of %d files did not verify", failed, total)
+	}
+	return nil
+}
+
+// verifyFile compares the checksums of source and dest. Checksums stored in
+// object metadata are used when available so that objects do not have to
+// be downloaded.
+func verifyFile(ctx clingy.Context, fs ulfs.Filesystem, source, dest ulloc.Location) verifyRecord {
+	record := verifyRecord{
+		Source: source.String(),
+		Dest:   dest.String(),
+		Status: "ok",
+	}
+
+	expected, err := objectChecksum(ctx, fs, source)
+	if err != nil {
+		record.Status, record.Error = "error", err.Error()
+		return record
+	}
+	actual, err := objectChecksum(ctx, fs, dest)
+	switch {
+	case isNotExist(err):
+		record.Status = "missing"
+	case err != nil:
+		record.Status, record.Error = "error", err.Error()
+	case expected != actual:
+		record.Status = "mismatch"
+	}
+	return record
+}
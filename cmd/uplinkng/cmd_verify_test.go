@@ -0,0 +1,67 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/clingy"
+
+	"storj.io/storj/cmd/uplinkng/ulfs"
+	"storj.io/storj/cmd/uplinkng/ulloc"
+	"storj.io/storj/cmd/uplinkng/ultest"
+)
+
+func TestVerify(t *testing.T) {
+	state := ultest.Setup(commands,
+		ultest.WithBucket("user"),
+		ultest.WithFile("/home/user/dir/good", "good"),
+		ultest.WithFile("/home/user/dir/bad", "good"),
+		ultest.WithFile("/home/user/dir/missing", "missing"),
+		withChecksummedFile("sj://user/dir/good", "good", "770e607624d689265ca6c44884d0807d9b054d23c473c106c72be9de08b7376c"),
+		ultest.WithFile("sj://user/dir/bad", "bad"),
+	)
+
+	t.Run("File", func(t *testing.T) {
+		state.Succeed(t, "verify", "/home/user/dir/good", "sj://user/dir/good").RequireStdout(t, `
+			STATUS    SOURCE                 DEST
+			ok        /home/user/dir/good    sj://user/dir/good
+		`)
+
+		state.Fail(t, "verify", "/home/user/dir/bad", "sj://user/dir/bad")
+		state.Fail(t, "verify", "/home/user/dir/good", "-")
+	})
+
+	t.Run("Recursive", func(t *testing.T) {
+		state.Fail(t, "verify", "/home/user/dir", "sj://user/dir", "-r").RequireStdout(t, `
+			STATUS      SOURCE                    DEST
+			mismatch    /home/user/dir/bad        sj://user/dir/bad
+			ok          /home/user/dir/good       sj://user/dir/good
+			missing     /home/user/dir/missing    sj://user/dir/missing
+		`)
+	})
+
+	t.Run("JSONL", func(t *testing.T) {
+		state.Fail(t, "verify", "sj://user/dir/bad", "/home/user/dir/bad", "--output", "jsonl").RequireStdout(t, `
+			{"source":"sj://user/dir/bad","dest":"/home/user/dir/bad","status":"mismatch"}
+		`)
+	})
+}
+
+// withChecksummedFile creates a remote file with the provided contents and
+// checksum stored in its metadata.
+func withChecksummedFile(location, contents, checksum string) ultest.ExecuteOption {
+	return ultest.WithFilesystem(func(t *testing.T, ctx clingy.Context, fs ulfs.Filesystem) {
+		loc, err := ulloc.Parse(location)
+		require.NoError(t, err)
+
+		wh, err := fs.Create(ctx, loc)
+		require.NoError(t, err)
+		_, err = wh.Write([]byte(contents))
+		require.NoError(t, err)
+		require.NoError(t, wh.SetMetadata(ctx, map[string]string{checksumMetadataKey: checksum}))
+		require.NoError(t, wh.Commit())
+	})
+}
@@ -10,14 +10,24 @@ import (
 
 	"github.com/zeebo/clingy"
 	"github.com/zeebo/errs"
+
+	"storj.io/storj/cmd/uplinkng/ulext"
 )
 
+// versionRecord is the machine readable form of a module version.
+type versionRecord struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+}
+
 type cmdVersion struct {
+	ex ulext.External
+
 	verbose bool
 }
 
-func newCmdVersion() *cmdVersion {
-	return &cmdVersion{}
+func newCmdVersion(ex ulext.External) *cmdVersion {
+	return &cmdVersion{ex: ex}
 }
 
 func (c *cmdVersion) Setup(params clingy.Parameters) {
@@ -33,13 +43,13 @@ func (c *cmdVersion) Execute(ctx clingy.Context) error {
 		return errs.New("unable to read build info")
 	}
 
-	tw := newTabbedWriter(ctx.Stdout(), "PATH", "VERSION")
-	defer tw.Done()
+	rw := newRecordWriter(ctx.Stdout(), c.ex.OutputFormat(), "PATH", "VERSION")
+	defer rw.Done()
 
-	tw.WriteLine(bi.Main.Path, bi.Main.Version)
+	rw.Write(versionRecord{Path: bi.Main.Path, Version: bi.Main.Version}, bi.Main.Path, bi.Main.Version)
 	for _, mod := range bi.Deps {
 		if c.verbose || strings.HasPrefix(mod.Path, "storj.io/") {
-			tw.WriteLine(mod.Path, mod.Version)
+			rw.Write(versionRecord{Path: mod.Path, Version: mod.Version}, mod.Path, mod.Version)
 		}
 	}
 
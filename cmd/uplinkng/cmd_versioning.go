@@ -0,0 +1,87 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/zeebo/clingy"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/cmd/uplinkng/ulext"
+	"storj.io/storj/cmd/uplinkng/ulloc"
+)
+
+// versioningRecord is the machine readable versioning state of a bucket.
+type versioningRecord struct {
+	Bucket     string `json:"bucket"`
+	Versioning string `json:"versioning"`
+}
+
+type cmdVersioning struct {
+	ex ulext.External
+
+	access string
+
+	location ulloc.Location
+	state    *string
+}
+
+func newCmdVersioning(ex ulext.External) *cmdVersioning {
+	return &cmdVersioning{ex: ex}
+}
+
+func (c *cmdVersioning) Setup(params clingy.Parameters) {
+	c.access = params.Flag("access", "Access name or value to use", "").(string)
+
+	c.location = params.Arg("bucket", "Bucket to show or change the versioning of (sj://BUCKET)",
+		clingy.Transform(ulloc.Parse),
+	).(ulloc.Location)
+	c.state = params.Arg("state", "Turns versioning on or off (on, off)", clingy.Optional,
+		clingy.Transform(func(state string) (string, error) {
+			switch state {
+			case "on", "off":
+				return state, nil
+			default:
+				return "", errs.New("invalid versioning state: %q", state)
+			}
+		}),
+	).(*string)
+}
+
+func (c *cmdVersioning) Execute(ctx clingy.Context) error {
+	bucket, key, ok := c.location.RemoteParts()
+	if !ok || key != "" {
+		return errs.New("location must be a bucket: %q", c.location)
+	}
+
+	client, err := c.ex.OpenMetainfo(ctx, c.access)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	if c.state != nil {
+		if err := client.SetBucketVersioning(ctx, bucket, *c.state == "on"); err != nil {
+			return err
+		}
+	}
+
+	enabled, err := client.GetBucketVersioning(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	state := "off"
+	if enabled {
+		state = "on"
+	}
+
+	format := c.ex.OutputFormat()
+	if format != ulext.OutputTable {
+		return writeRecord(ctx.Stdout(), format, versioningRecord{Bucket: bucket, Versioning: state})
+	}
+	fmt.Fprintln(ctx.Stdout(), "versioning of", c.location, "is", state)
+	return nil
+}
@@ -13,10 +13,20 @@ import (
 
 	"github.com/zeebo/clingy"
 	"github.com/zeebo/errs"
+
+	"storj.io/storj/cmd/uplinkng/ulext"
+	"storj.io/storj/cmd/uplinkng/ulfs"
 )
 
 type external struct {
-	interactive bool // controls if interactive input is allowed
+	interactive bool   // controls if interactive input is allowed
+	output      string // format that results and errors are written in
+
+	rate struct {
+		limit    int64             // bytes per second transferred outside of the schedule
+		schedule []ulfs.RateWindow // times of day with a different rate
+		limiter  *ulfs.RateLimiter // shared by every filesystem that is opened
+	}
 
 	dirs struct {
 		loaded  bool   // true if Setup has been called
@@ -63,10 +73,29 @@ func (ex *external) Setup(f clingy.Flags) {
 		clingy.Advanced,
 	).(string)
 
+	ex.output = ulext.OutputFlag(f)
+
 	ex.dirs.loaded = true
+
+	// the rate flags are registered after the directories are loaded so
+	// that they can be set in the config file.
+	ex.rate.limit = f.Flag(
+		"rate-limit", "Limits the combined rate of all transfers, like 20MiB/s (unlimited if not set)", int64(0),
+		clingy.Transform(parseRate),
+		clingy.Type("rate"),
+	).(int64)
+
+	ex.rate.schedule = f.Flag(
+		"rate-schedule", "Uses a different rate limit during a time of day, like 22:00-06:00=unlimited", []ulfs.RateWindow{},
+		clingy.Transform(parseRateWindow),
+		clingy.Repeated,
+		clingy.Advanced,
+	).([]ulfs.RateWindow)
 }
 
+func (ex *external) OutputFormat() string     { return ex.output }
 func (ex *external) AccessInfoFile() string   { return filepath.Join(ex.dirs.current, "access.json") }
+func (ex *external) UploadStateDir() string   { return filepath.Join(ex.dirs.current, "uploads") }
 func (ex *external) ConfigFile() string       { return filepath.Join(ex.dirs.current, "config.ini") }
 func (ex *external) legacyConfigFile() string { return filepath.Join(ex.dirs.legacy, "config.yaml") }
 
@@ -6,8 +6,11 @@ package main
 import (
 	"context"
 
+	"github.com/zeebo/errs"
+
 	"storj.io/storj/cmd/uplinkng/ulext"
 	"storj.io/storj/cmd/uplinkng/ulfs"
+	"storj.io/storj/cmd/uplinkng/ulmetainfo"
 	"storj.io/uplink"
 	privateAccess "storj.io/uplink/private/access"
 )
@@ -17,7 +20,24 @@ func (ex *external) OpenFilesystem(ctx context.Context, accessName string, optio
 	if err != nil {
 		return nil, err
 	}
-	return ulfs.NewMixed(ulfs.NewLocal(), ulfs.NewRemote(project)), nil
+	client, err := ex.OpenMetainfo(ctx, accessName, options...)
+	if err != nil {
+		return nil, errs.Combine(err, project.Close())
+	}
+	mixed := ulfs.NewMixed(ulfs.NewLocal(), ulfs.NewRemote(project, client))
+	mixed.SetFilter(ulext.LoadOptions(options...).Filter)
+
+	fs := ulfs.Filesystem(mixed)
+
+	// every filesystem shares the same limiter so that the rate limit
+	// applies to all of the transfers of the command together.
+	if ex.rate.limiter == nil {
+		ex.rate.limiter = ulfs.NewRateLimiter(ex.rate.limit, ex.rate.schedule)
+	}
+	if ex.rate.limiter.Enabled() {
+		fs = ulfs.NewRateLimited(fs, ex.rate.limiter)
+	}
+	return fs, nil
 }
 
 func (ex *external) OpenProject(ctx context.Context, accessName string, options ...ulext.Option) (*uplink.Project, error) {
@@ -36,3 +56,14 @@ func (ex *external) OpenProject(ctx context.Context, accessName string, options
 
 	return uplink.OpenProject(ctx, access)
 }
+
+func (ex *external) OpenMetainfo(ctx context.Context, accessName string, options ...ulext.Option) (*ulmetainfo.Client, error) {
+	opts := ulext.LoadOptions(options...)
+
+	access, err := ex.OpenAccess(accessName)
+	if err != nil {
+		return nil, err
+	}
+
+	return ulmetainfo.New(access, opts.EncryptionBypass)
+}
@@ -0,0 +1,42 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"github.com/zeebo/clingy"
+
+	"storj.io/storj/cmd/uplinkng/ulext"
+	"storj.io/storj/cmd/uplinkng/ulfs"
+	"storj.io/storj/cmd/uplinkng/ulloc"
+)
+
+// filterFlags holds flags and provides a Setup method for commands that
+// select the entries of recursive operations.
+type filterFlags struct {
+	includes []string // only files matching one of the patterns are used
+	excludes []string // files and directories matching any of the patterns are skipped
+}
+
+func (ff *filterFlags) Setup(params clingy.Parameters) {
+	ff.includes = params.Flag("include", "Only use files matching the glob pattern in recursive operations", []string{},
+		clingy.Transform(ulfs.CheckPattern),
+		clingy.Repeated,
+	).([]string)
+	ff.excludes = params.Flag("exclude", "Skip files and directories matching the glob pattern in recursive operations. "+
+		"Patterns are also read from "+ulfs.IgnoreFile+" files in local directories and remote prefixes", []string{},
+		clingy.Transform(ulfs.CheckPattern),
+		clingy.Repeated,
+	).([]string)
+}
+
+// option returns the option to open a filesystem that skips the entries of
+// recursive listings matched by the flags or by .storjignore files. The
+// skipped entries are passed to skipped if it is not nil.
+func (ff *filterFlags) option(skipped func(loc ulloc.Location, reason string)) ulext.Option {
+	return ulext.WithFilter(&ulfs.Filter{
+		Includes: ff.includes,
+		Excludes: ff.excludes,
+		Skipped:  skipped,
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/cmd/uplinkng/ulfs"
+	"storj.io/storj/cmd/uplinkng/ulloc"
+)
+
+func TestMatcher(t *testing.T) {
+	matcher, err := ulfs.NewMatcher(&ulfs.Filter{
+		Includes: []string{"*.go", "docs/**"},
+		Excludes: []string{"vendor/", "*_test.go"},
+	})
+	require.NoError(t, err)
+
+	matcher.AddIgnoreFile("", []byte("# generated files\n/gen\n*.pb.go\n!keep.pb.go\n"))
+	matcher.AddIgnoreFile("sub/", []byte("local.go\n"))
+
+	for _, tc := range []struct {
+		path    string
+		skipped string
+	}{
+		{"main.go", ""},
+		{"pkg/util.go", ""},
+		{"docs/a/b.md", ""},
+		{"README.md", "README.md"},
+		{"main_test.go", "main_test.go"},
+		{"vendor/lib/lib.go", "vendor/"},
+		{"gen/x.go", "gen/"},
+		{"pkg/gen/x.go", ""},
+		{"pkg/api.pb.go", "pkg/api.pb.go"},
+		{"pkg/keep.pb.go", ""},
+		{"sub/local.go", "sub/local.go"},
+		{"local.go", ""},
+	} {
+		skipped, reason, skip := matcher.MatchPath(tc.path)
+		require.Equal(t, tc.skipped != "", skip, tc.path)
+		require.Equal(t, tc.skipped, skipped, tc.path)
+		require.Equal(t, skip, reason != "", tc.path)
+	}
+
+	_, err = ulfs.CheckPattern("[a-")
+	require.Error(t, err)
+	_, err = ulfs.CheckPattern("!")
+	require.Error(t, err)
+}
+
+func TestLocalFilter(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	root := ctx.Dir("root")
+	for path, contents := range map[string]string{
+		".storjignore":     "*.log\n",
+		"a.txt":            "",
+		"b.log":            "",
+		"build/x.txt":      "",
+		"sub/.storjignore": "local.txt\n!keep.log\n",
+		"sub/local.txt":    "",
+		"sub/keep.log":     "",
+		"sub/c.go":         "",
+	} {
+		path = filepath.Join(root, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	}
+
+	skipped := map[string]string{}
+	iter, err := ulfs.NewLocal().ListObjects(ctx, root+string(filepath.Separator), true, &ulfs.Filter{
+		Excludes: []string{"build/"},
+		Skipped: func(loc ulloc.Location, reason string) {
+			rel, err := filepath.Rel(root, loc.Loc())
+			require.NoError(t, err)
+			if ulloc.NewLocal(loc.Loc()).Directoryish() {
+				rel += "/"
+			}
+			skipped[filepath.ToSlash(rel)] = reason
+		},
+	})
+	require.NoError(t, err)
+
+	var listed []string
+	for iter.Next() {
+		rel, err := filepath.Rel(root, iter.Item().Loc.Loc())
+		require.NoError(t, err)
+		listed = append(listed, filepath.ToSlash(rel))
+	}
+	require.NoError(t, iter.Err())
+
+	// the ignore file of a directory applies beneath it and can include
+	// entries that the ignore files above it skip.
+	require.ElementsMatch(t, []string{".storjignore", "a.txt", "sub/.storjignore", "sub/c.go", "sub/keep.log"}, listed)
+	require.Equal(t, map[string]string{
+		"b.log":         `ignored by "*.log" in .storjignore`,
+		"build/":        `excluded by "build/"`,
+		"sub/local.txt": `ignored by "local.txt" in sub/.storjignore`,
+	}, skipped)
+}
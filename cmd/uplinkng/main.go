@@ -28,7 +28,12 @@ func main() {
 		commands(cmds, ex)
 	})
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
+		switch format := ex.OutputFormat(); format {
+		case ulext.OutputJSON, ulext.OutputJSONL:
+			_ = writeRecord(os.Stderr, format, newErrorRecord(err))
+		default:
+			fmt.Fprintf(os.Stderr, "%+v\n", err)
+		}
 	}
 	if !ok || err != nil {
 		os.Exit(1)
@@ -49,10 +54,37 @@ func commands(cmds clingy.Commands, ex ulext.External) {
 	cmds.New("mb", "Create a new bucket", newCmdMb(ex))
 	cmds.New("rb", "Remove a bucket bucket", newCmdRb(ex))
 	cmds.New("cp", "Copies files or objects into or out of tardigrade", newCmdCp(ex))
+	cmds.New("mv", "Moves files or objects", newCmdMv(ex))
+	cmds.New("sync", "Copies only new or changed files or objects between locations", newCmdSync(ex))
+	cmds.New("verify", "Checks that copies of files or objects match without copying them", newCmdVerify(ex))
 	cmds.New("ls", "Lists buckets, prefixes, or objects", newCmdLs(ex))
 	cmds.New("rm", "Remove an object", newCmdRm(ex))
+	cmds.New("stat", "Shows the system and custom metadata of an object", newCmdStat(ex))
+	cmds.New("du", "Shows the total size of objects beneath prefixes", newCmdDu(ex))
+	cmds.New("find", "Finds objects matching filters", newCmdFind(ex))
 	cmds.Group("meta", "Object metadata related commands", func() {
 		cmds.New("get", "Get an object's metadata", newCmdMetaGet(ex))
+		cmds.Group("tag", "Object and bucket tag related commands", func() {
+			cmds.New("set", "Replace the tags of a bucket or object", newCmdMetaTagSet(ex))
+			cmds.New("get", "Get the tags of a bucket or object", newCmdMetaTagGet(ex))
+		})
 	})
-	cmds.New("version", "Prints version information", newCmdVersion())
+	cmds.Group("uploads", "Pending multipart upload related commands", func() {
+		cmds.New("abort", "Abort pending multipart uploads", newCmdUploadsAbort(ex))
+	})
+	cmds.Group("lifecycle", "Bucket lifecycle rule related commands", func() {
+		cmds.New("set", "Add or replace a rule deleting old objects or pending uploads of a bucket", newCmdLifecycleSet(ex))
+		cmds.New("delete", "Delete a rule or all rules of a bucket", newCmdLifecycleDelete(ex))
+		cmds.New("list", "List the rules of a bucket", newCmdLifecycleList(ex))
+	})
+	cmds.Group("retention", "Object retention and legal hold related commands", func() {
+		cmds.New("default", "Shows or changes the default retention of new objects of a bucket", newCmdRetentionDefault(ex))
+		cmds.New("set", "Protect an object from deletion until a date", newCmdRetentionSet(ex))
+		cmds.New("legal-hold", "Place or remove a legal hold protecting an object from deletion", newCmdRetentionLegalHold(ex))
+		cmds.New("get", "Get the retention and legal hold of an object", newCmdRetentionGet(ex))
+	})
+	cmds.New("restore", "Makes an older version of an object its latest version", newCmdRestore(ex))
+	cmds.New("versioning", "Shows or changes whether a bucket keeps every version of its objects", newCmdVersioning(ex))
+	cmds.New("usage", "Shows the storage, egress and object usage of buckets", newCmdUsage(ex))
+	cmds.New("version", "Prints version information", newCmdVersion(ex))
 }
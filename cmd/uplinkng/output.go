@@ -0,0 +1,190 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/cmd/uplinkng/ulext"
+	"storj.io/storj/cmd/uplinkng/ulfs"
+	"storj.io/uplink"
+)
+
+// objectRecord is the machine readable form of an object or prefix.
+type objectRecord struct {
+	Location string            `json:"location"`
+	Kind     string            `json:"kind"`
+	Size     int64             `json:"size"`
+	Created  *time.Time        `json:"created,omitempty"`
+	Expires  *time.Time        `json:"expires,omitempty"`
+	UploadID string            `json:"uploadId,omitempty"`
+	Segments *int64            `json:"segments,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// newObjectRecord converts the object info into an objectRecord.
+func newObjectRecord(info ulfs.ObjectInfo) objectRecord {
+	record := objectRecord{
+		Location: info.Loc.String(),
+		Kind:     "object",
+		Size:     info.ContentLength,
+		UploadID: info.UploadID,
+		Metadata: info.Metadata,
+	}
+	switch {
+	case info.IsPrefix:
+		record.Kind = "prefix"
+	case info.UploadID != "":
+		record.Kind = "pending"
+	}
+	record.Created = optionalTime(info.Created)
+	record.Expires = optionalTime(info.Expires)
+	return record
+}
+
+// optionalTime returns nil for the zero time so that it is left out of JSON.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
+
+// recordWriter writes the results of a command in the format selected with
+// the global --output flag. Tables are written with a tabbedWriter, or as
+// plain lines when there are no headers. For jsonl every record is written on
+// its own line, and for json the records are written as one array by Done.
+type recordWriter struct {
+	format string
+	w      io.Writer
+	tw     *tabbedWriter
+
+	mu      sync.Mutex
+	records []interface{}
+}
+
+func newRecordWriter(w io.Writer, format string, headers ...string) *recordWriter {
+	rw := &recordWriter{
+		format:  format,
+		w:       w,
+		records: []interface{}{},
+	}
+	if format == ulext.OutputTable && len(headers) > 0 {
+		rw.tw = newTabbedWriter(w, headers...)
+	}
+	return rw
+}
+
+// Write writes the record, or the columns when writing a table. It is safe
+// to call concurrently.
+func (rw *recordWriter) Write(record interface{}, columns ...interface{}) {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	switch {
+	case rw.format == ulext.OutputJSON:
+		rw.records = append(rw.records, record)
+	case rw.format == ulext.OutputJSONL:
+		_ = writeRecord(rw.w, rw.format, record)
+	case rw.tw != nil:
+		rw.tw.WriteLine(columns...)
+	default:
+		fmt.Fprintln(rw.w, columns...)
+	}
+}
+
+// Done flushes the table or writes the collected json records.
+func (rw *recordWriter) Done() {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	switch {
+	case rw.format == ulext.OutputJSON:
+		_ = writeRecord(rw.w, rw.format, rw.records)
+	case rw.tw != nil:
+		rw.tw.Done()
+	}
+}
+
+// writeRecord writes a single record as indented JSON for json and as one
+// line for jsonl.
+func writeRecord(w io.Writer, format string, record interface{}) error {
+	var data []byte
+	var err error
+	if format == ulext.OutputJSONL {
+		data, err = json.Marshal(record)
+	} else {
+		data, err = json.MarshalIndent(record, "", "  ")
+	}
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return errs.Wrap(err)
+}
+
+// errorRecord is the machine readable form of an error.
+type errorRecord struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// newErrorRecord converts err into an errorRecord.
+func newErrorRecord(err error) errorRecord {
+	var record errorRecord
+	record.Error.Code = errorCode(err)
+	record.Error.Message = err.Error()
+	return record
+}
+
+// errorCode returns a stable code for the kind of the error, so that scripts
+// do not have to match on error messages.
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, uplink.ErrBucketNameInvalid):
+		return "bucket_name_invalid"
+	case errors.Is(err, uplink.ErrBucketAlreadyExists):
+		return "bucket_already_exists"
+	case errors.Is(err, uplink.ErrBucketNotEmpty):
+		return "bucket_not_empty"
+	case errors.Is(err, uplink.ErrBucketNotFound):
+		return "bucket_not_found"
+	case errors.Is(err, uplink.ErrObjectKeyInvalid):
+		return "object_key_invalid"
+	case errors.Is(err, uplink.ErrObjectNotFound):
+		return "object_not_found"
+	case errors.Is(err, uplink.ErrUploadIDInvalid):
+		return "upload_id_invalid"
+	case errors.Is(err, uplink.ErrPermissionDenied):
+		return "permission_denied"
+	case errors.Is(err, uplink.ErrTooManyRequests):
+		return "too_many_requests"
+	case errors.Is(err, uplink.ErrBandwidthLimitExceeded):
+		return "bandwidth_limit_exceeded"
+	case errChecksumMismatch.Has(err):
+		return "checksum_mismatch"
+	case errors.Is(err, os.ErrNotExist):
+		return "file_not_found"
+	case errors.Is(err, os.ErrPermission):
+		return "file_permission_denied"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/cmd/uplinkng/ultest"
+	"storj.io/uplink"
+)
+
+func TestOutputErrors(t *testing.T) {
+	state := ultest.Setup(commands, ultest.WithFile("sj://user/file.txt"))
+
+	state.Fail(t, "ls", "sj://user", "--output", "xml")
+}
+
+func TestOutputRecords(t *testing.T) {
+	state := ultest.Setup(commands,
+		ultest.WithFile("sj://user/files/file1.txt", "one"),
+		ultest.WithFile("sj://user/files/file2.txt", "two"),
+		ultest.WithFile("sj://user/other.txt", "other"),
+	)
+
+	t.Run("Ls", func(t *testing.T) {
+		state.Succeed(t, "ls", "sj://user", "--output", "json").RequireStdout(t, `
+			[
+			  {
+			    "kind": "prefix",
+			    "key": "files/"
+			  },
+			  {
+			    "kind": "object",
+			    "key": "other.txt",
+			    "created": "1970-01-01T00:00:03Z",
+			    "size": 0
+			  }
+			]
+		`)
+
+		state.Succeed(t, "ls", "sj://nothing", "--output", "json").RequireStdout(t, `[]`)
+	})
+
+	t.Run("Rm", func(t *testing.T) {
+		state.Succeed(t, "rm", "sj://user/files", "-r", "--output", "jsonl").RequireStdout(t, `
+			{"location":"sj://user/files/file1.txt","removed":true}
+			{"location":"sj://user/files/file2.txt","removed":true}
+		`)
+	})
+
+	t.Run("Cp", func(t *testing.T) {
+		state.Succeed(t, "cp", "sj://user/files", "/home/user/dest", "-r", "--output", "jsonl").RequireStdout(t, `
+			{"type":"file","action":"download","source":"sj://user/files/file1.txt","dest":"/home/user/dest/files/file1.txt","status":"copied"}
+			{"type":"file","action":"download","source":"sj://user/files/file2.txt","dest":"/home/user/dest/files/file2.txt","status":"copied"}
+			{"type":"summary","copied":2,"failed":0,"skipped":0}
+		`)
+
+		state.Succeed(t, "cp", "sj://user/other.txt", "/home/user/", "--dryrun", "--output", "json").RequireStdout(t, `
+			[
+			  {
+			    "type": "file",
+			    "action": "download",
+			    "source": "sj://user/other.txt",
+			    "dest": "/home/user/other.txt",
+			    "status": "dry-run"
+			  },
+			  {
+			    "type": "summary",
+			    "copied": 0,
+			    "failed": 0,
+			    "skipped": 0,
+			    "dry_run": 1
+			  }
+			]
+		`)
+
+		state.Fail(t, "cp", "sj://user/missing.txt", "/home/user/", "--output", "jsonl").RequireStdout(t, `
+			{"type":"file","action":"download","source":"sj://user/missing.txt","dest":"/home/user/missing.txt","status":"failed","error":"file does not exist"}
+			{"type":"summary","copied":0,"failed":1,"skipped":0}
+		`)
+	})
+}
+
+func TestErrorRecord(t *testing.T) {
+	for _, tt := range []struct {
+		err  error
+		code string
+	}{
+		{errs.Wrap(uplink.ErrObjectNotFound), "object_not_found"},
+		{errs.Wrap(uplink.ErrBucketNotFound), "bucket_not_found"},
+		{errs.Wrap(uplink.ErrPermissionDenied), "permission_denied"},
+		{errs.Wrap(context.Canceled), "canceled"},
+		{&os.PathError{Op: "open", Path: "/missing", Err: os.ErrNotExist}, "file_not_found"},
+		{errs.New("something else"), "error"},
+	} {
+		require.Equal(t, tt.code, errorCode(tt.err), tt.err.Error())
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, writeRecord(&out, "jsonl", newErrorRecord(errs.Wrap(uplink.ErrObjectNotFound))))
+	require.Equal(t, `{"error":{"code":"object_not_found","message":"object not found"}}`+"\n", out.String())
+}
@@ -0,0 +1,148 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/clingy"
+	"github.com/zeebo/errs"
+	"golang.org/x/sync/errgroup"
+)
+
+// rangeAttempts is how many times a single range is tried before the whole
+// copy fails.
+const rangeAttempts = 3
+
+// byteRange is a range of bytes of a file or an object.
+type byteRange struct {
+	Index  int
+	Offset int64
+	Length int64
+}
+
+// splitRanges splits size bytes into ranges of rangeSize bytes. Only the last
+// range may be shorter.
+func splitRanges(size, rangeSize int64) []byteRange {
+	var ranges []byteRange
+	for offset := int64(0); offset < size; offset += rangeSize {
+		length := rangeSize
+		if remaining := size - offset; remaining < length {
+			length = remaining
+		}
+		ranges = append(ranges, byteRange{
+			Index:  len(ranges),
+			Offset: offset,
+			Length: length,
+		})
+	}
+	return ranges
+}
+
+// splitSegmentRanges splits size bytes into ranges of at most rangeSize bytes
+// that don't start or end in the middle of a segment, unless the segment is
+// larger than rangeSize. Consecutive segments are joined into one range while
+// they fit into rangeSize. The segments start at the offsets, which must be
+// increasing. Without offsets the bytes are split like splitRanges.
+func splitSegmentRanges(size, rangeSize int64, offsets []int64) []byteRange {
+	if len(offsets) == 0 {
+		return splitRanges(size, rangeSize)
+	}
+
+	var ranges []byteRange
+	add := func(offset, length int64) {
+		ranges = append(ranges, byteRange{
+			Index:  len(ranges),
+			Offset: offset,
+			Length: length,
+		})
+	}
+
+	// pending is the length of the segments starting at start that are joined
+	// into the next range.
+	var start, pending int64
+	for i := range offsets {
+		offset, end := offsets[i], size
+		if i+1 < len(offsets) {
+			end = offsets[i+1]
+		}
+		length := end - offset
+
+		if pending+length <= rangeSize {
+			pending += length
+			continue
+		}
+		if pending > 0 {
+			add(start, pending)
+		}
+		start, pending = offset, 0
+		if length <= rangeSize {
+			pending = length
+			continue
+		}
+		for _, r := range splitRanges(length, rangeSize) {
+			add(offset+r.Offset, r.Length)
+		}
+		start = end
+	}
+	if pending > 0 {
+		add(start, pending)
+	}
+	return ranges
+}
+
+// processRanges calls fn for every range with at most parallelism calls
+// running at the same time. A failed range is retried on its own without
+// redoing the other ranges. Once a range fails every attempt, the context
+// passed to fn is canceled so that the other ranges stop early.
+func processRanges(ctx clingy.Context, ranges []byteRange, parallelism int, fn func(ctx clingy.Context, r byteRange) error) error {
+	group, groupCtx := errgroup.WithContext(ctx)
+	rangeCtx := &cancelContext{Context: ctx, cancel: groupCtx}
+	limiter := make(chan struct{}, parallelism)
+
+	for _, r := range ranges {
+		r := r
+
+		select {
+		case limiter <- struct{}{}:
+		case <-groupCtx.Done():
+		}
+		if groupCtx.Err() != nil {
+			break
+		}
+
+		group.Go(func() error {
+			defer func() { <-limiter }()
+
+			var err error
+			for attempt := 0; attempt < rangeAttempts; attempt++ {
+				if err = fn(rangeCtx, r); err == nil || groupCtx.Err() != nil {
+					break
+				}
+			}
+			if err != nil {
+				return errs.New("range %d-%d failed: %v", r.Offset, r.Offset+r.Length, err)
+			}
+			return nil
+		})
+	}
+
+	err := group.Wait()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// cancelContext is a clingy.Context that is canceled with another context.
+type cancelContext struct {
+	clingy.Context
+	cancel context.Context
+}
+
+func (c *cancelContext) Deadline() (time.Time, bool)       { return c.cancel.Deadline() }
+func (c *cancelContext) Done() <-chan struct{}             { return c.cancel.Done() }
+func (c *cancelContext) Err() error                        { return c.cancel.Err() }
+func (c *cancelContext) Value(key interface{}) interface{} { return c.cancel.Value(key) }
@@ -0,0 +1,107 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/clingy"
+	"github.com/zeebo/errs"
+)
+
+func TestSplitRanges(t *testing.T) {
+	require.Empty(t, splitRanges(0, 4))
+	require.Equal(t, []byteRange{
+		{Index: 0, Offset: 0, Length: 4},
+		{Index: 1, Offset: 4, Length: 4},
+		{Index: 2, Offset: 8, Length: 2},
+	}, splitRanges(10, 4))
+	require.Equal(t, []byteRange{
+		{Index: 0, Offset: 0, Length: 4},
+		{Index: 1, Offset: 4, Length: 4},
+	}, splitRanges(8, 4))
+}
+
+func TestSplitSegmentRanges(t *testing.T) {
+	require.Equal(t, splitRanges(10, 4), splitSegmentRanges(10, 4, nil))
+
+	// small segments are joined while they fit into a range.
+	require.Equal(t, []byteRange{
+		{Index: 0, Offset: 0, Length: 4},
+		{Index: 1, Offset: 4, Length: 4},
+		{Index: 2, Offset: 8, Length: 2},
+	}, splitSegmentRanges(10, 5, []int64{0, 2, 4, 6, 8}))
+
+	// large segments are split, but no range crosses a segment boundary.
+	require.Equal(t, []byteRange{
+		{Index: 0, Offset: 0, Length: 3},
+		{Index: 1, Offset: 3, Length: 1},
+		{Index: 2, Offset: 4, Length: 3},
+		{Index: 3, Offset: 7, Length: 1},
+		{Index: 4, Offset: 8, Length: 2},
+	}, splitSegmentRanges(10, 3, []int64{0, 4, 8}))
+
+	// a single segment is a single range.
+	require.Equal(t, []byteRange{
+		{Index: 0, Offset: 0, Length: 8},
+	}, splitSegmentRanges(8, 8, []int64{0}))
+}
+
+func TestProcessRangesRetries(t *testing.T) {
+	ctx := &testContext{Context: context.Background()}
+
+	var mu sync.Mutex
+	attempts := make(map[int]int)
+	process := func(ctx clingy.Context, r byteRange) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		attempts[r.Index]++
+		// the second range fails once and the third range always fails.
+		if (r.Index == 1 && attempts[r.Index] == 1) || r.Index == 2 {
+			return errs.New("failed")
+		}
+		return nil
+	}
+
+	// the ranges run one after another, so that the failing range doesn't
+	// cancel the retries of the other ranges.
+	err := processRanges(ctx, splitRanges(10, 4), 1, process)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "range 8-10 failed")
+	require.Equal(t, map[int]int{0: 1, 1: 2, 2: rangeAttempts}, attempts)
+
+	attempts = make(map[int]int)
+	require.NoError(t, processRanges(ctx, splitRanges(8, 4), 2, process))
+	require.Equal(t, map[int]int{0: 1, 1: 2}, attempts)
+}
+
+func TestProcessRangesCancels(t *testing.T) {
+	ctx := &testContext{Context: context.Background()}
+
+	var mu sync.Mutex
+	started := make(map[int]bool)
+	err := processRanges(ctx, splitRanges(40, 4), 2, func(ctx clingy.Context, r byteRange) error {
+		mu.Lock()
+		started[r.Index] = true
+		mu.Unlock()
+
+		// the first range fails and the other ranges wait until they are
+		// canceled.
+		if r.Index == 0 {
+			return errs.New("failed")
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "range 0-4 failed")
+
+	// no more ranges are started after the first range failed.
+	require.True(t, started[0])
+	require.Less(t, len(started), 4)
+}
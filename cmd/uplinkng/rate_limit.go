@@ -0,0 +1,66 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/cmd/uplinkng/ulfs"
+)
+
+// parseRate parses a rate like '20MiB/s' into bytes per second. The rate
+// 'unlimited' or zero means that there is no limit.
+func parseRate(rate string) (int64, error) {
+	if rate == "unlimited" {
+		return 0, nil
+	}
+	bps, err := parseSize(strings.TrimSuffix(rate, "/s"))
+	if err != nil {
+		return 0, errs.New("invalid rate: %q", rate)
+	}
+	if bps < 0 {
+		return 0, errs.New("invalid rate: %q must not be negative", rate)
+	}
+	return bps, nil
+}
+
+// parseRateWindow parses a time of day window with its rate like
+// '22:00-06:00=unlimited' or '09:00-17:00=5MiB/s'.
+func parseRateWindow(window string) (ulfs.RateWindow, error) {
+	times, rate := window, ""
+	if idx := strings.IndexByte(window, '='); idx >= 0 {
+		times, rate = window[:idx], window[idx+1:]
+	}
+	idx := strings.IndexByte(times, '-')
+	if idx < 0 || rate == "" {
+		return ulfs.RateWindow{}, errs.New("invalid rate schedule %q: expected START-END=RATE", window)
+	}
+
+	start, err := parseTimeOfDay(times[:idx])
+	if err != nil {
+		return ulfs.RateWindow{}, errs.New("invalid rate schedule %q: %v", window, err)
+	}
+	end, err := parseTimeOfDay(times[idx+1:])
+	if err != nil {
+		return ulfs.RateWindow{}, errs.New("invalid rate schedule %q: %v", window, err)
+	}
+	bps, err := parseRate(rate)
+	if err != nil {
+		return ulfs.RateWindow{}, errs.New("invalid rate schedule %q: %v", window, err)
+	}
+
+	return ulfs.RateWindow{Start: start, End: end, Rate: bps}, nil
+}
+
+// parseTimeOfDay parses a time like '22:00' into its offset from midnight.
+func parseTimeOfDay(clock string) (time.Duration, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, errs.New("invalid time of day: %q", clock)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/memory"
+	"storj.io/storj/cmd/uplinkng/ulfs"
+)
+
+func TestParseRate(t *testing.T) {
+	for _, tc := range []struct {
+		rate string
+		bps  int64
+	}{
+		{"unlimited", 0},
+		{"0", 0},
+		{"20MiB/s", 20 * memory.MiB.Int64()},
+		{"512KB", 512 * memory.KB.Int64()},
+	} {
+		bps, err := parseRate(tc.rate)
+		require.NoError(t, err, tc.rate)
+		require.Equal(t, tc.bps, bps, tc.rate)
+	}
+
+	for _, rate := range []string{"", "fast", "MiB/s", "-1MiB/s"} {
+		_, err := parseRate(rate)
+		require.Error(t, err, rate)
+	}
+}
+
+func TestRateSchedule(t *testing.T) {
+	night, err := parseRateWindow("22:00-06:00=unlimited")
+	require.NoError(t, err)
+	require.Equal(t, ulfs.RateWindow{Start: 22 * time.Hour, End: 6 * time.Hour}, night)
+
+	day, err := parseRateWindow("09:00-17:30=1MiB/s")
+	require.NoError(t, err)
+	require.Equal(t, ulfs.RateWindow{Start: 9 * time.Hour, End: 17*time.Hour + 30*time.Minute, Rate: memory.MiB.Int64()}, day)
+
+	for _, window := range []string{"", "22:00", "22:00-06:00", "22-06=1MiB/s", "22:00-06:00=fast"} {
+		_, err := parseRateWindow(window)
+		require.Error(t, err, window)
+	}
+
+	limiter := ulfs.NewRateLimiter(20*memory.MiB.Int64(), []ulfs.RateWindow{night, day})
+	require.True(t, limiter.Enabled())
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2021, 6, 1, hour, minute, 0, 0, time.Local)
+	}
+	require.Equal(t, int64(0), limiter.RateAt(at(23, 0)))
+	require.Equal(t, int64(0), limiter.RateAt(at(5, 59)))
+	require.Equal(t, 20*memory.MiB.Int64(), limiter.RateAt(at(6, 0)))
+	require.Equal(t, memory.MiB.Int64(), limiter.RateAt(at(12, 0)))
+	require.Equal(t, 20*memory.MiB.Int64(), limiter.RateAt(at(17, 30)))
+
+	require.False(t, ulfs.NewRateLimiter(0, []ulfs.RateWindow{night}).Enabled())
+}
@@ -8,8 +8,10 @@ import (
 	"context"
 
 	"github.com/zeebo/clingy"
+	"github.com/zeebo/errs"
 
 	"storj.io/storj/cmd/uplinkng/ulfs"
+	"storj.io/storj/cmd/uplinkng/ulmetainfo"
 	"storj.io/uplink"
 )
 
@@ -18,8 +20,11 @@ import (
 type External interface {
 	OpenFilesystem(ctx context.Context, accessName string, options ...Option) (ulfs.Filesystem, error)
 	OpenProject(ctx context.Context, accessName string, options ...Option) (*uplink.Project, error)
+	OpenMetainfo(ctx context.Context, accessName string, options ...Option) (*ulmetainfo.Client, error)
 
 	AccessInfoFile() string
+	UploadStateDir() string
+	OutputFormat() string
 	OpenAccess(accessName string) (access *uplink.Access, err error)
 	GetAccessInfo(required bool) (string, map[string]string, error)
 	SaveAccessInfo(defaultName string, accesses map[string]string) error
@@ -28,9 +33,32 @@ type External interface {
 	PromptInput(ctx clingy.Context, prompt string) (input string, err error)
 }
 
+// Output formats that can be selected with the global --output flag.
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputJSONL = "jsonl"
+)
+
+// OutputFlag registers the global --output flag and returns the selected output format.
+func OutputFlag(f clingy.Flags) string {
+	return f.Flag(
+		"output", "Output format of results and errors (table, json or jsonl)", OutputTable,
+		clingy.Transform(func(format string) (string, error) {
+			switch format {
+			case OutputTable, OutputJSON, OutputJSONL:
+				return format, nil
+			default:
+				return "", errs.New("invalid output format: %q", format)
+			}
+		}),
+	).(string)
+}
+
 // Options contains all of the possible options for opening a filesystem or project.
 type Options struct {
 	EncryptionBypass bool
+	Filter           *ulfs.Filter
 }
 
 // LoadOptions takes a slice of Option values and returns a filled out Options struct.
@@ -50,3 +78,8 @@ type Option struct {
 func BypassEncryption(bypass bool) Option {
 	return Option{apply: func(opt *Options) { opt.EncryptionBypass = bypass }}
 }
+
+// WithFilter will skip the entries of recursive listings that the filter skips.
+func WithFilter(filter *ulfs.Filter) Option {
+	return Option{apply: func(opt *Options) { opt.Filter = filter }}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/zeebo/errs"
 
 	"storj.io/storj/cmd/uplinkng/ulloc"
+	"storj.io/storj/cmd/uplinkng/ulmetainfo"
 	"storj.io/uplink"
 )
 
@@ -20,10 +21,22 @@ import (
 type Filesystem interface {
 	Close() error
 	Open(ctx clingy.Context, loc ulloc.Location) (ReadHandle, error)
+	OpenRange(ctx clingy.Context, loc ulloc.Location, offset, length int64) (ReadHandle, error)
+	OpenVersion(ctx clingy.Context, loc ulloc.Location, version int64) (ReadHandle, error)
 	Create(ctx clingy.Context, loc ulloc.Location) (WriteHandle, error)
+	CreateWriterAt(ctx clingy.Context, loc ulloc.Location, size int64) (WriterAtHandle, error)
 	Remove(ctx context.Context, loc ulloc.Location) error
+	Move(ctx context.Context, source, dest ulloc.Location) error
+	Copy(ctx context.Context, source, dest ulloc.Location) error
+	Stat(ctx context.Context, loc ulloc.Location) (*ObjectInfo, error)
+	SegmentOffsets(ctx context.Context, loc ulloc.Location) ([]int64, error)
 	ListObjects(ctx context.Context, prefix ulloc.Location, recursive bool) (ObjectIterator, error)
+	ListObjectVersions(ctx context.Context, prefix ulloc.Location, recursive bool) (ObjectIterator, error)
+	ListTaggedObjects(ctx context.Context, prefix ulloc.Location, recursive bool, tags map[string]string) (ObjectIterator, error)
 	ListUploads(ctx context.Context, prefix ulloc.Location, recursive bool) (ObjectIterator, error)
+	BeginMultipart(ctx context.Context, loc ulloc.Location) (MultipartUpload, error)
+	ResumeMultipart(ctx context.Context, loc ulloc.Location, uploadID string) (MultipartUpload, error)
+	AbortMultipart(ctx context.Context, loc ulloc.Location, uploadID string) error
 	IsLocalDir(ctx context.Context, loc ulloc.Location) bool
 }
 
@@ -37,7 +50,15 @@ type ObjectInfo struct {
 	Loc           ulloc.Location
 	IsPrefix      bool
 	Created       time.Time
+	Expires       time.Time
 	ContentLength int64
+	Metadata      map[string]string
+	UploadID      string
+
+	// Version and DeleteMarker are only set for listings of object versions
+	// and downloads of a specific version.
+	Version      int64
+	DeleteMarker bool
 }
 
 // uplinkObjectToObjectInfo returns an objectInfo converted from an *uplink.Object.
@@ -46,7 +67,9 @@ func uplinkObjectToObjectInfo(bucket string, obj *uplink.Object) ObjectInfo {
 		Loc:           ulloc.NewRemote(bucket, obj.Key),
 		IsPrefix:      obj.IsPrefix,
 		Created:       obj.System.Created,
+		Expires:       obj.System.Expires,
 		ContentLength: obj.System.ContentLength,
+		Metadata:      obj.Custom,
 	}
 }
 
@@ -56,7 +79,9 @@ func uplinkUploadInfoToObjectInfo(bucket string, upl *uplink.UploadInfo) ObjectI
 		Loc:           ulloc.NewRemote(bucket, upl.Key),
 		IsPrefix:      upl.IsPrefix,
 		Created:       upl.System.Created,
+		Expires:       upl.System.Expires,
 		ContentLength: upl.System.ContentLength,
+		UploadID:      upl.UploadID,
 	}
 }
 
@@ -89,6 +114,31 @@ func (u *uplinkReadHandle) Read(p []byte) (int, error) { return u.dl.Read(p) }
 func (u *uplinkReadHandle) Close() error               { return u.dl.Close() }
 func (u *uplinkReadHandle) Info() ObjectInfo           { return uplinkObjectToObjectInfo(u.bucket, u.dl.Info()) }
 
+// versionReadHandle implements readHandle for downloads of object versions.
+type versionReadHandle struct {
+	dl   *ulmetainfo.Download
+	info ObjectInfo
+}
+
+// newVersionReadHandle constructs a *versionReadHandle from a *ulmetainfo.Download.
+func newVersionReadHandle(bucket string, version int64, dl *ulmetainfo.Download) *versionReadHandle {
+	return &versionReadHandle{
+		dl: dl,
+		info: ObjectInfo{
+			Loc:           ulloc.NewRemote(bucket, dl.Object.Path),
+			Created:       dl.Object.Created,
+			Expires:       dl.Object.Expires,
+			ContentLength: dl.Object.Size,
+			Metadata:      dl.Object.Metadata,
+			Version:       version,
+		},
+	}
+}
+
+func (v *versionReadHandle) Read(p []byte) (int, error) { return v.dl.Read(p) }
+func (v *versionReadHandle) Close() error               { return v.dl.Close() }
+func (v *versionReadHandle) Info() ObjectInfo           { return v.info }
+
 // osReadHandle implements readHandle for *os.Files.
 type osReadHandle struct {
 	raw  *os.File
@@ -116,6 +166,22 @@ func (o *osReadHandle) Read(p []byte) (int, error) { return o.raw.Read(p) }
 func (o *osReadHandle) Close() error               { return o.raw.Close() }
 func (o *osReadHandle) Info() ObjectInfo           { return o.info }
 
+// rangeReadHandle limits a ReadHandle to a range of bytes.
+type rangeReadHandle struct {
+	ReadHandle
+	r io.Reader
+}
+
+// newRangeReadHandle constructs a *rangeReadHandle reading length bytes from rh.
+func newRangeReadHandle(rh ReadHandle, length int64) *rangeReadHandle {
+	return &rangeReadHandle{
+		ReadHandle: rh,
+		r:          io.LimitReader(rh, length),
+	}
+}
+
+func (r *rangeReadHandle) Read(p []byte) (int, error) { return r.r.Read(p) }
+
 // genericReadHandle implements readHandle for an io.Reader.
 type genericReadHandle struct{ r io.Reader }
 
@@ -135,6 +201,7 @@ func (g *genericReadHandle) Info() ObjectInfo           { return ObjectInfo{Cont
 // WriteHandle is anything that can be written to with commit/abort semantics.
 type WriteHandle interface {
 	io.Writer
+	SetMetadata(ctx context.Context, metadata map[string]string) error
 	Commit() error
 	Abort() error
 }
@@ -155,6 +222,10 @@ func (u *uplinkWriteHandle) Write(p []byte) (int, error) { return u.raw().Write(
 func (u *uplinkWriteHandle) Commit() error               { return u.raw().Commit() }
 func (u *uplinkWriteHandle) Abort() error                { return u.raw().Abort() }
 
+func (u *uplinkWriteHandle) SetMetadata(ctx context.Context, metadata map[string]string) error {
+	return u.raw().SetCustomMetadata(ctx, metadata)
+}
+
 // osWriteHandle implements writeHandle for *os.Files.
 type osWriteHandle struct {
 	fh   *os.File
@@ -166,7 +237,13 @@ func newOSWriteHandle(fh *os.File) *osWriteHandle {
 	return &osWriteHandle{fh: fh}
 }
 
-func (o *osWriteHandle) Write(p []byte) (int, error) { return o.fh.Write(p) }
+func (o *osWriteHandle) Write(p []byte) (int, error)              { return o.fh.Write(p) }
+func (o *osWriteHandle) WriteAt(p []byte, off int64) (int, error) { return o.fh.WriteAt(p, off) }
+
+// SetMetadata does nothing because files do not have custom metadata.
+func (o *osWriteHandle) SetMetadata(ctx context.Context, metadata map[string]string) error {
+	return nil
+}
 
 func (o *osWriteHandle) Commit() error {
 	if o.done {
@@ -189,6 +266,14 @@ func (o *osWriteHandle) Abort() error {
 	)
 }
 
+// WriterAtHandle is a WriteHandle that can be written to at any offset
+// concurrently.
+type WriterAtHandle interface {
+	io.WriterAt
+	Commit() error
+	Abort() error
+}
+
 // genericWriteHandle implements writeHandle for an io.Writer.
 type genericWriteHandle struct{ w io.Writer }
 
@@ -201,6 +286,62 @@ func (g *genericWriteHandle) Write(p []byte) (int, error) { return g.w.Write(p)
 func (g *genericWriteHandle) Commit() error               { return nil }
 func (g *genericWriteHandle) Abort() error                { return nil }
 
+func (g *genericWriteHandle) SetMetadata(ctx context.Context, metadata map[string]string) error {
+	return nil
+}
+
+//
+// multipart uploads
+//
+
+// MultipartUpload is an upload of a remote object in separately committed parts.
+type MultipartUpload interface {
+	UploadID() string
+	UploadPart(ctx context.Context, number uint32, r io.Reader) error
+	SetMetadata(ctx context.Context, metadata map[string]string) error
+	Commit(ctx context.Context) error
+	Abort(ctx context.Context) error
+}
+
+// uplinkMultipartUpload implements MultipartUpload for uplink multipart uploads.
+type uplinkMultipartUpload struct {
+	project  *uplink.Project
+	bucket   string
+	key      string
+	uploadID string
+	metadata map[string]string
+}
+
+func (u *uplinkMultipartUpload) UploadID() string { return u.uploadID }
+
+func (u *uplinkMultipartUpload) UploadPart(ctx context.Context, number uint32, r io.Reader) error {
+	part, err := u.project.UploadPart(ctx, u.bucket, u.key, u.uploadID, number)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return errs.Combine(err, part.Abort())
+	}
+	return errs.Wrap(part.Commit())
+}
+
+// SetMetadata sets the custom metadata that the object is committed with.
+func (u *uplinkMultipartUpload) SetMetadata(ctx context.Context, metadata map[string]string) error {
+	u.metadata = metadata
+	return nil
+}
+
+func (u *uplinkMultipartUpload) Commit(ctx context.Context) error {
+	_, err := u.project.CommitUpload(ctx, u.bucket, u.key, u.uploadID, &uplink.CommitUploadOptions{
+		CustomMetadata: u.metadata,
+	})
+	return errs.Wrap(err)
+}
+
+func (u *uplinkMultipartUpload) Abort(ctx context.Context) error {
+	return errs.Wrap(u.project.AbortUpload(ctx, u.bucket, u.key, u.uploadID))
+}
+
 //
 // object iteration
 //
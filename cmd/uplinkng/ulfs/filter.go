@@ -0,0 +1,303 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package ulfs
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/cmd/uplinkng/ulloc"
+)
+
+// IgnoreFile is the name of the files listing patterns, in gitignore syntax, of
+// entries that recursive operations skip.
+const IgnoreFile = ".storjignore"
+
+// Filter selects the entries of recursive listings. Patterns are globs matched
+// against the path relative to the listed prefix. Patterns without a slash
+// match at any depth, and "**" matches any number of directories.
+type Filter struct {
+	Includes []string // if any are given, files must match one of them
+	Excludes []string // files and directories matching any of them are skipped
+
+	// Skipped, if set, is called with every skipped entry and the reason that
+	// it was skipped. Skipped directories are reported once instead of
+	// reporting every entry beneath them.
+	Skipped func(loc ulloc.Location, reason string)
+}
+
+// CheckPattern returns the pattern if it is a valid glob pattern.
+func CheckPattern(pattern string) (string, error) {
+	if _, err := compilePattern(pattern, "", ""); err != nil {
+		return "", err
+	}
+	return pattern, nil
+}
+
+// pattern is a compiled glob pattern.
+type pattern struct {
+	text    string
+	source  string // where the pattern came from
+	base    string // directory the pattern is relative to, with a trailing slash
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// compilePattern compiles a line of an ignore file or a pattern given as a flag.
+func compilePattern(line, source, base string) (pattern, error) {
+	p := pattern{text: line, source: source, base: base}
+
+	glob := line
+	if strings.HasPrefix(glob, "!") {
+		p.negate, glob = true, glob[1:]
+	}
+	if strings.HasSuffix(glob, "/") {
+		p.dirOnly, glob = true, strings.TrimRight(glob, "/")
+	}
+	if glob == "" {
+		return pattern{}, errs.New("invalid pattern: %q", line)
+	}
+
+	// patterns with a slash before the end are relative to the base, and
+	// other patterns match at any depth.
+	var expr strings.Builder
+	expr.WriteString("^")
+	if strings.Contains(glob, "/") {
+		glob = strings.TrimPrefix(glob, "/")
+	} else {
+		expr.WriteString("(?:.*/)?")
+	}
+
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case strings.HasPrefix(glob[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case c == '*':
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		case c == '\\' && i+1 < len(glob):
+			expr.WriteString(regexp.QuoteMeta(glob[i+1 : i+2]))
+			i++
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				return pattern{}, errs.New("invalid pattern: %q: unterminated character class", line)
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			expr.WriteString("[" + class + "]")
+			i += end + 1
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString("$")
+
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		return pattern{}, errs.New("invalid pattern: %q: %v", line, err)
+	}
+	p.re = re
+	return p, nil
+}
+
+// match returns true if the pattern matches the path relative to the root.
+func (p pattern) match(rel string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+	if !strings.HasPrefix(rel, p.base) {
+		return false
+	}
+	return p.re.MatchString(rel[len(p.base):])
+}
+
+// Matcher decides which entries beneath the root of a listing a Filter skips.
+type Matcher struct {
+	includes []pattern
+	excludes []pattern
+	ignores  []pattern
+}
+
+// NewMatcher returns a Matcher for the patterns of the filter.
+func NewMatcher(filter *Filter) (*Matcher, error) {
+	m := new(Matcher)
+	for _, include := range filter.Includes {
+		p, err := compilePattern(include, "--include", "")
+		if err != nil {
+			return nil, err
+		}
+		m.includes = append(m.includes, p)
+	}
+	for _, exclude := range filter.Excludes {
+		p, err := compilePattern(exclude, "--exclude", "")
+		if err != nil {
+			return nil, err
+		}
+		m.excludes = append(m.excludes, p)
+	}
+	return m, nil
+}
+
+// AddIgnoreFile adds the patterns of an ignore file in the directory dir,
+// which is relative to the root and is empty or has a trailing slash. Lines
+// that are not valid patterns are skipped like git does.
+func (m *Matcher) AddIgnoreFile(dir string, data []byte) {
+	source := dir + IgnoreFile
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, `\#`) || strings.HasPrefix(line, `\!`) {
+			line = line[1:]
+		}
+		p, err := compilePattern(line, source, dir)
+		if err != nil {
+			continue
+		}
+		m.ignores = append(m.ignores, p)
+	}
+}
+
+// AddIgnoreFiles adds the ignore files by the directory they are in, like
+// AddIgnoreFile. The files of shallower directories are added first, so that
+// the patterns of deeper ignore files take precedence like in a walk.
+func (m *Matcher) AddIgnoreFiles(files map[string][]byte) {
+	dirs := make([]string, 0, len(files))
+	for dir := range files {
+		dirs = append(dirs, dir)
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		di, dj := strings.Count(dirs[i], "/"), strings.Count(dirs[j], "/")
+		if di != dj {
+			return di < dj
+		}
+		return dirs[i] < dirs[j]
+	})
+	for _, dir := range dirs {
+		m.AddIgnoreFile(dir, files[dir])
+	}
+}
+
+// IgnoreFileDir returns the directory of the ignore file with the path rel
+// relative to the root, if rel is the path of an ignore file.
+func IgnoreFileDir(rel string) (dir string, ok bool) {
+	if rel != IgnoreFile && !strings.HasSuffix(rel, "/"+IgnoreFile) {
+		return "", false
+	}
+	return rel[:len(rel)-len(IgnoreFile)], true
+}
+
+// Match returns the reason if the entry with the path relative to the root
+// is skipped. Entries beneath skipped directories are not considered.
+func (m *Matcher) Match(rel string, isDir bool) (reason string, skip bool) {
+	for _, p := range m.excludes {
+		if p.match(rel, isDir) {
+			return fmt.Sprintf("excluded by %q", p.text), true
+		}
+	}
+
+	// the last matching pattern of the ignore files wins, so that negated
+	// patterns can include entries again.
+	for i := len(m.ignores) - 1; i >= 0; i-- {
+		if p := m.ignores[i]; p.match(rel, isDir) {
+			if p.negate {
+				break
+			}
+			return fmt.Sprintf("ignored by %q in %s", p.text, p.source), true
+		}
+	}
+
+	if !isDir && len(m.includes) > 0 {
+		for _, p := range m.includes {
+			if p.match(rel, isDir) {
+				return "", false
+			}
+		}
+		return "not matched by any --include", true
+	}
+	return "", false
+}
+
+// MatchPath is like Match for a file, but also checks every directory that
+// the file is in. It returns the path of the skipped directory with a
+// trailing slash, or the path of the file, that the reason applies to.
+func (m *Matcher) MatchPath(rel string) (skipped, reason string, skip bool) {
+	for i := 0; i < len(rel); i++ {
+		if rel[i] != '/' {
+			continue
+		}
+		if reason, skip := m.Match(rel[:i], true); skip {
+			return rel[:i+1], reason, true
+		}
+	}
+	if reason, skip := m.Match(rel, false); skip {
+		return rel, reason, true
+	}
+	return "", "", false
+}
+
+// MatchObjects returns an iterator that skips the entries of iter beneath root
+// that the matcher skips, reporting them to the filter.
+func MatchObjects(root ulloc.Location, matcher *Matcher, filter *Filter, iter ObjectIterator) ObjectIterator {
+	return &matchedObjectIterator{
+		root:    root,
+		matcher: matcher,
+		filter:  filter,
+		iter:    iter,
+	}
+}
+
+// matchedObjectIterator skips the entries beneath root that the matcher
+// skips, reporting them to the filter.
+type matchedObjectIterator struct {
+	root    ulloc.Location
+	matcher *Matcher
+	filter  *Filter
+	iter    ObjectIterator
+
+	reported string // the last reported skipped directory
+}
+
+func (m *matchedObjectIterator) Next() bool {
+	for m.iter.Next() {
+		loc := m.iter.Item().Loc
+		if !loc.HasPrefix(m.root) {
+			return true
+		}
+		rel := loc.RemovePrefix(m.root).Loc()
+
+		skipped, reason, skip := m.matcher.MatchPath(rel)
+		if !skip {
+			return true
+		}
+		if m.filter.Skipped != nil && skipped != m.reported {
+			m.filter.Skipped(m.root.AppendKey(skipped), reason)
+		}
+		if strings.HasSuffix(skipped, "/") {
+			m.reported = skipped
+		}
+	}
+	return false
+}
+
+func (m *matchedObjectIterator) Err() error       { return m.iter.Err() }
+func (m *matchedObjectIterator) Item() ObjectInfo { return m.iter.Item() }
@@ -5,6 +5,7 @@ package ulfs
 
 import (
 	"context"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -33,8 +34,41 @@ func (l *Local) Open(ctx context.Context, path string) (ReadHandle, error) {
 	return newOSReadHandle(fh)
 }
 
+// OpenRange returns a read ReadHandle for length bytes starting at offset of the given local path.
+func (l *Local) OpenRange(ctx context.Context, path string, offset, length int64) (ReadHandle, error) {
+	fh, err := os.Open(path)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	if _, err := fh.Seek(offset, io.SeekStart); err != nil {
+		return nil, errs.Combine(err, fh.Close())
+	}
+	rh, err := newOSReadHandle(fh)
+	if err != nil {
+		return nil, errs.Combine(err, fh.Close())
+	}
+	return newRangeReadHandle(rh, length), nil
+}
+
 // Create makes any directories necessary to create a file at path and returns a WriteHandle.
 func (l *Local) Create(ctx context.Context, path string) (WriteHandle, error) {
+	return l.create(ctx, path)
+}
+
+// CreateWriterAt makes any directories necessary to create a file of size bytes at path and
+// returns a WriterAtHandle.
+func (l *Local) CreateWriterAt(ctx context.Context, path string, size int64) (WriterAtHandle, error) {
+	wh, err := l.create(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	if err := wh.fh.Truncate(size); err != nil {
+		return nil, errs.Combine(err, wh.Abort())
+	}
+	return wh, nil
+}
+
+func (l *Local) create(ctx context.Context, path string) (*osWriteHandle, error) {
 	fi, err := os.Stat(path)
 	if err != nil && !os.IsNotExist(err) {
 		return nil, errs.Wrap(err)
@@ -65,9 +99,24 @@ func (l *Local) Remove(ctx context.Context, path string) error {
 	return nil
 }
 
+// Stat returns information about the file or directory at the path.
+func (l *Local) Stat(ctx context.Context, path string) (*ObjectInfo, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &ObjectInfo{
+		Loc:           ulloc.NewLocal(path),
+		IsPrefix:      fi.IsDir(),
+		Created:       fi.ModTime(), // TODO: use real crtime
+		ContentLength: fi.Size(),
+	}, nil
+}
+
 // ListObjects returns an ObjectIterator listing files and directories that have string prefix
-// with the provided path.
-func (l *Local) ListObjects(ctx context.Context, path string, recursive bool) (ObjectIterator, error) {
+// with the provided path. Recursive listings skip the entries that the filter skips, if there
+// is one.
+func (l *Local) ListObjects(ctx context.Context, path string, recursive bool, filter *Filter) (ObjectIterator, error) {
 	prefix := path
 	if idx := strings.LastIndex(path, "/"); idx >= 0 {
 		prefix = path[:idx+1]
@@ -81,7 +130,21 @@ func (l *Local) ListObjects(ctx context.Context, path string, recursive bool) (O
 
 	var files []os.FileInfo
 	if recursive {
+		var lm *localMatcher
+		if filter != nil {
+			lm, err = newLocalMatcher(path, filter)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		err = filepath.Walk(prefix, func(path string, info os.FileInfo, err error) error {
+			if err == nil && lm.skip(path, info) {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
 			if err == nil && !info.IsDir() {
 				rel, err := filepath.Rel(prefix, path)
 				if err != nil {
@@ -135,6 +198,69 @@ func (l *Local) IsLocalDir(ctx context.Context, path string) bool {
 	return fi.IsDir()
 }
 
+// localMatcher skips the entries of a walk beneath root that a filter skips.
+// The ignore file of every directory is loaded when it is walked.
+type localMatcher struct {
+	root    string
+	filter  *Filter
+	matcher *Matcher
+}
+
+func newLocalMatcher(path string, filter *Filter) (*localMatcher, error) {
+	root, err := filepath.Abs(path)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	matcher, err := NewMatcher(filter)
+	if err != nil {
+		return nil, err
+	}
+	return &localMatcher{
+		root:    root + string(filepath.Separator),
+		filter:  filter,
+		matcher: matcher,
+	}, nil
+}
+
+// skip returns true if the walked path is skipped.
+func (lm *localMatcher) skip(path string, info os.FileInfo) bool {
+	if lm == nil {
+		return false
+	}
+	if filepath.Clean(path)+string(filepath.Separator) == lm.root {
+		lm.loadIgnoreFile(path, "")
+		return false
+	}
+	if !strings.HasPrefix(path, lm.root) {
+		return false
+	}
+
+	rel := filepath.ToSlash(path[len(lm.root):])
+	if reason, skip := lm.matcher.Match(rel, info.IsDir()); skip {
+		if lm.filter.Skipped != nil {
+			if info.IsDir() {
+				path += string(filepath.Separator)
+			}
+			lm.filter.Skipped(ulloc.NewLocal(path), reason)
+		}
+		return true
+	}
+
+	if info.IsDir() {
+		lm.loadIgnoreFile(path, rel+"/")
+	}
+	return false
+}
+
+// loadIgnoreFile adds the ignore file in the directory, if there is one.
+func (lm *localMatcher) loadIgnoreFile(dir, rel string) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, IgnoreFile))
+	if err != nil {
+		return
+	}
+	lm.matcher.AddIgnoreFile(rel, data)
+}
+
 type namedFileInfo struct {
 	os.FileInfo
 	name string
@@ -16,6 +16,7 @@ import (
 type Mixed struct {
 	local  *Local
 	remote *Remote
+	filter *Filter
 }
 
 // NewMixed returns a Mixed backed by the provided local and remote filesystems.
@@ -26,6 +27,11 @@ func NewMixed(local *Local, remote *Remote) *Mixed {
 	}
 }
 
+// SetFilter sets the filter that recursive listings skip entries with.
+func (m *Mixed) SetFilter(filter *Filter) {
+	m.filter = filter
+}
+
 // Close releases any resources that the Mixed contails.
 func (m *Mixed) Close() error {
 	return m.remote.Close()
@@ -41,6 +47,24 @@ func (m *Mixed) Open(ctx clingy.Context, loc ulloc.Location) (ReadHandle, error)
 	return newGenericReadHandle(ctx.Stdin()), nil
 }
 
+// OpenRange returns a ReadHandle to a range of bytes of either a local file or remote object.
+func (m *Mixed) OpenRange(ctx clingy.Context, loc ulloc.Location, offset, length int64) (ReadHandle, error) {
+	if bucket, key, ok := loc.RemoteParts(); ok {
+		return m.remote.OpenRange(ctx, bucket, key, offset, length)
+	} else if path, ok := loc.LocalParts(); ok {
+		return m.local.OpenRange(ctx, path, offset, length)
+	}
+	return nil, errs.New("unable to open a range of %q", loc)
+}
+
+// OpenVersion returns a ReadHandle to a version of a remote object.
+func (m *Mixed) OpenVersion(ctx clingy.Context, loc ulloc.Location, version int64) (ReadHandle, error) {
+	if bucket, key, ok := loc.RemoteParts(); ok {
+		return m.remote.OpenVersion(ctx, bucket, key, version)
+	}
+	return nil, errs.New("versions are only supported for remote objects: %q", loc)
+}
+
 // Create returns a WriteHandle to either a local file, remote object, or stdout.
 func (m *Mixed) Create(ctx clingy.Context, loc ulloc.Location) (WriteHandle, error) {
 	if bucket, key, ok := loc.RemoteParts(); ok {
@@ -51,6 +75,14 @@ func (m *Mixed) Create(ctx clingy.Context, loc ulloc.Location) (WriteHandle, err
 	return newGenericWriteHandle(ctx.Stdout()), nil
 }
 
+// CreateWriterAt returns a WriterAtHandle to a local file.
+func (m *Mixed) CreateWriterAt(ctx clingy.Context, loc ulloc.Location, size int64) (WriterAtHandle, error) {
+	if path, ok := loc.LocalParts(); ok {
+		return m.local.CreateWriterAt(ctx, path, size)
+	}
+	return nil, errs.New("writing at offsets is only supported for local files: %q", loc)
+}
+
 // Remove deletes either a local file or remote object.
 func (m *Mixed) Remove(ctx context.Context, loc ulloc.Location) error {
 	if bucket, key, ok := loc.RemoteParts(); ok {
@@ -61,17 +93,80 @@ func (m *Mixed) Remove(ctx context.Context, loc ulloc.Location) error {
 	return nil
 }
 
+// Move moves a remote object to another remote location without transferring
+// its data.
+func (m *Mixed) Move(ctx context.Context, source, dest ulloc.Location) error {
+	bucket, key, ok := source.RemoteParts()
+	if !ok {
+		return errs.New("only remote objects can be moved: %q", source)
+	}
+	newBucket, newKey, ok := dest.RemoteParts()
+	if !ok {
+		return errs.New("remote objects can only be moved to remote locations: %q", dest)
+	}
+	return m.remote.Move(ctx, bucket, key, newBucket, newKey)
+}
+
+// Copy copies a remote object to another remote location without transferring
+// its data.
+func (m *Mixed) Copy(ctx context.Context, source, dest ulloc.Location) error {
+	bucket, key, ok := source.RemoteParts()
+	if !ok {
+		return errs.New("only remote objects can be copied on the satellite: %q", source)
+	}
+	newBucket, newKey, ok := dest.RemoteParts()
+	if !ok {
+		return errs.New("remote objects can only be copied on the satellite to remote locations: %q", dest)
+	}
+	return m.remote.Copy(ctx, bucket, key, newBucket, newKey)
+}
+
+// Stat returns information about either a local file or remote object.
+func (m *Mixed) Stat(ctx context.Context, loc ulloc.Location) (*ObjectInfo, error) {
+	if bucket, key, ok := loc.RemoteParts(); ok {
+		return m.remote.Stat(ctx, bucket, key)
+	} else if path, ok := loc.LocalParts(); ok {
+		return m.local.Stat(ctx, path)
+	}
+	return nil, errs.New("unable to stat %q", loc)
+}
+
+// SegmentOffsets returns the offsets at which the segments of a remote object start.
+// Local files have no segments.
+func (m *Mixed) SegmentOffsets(ctx context.Context, loc ulloc.Location) ([]int64, error) {
+	if bucket, key, ok := loc.RemoteParts(); ok {
+		return m.remote.SegmentOffsets(ctx, bucket, key)
+	}
+	return nil, nil
+}
+
 // ListObjects lists either files and directories with some local path prefix or remote objects
 // with a given bucket and key.
 func (m *Mixed) ListObjects(ctx context.Context, prefix ulloc.Location, recursive bool) (ObjectIterator, error) {
 	if bucket, key, ok := prefix.RemoteParts(); ok {
-		return m.remote.ListObjects(ctx, bucket, key, recursive), nil
+		return m.remote.ListObjects(ctx, bucket, key, recursive, m.filter)
 	} else if path, ok := prefix.LocalParts(); ok {
-		return m.local.ListObjects(ctx, path, recursive)
+		return m.local.ListObjects(ctx, path, recursive, m.filter)
 	}
 	return nil, errs.New("unable to list objects for prefix %q", prefix)
 }
 
+// ListObjectVersions lists the versions of remote objects with a given bucket and key.
+func (m *Mixed) ListObjectVersions(ctx context.Context, prefix ulloc.Location, recursive bool) (ObjectIterator, error) {
+	if bucket, key, ok := prefix.RemoteParts(); ok {
+		return m.remote.ListObjectVersions(ctx, bucket, key, recursive, m.filter)
+	}
+	return nil, errs.New("versions are only supported for remote objects: %q", prefix)
+}
+
+// ListTaggedObjects lists the remote objects with a given bucket and key that have all of the tags.
+func (m *Mixed) ListTaggedObjects(ctx context.Context, prefix ulloc.Location, recursive bool, tags map[string]string) (ObjectIterator, error) {
+	if bucket, key, ok := prefix.RemoteParts(); ok {
+		return m.remote.ListTaggedObjects(ctx, bucket, key, recursive, tags, m.filter)
+	}
+	return nil, errs.New("tags are only supported for remote objects: %q", prefix)
+}
+
 // ListUploads lists all of the pending uploads for remote objects with some given bucket and key.
 func (m *Mixed) ListUploads(ctx context.Context, prefix ulloc.Location, recursive bool) (ObjectIterator, error) {
 	if bucket, key, ok := prefix.RemoteParts(); ok {
@@ -82,6 +177,30 @@ func (m *Mixed) ListUploads(ctx context.Context, prefix ulloc.Location, recursiv
 	return nil, errs.New("unable to list uploads for prefix %q", prefix)
 }
 
+// BeginMultipart starts a multipart upload of a remote object.
+func (m *Mixed) BeginMultipart(ctx context.Context, loc ulloc.Location) (MultipartUpload, error) {
+	if bucket, key, ok := loc.RemoteParts(); ok {
+		return m.remote.BeginMultipart(ctx, bucket, key)
+	}
+	return nil, errs.New("multipart uploads are only supported for remote objects: %q", loc)
+}
+
+// ResumeMultipart returns a previously started multipart upload of a remote object.
+func (m *Mixed) ResumeMultipart(ctx context.Context, loc ulloc.Location, uploadID string) (MultipartUpload, error) {
+	if bucket, key, ok := loc.RemoteParts(); ok {
+		return m.remote.ResumeMultipart(ctx, bucket, key, uploadID), nil
+	}
+	return nil, errs.New("multipart uploads are only supported for remote objects: %q", loc)
+}
+
+// AbortMultipart aborts a multipart upload of a remote object.
+func (m *Mixed) AbortMultipart(ctx context.Context, loc ulloc.Location, uploadID string) error {
+	if bucket, key, ok := loc.RemoteParts(); ok {
+		return m.remote.AbortMultipart(ctx, bucket, key, uploadID)
+	}
+	return errs.New("multipart uploads are only supported for remote objects: %q", loc)
+}
+
 // IsLocalDir returns true if the location is a directory that is local.
 func (m *Mixed) IsLocalDir(ctx context.Context, loc ulloc.Location) bool {
 	if path, ok := loc.LocalParts(); ok {
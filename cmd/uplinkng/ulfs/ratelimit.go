@@ -0,0 +1,246 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package ulfs
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/zeebo/clingy"
+	"golang.org/x/time/rate"
+
+	"storj.io/storj/cmd/uplinkng/ulloc"
+)
+
+// rateLimitChunk is the largest number of bytes that is waited for at once.
+const rateLimitChunk = 64 * 1024
+
+// RateWindow is a time of day during which a different rate applies.
+type RateWindow struct {
+	Start time.Duration // offset from midnight in local time
+	End   time.Duration // offset from midnight in local time, before Start if the window wraps midnight
+	Rate  int64         // bytes per second, zero for no limit
+}
+
+// contains returns true if the offset from midnight is within the window.
+func (w RateWindow) contains(offset time.Duration) bool {
+	if w.Start <= w.End {
+		return w.Start <= offset && offset < w.End
+	}
+	return offset >= w.Start || offset < w.End
+}
+
+// RateLimiter limits the rate that data is transferred at. A single
+// RateLimiter is shared by every transfer so that the limit applies to all
+// of them together.
+type RateLimiter struct {
+	rate    int64
+	windows []RateWindow
+
+	limiter *rate.Limiter
+}
+
+// NewRateLimiter returns a RateLimiter allowing bytesPerSecond, or any rate if
+// it is zero, except during the windows, where the rate of the first window
+// containing the current time applies.
+func NewRateLimiter(bytesPerSecond int64, windows []RateWindow) *RateLimiter {
+	return &RateLimiter{
+		rate:    bytesPerSecond,
+		windows: windows,
+		limiter: rate.NewLimiter(rate.Inf, rateLimitChunk),
+	}
+}
+
+// Enabled returns true if the rate is limited at any time of day.
+func (r *RateLimiter) Enabled() bool {
+	if r.rate > 0 {
+		return true
+	}
+	for _, w := range r.windows {
+		if w.Rate > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// RateAt returns the bytes per second allowed at the time, or zero if any
+// rate is allowed.
+func (r *RateLimiter) RateAt(t time.Time) int64 {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := t.Sub(midnight)
+	for _, w := range r.windows {
+		if w.contains(offset) {
+			return w.Rate
+		}
+	}
+	return r.rate
+}
+
+// WaitN blocks until n bytes may be transferred.
+func (r *RateLimiter) WaitN(ctx context.Context, n int) error {
+	now := time.Now()
+
+	limit := rate.Inf
+	if bps := r.RateAt(now); bps > 0 {
+		limit = rate.Limit(bps)
+	}
+	if r.limiter.Limit() != limit {
+		r.limiter.SetLimitAt(now, limit)
+	}
+
+	for n > 0 {
+		chunk := n
+		if chunk > rateLimitChunk {
+			chunk = rateLimitChunk
+		}
+		if err := r.limiter.WaitN(ctx, chunk); err != nil {
+			return err
+		}
+		n -= chunk
+	}
+	return nil
+}
+
+//
+// filesystem
+//
+
+// RateLimited wraps a Filesystem so that the data transferred to and from
+// remote locations is limited by a RateLimiter.
+type RateLimited struct {
+	Filesystem
+	limiter *RateLimiter
+}
+
+// NewRateLimited returns a RateLimited wrapping the filesystem.
+func NewRateLimited(fs Filesystem, limiter *RateLimiter) *RateLimited {
+	return &RateLimited{
+		Filesystem: fs,
+		limiter:    limiter,
+	}
+}
+
+// Open returns a ReadHandle that is rate limited if the location is remote.
+func (rl *RateLimited) Open(ctx clingy.Context, loc ulloc.Location) (ReadHandle, error) {
+	rh, err := rl.Filesystem.Open(ctx, loc)
+	if err != nil || !loc.Remote() {
+		return rh, err
+	}
+	return &rateLimitedReadHandle{ReadHandle: rh, r: rl.reader(ctx, rh)}, nil
+}
+
+// OpenRange returns a ReadHandle that is rate limited if the location is remote.
+func (rl *RateLimited) OpenRange(ctx clingy.Context, loc ulloc.Location, offset, length int64) (ReadHandle, error) {
+	rh, err := rl.Filesystem.OpenRange(ctx, loc, offset, length)
+	if err != nil || !loc.Remote() {
+		return rh, err
+	}
+	return &rateLimitedReadHandle{ReadHandle: rh, r: rl.reader(ctx, rh)}, nil
+}
+
+// OpenVersion returns a ReadHandle that is rate limited.
+func (rl *RateLimited) OpenVersion(ctx clingy.Context, loc ulloc.Location, version int64) (ReadHandle, error) {
+	rh, err := rl.Filesystem.OpenVersion(ctx, loc, version)
+	if err != nil {
+		return rh, err
+	}
+	return &rateLimitedReadHandle{ReadHandle: rh, r: rl.reader(ctx, rh)}, nil
+}
+
+// Create returns a WriteHandle that is rate limited if the location is remote.
+func (rl *RateLimited) Create(ctx clingy.Context, loc ulloc.Location) (WriteHandle, error) {
+	wh, err := rl.Filesystem.Create(ctx, loc)
+	if err != nil || !loc.Remote() {
+		return wh, err
+	}
+	return &rateLimitedWriteHandle{WriteHandle: wh, ctx: ctx, limiter: rl.limiter}, nil
+}
+
+// BeginMultipart returns a MultipartUpload whose parts are rate limited.
+func (rl *RateLimited) BeginMultipart(ctx context.Context, loc ulloc.Location) (MultipartUpload, error) {
+	upload, err := rl.Filesystem.BeginMultipart(ctx, loc)
+	if err != nil {
+		return nil, err
+	}
+	return &rateLimitedMultipartUpload{MultipartUpload: upload, rl: rl}, nil
+}
+
+// ResumeMultipart returns a MultipartUpload whose parts are rate limited.
+func (rl *RateLimited) ResumeMultipart(ctx context.Context, loc ulloc.Location, uploadID string) (MultipartUpload, error) {
+	upload, err := rl.Filesystem.ResumeMultipart(ctx, loc, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	return &rateLimitedMultipartUpload{MultipartUpload: upload, rl: rl}, nil
+}
+
+func (rl *RateLimited) reader(ctx context.Context, r io.Reader) *rateLimitedReader {
+	return &rateLimitedReader{ctx: ctx, r: r, limiter: rl.limiter}
+}
+
+// rateLimitedReader waits for the limiter after every read.
+type rateLimitedReader struct {
+	ctx     context.Context
+	r       io.Reader
+	limiter *RateLimiter
+}
+
+func (r *rateLimitedReader) Read(p []byte) (int, error) {
+	if len(p) > rateLimitChunk {
+		p = p[:rateLimitChunk]
+	}
+	n, err := r.r.Read(p)
+	if n > 0 {
+		if werr := r.limiter.WaitN(r.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
+// rateLimitedReadHandle is a ReadHandle whose reads are rate limited.
+type rateLimitedReadHandle struct {
+	ReadHandle
+	r *rateLimitedReader
+}
+
+func (r *rateLimitedReadHandle) Read(p []byte) (int, error) { return r.r.Read(p) }
+
+// rateLimitedWriteHandle is a WriteHandle whose writes are rate limited.
+type rateLimitedWriteHandle struct {
+	WriteHandle
+	ctx     context.Context
+	limiter *RateLimiter
+}
+
+func (w *rateLimitedWriteHandle) Write(p []byte) (n int, err error) {
+	for len(p) > 0 {
+		chunk := p
+		if len(chunk) > rateLimitChunk {
+			chunk = chunk[:rateLimitChunk]
+		}
+		if err := w.limiter.WaitN(w.ctx, len(chunk)); err != nil {
+			return n, err
+		}
+		m, err := w.WriteHandle.Write(chunk)
+		n += m
+		if err != nil {
+			return n, err
+		}
+		p = p[m:]
+	}
+	return n, nil
+}
+
+// rateLimitedMultipartUpload is a MultipartUpload whose parts are rate limited.
+type rateLimitedMultipartUpload struct {
+	MultipartUpload
+	rl *RateLimited
+}
+
+func (u *rateLimitedMultipartUpload) UploadPart(ctx context.Context, number uint32, r io.Reader) error {
+	return u.MultipartUpload.UploadPart(ctx, number, u.rl.reader(ctx, r))
+}
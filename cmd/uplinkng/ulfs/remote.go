@@ -5,29 +5,35 @@ package ulfs
 
 import (
 	"context"
+	"errors"
+	"io/ioutil"
 	"strings"
 
 	"github.com/zeebo/errs"
 
 	"storj.io/storj/cmd/uplinkng/ulloc"
+	"storj.io/storj/cmd/uplinkng/ulmetainfo"
 	"storj.io/uplink"
 )
 
 // Remote implements something close to a filesystem but backed by an uplink project.
 type Remote struct {
 	project *uplink.Project
+	client  *ulmetainfo.Client
 }
 
 // NewRemote returns something close to a filesystem and returns objects using the project.
-func NewRemote(project *uplink.Project) *Remote {
+// The client is used for the operations that the project does not support.
+func NewRemote(project *uplink.Project, client *ulmetainfo.Client) *Remote {
 	return &Remote{
 		project: project,
+		client:  client,
 	}
 }
 
 // Close releases any resources that the Remote contains.
 func (r *Remote) Close() error {
-	return r.project.Close()
+	return errs.Combine(r.project.Close(), r.client.Close())
 }
 
 // Open returns a ReadHandle for the object identified by a given bucket and key.
@@ -39,6 +45,29 @@ func (r *Remote) Open(ctx context.Context, bucket, key string) (ReadHandle, erro
 	return newUplinkReadHandle(bucket, fh), nil
 }
 
+// OpenRange returns a ReadHandle for length bytes starting at offset of the object identified by
+// a given bucket and key.
+func (r *Remote) OpenRange(ctx context.Context, bucket, key string, offset, length int64) (ReadHandle, error) {
+	fh, err := r.project.DownloadObject(ctx, bucket, key, &uplink.DownloadOptions{
+		Offset: offset,
+		Length: length,
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return newUplinkReadHandle(bucket, fh), nil
+}
+
+// OpenVersion returns a ReadHandle for the version of the object identified by a given
+// bucket and key.
+func (r *Remote) OpenVersion(ctx context.Context, bucket, key string, version int64) (ReadHandle, error) {
+	dl, err := r.client.DownloadObjectVersion(ctx, bucket, key, version, 0, -1)
+	if err != nil {
+		return nil, err
+	}
+	return newVersionReadHandle(bucket, version, dl), nil
+}
+
 // Create returns a WriteHandle for the object identified by a given bucket and key.
 func (r *Remote) Create(ctx context.Context, bucket, key string) (WriteHandle, error) {
 	fh, err := r.project.UploadObject(ctx, bucket, key, nil)
@@ -57,8 +86,53 @@ func (r *Remote) Remove(ctx context.Context, bucket, key string) error {
 	return nil
 }
 
+// Move moves the object at the provided key and bucket to the new bucket and key
+// on the satellite.
+func (r *Remote) Move(ctx context.Context, bucket, key, newBucket, newKey string) error {
+	return r.client.MoveObject(ctx, bucket, key, newBucket, newKey)
+}
+
+// Copy copies the object at the provided key and bucket to the new bucket and
+// key on the satellite.
+func (r *Remote) Copy(ctx context.Context, bucket, key, newBucket, newKey string) error {
+	return r.client.CopyObject(ctx, bucket, key, newBucket, newKey)
+}
+
+// Stat returns information about the object at the provided key and bucket.
+func (r *Remote) Stat(ctx context.Context, bucket, key string) (*ObjectInfo, error) {
+	obj, err := r.project.StatObject(ctx, bucket, key)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	info := uplinkObjectToObjectInfo(bucket, obj)
+	return &info, nil
+}
+
+// SegmentOffsets returns the offsets at which the segments of the object at the provided
+// key and bucket start.
+func (r *Remote) SegmentOffsets(ctx context.Context, bucket, key string) ([]int64, error) {
+	return r.client.SegmentOffsets(ctx, bucket, key)
+}
+
 // ListObjects lists all of the objects in some bucket that begin with the given prefix.
-func (r *Remote) ListObjects(ctx context.Context, bucket, prefix string, recursive bool) ObjectIterator {
+// Recursive listings skip the objects that the filter skips, if there is one.
+func (r *Remote) ListObjects(ctx context.Context, bucket, prefix string, recursive bool, filter *Filter) (ObjectIterator, error) {
+	return r.listObjects(ctx, bucket, prefix, recursive, filter, nil, false)
+}
+
+// ListObjectVersions lists every version and delete marker of the objects in some bucket
+// that begin with the given prefix, newest first.
+func (r *Remote) ListObjectVersions(ctx context.Context, bucket, prefix string, recursive bool, filter *Filter) (ObjectIterator, error) {
+	return r.listObjects(ctx, bucket, prefix, recursive, filter, nil, true)
+}
+
+// ListTaggedObjects lists the objects in some bucket that begin with the given prefix and
+// have all of the tags.
+func (r *Remote) ListTaggedObjects(ctx context.Context, bucket, prefix string, recursive bool, tags map[string]string, filter *Filter) (ObjectIterator, error) {
+	return r.listObjects(ctx, bucket, prefix, recursive, filter, tags, false)
+}
+
+func (r *Remote) listObjects(ctx context.Context, bucket, prefix string, recursive bool, filter *Filter, tags map[string]string, versions bool) (ObjectIterator, error) {
 	parentPrefix := ""
 	if idx := strings.LastIndexByte(prefix, '/'); idx >= 0 {
 		parentPrefix = prefix[:idx+1]
@@ -69,16 +143,89 @@ func (r *Remote) ListObjects(ctx context.Context, bucket, prefix string, recursi
 		trim = ulloc.NewRemote(bucket, parentPrefix)
 	}
 
-	return &filteredObjectIterator{
-		trim:   trim,
-		filter: ulloc.NewRemote(bucket, prefix),
-		iter: newUplinkObjectIterator(bucket, r.project.ListObjects(ctx, bucket,
+	var iter ObjectIterator
+	if versions {
+		iter = newVersionObjectIterator(bucket, r.client.ListPrefixVersions(ctx, bucket, parentPrefix, recursive))
+	} else if len(tags) > 0 {
+		iter = newTaggedObjectIterator(bucket, r.client.ListTaggedObjects(ctx, bucket, parentPrefix, recursive, tags))
+	} else {
+		iter = newUplinkObjectIterator(bucket, r.project.ListObjects(ctx, bucket,
 			&uplink.ListObjectsOptions{
 				Prefix:    parentPrefix,
 				Recursive: recursive,
 				System:    true,
-			})),
+			}))
+	}
+
+	if recursive && filter != nil {
+		root := ulloc.NewRemote(bucket, prefix).AsDirectoryish()
+		matcher, err := r.newMatcher(ctx, root, filter)
+		if err != nil {
+			return nil, err
+		}
+		iter = MatchObjects(root, matcher, filter, iter)
+	}
+
+	return &filteredObjectIterator{
+		trim:   trim,
+		filter: ulloc.NewRemote(bucket, prefix),
+		iter:   iter,
+	}, nil
+}
+
+// newMatcher returns a Matcher for the filter that includes the ignore files
+// stored beneath the root. The listing isn't ordered by directory, so the root
+// is listed once to find the ignore files before any object is matched.
+func (r *Remote) newMatcher(ctx context.Context, root ulloc.Location, filter *Filter) (*Matcher, error) {
+	matcher, err := NewMatcher(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket, prefix, _ := root.RemoteParts()
+	iter := r.project.ListObjects(ctx, bucket, &uplink.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: true,
+	})
+
+	files := make(map[string][]byte)
+	for iter.Next() {
+		dir, ok := IgnoreFileDir(strings.TrimPrefix(iter.Item().Key, prefix))
+		if !ok {
+			continue
+		}
+		data, err := r.readIgnoreFile(ctx, bucket, iter.Item().Key)
+		if err != nil {
+			return nil, err
+		}
+		if data != nil {
+			files[dir] = data
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, errs.Wrap(err)
+	}
+
+	matcher.AddIgnoreFiles(files)
+	return matcher, nil
+}
+
+// readIgnoreFile returns the contents of the ignore file, or nil if it was
+// deleted since it was listed.
+func (r *Remote) readIgnoreFile(ctx context.Context, bucket, key string) ([]byte, error) {
+	dl, err := r.project.DownloadObject(ctx, bucket, key, nil)
+	if errors.Is(err, uplink.ErrObjectNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errs.Wrap(err)
 	}
+	defer func() { _ = dl.Close() }()
+
+	data, err := ioutil.ReadAll(dl)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return data, nil
 }
 
 // ListUploads lists all of the pending uploads in some bucket that begin with the given prefix.
@@ -105,6 +252,31 @@ func (r *Remote) ListUploads(ctx context.Context, bucket, prefix string, recursi
 	}
 }
 
+// BeginMultipart starts a multipart upload of the object identified by a given bucket and key.
+func (r *Remote) BeginMultipart(ctx context.Context, bucket, key string) (MultipartUpload, error) {
+	info, err := r.project.BeginUpload(ctx, bucket, key, nil)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return r.ResumeMultipart(ctx, bucket, key, info.UploadID), nil
+}
+
+// ResumeMultipart returns a previously started multipart upload of the object identified by
+// a given bucket and key.
+func (r *Remote) ResumeMultipart(ctx context.Context, bucket, key, uploadID string) MultipartUpload {
+	return &uplinkMultipartUpload{
+		project:  r.project,
+		bucket:   bucket,
+		key:      key,
+		uploadID: uploadID,
+	}
+}
+
+// AbortMultipart aborts the multipart upload of the object identified by a given bucket and key.
+func (r *Remote) AbortMultipart(ctx context.Context, bucket, key, uploadID string) error {
+	return errs.Wrap(r.project.AbortUpload(ctx, bucket, key, uploadID))
+}
+
 // uplinkObjectIterator implements objectIterator for *uplink.ObjectIterator.
 type uplinkObjectIterator struct {
 	bucket string
@@ -125,6 +297,93 @@ func (u *uplinkObjectIterator) Item() ObjectInfo {
 	return uplinkObjectToObjectInfo(u.bucket, u.iter.Item())
 }
 
+// taggedObjectIterator implements objectIterator for *ulmetainfo.TaggedObjectIterator.
+type taggedObjectIterator struct {
+	bucket string
+	iter   *ulmetainfo.TaggedObjectIterator
+}
+
+// newTaggedObjectIterator constructs a *taggedObjectIterator from a *ulmetainfo.TaggedObjectIterator.
+func newTaggedObjectIterator(bucket string, iter *ulmetainfo.TaggedObjectIterator) *taggedObjectIterator {
+	return &taggedObjectIterator{
+		bucket: bucket,
+		iter:   iter,
+	}
+}
+
+func (t *taggedObjectIterator) Next() bool { return t.iter.Next() }
+func (t *taggedObjectIterator) Err() error { return t.iter.Err() }
+func (t *taggedObjectIterator) Item() ObjectInfo {
+	return uplinkObjectToObjectInfo(t.bucket, t.iter.Item())
+}
+
+// versionObjectIterator implements objectIterator for *ulmetainfo.PrefixVersionIterator.
+// The versions of each key are returned newest first.
+type versionObjectIterator struct {
+	bucket string
+	iter   *ulmetainfo.PrefixVersionIterator
+
+	pending  *ulmetainfo.PrefixVersion
+	versions []ObjectInfo // versions of the same key, newest last
+	item     ObjectInfo
+}
+
+// newVersionObjectIterator constructs a *versionObjectIterator from a *ulmetainfo.PrefixVersionIterator.
+func newVersionObjectIterator(bucket string, iter *ulmetainfo.PrefixVersionIterator) *versionObjectIterator {
+	return &versionObjectIterator{
+		bucket: bucket,
+		iter:   iter,
+	}
+}
+
+func (v *versionObjectIterator) Next() bool {
+	if len(v.versions) == 0 {
+		v.collectKey()
+		if len(v.versions) == 0 {
+			return false
+		}
+	}
+	last := len(v.versions) - 1
+	v.item, v.versions = v.versions[last], v.versions[:last]
+	return true
+}
+
+// collectKey collects the versions of the next key, which the listing returns
+// oldest first, or the next prefix.
+func (v *versionObjectIterator) collectKey() {
+	for {
+		var item ulmetainfo.PrefixVersion
+		if v.pending != nil {
+			item, v.pending = *v.pending, nil
+		} else if v.iter.Next() {
+			item = v.iter.Item()
+		} else {
+			return
+		}
+
+		loc := ulloc.NewRemote(v.bucket, item.Key)
+		if len(v.versions) > 0 && (item.IsPrefix || loc != v.versions[0].Loc) {
+			v.pending = &item
+			return
+		}
+
+		v.versions = append(v.versions, ObjectInfo{
+			Loc:           loc,
+			IsPrefix:      item.IsPrefix,
+			Created:       item.Created,
+			ContentLength: item.Size,
+			Version:       item.Version,
+			DeleteMarker:  item.DeleteMarker,
+		})
+		if item.IsPrefix {
+			return
+		}
+	}
+}
+
+func (v *versionObjectIterator) Err() error       { return v.iter.Err() }
+func (v *versionObjectIterator) Item() ObjectInfo { return v.item }
+
 // uplinkUploadIterator implements objectIterator for *multipart.UploadIterators.
 type uplinkUploadIterator struct {
 	bucket string
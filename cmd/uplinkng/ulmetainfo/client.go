@@ -0,0 +1,150 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package ulmetainfo provides the object operations of the satellite that the
+// uplink library does not support yet.
+package ulmetainfo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/encryption"
+	"storj.io/common/errs2"
+	"storj.io/common/grant"
+	"storj.io/common/identity"
+	"storj.io/common/paths"
+	"storj.io/common/pb"
+	"storj.io/common/peertls/tlsopts"
+	"storj.io/common/rpc"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/storj"
+	"storj.io/storj/private/metainfoextpb"
+	"storj.io/uplink"
+)
+
+// Client is a connection to the metainfo extensions of a satellite. The
+// connection is dialed on the first request.
+type Client struct {
+	access *grant.Access
+
+	mu     sync.Mutex
+	dialer rpc.Dialer
+	conn   *rpc.Conn
+}
+
+// New returns a Client for the satellite of the access. When bypass is true
+// object keys are expected to be encrypted already.
+func New(access *uplink.Access, bypass bool) (*Client, error) {
+	serialized, err := access.Serialize()
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	parsed, err := grant.ParseAccess(serialized)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	parsed.EncAccess.Store.EncryptionBypass = bypass
+
+	return &Client{access: parsed}, nil
+}
+
+// Close closes the connection to the satellite, if there is one.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return errs.Wrap(err)
+}
+
+// dial returns the connection to the satellite, dialing it if needed.
+func (c *Client) dial(ctx context.Context) (*rpc.Conn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn != nil {
+		return c.conn, nil
+	}
+
+	url, err := storj.ParseNodeURL(c.access.SatelliteAddress)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+
+	ident, err := identity.NewFullIdentity(ctx, identity.NewCAOptions{
+		Difficulty:  0,
+		Concurrency: 1,
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+
+	tlsOptions, err := tlsopts.NewOptions(ident, tlsopts.Config{
+		UsePeerCAWhitelist: false,
+		PeerIDVersions:     "0",
+	}, nil)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+
+	c.dialer = rpc.NewDefaultDialer(tlsOptions)
+	c.conn, err = c.dialer.DialNodeURL(ctx, url)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return c.conn, nil
+}
+
+// extensions returns a client for the metainfo extensions service.
+func (c *Client) extensions(ctx context.Context) (metainfoextpb.DRPCMetainfoExtensionsClient, error) {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return metainfoextpb.NewDRPCMetainfoExtensionsClient(conn), nil
+}
+
+func (c *Client) header() *pb.RequestHeader {
+	return &pb.RequestHeader{ApiKey: c.access.APIKey.SerializeRaw()}
+}
+
+// encryptKey returns the encrypted form of the key in the bucket.
+func (c *Client) encryptKey(bucket, key string) ([]byte, error) {
+	encKey, err := encryption.EncryptPathWithStoreCipher(bucket, paths.NewUnencrypted(key), c.access.EncAccess.Store)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return []byte(encKey.Raw()), nil
+}
+
+// decryptKey returns the key in the bucket with the encrypted form.
+func (c *Client) decryptKey(bucket string, encKey []byte) (string, error) {
+	key, err := encryption.DecryptPathWithStoreCipher(bucket, paths.NewEncrypted(string(encKey)), c.access.EncAccess.Store)
+	if err != nil {
+		return "", errs.Wrap(err)
+	}
+	return key.Raw(), nil
+}
+
+// convertError converts the NotFound errors of the satellite into the errors
+// of the uplink library so that callers can handle both the same way.
+func convertError(err error, bucket, key string) error {
+	switch {
+	case err == nil:
+		return nil
+	case errs2.IsRPC(err, rpcstatus.NotFound) && key == "":
+		return fmt.Errorf("%w (%q)", uplink.ErrBucketNotFound, bucket)
+	case errs2.IsRPC(err, rpcstatus.NotFound):
+		return fmt.Errorf("%w (%q)", uplink.ErrObjectNotFound, key)
+	default:
+		return errs.Wrap(err)
+	}
+}
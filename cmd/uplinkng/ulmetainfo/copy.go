@@ -0,0 +1,57 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package ulmetainfo
+
+import (
+	"context"
+
+	"storj.io/storj/private/metainfoextpb"
+)
+
+// CopyObject copies the latest version of the object to the new bucket and
+// key without transferring its data. The copy references the same pieces as
+// the object, only its keys are re-encrypted for the new location. An object
+// at the new location is replaced when the access allows deleting it.
+func (c *Client) CopyObject(ctx context.Context, bucket, key, newBucket, newKey string) error {
+	client, err := c.extensions(ctx)
+	if err != nil {
+		return err
+	}
+	encKey, err := c.encryptKey(bucket, key)
+	if err != nil {
+		return err
+	}
+	newEncKey, err := c.encryptKey(newBucket, newKey)
+	if err != nil {
+		return err
+	}
+
+	begin, err := client.BeginCopyObject(ctx, &metainfoextpb.BeginCopyObjectRequest{
+		Header:             c.header(),
+		Bucket:             []byte(bucket),
+		EncryptedObjectKey: encKey,
+	})
+	if err != nil {
+		return convertError(err, bucket, key)
+	}
+
+	keys, err := c.reencryptKeys(bucket, key, newBucket, newKey, begin)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.FinishCopyObject(ctx, &metainfoextpb.FinishCopyObjectRequest{
+		Header:                       c.header(),
+		Bucket:                       []byte(bucket),
+		EncryptedObjectKey:           encKey,
+		Version:                      begin.Version,
+		StreamId:                     begin.StreamId,
+		NewBucket:                    []byte(newBucket),
+		NewEncryptedObjectKey:        newEncKey,
+		NewEncryptedMetadataKeyNonce: keys.metadataKeyNonce,
+		NewEncryptedMetadataKey:      keys.metadataKey,
+		NewSegmentKeys:               keys.segmentKeys,
+	})
+	return convertError(err, newBucket, newKey)
+}
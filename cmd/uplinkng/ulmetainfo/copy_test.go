@@ -0,0 +1,56 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package ulmetainfo_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/cmd/uplinkng/ulmetainfo"
+	"storj.io/storj/private/testplanet"
+	"storj.io/uplink"
+)
+
+func TestCopyObject(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		client, err := ulmetainfo.New(planet.Uplinks[0].Access[satellite.ID()], false)
+		require.NoError(t, err)
+		defer ctx.Check(client.Close)
+
+		require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, satellite, "otherbucket"))
+
+		for name, data := range map[string][]byte{
+			"inline": testrand.Bytes(1 * memory.KiB),
+			"remote": testrand.Bytes(10 * memory.KiB),
+		} {
+			require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "testbucket", name, data))
+
+			require.NoError(t, client.CopyObject(ctx, "testbucket", name, "otherbucket", "copied/"+name))
+
+			for _, loc := range [][2]string{{"testbucket", name}, {"otherbucket", "copied/" + name}} {
+				downloaded, err := planet.Uplinks[0].Download(ctx, satellite, loc[0], loc[1])
+				require.NoError(t, err)
+				require.Equal(t, data, downloaded)
+			}
+
+			// deleting the source keeps the pieces used by the copy.
+			require.NoError(t, planet.Uplinks[0].DeleteObject(ctx, satellite, "testbucket", name))
+
+			downloaded, err := planet.Uplinks[0].Download(ctx, satellite, "otherbucket", "copied/"+name)
+			require.NoError(t, err)
+			require.Equal(t, data, downloaded)
+		}
+
+		err = client.CopyObject(ctx, "testbucket", "missing", "otherbucket", "missing")
+		require.True(t, errors.Is(err, uplink.ErrObjectNotFound))
+	})
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package ulmetainfo
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/encryption"
+	"storj.io/common/paths"
+	"storj.io/storj/private/metainfoextpb"
+)
+
+// LifecycleAction defines what a lifecycle rule does with the matching objects.
+type LifecycleAction int
+
+const (
+	// LifecycleExpireObjects deletes committed objects older than the rule age.
+	LifecycleExpireObjects = LifecycleAction(metainfoextpb.LifecycleAction_EXPIRE_OBJECTS)
+	// LifecycleAbortUploads deletes pending objects older than the rule age.
+	LifecycleAbortUploads = LifecycleAction(metainfoextpb.LifecycleAction_ABORT_UPLOADS)
+)
+
+// ParseLifecycleAction parses the textual representation of an action.
+func ParseLifecycleAction(action string) (LifecycleAction, error) {
+	switch action {
+	case LifecycleExpireObjects.String():
+		return LifecycleExpireObjects, nil
+	case LifecycleAbortUploads.String():
+		return LifecycleAbortUploads, nil
+	default:
+		return 0, errs.New("invalid lifecycle action %q: must be %q or %q",
+			action, LifecycleExpireObjects, LifecycleAbortUploads)
+	}
+}
+
+// String returns textual representation of the action.
+func (action LifecycleAction) String() string {
+	switch action {
+	case LifecycleExpireObjects:
+		return "expire-objects"
+	case LifecycleAbortUploads:
+		return "abort-uploads"
+	default:
+		return "unknown"
+	}
+}
+
+// LifecycleRule describes which objects of a bucket the satellite deletes
+// automatically.
+type LifecycleRule struct {
+	Name string
+	// Prefix is empty or ends with a "/". The satellite only sees it encrypted,
+	// so the rule applies to whole path components.
+	Prefix  string
+	Action  LifecycleAction
+	AgeDays int
+}
+
+// SetBucketLifecycleRule adds the lifecycle rule to the bucket, replacing the
+// rule with the same name.
+func (c *Client) SetBucketLifecycleRule(ctx context.Context, bucket string, rule LifecycleRule) error {
+	client, err := c.extensions(ctx)
+	if err != nil {
+		return err
+	}
+	encPrefix, err := c.encryptPrefix(bucket, rule.Prefix)
+	if err != nil {
+		return err
+	}
+	_, err = client.SetBucketLifecycleRule(ctx, &metainfoextpb.SetBucketLifecycleRuleRequest{
+		Header: c.header(),
+		Bucket: []byte(bucket),
+		Rule: &metainfoextpb.LifecycleRule{
+			Name:            rule.Name,
+			EncryptedPrefix: encPrefix,
+			Action:          metainfoextpb.LifecycleAction(rule.Action),
+			AgeDays:         int32(rule.AgeDays),
+		},
+	})
+	return convertError(err, bucket, "")
+}
+
+// DeleteBucketLifecycleRules deletes the lifecycle rule with the name, or all
+// lifecycle rules of the bucket when the name is empty.
+func (c *Client) DeleteBucketLifecycleRules(ctx context.Context, bucket, name string) error {
+	client, err := c.extensions(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = client.DeleteBucketLifecycleRules(ctx, &metainfoextpb.DeleteBucketLifecycleRulesRequest{
+		Header: c.header(),
+		Bucket: []byte(bucket),
+		Name:   name,
+	})
+	return convertError(err, bucket, "")
+}
+
+// ListBucketLifecycleRules returns the lifecycle rules of the bucket ordered by
+// name.
+func (c *Client) ListBucketLifecycleRules(ctx context.Context, bucket string) ([]LifecycleRule, error) {
+	client, err := c.extensions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.ListBucketLifecycleRules(ctx, &metainfoextpb.ListBucketLifecycleRulesRequest{
+		Header: c.header(),
+		Bucket: []byte(bucket),
+	})
+	if err != nil {
+		return nil, convertError(err, bucket, "")
+	}
+
+	rules := make([]LifecycleRule, 0, len(resp.Rules))
+	for _, rule := range resp.Rules {
+		prefix, err := c.decryptPrefix(bucket, rule.EncryptedPrefix)
+		if err != nil {
+			return nil, errs.New("unable to decrypt prefix of lifecycle rule %q: %v", rule.Name, err)
+		}
+		rules = append(rules, LifecycleRule{
+			Name:    rule.Name,
+			Prefix:  prefix,
+			Action:  LifecycleAction(rule.Action),
+			AgeDays: int(rule.AgeDays),
+		})
+	}
+	return rules, nil
+}
+
+// encryptPrefix encrypts a prefix of whole path components. The trailing slash
+// is kept unencrypted, so that the encrypted prefix matches the encrypted keys
+// of all objects under it.
+func (c *Client) encryptPrefix(bucket, prefix string) ([]byte, error) {
+	if prefix == "" {
+		return nil, nil
+	}
+	if !strings.HasSuffix(prefix, "/") {
+		return nil, errs.New("prefix %q must end with a /", prefix)
+	}
+	encPrefix, err := c.encryptKey(bucket, strings.TrimSuffix(prefix, "/"))
+	if err != nil {
+		return nil, err
+	}
+	return append(encPrefix, '/'), nil
+}
+
+// decryptPrefix decrypts a prefix encrypted by encryptPrefix.
+func (c *Client) decryptPrefix(bucket string, encPrefix []byte) (string, error) {
+	if len(encPrefix) == 0 {
+		return "", nil
+	}
+	encPath := strings.TrimSuffix(string(encPrefix), "/")
+	prefix, err := encryption.DecryptPathWithStoreCipher(bucket, paths.NewEncrypted(encPath), c.access.EncAccess.Store)
+	if err != nil {
+		return "", errs.Wrap(err)
+	}
+	return prefix.Raw() + "/", nil
+}
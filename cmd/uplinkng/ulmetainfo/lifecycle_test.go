@@ -0,0 +1,78 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package ulmetainfo_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/cmd/uplinkng/ulmetainfo"
+	"storj.io/storj/private/testplanet"
+	"storj.io/uplink"
+)
+
+func TestLifecycleRules(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		chore := satellite.Core.BucketLifecycle.Chore
+		chore.Loop.Pause()
+
+		client, err := ulmetainfo.New(planet.Uplinks[0].Access[satellite.ID()], false)
+		require.NoError(t, err)
+		defer ctx.Check(client.Close)
+
+		rule := ulmetainfo.LifecycleRule{
+			Name:    "logs",
+			Prefix:  "app/logs/",
+			Action:  ulmetainfo.LifecycleExpireObjects,
+			AgeDays: 30,
+		}
+
+		err = client.SetBucketLifecycleRule(ctx, "testbucket", rule)
+		require.True(t, errors.Is(err, uplink.ErrBucketNotFound))
+
+		for _, key := range []string{"app/logs/first", "app/logs/deep/second", "app/data"} {
+			require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "testbucket", key, testrand.Bytes(memory.KiB)))
+		}
+
+		require.NoError(t, client.SetBucketLifecycleRule(ctx, "testbucket", rule))
+		require.Error(t, client.SetBucketLifecycleRule(ctx, "testbucket", ulmetainfo.LifecycleRule{
+			Name:    "partial",
+			Prefix:  "app/lo",
+			Action:  ulmetainfo.LifecycleExpireObjects,
+			AgeDays: 30,
+		}))
+
+		rules, err := client.ListBucketLifecycleRules(ctx, "testbucket")
+		require.NoError(t, err)
+		require.Equal(t, []ulmetainfo.LifecycleRule{rule}, rules)
+
+		chore.SetNow(func() time.Time {
+			return time.Now().AddDate(0, 0, 31)
+		})
+		_, err = chore.ApplyRules(ctx)
+		require.NoError(t, err)
+
+		// the encrypted prefix only matches the objects under the prefix.
+		for _, key := range []string{"app/logs/first", "app/logs/deep/second"} {
+			_, err = planet.Uplinks[0].Download(ctx, satellite, "testbucket", key)
+			require.True(t, errors.Is(err, uplink.ErrObjectNotFound))
+		}
+		_, err = planet.Uplinks[0].Download(ctx, satellite, "testbucket", "app/data")
+		require.NoError(t, err)
+
+		require.NoError(t, client.DeleteBucketLifecycleRules(ctx, "testbucket", ""))
+		rules, err = client.ListBucketLifecycleRules(ctx, "testbucket")
+		require.NoError(t, err)
+		require.Empty(t, rules)
+	})
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package ulmetainfo
+
+import (
+	"context"
+	"crypto/rand"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/encryption"
+	"storj.io/common/paths"
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/storj/private/metainfoextpb"
+)
+
+// MoveObject moves the latest version of the object to the new bucket and key
+// without transferring its data. Only the keys of the object are re-encrypted
+// for the new location.
+func (c *Client) MoveObject(ctx context.Context, bucket, key, newBucket, newKey string) error {
+	client, err := c.extensions(ctx)
+	if err != nil {
+		return err
+	}
+	encKey, err := c.encryptKey(bucket, key)
+	if err != nil {
+		return err
+	}
+	newEncKey, err := c.encryptKey(newBucket, newKey)
+	if err != nil {
+		return err
+	}
+
+	begin, err := client.BeginMoveObject(ctx, &metainfoextpb.BeginMoveObjectRequest{
+		Header:             c.header(),
+		Bucket:             []byte(bucket),
+		EncryptedObjectKey: encKey,
+	})
+	if err != nil {
+		return convertError(err, bucket, key)
+	}
+
+	keys, err := c.reencryptKeys(bucket, key, newBucket, newKey, begin)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.FinishMoveObject(ctx, &metainfoextpb.FinishMoveObjectRequest{
+		Header:                       c.header(),
+		Bucket:                       []byte(bucket),
+		EncryptedObjectKey:           encKey,
+		Version:                      begin.Version,
+		StreamId:                     begin.StreamId,
+		NewBucket:                    []byte(newBucket),
+		NewEncryptedObjectKey:        newEncKey,
+		NewEncryptedMetadataKeyNonce: keys.metadataKeyNonce,
+		NewEncryptedMetadataKey:      keys.metadataKey,
+		NewSegmentKeys:               keys.segmentKeys,
+	})
+	return convertError(err, newBucket, newKey)
+}
+
+// reencryptedKeys are the keys of an object encrypted for a new location.
+type reencryptedKeys struct {
+	metadataKeyNonce []byte
+	metadataKey      []byte
+	segmentKeys      []*metainfoextpb.EncryptedKeyAndNonce
+}
+
+// objectKeys are the keys of an object returned when beginning a move or a copy.
+type objectKeys interface {
+	GetEncryptedMetadataKeyNonce() []byte
+	GetEncryptedMetadataKey() []byte
+	GetSegmentKeys() []*metainfoextpb.EncryptedKeyAndNonce
+	GetEncryptionParameters() *pb.EncryptionParameters
+}
+
+// reencryptKeys decrypts the metadata and segment keys of the object with the
+// key derived from its current location and encrypts them with the key
+// derived from the new location.
+func (c *Client) reencryptKeys(bucket, key, newBucket, newKey string, begin objectKeys) (_ reencryptedKeys, err error) {
+	store := c.access.EncAccess.Store
+	if store.EncryptionBypass {
+		return reencryptedKeys{}, errs.New("object keys cannot be re-encrypted when encryption is bypassed")
+	}
+	if begin.GetEncryptionParameters() == nil {
+		return reencryptedKeys{}, errs.New("encryption parameters missing")
+	}
+	cipher := storj.CipherSuite(begin.GetEncryptionParameters().CipherSuite)
+
+	derivedKey, err := encryption.DeriveContentKey(bucket, paths.NewUnencrypted(key), store)
+	if err != nil {
+		return reencryptedKeys{}, errs.Wrap(err)
+	}
+	newDerivedKey, err := encryption.DeriveContentKey(newBucket, paths.NewUnencrypted(newKey), store)
+	if err != nil {
+		return reencryptedKeys{}, errs.Wrap(err)
+	}
+
+	reencrypt := func(encryptedKey, encryptedKeyNonce []byte) (newEncryptedKey, newNonce []byte, err error) {
+		nonce, err := storj.NonceFromBytes(encryptedKeyNonce)
+		if err != nil {
+			return nil, nil, errs.Wrap(err)
+		}
+		contentKey, err := encryption.DecryptKey(encryptedKey, cipher, derivedKey, &nonce)
+		if err != nil {
+			return nil, nil, errs.Wrap(err)
+		}
+
+		var keyNonce storj.Nonce
+		if _, err := rand.Read(keyNonce[:]); err != nil {
+			return nil, nil, errs.Wrap(err)
+		}
+		newEncryptedKey, err = encryption.EncryptKey(contentKey, cipher, newDerivedKey, &keyNonce)
+		if err != nil {
+			return nil, nil, errs.Wrap(err)
+		}
+		return newEncryptedKey, keyNonce[:], nil
+	}
+
+	var keys reencryptedKeys
+	if len(begin.GetEncryptedMetadataKey()) > 0 {
+		keys.metadataKey, keys.metadataKeyNonce, err = reencrypt(begin.GetEncryptedMetadataKey(), begin.GetEncryptedMetadataKeyNonce())
+		if err != nil {
+			return reencryptedKeys{}, err
+		}
+	}
+	for _, segment := range begin.GetSegmentKeys() {
+		newEncryptedKey, newNonce, err := reencrypt(segment.EncryptedKey, segment.EncryptedKeyNonce)
+		if err != nil {
+			return reencryptedKeys{}, err
+		}
+		keys.segmentKeys = append(keys.segmentKeys, &metainfoextpb.EncryptedKeyAndNonce{
+			Position:          segment.Position,
+			EncryptedKeyNonce: newNonce,
+			EncryptedKey:      newEncryptedKey,
+		})
+	}
+	return keys, nil
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package ulmetainfo_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/cmd/uplinkng/ulmetainfo"
+	"storj.io/storj/private/testplanet"
+	"storj.io/uplink"
+)
+
+func TestMoveObject(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		client, err := ulmetainfo.New(planet.Uplinks[0].Access[satellite.ID()], false)
+		require.NoError(t, err)
+		defer ctx.Check(client.Close)
+
+		require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, satellite, "otherbucket"))
+
+		for name, data := range map[string][]byte{
+			"inline": testrand.Bytes(1 * memory.KiB),
+			"remote": testrand.Bytes(10 * memory.KiB),
+		} {
+			require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "testbucket", name, data))
+
+			require.NoError(t, client.MoveObject(ctx, "testbucket", name, "otherbucket", "moved/"+name))
+
+			downloaded, err := planet.Uplinks[0].Download(ctx, satellite, "otherbucket", "moved/"+name)
+			require.NoError(t, err)
+			require.Equal(t, data, downloaded)
+
+			_, err = planet.Uplinks[0].Download(ctx, satellite, "testbucket", name)
+			require.True(t, errors.Is(err, uplink.ErrObjectNotFound))
+		}
+
+		err = client.MoveObject(ctx, "testbucket", "missing", "otherbucket", "missing")
+		require.True(t, errors.Is(err, uplink.ErrObjectNotFound))
+	})
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package ulmetainfo
+
+import (
+	"context"
+	"time"
+
+	"storj.io/storj/private/metainfoextpb"
+)
+
+// ObjectLock describes how an object version is protected from deletion.
+type ObjectLock struct {
+	// RetainUntil is nil when the object version has no retention.
+	RetainUntil *time.Time
+	LegalHold   bool
+}
+
+// SetBucketRetention sets the number of days that newly committed objects of
+// the bucket can't be deleted or overwritten. Zero days disable the default
+// retention.
+func (c *Client) SetBucketRetention(ctx context.Context, bucket string, days int) error {
+	client, err := c.extensions(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = client.SetBucketRetention(ctx, &metainfoextpb.SetBucketRetentionRequest{
+		Header: c.header(),
+		Bucket: []byte(bucket),
+		Days:   int32(days),
+	})
+	return convertError(err, bucket, "")
+}
+
+// GetBucketRetention returns the default retention of the bucket in days, zero
+// means that the bucket has no default retention.
+func (c *Client) GetBucketRetention(ctx context.Context, bucket string) (int, error) {
+	client, err := c.extensions(ctx)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.GetBucketRetention(ctx, &metainfoextpb.GetBucketRetentionRequest{
+		Header: c.header(),
+		Bucket: []byte(bucket),
+	})
+	if err != nil {
+		return 0, convertError(err, bucket, "")
+	}
+	return int(resp.Days), nil
+}
+
+// SetObjectRetention protects the object version, or the latest version when
+// version is zero, from deletion until retainUntil. An existing retention can
+// only be extended.
+func (c *Client) SetObjectRetention(ctx context.Context, bucket, key string, version int64, retainUntil time.Time) error {
+	client, err := c.extensions(ctx)
+	if err != nil {
+		return err
+	}
+	encKey, err := c.encryptKey(bucket, key)
+	if err != nil {
+		return err
+	}
+	_, err = client.SetObjectRetention(ctx, &metainfoextpb.SetObjectRetentionRequest{
+		Header:             c.header(),
+		Bucket:             []byte(bucket),
+		EncryptedObjectKey: encKey,
+		Version:            version,
+		RetainUntil:        retainUntil,
+	})
+	return convertError(err, bucket, key)
+}
+
+// SetObjectLegalHold places or removes a legal hold on the object version, or
+// on the latest version when version is zero.
+func (c *Client) SetObjectLegalHold(ctx context.Context, bucket, key string, version int64, enabled bool) error {
+	client, err := c.extensions(ctx)
+	if err != nil {
+		return err
+	}
+	encKey, err := c.encryptKey(bucket, key)
+	if err != nil {
+		return err
+	}
+	_, err = client.SetObjectLegalHold(ctx, &metainfoextpb.SetObjectLegalHoldRequest{
+		Header:             c.header(),
+		Bucket:             []byte(bucket),
+		EncryptedObjectKey: encKey,
+		Version:            version,
+		Enabled:            enabled,
+	})
+	return convertError(err, bucket, key)
+}
+
+// GetObjectLock returns the retention and legal hold of the object version, or
+// of the latest version when version is zero.
+func (c *Client) GetObjectLock(ctx context.Context, bucket, key string, version int64) (ObjectLock, error) {
+	client, err := c.extensions(ctx)
+	if err != nil {
+		return ObjectLock{}, err
+	}
+	encKey, err := c.encryptKey(bucket, key)
+	if err != nil {
+		return ObjectLock{}, err
+	}
+	resp, err := client.GetObjectLock(ctx, &metainfoextpb.GetObjectLockRequest{
+		Header:             c.header(),
+		Bucket:             []byte(bucket),
+		EncryptedObjectKey: encKey,
+		Version:            version,
+	})
+	if err != nil {
+		return ObjectLock{}, convertError(err, bucket, key)
+	}
+	return ObjectLock{RetainUntil: resp.RetainUntil, LegalHold: resp.LegalHold}, nil
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package ulmetainfo_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/cmd/uplinkng/ulmetainfo"
+	"storj.io/storj/private/testplanet"
+	"storj.io/uplink"
+)
+
+func TestRetention(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		client, err := ulmetainfo.New(planet.Uplinks[0].Access[satellite.ID()], false)
+		require.NoError(t, err)
+		defer ctx.Check(client.Close)
+
+		err = client.SetBucketRetention(ctx, "testbucket", 1)
+		require.True(t, errors.Is(err, uplink.ErrBucketNotFound))
+
+		require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, satellite, "testbucket"))
+		require.NoError(t, client.SetBucketRetention(ctx, "testbucket", 1))
+		days, err := client.GetBucketRetention(ctx, "testbucket")
+		require.NoError(t, err)
+		require.Equal(t, 1, days)
+
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "retained", testrand.Bytes(memory.KiB)))
+		require.NoError(t, client.SetBucketRetention(ctx, "testbucket", 0))
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "held", testrand.Bytes(memory.KiB)))
+
+		lock, err := client.GetObjectLock(ctx, "testbucket", "retained", 0)
+		require.NoError(t, err)
+		require.NotNil(t, lock.RetainUntil)
+		require.WithinDuration(t, time.Now().Add(24*time.Hour), *lock.RetainUntil, time.Minute)
+
+		retainUntil := time.Now().Add(48 * time.Hour)
+		require.NoError(t, client.SetObjectRetention(ctx, "testbucket", "retained", 0, retainUntil))
+		lock, err = client.GetObjectLock(ctx, "testbucket", "retained", 0)
+		require.NoError(t, err)
+		require.WithinDuration(t, retainUntil, *lock.RetainUntil, time.Second)
+
+		lock, err = client.GetObjectLock(ctx, "testbucket", "held", 0)
+		require.NoError(t, err)
+		require.Equal(t, ulmetainfo.ObjectLock{}, lock)
+
+		require.NoError(t, client.SetObjectLegalHold(ctx, "testbucket", "held", 0, true))
+		lock, err = client.GetObjectLock(ctx, "testbucket", "held", 0)
+		require.NoError(t, err)
+		require.True(t, lock.LegalHold)
+
+		for _, key := range []string{"retained", "held"} {
+			require.Error(t, planet.Uplinks[0].DeleteObject(ctx, satellite, "testbucket", key))
+		}
+
+		require.NoError(t, client.SetObjectLegalHold(ctx, "testbucket", "held", 0, false))
+		require.NoError(t, planet.Uplinks[0].DeleteObject(ctx, satellite, "testbucket", "held"))
+
+		_, err = client.GetObjectLock(ctx, "testbucket", "held", 0)
+		require.True(t, errors.Is(err, uplink.ErrObjectNotFound))
+	})
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package ulmetainfo
+
+import (
+	"context"
+
+	"storj.io/common/pb"
+	"storj.io/uplink/private/metaclient"
+)
+
+// SegmentOffsets returns the plain offsets at which the segments of the latest
+// version of the object start, in order. An object without data has no
+// segments.
+func (c *Client) SegmentOffsets(ctx context.Context, bucket, key string) ([]int64, error) {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	encKey, err := c.encryptKey(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+
+	client := metaclient.NewClient(pb.NewDRPCMetainfoClient(conn), c.access.APIKey, "")
+
+	object, err := client.GetObject(ctx, metaclient.GetObjectParams{
+		Bucket:        []byte(bucket),
+		EncryptedPath: encKey,
+	})
+	if err != nil {
+		return nil, convertError(err, bucket, key)
+	}
+
+	// the offsets are summed from the segment sizes, because segments of
+	// migrated objects don't have plain offsets.
+	var offsets []int64
+	var offset int64
+	var cursor metaclient.SegmentPosition
+	for {
+		list, err := client.ListSegments(ctx, metaclient.ListSegmentsParams{
+			StreamID: object.StreamID,
+			Cursor:   cursor,
+		})
+		if err != nil {
+			return nil, convertError(err, bucket, key)
+		}
+		for _, segment := range list.Items {
+			offsets = append(offsets, offset)
+			offset += segment.PlainSize
+			cursor = segment.Position
+		}
+		if !list.More || len(list.Items) == 0 {
+			return offsets, nil
+		}
+	}
+}
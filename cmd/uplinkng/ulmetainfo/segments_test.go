@@ -0,0 +1,46 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package ulmetainfo_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/cmd/uplinkng/ulmetainfo"
+	"storj.io/storj/private/testplanet"
+	"storj.io/uplink"
+)
+
+func TestSegmentOffsets(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: testplanet.MaxSegmentSize(10 * memory.KiB),
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		client, err := ulmetainfo.New(planet.Uplinks[0].Access[satellite.ID()], false)
+		require.NoError(t, err)
+		defer ctx.Check(client.Close)
+
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "inline", testrand.Bytes(1*memory.KiB)))
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "remote", testrand.Bytes(25*memory.KiB)))
+
+		offsets, err := client.SegmentOffsets(ctx, "testbucket", "inline")
+		require.NoError(t, err)
+		require.Equal(t, []int64{0}, offsets)
+
+		offsets, err = client.SegmentOffsets(ctx, "testbucket", "remote")
+		require.NoError(t, err)
+		require.Equal(t, []int64{0, 10 * memory.KiB.Int64(), 20 * memory.KiB.Int64()}, offsets)
+
+		_, err = client.SegmentOffsets(ctx, "testbucket", "missing")
+		require.True(t, errors.Is(err, uplink.ErrObjectNotFound))
+	})
+}
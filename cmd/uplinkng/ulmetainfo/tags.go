@@ -0,0 +1,199 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package ulmetainfo
+
+import (
+	"context"
+
+	"storj.io/common/pb"
+	"storj.io/storj/private/metainfoextpb"
+	"storj.io/uplink"
+	"storj.io/uplink/private/metaclient"
+)
+
+// SetBucketTags replaces the tags of the bucket, empty tags remove all of them.
+func (c *Client) SetBucketTags(ctx context.Context, bucket string, tags map[string]string) error {
+	client, err := c.extensions(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = client.SetBucketTags(ctx, &metainfoextpb.SetBucketTagsRequest{
+		Header: c.header(),
+		Bucket: []byte(bucket),
+		Tags:   tags,
+	})
+	return convertError(err, bucket, "")
+}
+
+// GetBucketTags returns the tags of the bucket.
+func (c *Client) GetBucketTags(ctx context.Context, bucket string) (map[string]string, error) {
+	client, err := c.extensions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.GetBucketTags(ctx, &metainfoextpb.GetBucketTagsRequest{
+		Header: c.header(),
+		Bucket: []byte(bucket),
+	})
+	if err != nil {
+		return nil, convertError(err, bucket, "")
+	}
+	return resp.Tags, nil
+}
+
+// SetObjectTags replaces the tags of the latest version of the object, empty
+// tags remove all of them.
+func (c *Client) SetObjectTags(ctx context.Context, bucket, key string, tags map[string]string) error {
+	client, err := c.extensions(ctx)
+	if err != nil {
+		return err
+	}
+	encKey, err := c.encryptKey(bucket, key)
+	if err != nil {
+		return err
+	}
+	_, err = client.SetObjectTags(ctx, &metainfoextpb.SetObjectTagsRequest{
+		Header:             c.header(),
+		Bucket:             []byte(bucket),
+		EncryptedObjectKey: encKey,
+		Tags:               tags,
+	})
+	return convertError(err, bucket, key)
+}
+
+// GetObjectTags returns the tags of the latest version of the object.
+func (c *Client) GetObjectTags(ctx context.Context, bucket, key string) (map[string]string, error) {
+	client, err := c.extensions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	encKey, err := c.encryptKey(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.GetObjectTags(ctx, &metainfoextpb.GetObjectTagsRequest{
+		Header:             c.header(),
+		Bucket:             []byte(bucket),
+		EncryptedObjectKey: encKey,
+	})
+	if err != nil {
+		return nil, convertError(err, bucket, key)
+	}
+	return resp.Tags, nil
+}
+
+// ListTaggedObjects returns an iterator over the objects under the prefix that
+// have all of the tags. The prefix must be empty or end with a slash.
+func (c *Client) ListTaggedObjects(ctx context.Context, bucket, prefix string, recursive bool, tags map[string]string) *TaggedObjectIterator {
+	return &TaggedObjectIterator{
+		ctx:    ctx,
+		client: c,
+		bucket: bucket,
+		tags:   tags,
+		options: metaclient.ListOptions{
+			Prefix:    prefix,
+			Recursive: recursive,
+			Direction: metaclient.After,
+		},
+	}
+}
+
+// TaggedObjectIterator is an iterator over the objects that have all of the
+// tags.
+type TaggedObjectIterator struct {
+	ctx     context.Context
+	client  *Client
+	bucket  string
+	tags    map[string]string
+	options metaclient.ListOptions
+
+	list     *metaclient.ObjectList
+	position int
+	err      error
+}
+
+// Next prepares the next object for reading with Item. It returns false when
+// there are no more objects or an error happened.
+func (it *TaggedObjectIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if it.list != nil && it.position < len(it.list.Items)-1 {
+		it.position++
+		return true
+	}
+	if it.list != nil && !it.list.More {
+		return false
+	}
+
+	list, err := it.client.listTaggedObjects(it.ctx, it.bucket, it.options, it.tags)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.list = &list
+	it.options = it.options.NextPage(list)
+	it.position = 0
+	return len(list.Items) > 0
+}
+
+// Err returns the error, if one happened during iteration.
+func (it *TaggedObjectIterator) Err() error { return it.err }
+
+// Item returns the current object with its system and custom metadata.
+func (it *TaggedObjectIterator) Item() *uplink.Object {
+	item := it.list.Items[it.position]
+	return &uplink.Object{
+		Key:      it.list.Prefix + item.Path,
+		IsPrefix: item.IsPrefix,
+		System: uplink.SystemMetadata{
+			Created:       item.Created,
+			Expires:       item.Expires,
+			ContentLength: item.Size,
+		},
+		Custom: item.Metadata,
+	}
+}
+
+// listTaggedObjects lists a page of the objects that have all of the tags.
+func (c *Client) listTaggedObjects(ctx context.Context, bucket string, options metaclient.ListOptions, tags map[string]string) (metaclient.ObjectList, error) {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return metaclient.ObjectList{}, err
+	}
+
+	// the metaclient lists objects with the ListObjects request of the
+	// metainfo service, which does not filter by tags, so the request is
+	// redirected to ListTaggedObjects.
+	tagged := &taggedMetainfoClient{
+		DRPCMetainfoClient: pb.NewDRPCMetainfoClient(conn),
+		extensions:         metainfoextpb.NewDRPCMetainfoExtensionsClient(conn),
+		tags:               tags,
+	}
+	db := metaclient.New(metaclient.NewClient(tagged, c.access.APIKey, ""), c.access.EncAccess.Store)
+
+	list, err := db.ListObjects(ctx, bucket, options)
+	if err != nil {
+		return metaclient.ObjectList{}, convertError(err, bucket, "")
+	}
+	return list, nil
+}
+
+// taggedMetainfoClient lists only the objects that have all of the tags.
+type taggedMetainfoClient struct {
+	pb.DRPCMetainfoClient
+	extensions metainfoextpb.DRPCMetainfoExtensionsClient
+	tags       map[string]string
+}
+
+func (t *taggedMetainfoClient) ListObjects(ctx context.Context, req *pb.ObjectListRequest) (*pb.ObjectListResponse, error) {
+	resp, err := t.extensions.ListTaggedObjects(ctx, &metainfoextpb.ListTaggedObjectsRequest{
+		List: req,
+		Tags: t.tags,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.List, nil
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package ulmetainfo_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/cmd/uplinkng/ulmetainfo"
+	"storj.io/storj/private/testplanet"
+	"storj.io/uplink"
+)
+
+func TestTags(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		client, err := ulmetainfo.New(planet.Uplinks[0].Access[satellite.ID()], false)
+		require.NoError(t, err)
+		defer ctx.Check(client.Close)
+
+		for _, key := range []string{"dir/red", "dir/blue", "other/red", "plain"} {
+			require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "testbucket", key, testrand.Bytes(memory.KiB)))
+		}
+
+		require.NoError(t, client.SetBucketTags(ctx, "testbucket", map[string]string{"team": "storage"}))
+		bucketTags, err := client.GetBucketTags(ctx, "testbucket")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"team": "storage"}, bucketTags)
+
+		require.NoError(t, client.SetObjectTags(ctx, "testbucket", "dir/red", map[string]string{"color": "red", "size": "big"}))
+		require.NoError(t, client.SetObjectTags(ctx, "testbucket", "dir/blue", map[string]string{"color": "blue"}))
+		require.NoError(t, client.SetObjectTags(ctx, "testbucket", "other/red", map[string]string{"color": "red"}))
+
+		objectTags, err := client.GetObjectTags(ctx, "testbucket", "dir/red")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"color": "red", "size": "big"}, objectTags)
+
+		_, err = client.GetObjectTags(ctx, "testbucket", "missing")
+		require.True(t, errors.Is(err, uplink.ErrObjectNotFound))
+
+		list := func(prefix string, recursive bool, tags map[string]string) []string {
+			it := client.ListTaggedObjects(ctx, "testbucket", prefix, recursive, tags)
+			var keys []string
+			for it.Next() {
+				keys = append(keys, it.Item().Key)
+			}
+			require.NoError(t, it.Err())
+			return keys
+		}
+
+		require.Equal(t, []string{"dir/red", "other/red"}, list("", true, map[string]string{"color": "red"}))
+		require.Equal(t, []string{"dir/red"}, list("", true, map[string]string{"color": "red", "size": "big"}))
+		require.Equal(t, []string{"dir/red"}, list("dir/", false, map[string]string{"color": "red"}))
+		require.Equal(t, []string{"dir/"}, list("", false, map[string]string{"color": "blue"}))
+
+		// empty tags remove all of them.
+		require.NoError(t, client.SetObjectTags(ctx, "testbucket", "dir/red", nil))
+		require.Equal(t, []string{"other/red"}, list("", true, map[string]string{"color": "red"}))
+	})
+}
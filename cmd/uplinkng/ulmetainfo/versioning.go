@@ -0,0 +1,318 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package ulmetainfo
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/storj/private/metainfoextpb"
+	"storj.io/uplink/private/ecclient"
+	"storj.io/uplink/private/metaclient"
+	"storj.io/uplink/private/storage/streams"
+	"storj.io/uplink/private/stream"
+)
+
+// ObjectVersion is a committed version or a delete marker of an object.
+type ObjectVersion struct {
+	Version      int64
+	DeleteMarker bool
+	Created      time.Time
+	Size         int64
+}
+
+// SetBucketVersioning enables or disables versioning of the bucket.
+func (c *Client) SetBucketVersioning(ctx context.Context, bucket string, enabled bool) error {
+	client, err := c.extensions(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = client.SetBucketVersioning(ctx, &metainfoextpb.SetBucketVersioningRequest{
+		Header:  c.header(),
+		Bucket:  []byte(bucket),
+		Enabled: enabled,
+	})
+	return convertError(err, bucket, "")
+}
+
+// GetBucketVersioning returns whether versioning is enabled for the bucket.
+func (c *Client) GetBucketVersioning(ctx context.Context, bucket string) (bool, error) {
+	client, err := c.extensions(ctx)
+	if err != nil {
+		return false, err
+	}
+	resp, err := client.GetBucketVersioning(ctx, &metainfoextpb.GetBucketVersioningRequest{
+		Header: c.header(),
+		Bucket: []byte(bucket),
+	})
+	if err != nil {
+		return false, convertError(err, bucket, "")
+	}
+	return resp.Enabled, nil
+}
+
+// ListObjectVersions returns every version and delete marker of the object,
+// newest first.
+func (c *Client) ListObjectVersions(ctx context.Context, bucket, key string) ([]ObjectVersion, error) {
+	client, err := c.extensions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	encKey, err := c.encryptKey(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []ObjectVersion
+	var cursor int64
+	for {
+		resp, err := client.ListObjectVersions(ctx, &metainfoextpb.ListObjectVersionsRequest{
+			Header:             c.header(),
+			Bucket:             []byte(bucket),
+			EncryptedObjectKey: encKey,
+			Cursor:             cursor,
+		})
+		if err != nil {
+			return nil, convertError(err, bucket, key)
+		}
+		for _, item := range resp.Items {
+			versions = append(versions, ObjectVersion{
+				Version:      item.Version,
+				DeleteMarker: item.DeleteMarker,
+				Created:      item.CreatedAt,
+				Size:         item.PlainSize,
+			})
+			cursor = item.Version
+		}
+		if !resp.More || len(resp.Items) == 0 {
+			return versions, nil
+		}
+	}
+}
+
+// ListPrefixVersions returns an iterator over every version and delete marker
+// of the objects under the prefix, including the objects whose latest version
+// is a delete marker. The prefix must be empty or end with a slash.
+func (c *Client) ListPrefixVersions(ctx context.Context, bucket, prefix string, recursive bool) *PrefixVersionIterator {
+	return &PrefixVersionIterator{
+		ctx:       ctx,
+		client:    c,
+		bucket:    bucket,
+		prefix:    prefix,
+		recursive: recursive,
+	}
+}
+
+// PrefixVersion is a version or delete marker of an object, or a prefix of
+// a non-recursive listing.
+type PrefixVersion struct {
+	Key      string
+	IsPrefix bool
+	ObjectVersion
+}
+
+// PrefixVersionIterator is an iterator over the versions of the objects under
+// a prefix. The versions of each key are ordered from oldest to newest.
+type PrefixVersionIterator struct {
+	ctx       context.Context
+	client    *Client
+	bucket    string
+	prefix    string
+	recursive bool
+
+	encPrefix     []byte
+	cursor        []byte
+	cursorVersion int64
+	more          bool
+
+	items []PrefixVersion
+	item  PrefixVersion
+	err   error
+}
+
+// Next prepares the next version for reading with Item. It returns false when
+// there are no more versions or an error happened.
+func (it *PrefixVersionIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if len(it.items) == 0 {
+		if it.cursor != nil && !it.more {
+			return false
+		}
+		if it.err = it.nextPage(); it.err != nil || len(it.items) == 0 {
+			return false
+		}
+	}
+	it.item, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// nextPage lists the page after the cursor.
+func (it *PrefixVersionIterator) nextPage() error {
+	client, err := it.client.extensions(it.ctx)
+	if err != nil {
+		return err
+	}
+	if it.cursor == nil {
+		it.encPrefix, err = it.client.encryptPrefix(it.bucket, it.prefix)
+		if err != nil {
+			return err
+		}
+	}
+
+	resp, err := client.ListPrefixVersions(it.ctx, &metainfoextpb.ListPrefixVersionsRequest{
+		Header:          it.client.header(),
+		Bucket:          []byte(it.bucket),
+		EncryptedPrefix: it.encPrefix,
+		Recursive:       it.recursive,
+		EncryptedCursor: it.cursor,
+		CursorVersion:   it.cursorVersion,
+	})
+	if err != nil {
+		return convertError(err, it.bucket, "")
+	}
+
+	it.more = resp.More
+	it.cursor = []byte{}
+	for _, item := range resp.Items {
+		encKey := append(append([]byte{}, it.encPrefix...), item.EncryptedObjectKey...)
+
+		version := PrefixVersion{IsPrefix: item.IsPrefix}
+		if item.IsPrefix {
+			version.Key, err = it.client.decryptPrefix(it.bucket, encKey)
+		} else {
+			version.Key, err = it.client.decryptKey(it.bucket, encKey)
+		}
+		if err != nil {
+			return errs.New("unable to decrypt key of a version: %v", err)
+		}
+		if item.Version != nil {
+			version.ObjectVersion = ObjectVersion{
+				Version:      item.Version.Version,
+				DeleteMarker: item.Version.DeleteMarker,
+				Created:      item.Version.CreatedAt,
+				Size:         item.Version.PlainSize,
+			}
+		}
+
+		it.items = append(it.items, version)
+		it.cursor, it.cursorVersion = item.EncryptedObjectKey, version.Version
+	}
+	return nil
+}
+
+// Err returns the error, if one happened during iteration.
+func (it *PrefixVersionIterator) Err() error { return it.err }
+
+// Item returns the current version.
+func (it *PrefixVersionIterator) Item() PrefixVersion { return it.item }
+
+// RestoreObjectVersion makes the version of the object its latest version and
+// returns the version number of the restored copy. The data is not uploaded
+// again, the restored version shares the pieces of the original one.
+func (c *Client) RestoreObjectVersion(ctx context.Context, bucket, key string, version int64) (int64, error) {
+	client, err := c.extensions(ctx)
+	if err != nil {
+		return 0, err
+	}
+	encKey, err := c.encryptKey(bucket, key)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.RestoreObjectVersion(ctx, &metainfoextpb.RestoreObjectVersionRequest{
+		Header:             c.header(),
+		Bucket:             []byte(bucket),
+		EncryptedObjectKey: encKey,
+		Version:            version,
+	})
+	if err != nil {
+		return 0, convertError(err, bucket, key)
+	}
+	return resp.Version, nil
+}
+
+// Download is a download of a specific version of an object.
+type Download struct {
+	Object   metaclient.Object
+	download *stream.Download
+	streams  *streams.Store
+}
+
+// Read reads the contents of the object version.
+func (d *Download) Read(p []byte) (int, error) { return d.download.Read(p) }
+
+// Close closes the download.
+func (d *Download) Close() error {
+	return errs.Combine(d.download.Close(), d.streams.Close())
+}
+
+// DownloadObjectVersion downloads length bytes starting at offset of the
+// version of the object. A negative length downloads until the end.
+func (c *Client) DownloadObjectVersion(ctx context.Context, bucket, key string, version, offset, length int64) (_ *Download, err error) {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the metaclient downloads objects with the DownloadObject request of the
+	// metainfo service, which always returns the latest version, so the
+	// request is redirected to DownloadObjectVersion.
+	versioned := &versionedMetainfoClient{
+		DRPCMetainfoClient: pb.NewDRPCMetainfoClient(conn),
+		extensions:         metainfoextpb.NewDRPCMetainfoExtensionsClient(conn),
+		version:            version,
+	}
+	db := metaclient.New(metaclient.NewClient(versioned, c.access.APIKey, ""), c.access.EncAccess.Store)
+
+	streamRange := metaclient.StreamRange{Mode: metaclient.StreamRangeStart, Start: offset}
+	if length >= 0 {
+		streamRange = metaclient.StreamRange{Mode: metaclient.StreamRangeStartLimit, Start: offset, Limit: offset + length}
+	}
+
+	info, err := db.DownloadObject(ctx, bucket, key, metaclient.DownloadOptions{Range: streamRange})
+	if err != nil {
+		return nil, convertError(err, bucket, key)
+	}
+
+	store, err := streams.NewStreamStore(
+		metaclient.NewClient(pb.NewDRPCMetainfoClient(conn), c.access.APIKey, ""),
+		ecclient.New(c.dialer, 0),
+		64*memory.MiB.Int64(),
+		c.access.EncAccess.Store,
+		info.Object.EncryptionParameters,
+		4*memory.KiB.Int(),
+	)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+
+	return &Download{
+		Object:   info.Object,
+		download: stream.NewDownloadRange(ctx, info, store, info.Range.Start, info.Range.Limit-info.Range.Start),
+		streams:  store,
+	}, nil
+}
+
+// versionedMetainfoClient downloads a specific version of the objects.
+type versionedMetainfoClient struct {
+	pb.DRPCMetainfoClient
+	extensions metainfoextpb.DRPCMetainfoExtensionsClient
+	version    int64
+}
+
+func (v *versionedMetainfoClient) DownloadObject(ctx context.Context, req *pb.ObjectDownloadRequest) (*pb.ObjectDownloadResponse, error) {
+	resp, err := v.extensions.DownloadObjectVersion(ctx, &metainfoextpb.DownloadObjectVersionRequest{
+		Download: req,
+		Version:  v.version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Download, nil
+}
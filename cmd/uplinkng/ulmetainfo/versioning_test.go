@@ -0,0 +1,87 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package ulmetainfo_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/cmd/uplinkng/ulmetainfo"
+	"storj.io/storj/private/testplanet"
+)
+
+func TestPrefixVersions(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		uplink := planet.Uplinks[0]
+		client, err := ulmetainfo.New(uplink.Access[satellite.ID()], false)
+		require.NoError(t, err)
+		defer ctx.Check(client.Close)
+
+		require.NoError(t, uplink.CreateBucket(ctx, satellite, "testbucket"))
+		require.NoError(t, client.SetBucketVersioning(ctx, "testbucket", true))
+
+		first := testrand.Bytes(memory.KiB)
+		for _, key := range []string{"dir/a", "dir/a", "dir/sub/b", "c"} {
+			require.NoError(t, uplink.Upload(ctx, satellite, "testbucket", key, first))
+		}
+		// the latest version of c is a delete marker.
+		require.NoError(t, uplink.DeleteObject(ctx, satellite, "testbucket", "c"))
+
+		type item struct {
+			Key          string
+			IsPrefix     bool
+			Version      int64
+			DeleteMarker bool
+		}
+		list := func(prefix string, recursive bool) (items []item) {
+			iter := client.ListPrefixVersions(ctx, "testbucket", prefix, recursive)
+			for iter.Next() {
+				version := iter.Item()
+				items = append(items, item{version.Key, version.IsPrefix, version.Version, version.DeleteMarker})
+			}
+			require.NoError(t, iter.Err())
+
+			// the keys are ordered by their encrypted form, the versions of a
+			// key stay in order.
+			sort.SliceStable(items, func(i, k int) bool { return items[i].Key < items[k].Key })
+			return items
+		}
+
+		require.Equal(t, []item{
+			{Key: "c", Version: 1},
+			{Key: "c", Version: 2, DeleteMarker: true},
+			{Key: "dir/a", Version: 1},
+			{Key: "dir/a", Version: 2},
+			{Key: "dir/sub/b", Version: 1},
+		}, list("", true))
+
+		require.Equal(t, []item{
+			{Key: "c", Version: 1},
+			{Key: "c", Version: 2, DeleteMarker: true},
+			{Key: "dir/", IsPrefix: true},
+		}, list("", false))
+
+		require.Equal(t, []item{
+			{Key: "dir/a", Version: 1},
+			{Key: "dir/a", Version: 2},
+			{Key: "dir/sub/", IsPrefix: true},
+		}, list("dir/", false))
+
+		version, err := client.RestoreObjectVersion(ctx, "testbucket", "c", 1)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, version)
+
+		downloaded, err := uplink.Download(ctx, satellite, "testbucket", "c")
+		require.NoError(t, err)
+		require.Equal(t, first, downloaded)
+	})
+}
@@ -11,24 +11,39 @@ import (
 
 	"storj.io/storj/cmd/uplinkng/ulext"
 	"storj.io/storj/cmd/uplinkng/ulfs"
+	"storj.io/storj/cmd/uplinkng/ulmetainfo"
 	"storj.io/uplink"
 )
 
 type external struct {
 	ulext.External
 
-	fs      ulfs.Filesystem
-	project *uplink.Project
+	fs       ulfs.Filesystem
+	project  *uplink.Project
+	stateDir string
+	output   string
 }
 
-func newExternal(fs ulfs.Filesystem, project *uplink.Project) *external {
+func newExternal(fs ulfs.Filesystem, project *uplink.Project, stateDir string) *external {
 	return &external{
-		fs:      fs,
-		project: project,
+		fs:       fs,
+		project:  project,
+		stateDir: stateDir,
 	}
 }
 
+func (ex *external) Setup(f clingy.Flags) {
+	ex.output = ulext.OutputFlag(f)
+}
+
+func (ex *external) OutputFormat() string {
+	return ex.output
+}
+
 func (ex *external) OpenFilesystem(ctx context.Context, access string, options ...ulext.Option) (ulfs.Filesystem, error) {
+	if tfs, ok := ex.fs.(*testFilesystem); ok {
+		tfs.filter = ulext.LoadOptions(options...).Filter
+	}
 	return ex.fs, nil
 }
 
@@ -36,10 +51,18 @@ func (ex *external) OpenProject(ctx context.Context, access string, options ...u
 	return ex.project, nil
 }
 
-func (ex *external) OpenAccess(accessName string) (access *uplink.Access, err error) {
+func (ex *external) OpenMetainfo(ctx context.Context, access string, options ...ulext.Option) (*ulmetainfo.Client, error) {
 	return nil, errs.New("not implemented")
 }
 
+func (ex *external) UploadStateDir() string {
+	return ex.stateDir
+}
+
+func (ex *external) OpenAccess(accessName string) (access *uplink.Access, err error) {
+	return uplink.ParseAccess(accessName)
+}
+
 func (ex *external) GetAccessInfo(required bool) (string, map[string]string, error) {
 	return "", nil, errs.New("not implemented")
 }
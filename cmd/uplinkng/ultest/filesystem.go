@@ -6,9 +6,14 @@ package ultest
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/zeebo/clingy"
@@ -22,27 +27,66 @@ import (
 // ulfs.Filesystem
 //
 
+// testSegmentSize is the size of the segments that remote files are split into.
+const testSegmentSize = 4
+
 type testFilesystem struct {
-	stdin   string
-	created int64
-	files   map[ulloc.Location]memFileData
-	pending map[ulloc.Location][]*memWriteHandle
-	locals  map[string]bool // true means path is a directory
-	buckets map[string]struct{}
+	stdin     string
+	created   int64
+	files     map[ulloc.Location]memFileData
+	versions  map[ulloc.Location][]memFileData // every version of remote files, oldest first
+	pending   map[ulloc.Location][]*memWriteHandle
+	multipart map[string]*memMultipartUpload
+	locals    map[string]bool // true means path is a directory
+	buckets   map[string]struct{}
+	filter    *ulfs.Filter
 }
 
 func newTestFilesystem() *testFilesystem {
 	return &testFilesystem{
-		files:   make(map[ulloc.Location]memFileData),
-		pending: make(map[ulloc.Location][]*memWriteHandle),
-		locals:  make(map[string]bool),
-		buckets: make(map[string]struct{}),
+		files:     make(map[ulloc.Location]memFileData),
+		versions:  make(map[ulloc.Location][]memFileData),
+		pending:   make(map[ulloc.Location][]*memWriteHandle),
+		multipart: make(map[string]*memMultipartUpload),
+		locals:    make(map[string]bool),
+		buckets:   make(map[string]struct{}),
 	}
 }
 
 type memFileData struct {
-	contents string
-	created  int64
+	contents     string
+	created      int64
+	metadata     map[string]string
+	tags         map[string]string
+	deleteMarker bool
+}
+
+func (mf memFileData) info(loc ulloc.Location) ulfs.ObjectInfo {
+	return ulfs.ObjectInfo{
+		Loc:           loc,
+		Created:       time.Unix(mf.created, 0),
+		ContentLength: int64(len(mf.contents)),
+		Metadata:      mf.metadata,
+	}
+}
+
+// hasTags returns whether the file has all of the tags.
+func (mf memFileData) hasTags(tags map[string]string) bool {
+	for key, value := range tags {
+		if v, ok := mf.tags[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// commit stores the file at the location, and keeps it as a version if the
+// location is remote.
+func (tfs *testFilesystem) commit(loc ulloc.Location, mf memFileData) {
+	tfs.files[loc] = mf
+	if loc.Remote() {
+		tfs.versions[loc] = append(tfs.versions[loc], mf)
+	}
 }
 
 func (tfs *testFilesystem) ensureBucket(name string) {
@@ -73,7 +117,39 @@ func (tfs *testFilesystem) Open(ctx clingy.Context, loc ulloc.Location) (_ ulfs.
 	if !ok {
 		return nil, errs.New("file does not exist")
 	}
-	return &byteReadHandle{Buffer: bytes.NewBufferString(mf.contents)}, nil
+	return &byteReadHandle{
+		Buffer: bytes.NewBufferString(mf.contents),
+		info:   mf.info(loc),
+	}, nil
+}
+
+func (tfs *testFilesystem) OpenRange(ctx clingy.Context, loc ulloc.Location, offset, length int64) (_ ulfs.ReadHandle, err error) {
+	mf, ok := tfs.files[loc]
+	if !ok {
+		return nil, errs.New("file does not exist")
+	}
+	if offset < 0 || offset > int64(len(mf.contents)) {
+		return nil, errs.New("invalid range offset %d", offset)
+	}
+	end := int64(len(mf.contents))
+	if length >= 0 && offset+length < end {
+		end = offset + length
+	}
+	return &byteReadHandle{Buffer: bytes.NewBufferString(mf.contents[offset:end])}, nil
+}
+
+func (tfs *testFilesystem) OpenVersion(ctx clingy.Context, loc ulloc.Location, version int64) (_ ulfs.ReadHandle, err error) {
+	versions := tfs.versions[loc]
+	if version <= 0 || version > int64(len(versions)) || versions[version-1].deleteMarker {
+		return nil, errs.New("version %d of %q does not exist", version, loc)
+	}
+	mf := versions[version-1]
+	info := mf.info(loc)
+	info.Version = version
+	return &byteReadHandle{
+		Buffer: bytes.NewBufferString(mf.contents),
+		info:   info,
+	}, nil
 }
 
 func (tfs *testFilesystem) Create(ctx clingy.Context, loc ulloc.Location) (_ ulfs.WriteHandle, err error) {
@@ -109,16 +185,120 @@ func (tfs *testFilesystem) Create(ctx clingy.Context, loc ulloc.Location) (_ ulf
 	return wh, nil
 }
 
+func (tfs *testFilesystem) CreateWriterAt(ctx clingy.Context, loc ulloc.Location, size int64) (_ ulfs.WriterAtHandle, err error) {
+	if !loc.Local() {
+		return nil, errs.New("writing at offsets is only supported for local files: %q", loc)
+	}
+
+	wh, err := tfs.Create(ctx, loc)
+	if err != nil {
+		return nil, err
+	}
+	return &memWriterAtHandle{
+		memWriteHandle: wh.(*memWriteHandle),
+		data:           make([]byte, size),
+	}, nil
+}
+
 func (tfs *testFilesystem) Remove(ctx context.Context, loc ulloc.Location) error {
+	if _, ok := tfs.files[loc]; ok && loc.Remote() {
+		tfs.created++
+		tfs.versions[loc] = append(tfs.versions[loc], memFileData{created: tfs.created, deleteMarker: true})
+	}
 	delete(tfs.files, loc)
 	return nil
 }
 
+func (tfs *testFilesystem) Move(ctx context.Context, source, dest ulloc.Location) error {
+	mf, err := tfs.remoteTarget(source, dest, false)
+	if err != nil {
+		return err
+	}
+	if err := tfs.Remove(ctx, source); err != nil {
+		return err
+	}
+	tfs.commit(dest, mf)
+	return nil
+}
+
+func (tfs *testFilesystem) Copy(ctx context.Context, source, dest ulloc.Location) error {
+	if source == dest {
+		return errs.New("object can't be copied onto itself: %q", source)
+	}
+	mf, err := tfs.remoteTarget(source, dest, true)
+	if err != nil {
+		return err
+	}
+	tfs.created++
+	mf.created = tfs.created
+	tfs.commit(dest, mf)
+	return nil
+}
+
+// remoteTarget returns the source of a move or copy on the satellite. Like the
+// satellite, only copies overwrite existing objects.
+func (tfs *testFilesystem) remoteTarget(source, dest ulloc.Location, overwrite bool) (memFileData, error) {
+	if !source.Remote() || !dest.Remote() {
+		return memFileData{}, errs.New("only remote objects can be moved or copied: %q to %q", source, dest)
+	}
+	mf, ok := tfs.files[source]
+	if !ok {
+		return memFileData{}, errs.New("file does not exist")
+	}
+	bucket, _, _ := dest.RemoteParts()
+	if _, ok := tfs.buckets[bucket]; !ok {
+		return memFileData{}, errs.New("bucket %q does not exist", bucket)
+	}
+	if _, ok := tfs.files[dest]; ok && !overwrite {
+		return memFileData{}, errs.New("object already exists: %q", dest)
+	}
+	return mf, nil
+}
+
+func (tfs *testFilesystem) Stat(ctx context.Context, loc ulloc.Location) (*ulfs.ObjectInfo, error) {
+	mf, ok := tfs.files[loc]
+	if !ok {
+		return nil, errs.Wrap(os.ErrNotExist)
+	}
+	info := mf.info(loc)
+	return &info, nil
+}
+
+func (tfs *testFilesystem) SegmentOffsets(ctx context.Context, loc ulloc.Location) ([]int64, error) {
+	mf, ok := tfs.files[loc]
+	if !ok {
+		return nil, errs.Wrap(os.ErrNotExist)
+	}
+	if !loc.Remote() {
+		return nil, nil
+	}
+	var offsets []int64
+	for offset := int64(0); offset < int64(len(mf.contents)); offset += testSegmentSize {
+		offsets = append(offsets, offset)
+	}
+	return offsets, nil
+}
+
 func (tfs *testFilesystem) ListObjects(ctx context.Context, prefix ulloc.Location, recursive bool) (ulfs.ObjectIterator, error) {
+	return tfs.listObjects(ctx, prefix, recursive, nil)
+}
+
+func (tfs *testFilesystem) ListTaggedObjects(ctx context.Context, prefix ulloc.Location, recursive bool, tags map[string]string) (ulfs.ObjectIterator, error) {
+	if !prefix.Remote() {
+		return nil, errs.New("tags are only supported for remote objects: %q", prefix)
+	}
+	return tfs.listObjects(ctx, prefix, recursive, tags)
+}
+
+// listObjects lists the files with the prefix that have all of the tags.
+func (tfs *testFilesystem) listObjects(ctx context.Context, prefix ulloc.Location, recursive bool, tags map[string]string) (ulfs.ObjectIterator, error) {
 	prefixDir := prefix.AsDirectoryish()
 
 	var infos []ulfs.ObjectInfo
 	for loc, mf := range tfs.files {
+		if !mf.hasTags(tags) {
+			continue
+		}
 		if loc.HasPrefix(prefixDir) || loc == prefix {
 			infos = append(infos, ulfs.ObjectInfo{
 				Loc:     loc,
@@ -133,6 +313,61 @@ func (tfs *testFilesystem) ListObjects(ctx context.Context, prefix ulloc.Locatio
 		infos = collapseObjectInfos(prefix, infos)
 	}
 
+	var iter ulfs.ObjectIterator = &objectInfoIterator{infos: infos}
+	if recursive && tfs.filter != nil {
+		matcher, err := ulfs.NewMatcher(tfs.filter)
+		if err != nil {
+			return nil, err
+		}
+		files := make(map[string][]byte)
+		for loc, mf := range tfs.files {
+			if !loc.HasPrefix(prefixDir) {
+				continue
+			}
+			if dir, ok := ulfs.IgnoreFileDir(loc.RemovePrefix(prefixDir).Loc()); ok {
+				files[dir] = []byte(mf.contents)
+			}
+		}
+		matcher.AddIgnoreFiles(files)
+		iter = ulfs.MatchObjects(prefixDir, matcher, tfs.filter, iter)
+	}
+
+	return iter, nil
+}
+
+func (tfs *testFilesystem) ListObjectVersions(ctx context.Context, prefix ulloc.Location, recursive bool) (ulfs.ObjectIterator, error) {
+	if !prefix.Remote() {
+		return nil, errs.New("versions are only supported for remote objects: %q", prefix)
+	}
+	prefixDir := prefix.AsDirectoryish()
+
+	var locs []ulfs.ObjectInfo
+	for loc := range tfs.versions {
+		if loc.HasPrefix(prefixDir) || loc == prefix {
+			locs = append(locs, ulfs.ObjectInfo{Loc: loc})
+		}
+	}
+	sort.Sort(objectInfos(locs))
+
+	var infos []ulfs.ObjectInfo
+	for _, oi := range locs {
+		if _, ok := oi.Loc.ListKeyName(prefix); ok && !recursive {
+			infos = append(infos, oi)
+			continue
+		}
+		versions := tfs.versions[oi.Loc]
+		for i := len(versions) - 1; i >= 0; i-- {
+			info := versions[i].info(oi.Loc)
+			info.Version = int64(i + 1)
+			info.DeleteMarker = versions[i].deleteMarker
+			infos = append(infos, info)
+		}
+	}
+
+	if !recursive {
+		infos = collapseObjectInfos(prefix, infos)
+	}
+
 	return &objectInfoIterator{infos: infos}, nil
 }
 
@@ -151,6 +386,16 @@ func (tfs *testFilesystem) ListUploads(ctx context.Context, prefix ulloc.Locatio
 		}
 	}
 
+	for id, mu := range tfs.multipart {
+		if mu.loc.HasPrefix(prefixDir) || mu.loc == prefix {
+			infos = append(infos, ulfs.ObjectInfo{
+				Loc:      mu.loc,
+				Created:  time.Unix(mu.cre, 0),
+				UploadID: id,
+			})
+		}
+	}
+
 	sort.Sort(objectInfos(infos))
 
 	if !recursive {
@@ -160,6 +405,44 @@ func (tfs *testFilesystem) ListUploads(ctx context.Context, prefix ulloc.Locatio
 	return &objectInfoIterator{infos: infos}, nil
 }
 
+func (tfs *testFilesystem) BeginMultipart(ctx context.Context, loc ulloc.Location) (ulfs.MultipartUpload, error) {
+	bucket, _, ok := loc.RemoteParts()
+	if !ok {
+		return nil, errs.New("multipart uploads are only supported for remote objects: %q", loc)
+	}
+	if _, ok := tfs.buckets[bucket]; !ok {
+		return nil, errs.New("bucket %q does not exist", bucket)
+	}
+
+	tfs.created++
+	mu := &memMultipartUpload{
+		id:    fmt.Sprintf("upload-%d", tfs.created),
+		loc:   loc,
+		tfs:   tfs,
+		cre:   tfs.created,
+		parts: make(map[uint32]string),
+	}
+	tfs.multipart[mu.id] = mu
+
+	return mu, nil
+}
+
+func (tfs *testFilesystem) ResumeMultipart(ctx context.Context, loc ulloc.Location, uploadID string) (ulfs.MultipartUpload, error) {
+	mu, ok := tfs.multipart[uploadID]
+	if !ok || mu.loc != loc {
+		return nil, errs.New("upload %q does not exist", uploadID)
+	}
+	return mu, nil
+}
+
+func (tfs *testFilesystem) AbortMultipart(ctx context.Context, loc ulloc.Location, uploadID string) error {
+	mu, err := tfs.ResumeMultipart(ctx, loc, uploadID)
+	if err != nil {
+		return err
+	}
+	return mu.Abort(ctx)
+}
+
 func (tfs *testFilesystem) IsLocalDir(ctx context.Context, loc ulloc.Location) (local bool) {
 	path, ok := loc.LocalParts()
 	return ok && (filepath.Clean(path) == "." || tfs.locals[path])
@@ -197,10 +480,11 @@ func (tfs *testFilesystem) mkdir(ctx context.Context, dir string) error {
 
 type byteReadHandle struct {
 	*bytes.Buffer
+	info ulfs.ObjectInfo
 }
 
 func (b *byteReadHandle) Close() error          { return nil }
-func (b *byteReadHandle) Info() ulfs.ObjectInfo { return ulfs.ObjectInfo{} }
+func (b *byteReadHandle) Info() ulfs.ObjectInfo { return b.info }
 
 //
 // ulfs.WriteHandle
@@ -211,6 +495,7 @@ type memWriteHandle struct {
 	loc  ulloc.Location
 	tfs  *testFilesystem
 	cre  int64
+	meta map[string]string
 	done bool
 }
 
@@ -218,6 +503,11 @@ func (b *memWriteHandle) Write(p []byte) (int, error) {
 	return b.buf.Write(p)
 }
 
+func (b *memWriteHandle) SetMetadata(ctx context.Context, metadata map[string]string) error {
+	b.meta = metadata
+	return nil
+}
+
 func (b *memWriteHandle) Commit() error {
 	if err := b.close(); err != nil {
 		return err
@@ -227,10 +517,11 @@ func (b *memWriteHandle) Commit() error {
 		b.tfs.locals[path] = false
 	}
 
-	b.tfs.files[b.loc] = memFileData{
+	b.tfs.commit(b.loc, memFileData{
 		contents: b.buf.String(),
 		created:  b.cre,
-	}
+		metadata: b.meta,
+	})
 	return nil
 }
 
@@ -265,12 +556,103 @@ func (b *memWriteHandle) close() error {
 	return nil
 }
 
+type memWriterAtHandle struct {
+	*memWriteHandle
+
+	mu   sync.Mutex
+	data []byte
+}
+
+func (b *memWriterAtHandle) WriteAt(p []byte, off int64) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if off < 0 || off+int64(len(p)) > int64(len(b.data)) {
+		return 0, errs.New("write outside of file")
+	}
+	return copy(b.data[off:], p), nil
+}
+
+func (b *memWriterAtHandle) Commit() error {
+	b.buf.Reset()
+	b.buf.Write(b.data)
+	return b.memWriteHandle.Commit()
+}
+
+type memMultipartUpload struct {
+	id  string
+	loc ulloc.Location
+	tfs *testFilesystem
+	cre int64
+
+	meta    map[string]string
+	partsMu sync.Mutex
+	parts   map[uint32]string
+}
+
+func (mu *memMultipartUpload) UploadID() string { return mu.id }
+
+func (mu *memMultipartUpload) UploadPart(ctx context.Context, number uint32, r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+
+	mu.partsMu.Lock()
+	defer mu.partsMu.Unlock()
+
+	mu.parts[number] = string(data)
+	return nil
+}
+
+func (mu *memMultipartUpload) SetMetadata(ctx context.Context, metadata map[string]string) error {
+	mu.meta = metadata
+	return nil
+}
+
+func (mu *memMultipartUpload) Commit(ctx context.Context) error {
+	if _, ok := mu.tfs.multipart[mu.id]; !ok {
+		return errs.New("already done")
+	}
+	delete(mu.tfs.multipart, mu.id)
+
+	numbers := make([]int, 0, len(mu.parts))
+	for number := range mu.parts {
+		numbers = append(numbers, int(number))
+	}
+	sort.Ints(numbers)
+
+	var contents strings.Builder
+	for _, number := range numbers {
+		contents.WriteString(mu.parts[uint32(number)])
+	}
+
+	mu.tfs.commit(mu.loc, memFileData{
+		contents: contents.String(),
+		created:  mu.cre,
+		metadata: mu.meta,
+	})
+	return nil
+}
+
+func (mu *memMultipartUpload) Abort(ctx context.Context) error {
+	if _, ok := mu.tfs.multipart[mu.id]; !ok {
+		return errs.New("already done")
+	}
+	delete(mu.tfs.multipart, mu.id)
+	return nil
+}
+
 type discardWriteHandle struct{}
 
 func (discardWriteHandle) Write(p []byte) (int, error) { return len(p), nil }
 func (discardWriteHandle) Commit() error               { return nil }
 func (discardWriteHandle) Abort() error                { return nil }
 
+func (discardWriteHandle) SetMetadata(ctx context.Context, metadata map[string]string) error {
+	return nil
+}
+
 //
 // ulfs.ObjectIterator
 //
@@ -85,7 +85,9 @@ func (st State) Run(t *testing.T, args ...string) Result {
 			return cmd.Execute(ctx)
 		},
 	}.Run(context.Background(), func(cmds clingy.Commands) {
-		st.cmds(cmds, newExternal(tfs, nil))
+		ex := newExternal(tfs, nil, t.TempDir())
+		ex.Setup(cmds)
+		st.cmds(cmds, ex)
 	})
 
 	if ok && err == nil {
@@ -155,6 +157,19 @@ func WithFile(location string, contents ...string) ExecuteOption {
 	}}
 }
 
+// WithTags sets the tags of the file created at the given location.
+func WithTags(location string, tags map[string]string) ExecuteOption {
+	return ExecuteOption{func(t *testing.T, ctx clingy.Context, tfs *testFilesystem) {
+		loc, err := ulloc.Parse(location)
+		require.NoError(t, err)
+
+		mf, ok := tfs.files[loc]
+		require.True(t, ok, "file %q does not exist", location)
+		mf.tags = tags
+		tfs.files[loc] = mf
+	}}
+}
+
 // WithPendingFile sets the command to execute with a pending upload happening to
 // the provided location.
 func WithPendingFile(location string) ExecuteOption {
@@ -170,3 +185,19 @@ func WithPendingFile(location string) ExecuteOption {
 		require.NoError(t, err)
 	}}
 }
+
+// WithPendingMultipart sets the command to execute with a pending multipart upload
+// to the provided location.
+func WithPendingMultipart(location string) ExecuteOption {
+	return ExecuteOption{func(t *testing.T, ctx clingy.Context, tfs *testFilesystem) {
+		loc, err := ulloc.Parse(location)
+		require.NoError(t, err)
+
+		if bucket, _, ok := loc.RemoteParts(); ok {
+			tfs.ensureBucket(bucket)
+		}
+
+		_, err = tfs.BeginMultipart(ctx, loc)
+		require.NoError(t, err)
+	}}
+}
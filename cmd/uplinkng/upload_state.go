@@ -0,0 +1,121 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/cmd/uplinkng/ulloc"
+)
+
+// uploadState records the progress of a multipart upload so that an
+// interrupted cp can be continued with --resume.
+type uploadState struct {
+	Source   string `json:"source"`
+	Dest     string `json:"dest"`
+	UploadID string `json:"uploadId"`
+	PartSize int64  `json:"partSize"`
+
+	// Size and Modified describe the source when the upload was started, so
+	// that a changed source is never resumed.
+	Size     int64     `json:"size"`
+	Modified time.Time `json:"modified"`
+
+	// Parts are the numbers of the committed parts. Part n starts at byte
+	// (n-1)*PartSize of the source.
+	Parts []uint32 `json:"parts"`
+}
+
+// uploadStatePath returns the path of the state file of an upload from source
+// to dest in dir.
+func uploadStatePath(dir string, source, dest ulloc.Location) string {
+	sourceName := source.String()
+	if path, ok := source.LocalParts(); ok {
+		if abs, err := filepath.Abs(path); err == nil {
+			sourceName = abs
+		}
+	}
+	sum := sha256.Sum256([]byte(sourceName + "\x00" + dest.String()))
+	return filepath.Join(dir, hex.EncodeToString(sum[:16])+".json")
+}
+
+// loadUploadState reads the state file at path. It returns nil if there is no
+// state file.
+func loadUploadState(path string) (*uploadState, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errs.Wrap(err)
+	}
+
+	var state uploadState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, errs.New("invalid upload state file %q: %v", path, err)
+	}
+	return &state, nil
+}
+
+// loadUploadStates reads all of the state files in dir.
+func loadUploadStates(dir string) (paths []string, states []*uploadState, err error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, errs.Wrap(err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		state, err := loadUploadState(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if state != nil {
+			paths = append(paths, path)
+			states = append(states, state)
+		}
+	}
+	return paths, states, nil
+}
+
+// save atomically replaces the state file at path.
+func (state *uploadState) save(path string) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errs.Wrap(err)
+	}
+
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return errs.Wrap(err)
+	}
+	return errs.Wrap(os.Rename(tmp, path))
+}
+
+// removeUploadState removes the state file at path if it exists.
+func removeUploadState(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errs.Wrap(err)
+	}
+	return nil
+}
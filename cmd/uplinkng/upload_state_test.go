@@ -0,0 +1,151 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/clingy"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/cmd/uplinkng/ulext"
+	"storj.io/storj/cmd/uplinkng/ulfs"
+	"storj.io/storj/cmd/uplinkng/ulloc"
+)
+
+func TestCpResume(t *testing.T) {
+	ctx := &testContext{Context: context.Background()}
+	ex := &stateDirExternal{dir: t.TempDir()}
+	fs := &partsFilesystem{
+		contents: "0123456789",
+		failPart: 2,
+		parts:    make(map[uint32]string),
+	}
+
+	source := ulloc.NewLocal("/home/user/big")
+	dest := ulloc.NewRemote("user", "big")
+	cp := &cmdCp{ex: ex, partSize: 4}
+
+	// the second part fails, which stops the upload after the first part is
+	// committed.
+	err := cp.copyFile(ctx, fs, source, dest, 1, false)
+	require.Error(t, err)
+	require.Contains(t, ctx.stderr.String(), "--resume")
+
+	state, err := loadUploadState(uploadStatePath(ex.dir, source, dest))
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	require.Equal(t, []uint32{1}, state.Parts)
+	require.Equal(t, map[uint32]string{1: "0123"}, fs.parts)
+
+	// resuming only uploads the missing parts of the same upload.
+	fs.failPart = 0
+	cp.resume = true
+	require.NoError(t, cp.copyFile(ctx, fs, source, dest, 1, false))
+	require.Equal(t, 1, fs.begun)
+	require.Equal(t, "0123456789", fs.committed)
+	require.Equal(t, map[string]string{
+		checksumMetadataKey: "84d89877f0d4041efb6bf91a16f0248f2fd573e6af05c19f96bedb9f882f7882",
+	}, fs.metadata)
+
+	state, err = loadUploadState(uploadStatePath(ex.dir, source, dest))
+	require.NoError(t, err)
+	require.Nil(t, state)
+}
+
+// partsFilesystem is a filesystem containing a single local file, which can
+// only be uploaded as a multipart upload. It is also the multipart upload.
+type partsFilesystem struct {
+	ulfs.Filesystem
+
+	contents  string
+	failPart  uint32
+	parts     map[uint32]string
+	begun     int
+	metadata  map[string]string
+	committed string
+}
+
+func (fs *partsFilesystem) Stat(ctx context.Context, loc ulloc.Location) (*ulfs.ObjectInfo, error) {
+	return &ulfs.ObjectInfo{
+		Loc:           loc,
+		Created:       time.Unix(1, 0),
+		ContentLength: int64(len(fs.contents)),
+	}, nil
+}
+
+func (fs *partsFilesystem) Open(ctx clingy.Context, loc ulloc.Location) (ulfs.ReadHandle, error) {
+	return stringReadHandle{strings.NewReader(fs.contents)}, nil
+}
+
+func (fs *partsFilesystem) OpenRange(ctx clingy.Context, loc ulloc.Location, offset, length int64) (ulfs.ReadHandle, error) {
+	return stringReadHandle{strings.NewReader(fs.contents[offset : offset+length])}, nil
+}
+
+func (fs *partsFilesystem) BeginMultipart(ctx context.Context, loc ulloc.Location) (ulfs.MultipartUpload, error) {
+	fs.begun++
+	return fs, nil
+}
+
+func (fs *partsFilesystem) ResumeMultipart(ctx context.Context, loc ulloc.Location, uploadID string) (ulfs.MultipartUpload, error) {
+	return fs, nil
+}
+
+func (fs *partsFilesystem) UploadID() string { return "upload" }
+
+func (fs *partsFilesystem) UploadPart(ctx context.Context, number uint32, r io.Reader) error {
+	if number == fs.failPart {
+		return errs.New("part %d failed", number)
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	fs.parts[number] = string(data)
+	return nil
+}
+
+func (fs *partsFilesystem) SetMetadata(ctx context.Context, metadata map[string]string) error {
+	fs.metadata = metadata
+	return nil
+}
+
+func (fs *partsFilesystem) Commit(ctx context.Context) error {
+	for number := uint32(1); number <= uint32(len(fs.parts)); number++ {
+		fs.committed += fs.parts[number]
+	}
+	return nil
+}
+
+func (fs *partsFilesystem) Abort(ctx context.Context) error { return nil }
+
+type stringReadHandle struct{ *strings.Reader }
+
+func (stringReadHandle) Close() error          { return nil }
+func (stringReadHandle) Info() ulfs.ObjectInfo { return ulfs.ObjectInfo{} }
+
+type stateDirExternal struct {
+	ulext.External
+	dir string
+}
+
+func (ex *stateDirExternal) UploadStateDir() string { return ex.dir }
+
+type testContext struct {
+	context.Context
+	stdout, stderr bytes.Buffer
+}
+
+func (c *testContext) Read(p []byte) (int, error)  { return 0, io.EOF }
+func (c *testContext) Write(p []byte) (int, error) { return c.stdout.Write(p) }
+func (c *testContext) Stdin() io.Reader            { return c }
+func (c *testContext) Stdout() io.Writer           { return &c.stdout }
+func (c *testContext) Stderr() io.Writer           { return &c.stderr }
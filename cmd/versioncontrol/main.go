@@ -4,6 +4,7 @@
 package main
 
 import (
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -15,6 +16,7 @@ import (
 	"storj.io/private/cfgstruct"
 	"storj.io/private/process"
 	_ "storj.io/storj/private/version" // This attaches version information during release builds.
+	"storj.io/storj/private/version/release"
 	"storj.io/storj/versioncontrol"
 )
 
@@ -34,6 +36,12 @@ var (
 		RunE:        cmdSetup,
 		Annotations: map[string]string{"type": "setup"},
 	}
+	releaseKeyCmd = &cobra.Command{
+		Use:   "release-key <key path>",
+		Short: "Create the key that signs release manifests and print its public key",
+		Args:  cobra.ExactArgs(1),
+		RunE:  cmdReleaseKey,
+	}
 
 	runCfg   versioncontrol.Config
 	setupCfg versioncontrol.Config
@@ -51,6 +59,7 @@ func init() {
 	defaults := cfgstruct.DefaultsFlag(rootCmd)
 	rootCmd.AddCommand(runCmd)
 	rootCmd.AddCommand(setupCmd)
+	rootCmd.AddCommand(releaseKeyCmd)
 	process.Bind(runCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir))
 	process.Bind(setupCmd, &setupCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.SetupMode())
 }
@@ -93,6 +102,20 @@ func cmdSetup(cmd *cobra.Command, args []string) (err error) {
 		process.SaveConfigWithOverrides(overrides))
 }
 
+func cmdReleaseKey(cmd *cobra.Command, args []string) (err error) {
+	if _, err := os.Stat(args[0]); err == nil {
+		return fmt.Errorf("release key already exists (%v)", args[0])
+	}
+
+	publicKey, err := release.GenerateKey(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(hex.EncodeToString(publicKey))
+	return nil
+}
+
 func main() {
 	process.Exec(rootCmd)
 }
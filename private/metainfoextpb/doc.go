@@ -0,0 +1,8 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+//go:generate go run gen.go
+
+// Package metainfoextpb contains proto definitions for the object operations
+// of the satellite that the metainfo service does not have yet.
+package metainfoextpb
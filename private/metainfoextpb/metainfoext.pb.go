@@ -0,0 +1,2847 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: metainfoext.proto
+
+package metainfoextpb
+
+import (
+	fmt "fmt"
+	math "math"
+	time "time"
+
+	proto "github.com/gogo/protobuf/proto"
+
+	pb "storj.io/common/pb"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// LifecycleAction defines what a lifecycle rule does with the matching objects.
+type LifecycleAction int32
+
+const (
+	LifecycleAction_INVALID LifecycleAction = 0
+	// EXPIRE_OBJECTS deletes committed objects older than the rule age.
+	LifecycleAction_EXPIRE_OBJECTS LifecycleAction = 1
+	// ABORT_UPLOADS deletes pending objects older than the rule age.
+	LifecycleAction_ABORT_UPLOADS LifecycleAction = 2
+)
+
+var LifecycleAction_name = map[int32]string{
+	0: "INVALID",
+	1: "EXPIRE_OBJECTS",
+	2: "ABORT_UPLOADS",
+}
+
+var LifecycleAction_value = map[string]int32{
+	"INVALID":        0,
+	"EXPIRE_OBJECTS": 1,
+	"ABORT_UPLOADS":  2,
+}
+
+func (x LifecycleAction) String() string {
+	return proto.EnumName(LifecycleAction_name, int32(x))
+}
+
+func (LifecycleAction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{0}
+}
+
+type SetBucketVersioningRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket               []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Enabled              bool              `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SetBucketVersioningRequest) Reset()         { *m = SetBucketVersioningRequest{} }
+func (m *SetBucketVersioningRequest) String() string { return proto.CompactTextString(m) }
+func (*SetBucketVersioningRequest) ProtoMessage()    {}
+func (*SetBucketVersioningRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{0}
+}
+func (m *SetBucketVersioningRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetBucketVersioningRequest.Unmarshal(m, b)
+}
+func (m *SetBucketVersioningRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetBucketVersioningRequest.Marshal(b, m, deterministic)
+}
+func (m *SetBucketVersioningRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetBucketVersioningRequest.Merge(m, src)
+}
+func (m *SetBucketVersioningRequest) XXX_Size() int {
+	return xxx_messageInfo_SetBucketVersioningRequest.Size(m)
+}
+func (m *SetBucketVersioningRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetBucketVersioningRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetBucketVersioningRequest proto.InternalMessageInfo
+
+func (m *SetBucketVersioningRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *SetBucketVersioningRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *SetBucketVersioningRequest) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+type SetBucketVersioningResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetBucketVersioningResponse) Reset()         { *m = SetBucketVersioningResponse{} }
+func (m *SetBucketVersioningResponse) String() string { return proto.CompactTextString(m) }
+func (*SetBucketVersioningResponse) ProtoMessage()    {}
+func (*SetBucketVersioningResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{1}
+}
+func (m *SetBucketVersioningResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetBucketVersioningResponse.Unmarshal(m, b)
+}
+func (m *SetBucketVersioningResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetBucketVersioningResponse.Marshal(b, m, deterministic)
+}
+func (m *SetBucketVersioningResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetBucketVersioningResponse.Merge(m, src)
+}
+func (m *SetBucketVersioningResponse) XXX_Size() int {
+	return xxx_messageInfo_SetBucketVersioningResponse.Size(m)
+}
+func (m *SetBucketVersioningResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetBucketVersioningResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetBucketVersioningResponse proto.InternalMessageInfo
+
+type GetBucketVersioningRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket               []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetBucketVersioningRequest) Reset()         { *m = GetBucketVersioningRequest{} }
+func (m *GetBucketVersioningRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBucketVersioningRequest) ProtoMessage()    {}
+func (*GetBucketVersioningRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{2}
+}
+func (m *GetBucketVersioningRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBucketVersioningRequest.Unmarshal(m, b)
+}
+func (m *GetBucketVersioningRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBucketVersioningRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBucketVersioningRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBucketVersioningRequest.Merge(m, src)
+}
+func (m *GetBucketVersioningRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBucketVersioningRequest.Size(m)
+}
+func (m *GetBucketVersioningRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBucketVersioningRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBucketVersioningRequest proto.InternalMessageInfo
+
+func (m *GetBucketVersioningRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetBucketVersioningRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+type GetBucketVersioningResponse struct {
+	Enabled              bool     `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBucketVersioningResponse) Reset()         { *m = GetBucketVersioningResponse{} }
+func (m *GetBucketVersioningResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBucketVersioningResponse) ProtoMessage()    {}
+func (*GetBucketVersioningResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{3}
+}
+func (m *GetBucketVersioningResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBucketVersioningResponse.Unmarshal(m, b)
+}
+func (m *GetBucketVersioningResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBucketVersioningResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBucketVersioningResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBucketVersioningResponse.Merge(m, src)
+}
+func (m *GetBucketVersioningResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBucketVersioningResponse.Size(m)
+}
+func (m *GetBucketVersioningResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBucketVersioningResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBucketVersioningResponse proto.InternalMessageInfo
+
+func (m *GetBucketVersioningResponse) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+type ListObjectVersionsRequest struct {
+	Header             *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket             []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	EncryptedObjectKey []byte            `protobuf:"bytes,3,opt,name=encrypted_object_key,json=encryptedObjectKey,proto3" json:"encrypted_object_key,omitempty"`
+	// cursor is exclusive, only versions older than the cursor are listed.
+	Cursor               int64    `protobuf:"varint,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit                int32    `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListObjectVersionsRequest) Reset()         { *m = ListObjectVersionsRequest{} }
+func (m *ListObjectVersionsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListObjectVersionsRequest) ProtoMessage()    {}
+func (*ListObjectVersionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{4}
+}
+func (m *ListObjectVersionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListObjectVersionsRequest.Unmarshal(m, b)
+}
+func (m *ListObjectVersionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListObjectVersionsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListObjectVersionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListObjectVersionsRequest.Merge(m, src)
+}
+func (m *ListObjectVersionsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListObjectVersionsRequest.Size(m)
+}
+func (m *ListObjectVersionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListObjectVersionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListObjectVersionsRequest proto.InternalMessageInfo
+
+func (m *ListObjectVersionsRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *ListObjectVersionsRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *ListObjectVersionsRequest) GetEncryptedObjectKey() []byte {
+	if m != nil {
+		return m.EncryptedObjectKey
+	}
+	return nil
+}
+
+func (m *ListObjectVersionsRequest) GetCursor() int64 {
+	if m != nil {
+		return m.Cursor
+	}
+	return 0
+}
+
+func (m *ListObjectVersionsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ListObjectVersionsResponse struct {
+	Items                []*ObjectVersion `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	More                 bool             `protobuf:"varint,2,opt,name=more,proto3" json:"more,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ListObjectVersionsResponse) Reset()         { *m = ListObjectVersionsResponse{} }
+func (m *ListObjectVersionsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListObjectVersionsResponse) ProtoMessage()    {}
+func (*ListObjectVersionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{5}
+}
+func (m *ListObjectVersionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListObjectVersionsResponse.Unmarshal(m, b)
+}
+func (m *ListObjectVersionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListObjectVersionsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListObjectVersionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListObjectVersionsResponse.Merge(m, src)
+}
+func (m *ListObjectVersionsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListObjectVersionsResponse.Size(m)
+}
+func (m *ListObjectVersionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListObjectVersionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListObjectVersionsResponse proto.InternalMessageInfo
+
+func (m *ListObjectVersionsResponse) GetItems() []*ObjectVersion {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *ListObjectVersionsResponse) GetMore() bool {
+	if m != nil {
+		return m.More
+	}
+	return false
+}
+
+type ObjectVersion struct {
+	Version              int64     `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	DeleteMarker         bool      `protobuf:"varint,2,opt,name=delete_marker,json=deleteMarker,proto3" json:"delete_marker,omitempty"`
+	CreatedAt            time.Time `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3,stdtime" json:"created_at"`
+	PlainSize            int64     `protobuf:"varint,4,opt,name=plain_size,json=plainSize,proto3" json:"plain_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ObjectVersion) Reset()         { *m = ObjectVersion{} }
+func (m *ObjectVersion) String() string { return proto.CompactTextString(m) }
+func (*ObjectVersion) ProtoMessage()    {}
+func (*ObjectVersion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{6}
+}
+func (m *ObjectVersion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ObjectVersion.Unmarshal(m, b)
+}
+func (m *ObjectVersion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ObjectVersion.Marshal(b, m, deterministic)
+}
+func (m *ObjectVersion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ObjectVersion.Merge(m, src)
+}
+func (m *ObjectVersion) XXX_Size() int {
+	return xxx_messageInfo_ObjectVersion.Size(m)
+}
+func (m *ObjectVersion) XXX_DiscardUnknown() {
+	xxx_messageInfo_ObjectVersion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ObjectVersion proto.InternalMessageInfo
+
+func (m *ObjectVersion) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *ObjectVersion) GetDeleteMarker() bool {
+	if m != nil {
+		return m.DeleteMarker
+	}
+	return false
+}
+
+func (m *ObjectVersion) GetCreatedAt() time.Time {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return time.Time{}
+}
+
+func (m *ObjectVersion) GetPlainSize() int64 {
+	if m != nil {
+		return m.PlainSize
+	}
+	return 0
+}
+
+// ListPrefixVersionsRequest lists the committed versions and delete markers of
+// every object under a prefix, ordered by key and by version from oldest to
+// newest. Non-recursive listings collapse the keys containing a "/" after the
+// prefix into prefixes, including those with only delete markers.
+type ListPrefixVersionsRequest struct {
+	Header *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// encrypted_prefix is empty or ends with a "/".
+	EncryptedPrefix []byte `protobuf:"bytes,3,opt,name=encrypted_prefix,json=encryptedPrefix,proto3" json:"encrypted_prefix,omitempty"`
+	Recursive       bool   `protobuf:"varint,4,opt,name=recursive,proto3" json:"recursive,omitempty"`
+	// encrypted_cursor is relative to the prefix. The cursor is exclusive,
+	// the listing continues after cursor_version of the cursor key.
+	EncryptedCursor      []byte   `protobuf:"bytes,5,opt,name=encrypted_cursor,json=encryptedCursor,proto3" json:"encrypted_cursor,omitempty"`
+	CursorVersion        int64    `protobuf:"varint,6,opt,name=cursor_version,json=cursorVersion,proto3" json:"cursor_version,omitempty"`
+	Limit                int32    `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPrefixVersionsRequest) Reset()         { *m = ListPrefixVersionsRequest{} }
+func (m *ListPrefixVersionsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListPrefixVersionsRequest) ProtoMessage()    {}
+func (*ListPrefixVersionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{7}
+}
+func (m *ListPrefixVersionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPrefixVersionsRequest.Unmarshal(m, b)
+}
+func (m *ListPrefixVersionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPrefixVersionsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListPrefixVersionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPrefixVersionsRequest.Merge(m, src)
+}
+func (m *ListPrefixVersionsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListPrefixVersionsRequest.Size(m)
+}
+func (m *ListPrefixVersionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPrefixVersionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPrefixVersionsRequest proto.InternalMessageInfo
+
+func (m *ListPrefixVersionsRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *ListPrefixVersionsRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *ListPrefixVersionsRequest) GetEncryptedPrefix() []byte {
+	if m != nil {
+		return m.EncryptedPrefix
+	}
+	return nil
+}
+
+func (m *ListPrefixVersionsRequest) GetRecursive() bool {
+	if m != nil {
+		return m.Recursive
+	}
+	return false
+}
+
+func (m *ListPrefixVersionsRequest) GetEncryptedCursor() []byte {
+	if m != nil {
+		return m.EncryptedCursor
+	}
+	return nil
+}
+
+func (m *ListPrefixVersionsRequest) GetCursorVersion() int64 {
+	if m != nil {
+		return m.CursorVersion
+	}
+	return 0
+}
+
+func (m *ListPrefixVersionsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ListPrefixVersionsResponse struct {
+	Items                []*PrefixVersion `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	More                 bool             `protobuf:"varint,2,opt,name=more,proto3" json:"more,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ListPrefixVersionsResponse) Reset()         { *m = ListPrefixVersionsResponse{} }
+func (m *ListPrefixVersionsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListPrefixVersionsResponse) ProtoMessage()    {}
+func (*ListPrefixVersionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{8}
+}
+func (m *ListPrefixVersionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPrefixVersionsResponse.Unmarshal(m, b)
+}
+func (m *ListPrefixVersionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPrefixVersionsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListPrefixVersionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPrefixVersionsResponse.Merge(m, src)
+}
+func (m *ListPrefixVersionsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListPrefixVersionsResponse.Size(m)
+}
+func (m *ListPrefixVersionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPrefixVersionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPrefixVersionsResponse proto.InternalMessageInfo
+
+func (m *ListPrefixVersionsResponse) GetItems() []*PrefixVersion {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *ListPrefixVersionsResponse) GetMore() bool {
+	if m != nil {
+		return m.More
+	}
+	return false
+}
+
+type PrefixVersion struct {
+	// encrypted_object_key is relative to the prefix of the request.
+	EncryptedObjectKey   []byte         `protobuf:"bytes,1,opt,name=encrypted_object_key,json=encryptedObjectKey,proto3" json:"encrypted_object_key,omitempty"`
+	IsPrefix             bool           `protobuf:"varint,2,opt,name=is_prefix,json=isPrefix,proto3" json:"is_prefix,omitempty"`
+	Version              *ObjectVersion `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *PrefixVersion) Reset()         { *m = PrefixVersion{} }
+func (m *PrefixVersion) String() string { return proto.CompactTextString(m) }
+func (*PrefixVersion) ProtoMessage()    {}
+func (*PrefixVersion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{9}
+}
+func (m *PrefixVersion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrefixVersion.Unmarshal(m, b)
+}
+func (m *PrefixVersion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrefixVersion.Marshal(b, m, deterministic)
+}
+func (m *PrefixVersion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrefixVersion.Merge(m, src)
+}
+func (m *PrefixVersion) XXX_Size() int {
+	return xxx_messageInfo_PrefixVersion.Size(m)
+}
+func (m *PrefixVersion) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrefixVersion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrefixVersion proto.InternalMessageInfo
+
+func (m *PrefixVersion) GetEncryptedObjectKey() []byte {
+	if m != nil {
+		return m.EncryptedObjectKey
+	}
+	return nil
+}
+
+func (m *PrefixVersion) GetIsPrefix() bool {
+	if m != nil {
+		return m.IsPrefix
+	}
+	return false
+}
+
+func (m *PrefixVersion) GetVersion() *ObjectVersion {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
+// DownloadObjectVersionRequest is a metainfo download request for a specific
+// version of the object instead of the latest one.
+type DownloadObjectVersionRequest struct {
+	Download             *pb.ObjectDownloadRequest `protobuf:"bytes,1,opt,name=download,proto3" json:"download,omitempty"`
+	Version              int64                     `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *DownloadObjectVersionRequest) Reset()         { *m = DownloadObjectVersionRequest{} }
+func (m *DownloadObjectVersionRequest) String() string { return proto.CompactTextString(m) }
+func (*DownloadObjectVersionRequest) ProtoMessage()    {}
+func (*DownloadObjectVersionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{10}
+}
+func (m *DownloadObjectVersionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DownloadObjectVersionRequest.Unmarshal(m, b)
+}
+func (m *DownloadObjectVersionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DownloadObjectVersionRequest.Marshal(b, m, deterministic)
+}
+func (m *DownloadObjectVersionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DownloadObjectVersionRequest.Merge(m, src)
+}
+func (m *DownloadObjectVersionRequest) XXX_Size() int {
+	return xxx_messageInfo_DownloadObjectVersionRequest.Size(m)
+}
+func (m *DownloadObjectVersionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DownloadObjectVersionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DownloadObjectVersionRequest proto.InternalMessageInfo
+
+func (m *DownloadObjectVersionRequest) GetDownload() *pb.ObjectDownloadRequest {
+	if m != nil {
+		return m.Download
+	}
+	return nil
+}
+
+func (m *DownloadObjectVersionRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type DownloadObjectVersionResponse struct {
+	Download             *pb.ObjectDownloadResponse `protobuf:"bytes,1,opt,name=download,proto3" json:"download,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *DownloadObjectVersionResponse) Reset()         { *m = DownloadObjectVersionResponse{} }
+func (m *DownloadObjectVersionResponse) String() string { return proto.CompactTextString(m) }
+func (*DownloadObjectVersionResponse) ProtoMessage()    {}
+func (*DownloadObjectVersionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{11}
+}
+func (m *DownloadObjectVersionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DownloadObjectVersionResponse.Unmarshal(m, b)
+}
+func (m *DownloadObjectVersionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DownloadObjectVersionResponse.Marshal(b, m, deterministic)
+}
+func (m *DownloadObjectVersionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DownloadObjectVersionResponse.Merge(m, src)
+}
+func (m *DownloadObjectVersionResponse) XXX_Size() int {
+	return xxx_messageInfo_DownloadObjectVersionResponse.Size(m)
+}
+func (m *DownloadObjectVersionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DownloadObjectVersionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DownloadObjectVersionResponse proto.InternalMessageInfo
+
+func (m *DownloadObjectVersionResponse) GetDownload() *pb.ObjectDownloadResponse {
+	if m != nil {
+		return m.Download
+	}
+	return nil
+}
+
+type BeginMoveObjectRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket               []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	EncryptedObjectKey   []byte            `protobuf:"bytes,3,opt,name=encrypted_object_key,json=encryptedObjectKey,proto3" json:"encrypted_object_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *BeginMoveObjectRequest) Reset()         { *m = BeginMoveObjectRequest{} }
+func (m *BeginMoveObjectRequest) String() string { return proto.CompactTextString(m) }
+func (*BeginMoveObjectRequest) ProtoMessage()    {}
+func (*BeginMoveObjectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{12}
+}
+func (m *BeginMoveObjectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BeginMoveObjectRequest.Unmarshal(m, b)
+}
+func (m *BeginMoveObjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BeginMoveObjectRequest.Marshal(b, m, deterministic)
+}
+func (m *BeginMoveObjectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BeginMoveObjectRequest.Merge(m, src)
+}
+func (m *BeginMoveObjectRequest) XXX_Size() int {
+	return xxx_messageInfo_BeginMoveObjectRequest.Size(m)
+}
+func (m *BeginMoveObjectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BeginMoveObjectRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BeginMoveObjectRequest proto.InternalMessageInfo
+
+func (m *BeginMoveObjectRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *BeginMoveObjectRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *BeginMoveObjectRequest) GetEncryptedObjectKey() []byte {
+	if m != nil {
+		return m.EncryptedObjectKey
+	}
+	return nil
+}
+
+// BeginMoveObjectResponse contains the keys of the latest version of the
+// object. The keys are encrypted with keys derived from the object path, so
+// the client has to re-encrypt them for the new location.
+type BeginMoveObjectResponse struct {
+	StreamId                  []byte                   `protobuf:"bytes,1,opt,name=stream_id,json=streamId,proto3" json:"stream_id,omitempty"`
+	Version                   int64                    `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	EncryptedMetadataKeyNonce []byte                   `protobuf:"bytes,3,opt,name=encrypted_metadata_key_nonce,json=encryptedMetadataKeyNonce,proto3" json:"encrypted_metadata_key_nonce,omitempty"`
+	EncryptedMetadataKey      []byte                   `protobuf:"bytes,4,opt,name=encrypted_metadata_key,json=encryptedMetadataKey,proto3" json:"encrypted_metadata_key,omitempty"`
+	SegmentKeys               []*EncryptedKeyAndNonce  `protobuf:"bytes,5,rep,name=segment_keys,json=segmentKeys,proto3" json:"segment_keys,omitempty"`
+	EncryptionParameters      *pb.EncryptionParameters `protobuf:"bytes,6,opt,name=encryption_parameters,json=encryptionParameters,proto3" json:"encryption_parameters,omitempty"`
+	XXX_NoUnkeyedLiteral      struct{}                 `json:"-"`
+	XXX_unrecognized          []byte                   `json:"-"`
+	XXX_sizecache             int32                    `json:"-"`
+}
+
+func (m *BeginMoveObjectResponse) Reset()         { *m = BeginMoveObjectResponse{} }
+func (m *BeginMoveObjectResponse) String() string { return proto.CompactTextString(m) }
+func (*BeginMoveObjectResponse) ProtoMessage()    {}
+func (*BeginMoveObjectResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{13}
+}
+func (m *BeginMoveObjectResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BeginMoveObjectResponse.Unmarshal(m, b)
+}
+func (m *BeginMoveObjectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BeginMoveObjectResponse.Marshal(b, m, deterministic)
+}
+func (m *BeginMoveObjectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BeginMoveObjectResponse.Merge(m, src)
+}
+func (m *BeginMoveObjectResponse) XXX_Size() int {
+	return xxx_messageInfo_BeginMoveObjectResponse.Size(m)
+}
+func (m *BeginMoveObjectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BeginMoveObjectResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BeginMoveObjectResponse proto.InternalMessageInfo
+
+func (m *BeginMoveObjectResponse) GetStreamId() []byte {
+	if m != nil {
+		return m.StreamId
+	}
+	return nil
+}
+
+func (m *BeginMoveObjectResponse) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *BeginMoveObjectResponse) GetEncryptedMetadataKeyNonce() []byte {
+	if m != nil {
+		return m.EncryptedMetadataKeyNonce
+	}
+	return nil
+}
+
+func (m *BeginMoveObjectResponse) GetEncryptedMetadataKey() []byte {
+	if m != nil {
+		return m.EncryptedMetadataKey
+	}
+	return nil
+}
+
+func (m *BeginMoveObjectResponse) GetSegmentKeys() []*EncryptedKeyAndNonce {
+	if m != nil {
+		return m.SegmentKeys
+	}
+	return nil
+}
+
+func (m *BeginMoveObjectResponse) GetEncryptionParameters() *pb.EncryptionParameters {
+	if m != nil {
+		return m.EncryptionParameters
+	}
+	return nil
+}
+
+type EncryptedKeyAndNonce struct {
+	Position             *pb.SegmentPosition `protobuf:"bytes,1,opt,name=position,proto3" json:"position,omitempty"`
+	EncryptedKeyNonce    []byte              `protobuf:"bytes,2,opt,name=encrypted_key_nonce,json=encryptedKeyNonce,proto3" json:"encrypted_key_nonce,omitempty"`
+	EncryptedKey         []byte              `protobuf:"bytes,3,opt,name=encrypted_key,json=encryptedKey,proto3" json:"encrypted_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *EncryptedKeyAndNonce) Reset()         { *m = EncryptedKeyAndNonce{} }
+func (m *EncryptedKeyAndNonce) String() string { return proto.CompactTextString(m) }
+func (*EncryptedKeyAndNonce) ProtoMessage()    {}
+func (*EncryptedKeyAndNonce) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{14}
+}
+func (m *EncryptedKeyAndNonce) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EncryptedKeyAndNonce.Unmarshal(m, b)
+}
+func (m *EncryptedKeyAndNonce) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EncryptedKeyAndNonce.Marshal(b, m, deterministic)
+}
+func (m *EncryptedKeyAndNonce) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EncryptedKeyAndNonce.Merge(m, src)
+}
+func (m *EncryptedKeyAndNonce) XXX_Size() int {
+	return xxx_messageInfo_EncryptedKeyAndNonce.Size(m)
+}
+func (m *EncryptedKeyAndNonce) XXX_DiscardUnknown() {
+	xxx_messageInfo_EncryptedKeyAndNonce.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EncryptedKeyAndNonce proto.InternalMessageInfo
+
+func (m *EncryptedKeyAndNonce) GetPosition() *pb.SegmentPosition {
+	if m != nil {
+		return m.Position
+	}
+	return nil
+}
+
+func (m *EncryptedKeyAndNonce) GetEncryptedKeyNonce() []byte {
+	if m != nil {
+		return m.EncryptedKeyNonce
+	}
+	return nil
+}
+
+func (m *EncryptedKeyAndNonce) GetEncryptedKey() []byte {
+	if m != nil {
+		return m.EncryptedKey
+	}
+	return nil
+}
+
+type FinishMoveObjectRequest struct {
+	Header                       *pb.RequestHeader       `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket                       []byte                  `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	EncryptedObjectKey           []byte                  `protobuf:"bytes,3,opt,name=encrypted_object_key,json=encryptedObjectKey,proto3" json:"encrypted_object_key,omitempty"`
+	Version                      int64                   `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	StreamId                     []byte                  `protobuf:"bytes,5,opt,name=stream_id,json=streamId,proto3" json:"stream_id,omitempty"`
+	NewBucket                    []byte                  `protobuf:"bytes,6,opt,name=new_bucket,json=newBucket,proto3" json:"new_bucket,omitempty"`
+	NewEncryptedObjectKey        []byte                  `protobuf:"bytes,7,opt,name=new_encrypted_object_key,json=newEncryptedObjectKey,proto3" json:"new_encrypted_object_key,omitempty"`
+	NewEncryptedMetadataKeyNonce []byte                  `protobuf:"bytes,8,opt,name=new_encrypted_metadata_key_nonce,json=newEncryptedMetadataKeyNonce,proto3" json:"new_encrypted_metadata_key_nonce,omitempty"`
+	NewEncryptedMetadataKey      []byte                  `protobuf:"bytes,9,opt,name=new_encrypted_metadata_key,json=newEncryptedMetadataKey,proto3" json:"new_encrypted_metadata_key,omitempty"`
+	NewSegmentKeys               []*EncryptedKeyAndNonce `protobuf:"bytes,10,rep,name=new_segment_keys,json=newSegmentKeys,proto3" json:"new_segment_keys,omitempty"`
+	XXX_NoUnkeyedLiteral         struct{}                `json:"-"`
+	XXX_unrecognized             []byte                  `json:"-"`
+	XXX_sizecache                int32                   `json:"-"`
+}
+
+func (m *FinishMoveObjectRequest) Reset()         { *m = FinishMoveObjectRequest{} }
+func (m *FinishMoveObjectRequest) String() string { return proto.CompactTextString(m) }
+func (*FinishMoveObjectRequest) ProtoMessage()    {}
+func (*FinishMoveObjectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{15}
+}
+func (m *FinishMoveObjectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FinishMoveObjectRequest.Unmarshal(m, b)
+}
+func (m *FinishMoveObjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FinishMoveObjectRequest.Marshal(b, m, deterministic)
+}
+func (m *FinishMoveObjectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FinishMoveObjectRequest.Merge(m, src)
+}
+func (m *FinishMoveObjectRequest) XXX_Size() int {
+	return xxx_messageInfo_FinishMoveObjectRequest.Size(m)
+}
+func (m *FinishMoveObjectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FinishMoveObjectRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FinishMoveObjectRequest proto.InternalMessageInfo
+
+func (m *FinishMoveObjectRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *FinishMoveObjectRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *FinishMoveObjectRequest) GetEncryptedObjectKey() []byte {
+	if m != nil {
+		return m.EncryptedObjectKey
+	}
+	return nil
+}
+
+func (m *FinishMoveObjectRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *FinishMoveObjectRequest) GetStreamId() []byte {
+	if m != nil {
+		return m.StreamId
+	}
+	return nil
+}
+
+func (m *FinishMoveObjectRequest) GetNewBucket() []byte {
+	if m != nil {
+		return m.NewBucket
+	}
+	return nil
+}
+
+func (m *FinishMoveObjectRequest) GetNewEncryptedObjectKey() []byte {
+	if m != nil {
+		return m.NewEncryptedObjectKey
+	}
+	return nil
+}
+
+func (m *FinishMoveObjectRequest) GetNewEncryptedMetadataKeyNonce() []byte {
+	if m != nil {
+		return m.NewEncryptedMetadataKeyNonce
+	}
+	return nil
+}
+
+func (m *FinishMoveObjectRequest) GetNewEncryptedMetadataKey() []byte {
+	if m != nil {
+		return m.NewEncryptedMetadataKey
+	}
+	return nil
+}
+
+func (m *FinishMoveObjectRequest) GetNewSegmentKeys() []*EncryptedKeyAndNonce {
+	if m != nil {
+		return m.NewSegmentKeys
+	}
+	return nil
+}
+
+type FinishMoveObjectResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FinishMoveObjectResponse) Reset()         { *m = FinishMoveObjectResponse{} }
+func (m *FinishMoveObjectResponse) String() string { return proto.CompactTextString(m) }
+func (*FinishMoveObjectResponse) ProtoMessage()    {}
+func (*FinishMoveObjectResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{16}
+}
+func (m *FinishMoveObjectResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FinishMoveObjectResponse.Unmarshal(m, b)
+}
+func (m *FinishMoveObjectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FinishMoveObjectResponse.Marshal(b, m, deterministic)
+}
+func (m *FinishMoveObjectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FinishMoveObjectResponse.Merge(m, src)
+}
+func (m *FinishMoveObjectResponse) XXX_Size() int {
+	return xxx_messageInfo_FinishMoveObjectResponse.Size(m)
+}
+func (m *FinishMoveObjectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FinishMoveObjectResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FinishMoveObjectResponse proto.InternalMessageInfo
+
+type BeginCopyObjectRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket               []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	EncryptedObjectKey   []byte            `protobuf:"bytes,3,opt,name=encrypted_object_key,json=encryptedObjectKey,proto3" json:"encrypted_object_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *BeginCopyObjectRequest) Reset()         { *m = BeginCopyObjectRequest{} }
+func (m *BeginCopyObjectRequest) String() string { return proto.CompactTextString(m) }
+func (*BeginCopyObjectRequest) ProtoMessage()    {}
+func (*BeginCopyObjectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{17}
+}
+func (m *BeginCopyObjectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BeginCopyObjectRequest.Unmarshal(m, b)
+}
+func (m *BeginCopyObjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BeginCopyObjectRequest.Marshal(b, m, deterministic)
+}
+func (m *BeginCopyObjectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BeginCopyObjectRequest.Merge(m, src)
+}
+func (m *BeginCopyObjectRequest) XXX_Size() int {
+	return xxx_messageInfo_BeginCopyObjectRequest.Size(m)
+}
+func (m *BeginCopyObjectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BeginCopyObjectRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BeginCopyObjectRequest proto.InternalMessageInfo
+
+func (m *BeginCopyObjectRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *BeginCopyObjectRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *BeginCopyObjectRequest) GetEncryptedObjectKey() []byte {
+	if m != nil {
+		return m.EncryptedObjectKey
+	}
+	return nil
+}
+
+// BeginCopyObjectResponse contains the keys of the latest version of the
+// object, which the client has to re-encrypt for the location of the copy.
+type BeginCopyObjectResponse struct {
+	StreamId                  []byte                   `protobuf:"bytes,1,opt,name=stream_id,json=streamId,proto3" json:"stream_id,omitempty"`
+	Version                   int64                    `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	EncryptedMetadataKeyNonce []byte                   `protobuf:"bytes,3,opt,name=encrypted_metadata_key_nonce,json=encryptedMetadataKeyNonce,proto3" json:"encrypted_metadata_key_nonce,omitempty"`
+	EncryptedMetadataKey      []byte                   `protobuf:"bytes,4,opt,name=encrypted_metadata_key,json=encryptedMetadataKey,proto3" json:"encrypted_metadata_key,omitempty"`
+	SegmentKeys               []*EncryptedKeyAndNonce  `protobuf:"bytes,5,rep,name=segment_keys,json=segmentKeys,proto3" json:"segment_keys,omitempty"`
+	EncryptionParameters      *pb.EncryptionParameters `protobuf:"bytes,6,opt,name=encryption_parameters,json=encryptionParameters,proto3" json:"encryption_parameters,omitempty"`
+	XXX_NoUnkeyedLiteral      struct{}                 `json:"-"`
+	XXX_unrecognized          []byte                   `json:"-"`
+	XXX_sizecache             int32                    `json:"-"`
+}
+
+func (m *BeginCopyObjectResponse) Reset()         { *m = BeginCopyObjectResponse{} }
+func (m *BeginCopyObjectResponse) String() string { return proto.CompactTextString(m) }
+func (*BeginCopyObjectResponse) ProtoMessage()    {}
+func (*BeginCopyObjectResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{18}
+}
+func (m *BeginCopyObjectResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BeginCopyObjectResponse.Unmarshal(m, b)
+}
+func (m *BeginCopyObjectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BeginCopyObjectResponse.Marshal(b, m, deterministic)
+}
+func (m *BeginCopyObjectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BeginCopyObjectResponse.Merge(m, src)
+}
+func (m *BeginCopyObjectResponse) XXX_Size() int {
+	return xxx_messageInfo_BeginCopyObjectResponse.Size(m)
+}
+func (m *BeginCopyObjectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BeginCopyObjectResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BeginCopyObjectResponse proto.InternalMessageInfo
+
+func (m *BeginCopyObjectResponse) GetStreamId() []byte {
+	if m != nil {
+		return m.StreamId
+	}
+	return nil
+}
+
+func (m *BeginCopyObjectResponse) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *BeginCopyObjectResponse) GetEncryptedMetadataKeyNonce() []byte {
+	if m != nil {
+		return m.EncryptedMetadataKeyNonce
+	}
+	return nil
+}
+
+func (m *BeginCopyObjectResponse) GetEncryptedMetadataKey() []byte {
+	if m != nil {
+		return m.EncryptedMetadataKey
+	}
+	return nil
+}
+
+func (m *BeginCopyObjectResponse) GetSegmentKeys() []*EncryptedKeyAndNonce {
+	if m != nil {
+		return m.SegmentKeys
+	}
+	return nil
+}
+
+func (m *BeginCopyObjectResponse) GetEncryptionParameters() *pb.EncryptionParameters {
+	if m != nil {
+		return m.EncryptionParameters
+	}
+	return nil
+}
+
+type FinishCopyObjectRequest struct {
+	Header                       *pb.RequestHeader       `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket                       []byte                  `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	EncryptedObjectKey           []byte                  `protobuf:"bytes,3,opt,name=encrypted_object_key,json=encryptedObjectKey,proto3" json:"encrypted_object_key,omitempty"`
+	Version                      int64                   `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	StreamId                     []byte                  `protobuf:"bytes,5,opt,name=stream_id,json=streamId,proto3" json:"stream_id,omitempty"`
+	NewBucket                    []byte                  `protobuf:"bytes,6,opt,name=new_bucket,json=newBucket,proto3" json:"new_bucket,omitempty"`
+	NewEncryptedObjectKey        []byte                  `protobuf:"bytes,7,opt,name=new_encrypted_object_key,json=newEncryptedObjectKey,proto3" json:"new_encrypted_object_key,omitempty"`
+	NewEncryptedMetadataKeyNonce []byte                  `protobuf:"bytes,8,opt,name=new_encrypted_metadata_key_nonce,json=newEncryptedMetadataKeyNonce,proto3" json:"new_encrypted_metadata_key_nonce,omitempty"`
+	NewEncryptedMetadataKey      []byte                  `protobuf:"bytes,9,opt,name=new_encrypted_metadata_key,json=newEncryptedMetadataKey,proto3" json:"new_encrypted_metadata_key,omitempty"`
+	NewSegmentKeys               []*EncryptedKeyAndNonce `protobuf:"bytes,10,rep,name=new_segment_keys,json=newSegmentKeys,proto3" json:"new_segment_keys,omitempty"`
+	XXX_NoUnkeyedLiteral         struct{}                `json:"-"`
+	XXX_unrecognized             []byte                  `json:"-"`
+	XXX_sizecache                int32                   `json:"-"`
+}
+
+func (m *FinishCopyObjectRequest) Reset()         { *m = FinishCopyObjectRequest{} }
+func (m *FinishCopyObjectRequest) String() string { return proto.CompactTextString(m) }
+func (*FinishCopyObjectRequest) ProtoMessage()    {}
+func (*FinishCopyObjectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{19}
+}
+func (m *FinishCopyObjectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FinishCopyObjectRequest.Unmarshal(m, b)
+}
+func (m *FinishCopyObjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FinishCopyObjectRequest.Marshal(b, m, deterministic)
+}
+func (m *FinishCopyObjectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FinishCopyObjectRequest.Merge(m, src)
+}
+func (m *FinishCopyObjectRequest) XXX_Size() int {
+	return xxx_messageInfo_FinishCopyObjectRequest.Size(m)
+}
+func (m *FinishCopyObjectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FinishCopyObjectRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FinishCopyObjectRequest proto.InternalMessageInfo
+
+func (m *FinishCopyObjectRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *FinishCopyObjectRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *FinishCopyObjectRequest) GetEncryptedObjectKey() []byte {
+	if m != nil {
+		return m.EncryptedObjectKey
+	}
+	return nil
+}
+
+func (m *FinishCopyObjectRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *FinishCopyObjectRequest) GetStreamId() []byte {
+	if m != nil {
+		return m.StreamId
+	}
+	return nil
+}
+
+func (m *FinishCopyObjectRequest) GetNewBucket() []byte {
+	if m != nil {
+		return m.NewBucket
+	}
+	return nil
+}
+
+func (m *FinishCopyObjectRequest) GetNewEncryptedObjectKey() []byte {
+	if m != nil {
+		return m.NewEncryptedObjectKey
+	}
+	return nil
+}
+
+func (m *FinishCopyObjectRequest) GetNewEncryptedMetadataKeyNonce() []byte {
+	if m != nil {
+		return m.NewEncryptedMetadataKeyNonce
+	}
+	return nil
+}
+
+func (m *FinishCopyObjectRequest) GetNewEncryptedMetadataKey() []byte {
+	if m != nil {
+		return m.NewEncryptedMetadataKey
+	}
+	return nil
+}
+
+func (m *FinishCopyObjectRequest) GetNewSegmentKeys() []*EncryptedKeyAndNonce {
+	if m != nil {
+		return m.NewSegmentKeys
+	}
+	return nil
+}
+
+type FinishCopyObjectResponse struct {
+	Version              int64    `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FinishCopyObjectResponse) Reset()         { *m = FinishCopyObjectResponse{} }
+func (m *FinishCopyObjectResponse) String() string { return proto.CompactTextString(m) }
+func (*FinishCopyObjectResponse) ProtoMessage()    {}
+func (*FinishCopyObjectResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{20}
+}
+func (m *FinishCopyObjectResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FinishCopyObjectResponse.Unmarshal(m, b)
+}
+func (m *FinishCopyObjectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FinishCopyObjectResponse.Marshal(b, m, deterministic)
+}
+func (m *FinishCopyObjectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FinishCopyObjectResponse.Merge(m, src)
+}
+func (m *FinishCopyObjectResponse) XXX_Size() int {
+	return xxx_messageInfo_FinishCopyObjectResponse.Size(m)
+}
+func (m *FinishCopyObjectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FinishCopyObjectResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FinishCopyObjectResponse proto.InternalMessageInfo
+
+func (m *FinishCopyObjectResponse) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+// RestoreObjectVersionRequest makes a committed version of an object its
+// latest version. The restored version shares the pieces of the original one.
+type RestoreObjectVersionRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket               []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	EncryptedObjectKey   []byte            `protobuf:"bytes,3,opt,name=encrypted_object_key,json=encryptedObjectKey,proto3" json:"encrypted_object_key,omitempty"`
+	Version              int64             `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *RestoreObjectVersionRequest) Reset()         { *m = RestoreObjectVersionRequest{} }
+func (m *RestoreObjectVersionRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreObjectVersionRequest) ProtoMessage()    {}
+func (*RestoreObjectVersionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{21}
+}
+func (m *RestoreObjectVersionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreObjectVersionRequest.Unmarshal(m, b)
+}
+func (m *RestoreObjectVersionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreObjectVersionRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreObjectVersionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreObjectVersionRequest.Merge(m, src)
+}
+func (m *RestoreObjectVersionRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreObjectVersionRequest.Size(m)
+}
+func (m *RestoreObjectVersionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreObjectVersionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreObjectVersionRequest proto.InternalMessageInfo
+
+func (m *RestoreObjectVersionRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *RestoreObjectVersionRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *RestoreObjectVersionRequest) GetEncryptedObjectKey() []byte {
+	if m != nil {
+		return m.EncryptedObjectKey
+	}
+	return nil
+}
+
+func (m *RestoreObjectVersionRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type RestoreObjectVersionResponse struct {
+	Version              int64    `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreObjectVersionResponse) Reset()         { *m = RestoreObjectVersionResponse{} }
+func (m *RestoreObjectVersionResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreObjectVersionResponse) ProtoMessage()    {}
+func (*RestoreObjectVersionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{22}
+}
+func (m *RestoreObjectVersionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreObjectVersionResponse.Unmarshal(m, b)
+}
+func (m *RestoreObjectVersionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreObjectVersionResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreObjectVersionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreObjectVersionResponse.Merge(m, src)
+}
+func (m *RestoreObjectVersionResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreObjectVersionResponse.Size(m)
+}
+func (m *RestoreObjectVersionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreObjectVersionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreObjectVersionResponse proto.InternalMessageInfo
+
+func (m *RestoreObjectVersionResponse) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+// SetBucketTagsRequest replaces the tags of a bucket, empty tags remove all
+// of them. Unlike the object metadata, tags are not encrypted.
+type SetBucketTagsRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket               []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Tags                 map[string]string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SetBucketTagsRequest) Reset()         { *m = SetBucketTagsRequest{} }
+func (m *SetBucketTagsRequest) String() string { return proto.CompactTextString(m) }
+func (*SetBucketTagsRequest) ProtoMessage()    {}
+func (*SetBucketTagsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{23}
+}
+func (m *SetBucketTagsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetBucketTagsRequest.Unmarshal(m, b)
+}
+func (m *SetBucketTagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetBucketTagsRequest.Marshal(b, m, deterministic)
+}
+func (m *SetBucketTagsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetBucketTagsRequest.Merge(m, src)
+}
+func (m *SetBucketTagsRequest) XXX_Size() int {
+	return xxx_messageInfo_SetBucketTagsRequest.Size(m)
+}
+func (m *SetBucketTagsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetBucketTagsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetBucketTagsRequest proto.InternalMessageInfo
+
+func (m *SetBucketTagsRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *SetBucketTagsRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *SetBucketTagsRequest) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type SetBucketTagsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetBucketTagsResponse) Reset()         { *m = SetBucketTagsResponse{} }
+func (m *SetBucketTagsResponse) String() string { return proto.CompactTextString(m) }
+func (*SetBucketTagsResponse) ProtoMessage()    {}
+func (*SetBucketTagsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{24}
+}
+func (m *SetBucketTagsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetBucketTagsResponse.Unmarshal(m, b)
+}
+func (m *SetBucketTagsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetBucketTagsResponse.Marshal(b, m, deterministic)
+}
+func (m *SetBucketTagsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetBucketTagsResponse.Merge(m, src)
+}
+func (m *SetBucketTagsResponse) XXX_Size() int {
+	return xxx_messageInfo_SetBucketTagsResponse.Size(m)
+}
+func (m *SetBucketTagsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetBucketTagsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetBucketTagsResponse proto.InternalMessageInfo
+
+type GetBucketTagsRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket               []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetBucketTagsRequest) Reset()         { *m = GetBucketTagsRequest{} }
+func (m *GetBucketTagsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBucketTagsRequest) ProtoMessage()    {}
+func (*GetBucketTagsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{25}
+}
+func (m *GetBucketTagsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBucketTagsRequest.Unmarshal(m, b)
+}
+func (m *GetBucketTagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBucketTagsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBucketTagsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBucketTagsRequest.Merge(m, src)
+}
+func (m *GetBucketTagsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBucketTagsRequest.Size(m)
+}
+func (m *GetBucketTagsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBucketTagsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBucketTagsRequest proto.InternalMessageInfo
+
+func (m *GetBucketTagsRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetBucketTagsRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+type GetBucketTagsResponse struct {
+	Tags                 map[string]string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetBucketTagsResponse) Reset()         { *m = GetBucketTagsResponse{} }
+func (m *GetBucketTagsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBucketTagsResponse) ProtoMessage()    {}
+func (*GetBucketTagsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{26}
+}
+func (m *GetBucketTagsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBucketTagsResponse.Unmarshal(m, b)
+}
+func (m *GetBucketTagsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBucketTagsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBucketTagsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBucketTagsResponse.Merge(m, src)
+}
+func (m *GetBucketTagsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBucketTagsResponse.Size(m)
+}
+func (m *GetBucketTagsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBucketTagsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBucketTagsResponse proto.InternalMessageInfo
+
+func (m *GetBucketTagsResponse) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+// SetObjectTagsRequest replaces the tags of an object version, empty tags
+// remove all of them. The latest version is used when version is zero.
+type SetObjectTagsRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket               []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	EncryptedObjectKey   []byte            `protobuf:"bytes,3,opt,name=encrypted_object_key,json=encryptedObjectKey,proto3" json:"encrypted_object_key,omitempty"`
+	Version              int64             `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	Tags                 map[string]string `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SetObjectTagsRequest) Reset()         { *m = SetObjectTagsRequest{} }
+func (m *SetObjectTagsRequest) String() string { return proto.CompactTextString(m) }
+func (*SetObjectTagsRequest) ProtoMessage()    {}
+func (*SetObjectTagsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{27}
+}
+func (m *SetObjectTagsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetObjectTagsRequest.Unmarshal(m, b)
+}
+func (m *SetObjectTagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetObjectTagsRequest.Marshal(b, m, deterministic)
+}
+func (m *SetObjectTagsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetObjectTagsRequest.Merge(m, src)
+}
+func (m *SetObjectTagsRequest) XXX_Size() int {
+	return xxx_messageInfo_SetObjectTagsRequest.Size(m)
+}
+func (m *SetObjectTagsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetObjectTagsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetObjectTagsRequest proto.InternalMessageInfo
+
+func (m *SetObjectTagsRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *SetObjectTagsRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *SetObjectTagsRequest) GetEncryptedObjectKey() []byte {
+	if m != nil {
+		return m.EncryptedObjectKey
+	}
+	return nil
+}
+
+func (m *SetObjectTagsRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *SetObjectTagsRequest) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type SetObjectTagsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetObjectTagsResponse) Reset()         { *m = SetObjectTagsResponse{} }
+func (m *SetObjectTagsResponse) String() string { return proto.CompactTextString(m) }
+func (*SetObjectTagsResponse) ProtoMessage()    {}
+func (*SetObjectTagsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{28}
+}
+func (m *SetObjectTagsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetObjectTagsResponse.Unmarshal(m, b)
+}
+func (m *SetObjectTagsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetObjectTagsResponse.Marshal(b, m, deterministic)
+}
+func (m *SetObjectTagsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetObjectTagsResponse.Merge(m, src)
+}
+func (m *SetObjectTagsResponse) XXX_Size() int {
+	return xxx_messageInfo_SetObjectTagsResponse.Size(m)
+}
+func (m *SetObjectTagsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetObjectTagsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetObjectTagsResponse proto.InternalMessageInfo
+
+type GetObjectTagsRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket               []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	EncryptedObjectKey   []byte            `protobuf:"bytes,3,opt,name=encrypted_object_key,json=encryptedObjectKey,proto3" json:"encrypted_object_key,omitempty"`
+	Version              int64             `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetObjectTagsRequest) Reset()         { *m = GetObjectTagsRequest{} }
+func (m *GetObjectTagsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetObjectTagsRequest) ProtoMessage()    {}
+func (*GetObjectTagsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{29}
+}
+func (m *GetObjectTagsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetObjectTagsRequest.Unmarshal(m, b)
+}
+func (m *GetObjectTagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetObjectTagsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetObjectTagsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetObjectTagsRequest.Merge(m, src)
+}
+func (m *GetObjectTagsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetObjectTagsRequest.Size(m)
+}
+func (m *GetObjectTagsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetObjectTagsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetObjectTagsRequest proto.InternalMessageInfo
+
+func (m *GetObjectTagsRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetObjectTagsRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *GetObjectTagsRequest) GetEncryptedObjectKey() []byte {
+	if m != nil {
+		return m.EncryptedObjectKey
+	}
+	return nil
+}
+
+func (m *GetObjectTagsRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type GetObjectTagsResponse struct {
+	Tags                 map[string]string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetObjectTagsResponse) Reset()         { *m = GetObjectTagsResponse{} }
+func (m *GetObjectTagsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetObjectTagsResponse) ProtoMessage()    {}
+func (*GetObjectTagsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{30}
+}
+func (m *GetObjectTagsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetObjectTagsResponse.Unmarshal(m, b)
+}
+func (m *GetObjectTagsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetObjectTagsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetObjectTagsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetObjectTagsResponse.Merge(m, src)
+}
+func (m *GetObjectTagsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetObjectTagsResponse.Size(m)
+}
+func (m *GetObjectTagsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetObjectTagsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetObjectTagsResponse proto.InternalMessageInfo
+
+func (m *GetObjectTagsResponse) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+// ListTaggedObjectsRequest is a metainfo list request for the objects that
+// have all of the tags.
+type ListTaggedObjectsRequest struct {
+	List                 *pb.ObjectListRequest `protobuf:"bytes,1,opt,name=list,proto3" json:"list,omitempty"`
+	Tags                 map[string]string     `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ListTaggedObjectsRequest) Reset()         { *m = ListTaggedObjectsRequest{} }
+func (m *ListTaggedObjectsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListTaggedObjectsRequest) ProtoMessage()    {}
+func (*ListTaggedObjectsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{31}
+}
+func (m *ListTaggedObjectsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTaggedObjectsRequest.Unmarshal(m, b)
+}
+func (m *ListTaggedObjectsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTaggedObjectsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListTaggedObjectsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTaggedObjectsRequest.Merge(m, src)
+}
+func (m *ListTaggedObjectsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListTaggedObjectsRequest.Size(m)
+}
+func (m *ListTaggedObjectsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTaggedObjectsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTaggedObjectsRequest proto.InternalMessageInfo
+
+func (m *ListTaggedObjectsRequest) GetList() *pb.ObjectListRequest {
+	if m != nil {
+		return m.List
+	}
+	return nil
+}
+
+func (m *ListTaggedObjectsRequest) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type ListTaggedObjectsResponse struct {
+	List                 *pb.ObjectListResponse `protobuf:"bytes,1,opt,name=list,proto3" json:"list,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *ListTaggedObjectsResponse) Reset()         { *m = ListTaggedObjectsResponse{} }
+func (m *ListTaggedObjectsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListTaggedObjectsResponse) ProtoMessage()    {}
+func (*ListTaggedObjectsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{32}
+}
+func (m *ListTaggedObjectsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTaggedObjectsResponse.Unmarshal(m, b)
+}
+func (m *ListTaggedObjectsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTaggedObjectsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListTaggedObjectsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTaggedObjectsResponse.Merge(m, src)
+}
+func (m *ListTaggedObjectsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListTaggedObjectsResponse.Size(m)
+}
+func (m *ListTaggedObjectsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTaggedObjectsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTaggedObjectsResponse proto.InternalMessageInfo
+
+func (m *ListTaggedObjectsResponse) GetList() *pb.ObjectListResponse {
+	if m != nil {
+		return m.List
+	}
+	return nil
+}
+
+type LifecycleRule struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// encrypted_prefix is matched against the encrypted object keys, a prefix
+	// ending with a "/" matches all objects under the same encrypted prefix.
+	EncryptedPrefix      []byte          `protobuf:"bytes,2,opt,name=encrypted_prefix,json=encryptedPrefix,proto3" json:"encrypted_prefix,omitempty"`
+	Action               LifecycleAction `protobuf:"varint,3,opt,name=action,proto3,enum=metainfoext.LifecycleAction" json:"action,omitempty"`
+	AgeDays              int32           `protobuf:"varint,4,opt,name=age_days,json=ageDays,proto3" json:"age_days,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *LifecycleRule) Reset()         { *m = LifecycleRule{} }
+func (m *LifecycleRule) String() string { return proto.CompactTextString(m) }
+func (*LifecycleRule) ProtoMessage()    {}
+func (*LifecycleRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{33}
+}
+func (m *LifecycleRule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LifecycleRule.Unmarshal(m, b)
+}
+func (m *LifecycleRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LifecycleRule.Marshal(b, m, deterministic)
+}
+func (m *LifecycleRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LifecycleRule.Merge(m, src)
+}
+func (m *LifecycleRule) XXX_Size() int {
+	return xxx_messageInfo_LifecycleRule.Size(m)
+}
+func (m *LifecycleRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_LifecycleRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LifecycleRule proto.InternalMessageInfo
+
+func (m *LifecycleRule) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LifecycleRule) GetEncryptedPrefix() []byte {
+	if m != nil {
+		return m.EncryptedPrefix
+	}
+	return nil
+}
+
+func (m *LifecycleRule) GetAction() LifecycleAction {
+	if m != nil {
+		return m.Action
+	}
+	return LifecycleAction_INVALID
+}
+
+func (m *LifecycleRule) GetAgeDays() int32 {
+	if m != nil {
+		return m.AgeDays
+	}
+	return 0
+}
+
+type SetBucketLifecycleRuleRequest struct {
+	Header *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// rule replaces the rule of the bucket with the same name.
+	Rule                 *LifecycleRule `protobuf:"bytes,3,opt,name=rule,proto3" json:"rule,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *SetBucketLifecycleRuleRequest) Reset()         { *m = SetBucketLifecycleRuleRequest{} }
+func (m *SetBucketLifecycleRuleRequest) String() string { return proto.CompactTextString(m) }
+func (*SetBucketLifecycleRuleRequest) ProtoMessage()    {}
+func (*SetBucketLifecycleRuleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{34}
+}
+func (m *SetBucketLifecycleRuleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetBucketLifecycleRuleRequest.Unmarshal(m, b)
+}
+func (m *SetBucketLifecycleRuleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetBucketLifecycleRuleRequest.Marshal(b, m, deterministic)
+}
+func (m *SetBucketLifecycleRuleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetBucketLifecycleRuleRequest.Merge(m, src)
+}
+func (m *SetBucketLifecycleRuleRequest) XXX_Size() int {
+	return xxx_messageInfo_SetBucketLifecycleRuleRequest.Size(m)
+}
+func (m *SetBucketLifecycleRuleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetBucketLifecycleRuleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetBucketLifecycleRuleRequest proto.InternalMessageInfo
+
+func (m *SetBucketLifecycleRuleRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *SetBucketLifecycleRuleRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *SetBucketLifecycleRuleRequest) GetRule() *LifecycleRule {
+	if m != nil {
+		return m.Rule
+	}
+	return nil
+}
+
+type SetBucketLifecycleRuleResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetBucketLifecycleRuleResponse) Reset()         { *m = SetBucketLifecycleRuleResponse{} }
+func (m *SetBucketLifecycleRuleResponse) String() string { return proto.CompactTextString(m) }
+func (*SetBucketLifecycleRuleResponse) ProtoMessage()    {}
+func (*SetBucketLifecycleRuleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{35}
+}
+func (m *SetBucketLifecycleRuleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetBucketLifecycleRuleResponse.Unmarshal(m, b)
+}
+func (m *SetBucketLifecycleRuleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetBucketLifecycleRuleResponse.Marshal(b, m, deterministic)
+}
+func (m *SetBucketLifecycleRuleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetBucketLifecycleRuleResponse.Merge(m, src)
+}
+func (m *SetBucketLifecycleRuleResponse) XXX_Size() int {
+	return xxx_messageInfo_SetBucketLifecycleRuleResponse.Size(m)
+}
+func (m *SetBucketLifecycleRuleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetBucketLifecycleRuleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetBucketLifecycleRuleResponse proto.InternalMessageInfo
+
+type DeleteBucketLifecycleRulesRequest struct {
+	Header *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// name of the rule to delete, all rules of the bucket are deleted when empty.
+	Name                 string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteBucketLifecycleRulesRequest) Reset()         { *m = DeleteBucketLifecycleRulesRequest{} }
+func (m *DeleteBucketLifecycleRulesRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteBucketLifecycleRulesRequest) ProtoMessage()    {}
+func (*DeleteBucketLifecycleRulesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{36}
+}
+func (m *DeleteBucketLifecycleRulesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteBucketLifecycleRulesRequest.Unmarshal(m, b)
+}
+func (m *DeleteBucketLifecycleRulesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteBucketLifecycleRulesRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteBucketLifecycleRulesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteBucketLifecycleRulesRequest.Merge(m, src)
+}
+func (m *DeleteBucketLifecycleRulesRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteBucketLifecycleRulesRequest.Size(m)
+}
+func (m *DeleteBucketLifecycleRulesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteBucketLifecycleRulesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteBucketLifecycleRulesRequest proto.InternalMessageInfo
+
+func (m *DeleteBucketLifecycleRulesRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *DeleteBucketLifecycleRulesRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *DeleteBucketLifecycleRulesRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type DeleteBucketLifecycleRulesResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteBucketLifecycleRulesResponse) Reset()         { *m = DeleteBucketLifecycleRulesResponse{} }
+func (m *DeleteBucketLifecycleRulesResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteBucketLifecycleRulesResponse) ProtoMessage()    {}
+func (*DeleteBucketLifecycleRulesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{37}
+}
+func (m *DeleteBucketLifecycleRulesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteBucketLifecycleRulesResponse.Unmarshal(m, b)
+}
+func (m *DeleteBucketLifecycleRulesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteBucketLifecycleRulesResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteBucketLifecycleRulesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteBucketLifecycleRulesResponse.Merge(m, src)
+}
+func (m *DeleteBucketLifecycleRulesResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteBucketLifecycleRulesResponse.Size(m)
+}
+func (m *DeleteBucketLifecycleRulesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteBucketLifecycleRulesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteBucketLifecycleRulesResponse proto.InternalMessageInfo
+
+type ListBucketLifecycleRulesRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket               []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ListBucketLifecycleRulesRequest) Reset()         { *m = ListBucketLifecycleRulesRequest{} }
+func (m *ListBucketLifecycleRulesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListBucketLifecycleRulesRequest) ProtoMessage()    {}
+func (*ListBucketLifecycleRulesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{38}
+}
+func (m *ListBucketLifecycleRulesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBucketLifecycleRulesRequest.Unmarshal(m, b)
+}
+func (m *ListBucketLifecycleRulesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBucketLifecycleRulesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListBucketLifecycleRulesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBucketLifecycleRulesRequest.Merge(m, src)
+}
+func (m *ListBucketLifecycleRulesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListBucketLifecycleRulesRequest.Size(m)
+}
+func (m *ListBucketLifecycleRulesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBucketLifecycleRulesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListBucketLifecycleRulesRequest proto.InternalMessageInfo
+
+func (m *ListBucketLifecycleRulesRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *ListBucketLifecycleRulesRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+type ListBucketLifecycleRulesResponse struct {
+	Rules                []*LifecycleRule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ListBucketLifecycleRulesResponse) Reset()         { *m = ListBucketLifecycleRulesResponse{} }
+func (m *ListBucketLifecycleRulesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListBucketLifecycleRulesResponse) ProtoMessage()    {}
+func (*ListBucketLifecycleRulesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{39}
+}
+func (m *ListBucketLifecycleRulesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBucketLifecycleRulesResponse.Unmarshal(m, b)
+}
+func (m *ListBucketLifecycleRulesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBucketLifecycleRulesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListBucketLifecycleRulesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBucketLifecycleRulesResponse.Merge(m, src)
+}
+func (m *ListBucketLifecycleRulesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListBucketLifecycleRulesResponse.Size(m)
+}
+func (m *ListBucketLifecycleRulesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBucketLifecycleRulesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListBucketLifecycleRulesResponse proto.InternalMessageInfo
+
+func (m *ListBucketLifecycleRulesResponse) GetRules() []*LifecycleRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+// SetBucketRetentionRequest sets the number of days that newly committed
+// objects of a bucket can't be deleted or overwritten. Zero days disable the
+// default retention.
+type SetBucketRetentionRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket               []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Days                 int32             `protobuf:"varint,3,opt,name=days,proto3" json:"days,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SetBucketRetentionRequest) Reset()         { *m = SetBucketRetentionRequest{} }
+func (m *SetBucketRetentionRequest) String() string { return proto.CompactTextString(m) }
+func (*SetBucketRetentionRequest) ProtoMessage()    {}
+func (*SetBucketRetentionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{40}
+}
+func (m *SetBucketRetentionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetBucketRetentionRequest.Unmarshal(m, b)
+}
+func (m *SetBucketRetentionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetBucketRetentionRequest.Marshal(b, m, deterministic)
+}
+func (m *SetBucketRetentionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetBucketRetentionRequest.Merge(m, src)
+}
+func (m *SetBucketRetentionRequest) XXX_Size() int {
+	return xxx_messageInfo_SetBucketRetentionRequest.Size(m)
+}
+func (m *SetBucketRetentionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetBucketRetentionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetBucketRetentionRequest proto.InternalMessageInfo
+
+func (m *SetBucketRetentionRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *SetBucketRetentionRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *SetBucketRetentionRequest) GetDays() int32 {
+	if m != nil {
+		return m.Days
+	}
+	return 0
+}
+
+type SetBucketRetentionResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetBucketRetentionResponse) Reset()         { *m = SetBucketRetentionResponse{} }
+func (m *SetBucketRetentionResponse) String() string { return proto.CompactTextString(m) }
+func (*SetBucketRetentionResponse) ProtoMessage()    {}
+func (*SetBucketRetentionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{41}
+}
+func (m *SetBucketRetentionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetBucketRetentionResponse.Unmarshal(m, b)
+}
+func (m *SetBucketRetentionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetBucketRetentionResponse.Marshal(b, m, deterministic)
+}
+func (m *SetBucketRetentionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetBucketRetentionResponse.Merge(m, src)
+}
+func (m *SetBucketRetentionResponse) XXX_Size() int {
+	return xxx_messageInfo_SetBucketRetentionResponse.Size(m)
+}
+func (m *SetBucketRetentionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetBucketRetentionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetBucketRetentionResponse proto.InternalMessageInfo
+
+type GetBucketRetentionRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket               []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetBucketRetentionRequest) Reset()         { *m = GetBucketRetentionRequest{} }
+func (m *GetBucketRetentionRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBucketRetentionRequest) ProtoMessage()    {}
+func (*GetBucketRetentionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{42}
+}
+func (m *GetBucketRetentionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBucketRetentionRequest.Unmarshal(m, b)
+}
+func (m *GetBucketRetentionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBucketRetentionRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBucketRetentionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBucketRetentionRequest.Merge(m, src)
+}
+func (m *GetBucketRetentionRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBucketRetentionRequest.Size(m)
+}
+func (m *GetBucketRetentionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBucketRetentionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBucketRetentionRequest proto.InternalMessageInfo
+
+func (m *GetBucketRetentionRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetBucketRetentionRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+type GetBucketRetentionResponse struct {
+	Days                 int32    `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBucketRetentionResponse) Reset()         { *m = GetBucketRetentionResponse{} }
+func (m *GetBucketRetentionResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBucketRetentionResponse) ProtoMessage()    {}
+func (*GetBucketRetentionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{43}
+}
+func (m *GetBucketRetentionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBucketRetentionResponse.Unmarshal(m, b)
+}
+func (m *GetBucketRetentionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBucketRetentionResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBucketRetentionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBucketRetentionResponse.Merge(m, src)
+}
+func (m *GetBucketRetentionResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBucketRetentionResponse.Size(m)
+}
+func (m *GetBucketRetentionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBucketRetentionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBucketRetentionResponse proto.InternalMessageInfo
+
+func (m *GetBucketRetentionResponse) GetDays() int32 {
+	if m != nil {
+		return m.Days
+	}
+	return 0
+}
+
+// SetObjectRetentionRequest protects an object version from deletion until
+// retain_until. The latest version is used when version is zero. An existing
+// retention can only be extended.
+type SetObjectRetentionRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket               []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	EncryptedObjectKey   []byte            `protobuf:"bytes,3,opt,name=encrypted_object_key,json=encryptedObjectKey,proto3" json:"encrypted_object_key,omitempty"`
+	Version              int64             `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	RetainUntil          time.Time         `protobuf:"bytes,5,opt,name=retain_until,json=retainUntil,proto3,stdtime" json:"retain_until"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SetObjectRetentionRequest) Reset()         { *m = SetObjectRetentionRequest{} }
+func (m *SetObjectRetentionRequest) String() string { return proto.CompactTextString(m) }
+func (*SetObjectRetentionRequest) ProtoMessage()    {}
+func (*SetObjectRetentionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{44}
+}
+func (m *SetObjectRetentionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetObjectRetentionRequest.Unmarshal(m, b)
+}
+func (m *SetObjectRetentionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetObjectRetentionRequest.Marshal(b, m, deterministic)
+}
+func (m *SetObjectRetentionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetObjectRetentionRequest.Merge(m, src)
+}
+func (m *SetObjectRetentionRequest) XXX_Size() int {
+	return xxx_messageInfo_SetObjectRetentionRequest.Size(m)
+}
+func (m *SetObjectRetentionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetObjectRetentionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetObjectRetentionRequest proto.InternalMessageInfo
+
+func (m *SetObjectRetentionRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *SetObjectRetentionRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *SetObjectRetentionRequest) GetEncryptedObjectKey() []byte {
+	if m != nil {
+		return m.EncryptedObjectKey
+	}
+	return nil
+}
+
+func (m *SetObjectRetentionRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *SetObjectRetentionRequest) GetRetainUntil() time.Time {
+	if m != nil {
+		return m.RetainUntil
+	}
+	return time.Time{}
+}
+
+type SetObjectRetentionResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetObjectRetentionResponse) Reset()         { *m = SetObjectRetentionResponse{} }
+func (m *SetObjectRetentionResponse) String() string { return proto.CompactTextString(m) }
+func (*SetObjectRetentionResponse) ProtoMessage()    {}
+func (*SetObjectRetentionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{45}
+}
+func (m *SetObjectRetentionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetObjectRetentionResponse.Unmarshal(m, b)
+}
+func (m *SetObjectRetentionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetObjectRetentionResponse.Marshal(b, m, deterministic)
+}
+func (m *SetObjectRetentionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetObjectRetentionResponse.Merge(m, src)
+}
+func (m *SetObjectRetentionResponse) XXX_Size() int {
+	return xxx_messageInfo_SetObjectRetentionResponse.Size(m)
+}
+func (m *SetObjectRetentionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetObjectRetentionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetObjectRetentionResponse proto.InternalMessageInfo
+
+// SetObjectLegalHoldRequest places or removes a legal hold on an object
+// version. The latest version is used when version is zero.
+type SetObjectLegalHoldRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket               []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	EncryptedObjectKey   []byte            `protobuf:"bytes,3,opt,name=encrypted_object_key,json=encryptedObjectKey,proto3" json:"encrypted_object_key,omitempty"`
+	Version              int64             `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	Enabled              bool              `protobuf:"varint,5,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SetObjectLegalHoldRequest) Reset()         { *m = SetObjectLegalHoldRequest{} }
+func (m *SetObjectLegalHoldRequest) String() string { return proto.CompactTextString(m) }
+func (*SetObjectLegalHoldRequest) ProtoMessage()    {}
+func (*SetObjectLegalHoldRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{46}
+}
+func (m *SetObjectLegalHoldRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetObjectLegalHoldRequest.Unmarshal(m, b)
+}
+func (m *SetObjectLegalHoldRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetObjectLegalHoldRequest.Marshal(b, m, deterministic)
+}
+func (m *SetObjectLegalHoldRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetObjectLegalHoldRequest.Merge(m, src)
+}
+func (m *SetObjectLegalHoldRequest) XXX_Size() int {
+	return xxx_messageInfo_SetObjectLegalHoldRequest.Size(m)
+}
+func (m *SetObjectLegalHoldRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetObjectLegalHoldRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetObjectLegalHoldRequest proto.InternalMessageInfo
+
+func (m *SetObjectLegalHoldRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *SetObjectLegalHoldRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *SetObjectLegalHoldRequest) GetEncryptedObjectKey() []byte {
+	if m != nil {
+		return m.EncryptedObjectKey
+	}
+	return nil
+}
+
+func (m *SetObjectLegalHoldRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *SetObjectLegalHoldRequest) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+type SetObjectLegalHoldResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetObjectLegalHoldResponse) Reset()         { *m = SetObjectLegalHoldResponse{} }
+func (m *SetObjectLegalHoldResponse) String() string { return proto.CompactTextString(m) }
+func (*SetObjectLegalHoldResponse) ProtoMessage()    {}
+func (*SetObjectLegalHoldResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{47}
+}
+func (m *SetObjectLegalHoldResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetObjectLegalHoldResponse.Unmarshal(m, b)
+}
+func (m *SetObjectLegalHoldResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetObjectLegalHoldResponse.Marshal(b, m, deterministic)
+}
+func (m *SetObjectLegalHoldResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetObjectLegalHoldResponse.Merge(m, src)
+}
+func (m *SetObjectLegalHoldResponse) XXX_Size() int {
+	return xxx_messageInfo_SetObjectLegalHoldResponse.Size(m)
+}
+func (m *SetObjectLegalHoldResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetObjectLegalHoldResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetObjectLegalHoldResponse proto.InternalMessageInfo
+
+type GetObjectLockRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket               []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	EncryptedObjectKey   []byte            `protobuf:"bytes,3,opt,name=encrypted_object_key,json=encryptedObjectKey,proto3" json:"encrypted_object_key,omitempty"`
+	Version              int64             `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetObjectLockRequest) Reset()         { *m = GetObjectLockRequest{} }
+func (m *GetObjectLockRequest) String() string { return proto.CompactTextString(m) }
+func (*GetObjectLockRequest) ProtoMessage()    {}
+func (*GetObjectLockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{48}
+}
+func (m *GetObjectLockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetObjectLockRequest.Unmarshal(m, b)
+}
+func (m *GetObjectLockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetObjectLockRequest.Marshal(b, m, deterministic)
+}
+func (m *GetObjectLockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetObjectLockRequest.Merge(m, src)
+}
+func (m *GetObjectLockRequest) XXX_Size() int {
+	return xxx_messageInfo_GetObjectLockRequest.Size(m)
+}
+func (m *GetObjectLockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetObjectLockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetObjectLockRequest proto.InternalMessageInfo
+
+func (m *GetObjectLockRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetObjectLockRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *GetObjectLockRequest) GetEncryptedObjectKey() []byte {
+	if m != nil {
+		return m.EncryptedObjectKey
+	}
+	return nil
+}
+
+func (m *GetObjectLockRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type GetObjectLockResponse struct {
+	// retain_until is missing when the object version has no retention.
+	RetainUntil          *time.Time `protobuf:"bytes,1,opt,name=retain_until,json=retainUntil,proto3,stdtime" json:"retain_until,omitempty"`
+	LegalHold            bool       `protobuf:"varint,2,opt,name=legal_hold,json=legalHold,proto3" json:"legal_hold,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *GetObjectLockResponse) Reset()         { *m = GetObjectLockResponse{} }
+func (m *GetObjectLockResponse) String() string { return proto.CompactTextString(m) }
+func (*GetObjectLockResponse) ProtoMessage()    {}
+func (*GetObjectLockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ade661ecd304013, []int{49}
+}
+func (m *GetObjectLockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetObjectLockResponse.Unmarshal(m, b)
+}
+func (m *GetObjectLockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetObjectLockResponse.Marshal(b, m, deterministic)
+}
+func (m *GetObjectLockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetObjectLockResponse.Merge(m, src)
+}
+func (m *GetObjectLockResponse) XXX_Size() int {
+	return xxx_messageInfo_GetObjectLockResponse.Size(m)
+}
+func (m *GetObjectLockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetObjectLockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetObjectLockResponse proto.InternalMessageInfo
+
+func (m *GetObjectLockResponse) GetRetainUntil() *time.Time {
+	if m != nil {
+		return m.RetainUntil
+	}
+	return nil
+}
+
+func (m *GetObjectLockResponse) GetLegalHold() bool {
+	if m != nil {
+		return m.LegalHold
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterEnum("metainfoext.LifecycleAction", LifecycleAction_name, LifecycleAction_value)
+	proto.RegisterType((*SetBucketVersioningRequest)(nil), "metainfoext.SetBucketVersioningRequest")
+	proto.RegisterType((*SetBucketVersioningResponse)(nil), "metainfoext.SetBucketVersioningResponse")
+	proto.RegisterType((*GetBucketVersioningRequest)(nil), "metainfoext.GetBucketVersioningRequest")
+	proto.RegisterType((*GetBucketVersioningResponse)(nil), "metainfoext.GetBucketVersioningResponse")
+	proto.RegisterType((*ListObjectVersionsRequest)(nil), "metainfoext.ListObjectVersionsRequest")
+	proto.RegisterType((*ListObjectVersionsResponse)(nil), "metainfoext.ListObjectVersionsResponse")
+	proto.RegisterType((*ObjectVersion)(nil), "metainfoext.ObjectVersion")
+	proto.RegisterType((*ListPrefixVersionsRequest)(nil), "metainfoext.ListPrefixVersionsRequest")
+	proto.RegisterType((*ListPrefixVersionsResponse)(nil), "metainfoext.ListPrefixVersionsResponse")
+	proto.RegisterType((*PrefixVersion)(nil), "metainfoext.PrefixVersion")
+	proto.RegisterType((*DownloadObjectVersionRequest)(nil), "metainfoext.DownloadObjectVersionRequest")
+	proto.RegisterType((*DownloadObjectVersionResponse)(nil), "metainfoext.DownloadObjectVersionResponse")
+	proto.RegisterType((*BeginMoveObjectRequest)(nil), "metainfoext.BeginMoveObjectRequest")
+	proto.RegisterType((*BeginMoveObjectResponse)(nil), "metainfoext.BeginMoveObjectResponse")
+	proto.RegisterType((*EncryptedKeyAndNonce)(nil), "metainfoext.EncryptedKeyAndNonce")
+	proto.RegisterType((*FinishMoveObjectRequest)(nil), "metainfoext.FinishMoveObjectRequest")
+	proto.RegisterType((*FinishMoveObjectResponse)(nil), "metainfoext.FinishMoveObjectResponse")
+	proto.RegisterType((*BeginCopyObjectRequest)(nil), "metainfoext.BeginCopyObjectRequest")
+	proto.RegisterType((*BeginCopyObjectResponse)(nil), "metainfoext.BeginCopyObjectResponse")
+	proto.RegisterType((*FinishCopyObjectRequest)(nil), "metainfoext.FinishCopyObjectRequest")
+	proto.RegisterType((*FinishCopyObjectResponse)(nil), "metainfoext.FinishCopyObjectResponse")
+	proto.RegisterType((*RestoreObjectVersionRequest)(nil), "metainfoext.RestoreObjectVersionRequest")
+	proto.RegisterType((*RestoreObjectVersionResponse)(nil), "metainfoext.RestoreObjectVersionResponse")
+	proto.RegisterType((*SetBucketTagsRequest)(nil), "metainfoext.SetBucketTagsRequest")
+	proto.RegisterMapType((map[string]string)(nil), "metainfoext.SetBucketTagsRequest.TagsEntry")
+	proto.RegisterType((*SetBucketTagsResponse)(nil), "metainfoext.SetBucketTagsResponse")
+	proto.RegisterType((*GetBucketTagsRequest)(nil), "metainfoext.GetBucketTagsRequest")
+	proto.RegisterType((*GetBucketTagsResponse)(nil), "metainfoext.GetBucketTagsResponse")
+	proto.RegisterMapType((map[string]string)(nil), "metainfoext.GetBucketTagsResponse.TagsEntry")
+	proto.RegisterType((*SetObjectTagsRequest)(nil), "metainfoext.SetObjectTagsRequest")
+	proto.RegisterMapType((map[string]string)(nil), "metainfoext.SetObjectTagsRequest.TagsEntry")
+	proto.RegisterType((*SetObjectTagsResponse)(nil), "metainfoext.SetObjectTagsResponse")
+	proto.RegisterType((*GetObjectTagsRequest)(nil), "metainfoext.GetObjectTagsRequest")
+	proto.RegisterType((*GetObjectTagsResponse)(nil), "metainfoext.GetObjectTagsResponse")
+	proto.RegisterMapType((map[string]string)(nil), "metainfoext.GetObjectTagsResponse.TagsEntry")
+	proto.RegisterType((*ListTaggedObjectsRequest)(nil), "metainfoext.ListTaggedObjectsRequest")
+	proto.RegisterMapType((map[string]string)(nil), "metainfoext.ListTaggedObjectsRequest.TagsEntry")
+	proto.RegisterType((*ListTaggedObjectsResponse)(nil), "metainfoext.ListTaggedObjectsResponse")
+	proto.RegisterType((*LifecycleRule)(nil), "metainfoext.LifecycleRule")
+	proto.RegisterType((*SetBucketLifecycleRuleRequest)(nil), "metainfoext.SetBucketLifecycleRuleRequest")
+	proto.RegisterType((*SetBucketLifecycleRuleResponse)(nil), "metainfoext.SetBucketLifecycleRuleResponse")
+	proto.RegisterType((*DeleteBucketLifecycleRulesRequest)(nil), "metainfoext.DeleteBucketLifecycleRulesRequest")
+	proto.RegisterType((*DeleteBucketLifecycleRulesResponse)(nil), "metainfoext.DeleteBucketLifecycleRulesResponse")
+	proto.RegisterType((*ListBucketLifecycleRulesRequest)(nil), "metainfoext.ListBucketLifecycleRulesRequest")
+	proto.RegisterType((*ListBucketLifecycleRulesResponse)(nil), "metainfoext.ListBucketLifecycleRulesResponse")
+	proto.RegisterType((*SetBucketRetentionRequest)(nil), "metainfoext.SetBucketRetentionRequest")
+	proto.RegisterType((*SetBucketRetentionResponse)(nil), "metainfoext.SetBucketRetentionResponse")
+	proto.RegisterType((*GetBucketRetentionRequest)(nil), "metainfoext.GetBucketRetentionRequest")
+	proto.RegisterType((*GetBucketRetentionResponse)(nil), "metainfoext.GetBucketRetentionResponse")
+	proto.RegisterType((*SetObjectRetentionRequest)(nil), "metainfoext.SetObjectRetentionRequest")
+	proto.RegisterType((*SetObjectRetentionResponse)(nil), "metainfoext.SetObjectRetentionResponse")
+	proto.RegisterType((*SetObjectLegalHoldRequest)(nil), "metainfoext.SetObjectLegalHoldRequest")
+	proto.RegisterType((*SetObjectLegalHoldResponse)(nil), "metainfoext.SetObjectLegalHoldResponse")
+	proto.RegisterType((*GetObjectLockRequest)(nil), "metainfoext.GetObjectLockRequest")
+	proto.RegisterType((*GetObjectLockResponse)(nil), "metainfoext.GetObjectLockResponse")
+}
+
+func init() { proto.RegisterFile("metainfoext.proto", fileDescriptor_0ade661ecd304013) }
+
+var fileDescriptor_0ade661ecd304013 = []byte{
+	// 1969 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x59, 0x4b, 0x6f, 0xdb, 0xc8,
+	0x1d, 0x5f, 0x4a, 0x96, 0x2d, 0xfd, 0xfd, 0x88, 0x33, 0xb1, 0x63, 0x99, 0xb6, 0x6b, 0x85, 0x79,
+	0xd8, 0xc9, 0x6e, 0xe5, 0xc0, 0x4d, 0x91, 0x45, 0xb7, 0xc0, 0xd6, 0x0f, 0xad, 0x36, 0xb5, 0xbd,
+	0x31, 0x68, 0x27, 0x28, 0x8a, 0x16, 0x04, 0x25, 0x4d, 0x64, 0xc6, 0x14, 0xa9, 0x25, 0x29, 0x3b,
+	0xda, 0xa2, 0x68, 0x3f, 0x42, 0xd1, 0x07, 0xd0, 0x5b, 0x81, 0x02, 0x3d, 0xf5, 0x56, 0xf4, 0x23,
+	0xf4, 0xd0, 0xf6, 0x1b, 0xf4, 0xd2, 0x5e, 0xfa, 0x15, 0x7a, 0xe8, 0xad, 0xe0, 0xcc, 0xf0, 0x31,
+	0xe2, 0x50, 0xd4, 0x41, 0x6a, 0x5d, 0x20, 0x37, 0x72, 0xf8, 0xfb, 0xbf, 0x7f, 0x33, 0x9c, 0xff,
+	0x0c, 0xdc, 0xee, 0x60, 0x4f, 0x37, 0xac, 0x37, 0x36, 0x7e, 0xe7, 0x55, 0xbb, 0x8e, 0xed, 0xd9,
+	0x68, 0x36, 0x36, 0x24, 0x43, 0xdb, 0x6e, 0xdb, 0xf4, 0x83, 0xbc, 0xd9, 0xb6, 0xed, 0xb6, 0x89,
+	0x77, 0xc8, 0x5b, 0xa3, 0xf7, 0x66, 0xc7, 0x33, 0x3a, 0xd8, 0xf5, 0xf4, 0x4e, 0x97, 0x01, 0x16,
+	0xb1, 0xd5, 0x74, 0xfa, 0x5d, 0xcf, 0xb0, 0x2d, 0x36, 0xb2, 0x10, 0xe8, 0xa2, 0xef, 0xca, 0x4f,
+	0x40, 0x3e, 0xc3, 0xde, 0x7e, 0xaf, 0x79, 0x89, 0xbd, 0xd7, 0xd8, 0x71, 0x0d, 0xdb, 0x32, 0xac,
+	0xb6, 0x8a, 0xbf, 0xec, 0x61, 0xd7, 0x43, 0x3b, 0x30, 0x7d, 0x81, 0xf5, 0x16, 0x76, 0xca, 0x52,
+	0x45, 0xda, 0x9e, 0xdd, 0x5d, 0xa9, 0x86, 0xe2, 0x0c, 0xf2, 0x39, 0xf9, 0xac, 0x32, 0x18, 0xba,
+	0x0b, 0xd3, 0x0d, 0xa2, 0xab, 0x9c, 0xab, 0x48, 0xdb, 0x73, 0x2a, 0x7b, 0x43, 0x65, 0x98, 0xc1,
+	0x96, 0xde, 0x30, 0x71, 0xab, 0x9c, 0xaf, 0x48, 0xdb, 0x45, 0x35, 0x78, 0x55, 0x36, 0x60, 0x4d,
+	0xe8, 0x80, 0xdb, 0xb5, 0x2d, 0x17, 0x2b, 0x18, 0xe4, 0xfa, 0xe4, 0xfd, 0x53, 0x9e, 0xc3, 0x5a,
+	0x3d, 0xdd, 0x8b, 0xb8, 0xfb, 0x12, 0xef, 0xfe, 0x9f, 0x24, 0x58, 0x3d, 0x36, 0x5c, 0xef, 0x65,
+	0xe3, 0x2d, 0x6e, 0x06, 0xa2, 0xee, 0xd8, 0xf3, 0xf7, 0x14, 0x96, 0x58, 0x29, 0x71, 0x4b, 0xb3,
+	0x89, 0x2d, 0xed, 0x12, 0xf7, 0x49, 0x32, 0xe7, 0x54, 0x14, 0x7e, 0xa3, 0x6e, 0x1c, 0xe1, 0xbe,
+	0xaf, 0xa9, 0xd9, 0x73, 0x5c, 0xdb, 0x29, 0x4f, 0x55, 0xa4, 0xed, 0xbc, 0xca, 0xde, 0xd0, 0x12,
+	0x14, 0x4c, 0xa3, 0x63, 0x78, 0xe5, 0x42, 0x45, 0xda, 0x2e, 0xa8, 0xf4, 0x45, 0x69, 0x80, 0x2c,
+	0x8a, 0x82, 0x85, 0xff, 0x14, 0x0a, 0x86, 0x87, 0x3b, 0x6e, 0x59, 0xaa, 0xe4, 0xb7, 0x67, 0x77,
+	0xe5, 0x6a, 0x9c, 0xa3, 0x9c, 0x8c, 0x4a, 0x81, 0x08, 0xc1, 0x54, 0xc7, 0x76, 0x30, 0x89, 0xa2,
+	0xa8, 0x92, 0x67, 0xe5, 0x0f, 0x12, 0xcc, 0x73, 0x60, 0x3f, 0xad, 0x57, 0xf4, 0x91, 0xe4, 0x27,
+	0xaf, 0x06, 0xaf, 0xe8, 0x3e, 0xcc, 0xb7, 0xb0, 0x89, 0x3d, 0xac, 0x75, 0x74, 0xe7, 0x12, 0x3b,
+	0x4c, 0xd1, 0x1c, 0x1d, 0x3c, 0x21, 0x63, 0xe8, 0x00, 0xa0, 0xe9, 0x60, 0xdd, 0x4f, 0x89, 0xee,
+	0x91, 0x54, 0xf8, 0xbe, 0xd1, 0x39, 0x51, 0x0d, 0xe6, 0x44, 0xf5, 0x3c, 0x98, 0x13, 0xfb, 0xc5,
+	0x3f, 0xff, 0x7d, 0xf3, 0x83, 0x9f, 0xfd, 0x63, 0x53, 0x52, 0x4b, 0x4c, 0x6e, 0xcf, 0x43, 0x1b,
+	0x00, 0x5d, 0x53, 0x37, 0x2c, 0xcd, 0x35, 0xbe, 0xc2, 0x2c, 0x57, 0x25, 0x32, 0x72, 0x66, 0x7c,
+	0x85, 0x95, 0x5f, 0xe5, 0x68, 0x7d, 0x4f, 0x1d, 0xfc, 0xc6, 0x78, 0x37, 0xb1, 0xfa, 0x3e, 0x86,
+	0xc5, 0xa8, 0xbe, 0x5d, 0x62, 0x8b, 0xd5, 0xf6, 0x56, 0x38, 0x4e, 0x5d, 0x40, 0xeb, 0x50, 0x72,
+	0xb0, 0x5f, 0x4c, 0xe3, 0x8a, 0xfa, 0x5b, 0x54, 0xa3, 0x01, 0x5e, 0x11, 0x23, 0x40, 0x61, 0x40,
+	0xd1, 0x01, 0x19, 0x46, 0x0f, 0x61, 0x81, 0x02, 0xb4, 0xa0, 0x08, 0xd3, 0x24, 0xfa, 0x79, 0x3a,
+	0x1a, 0x14, 0x29, 0x24, 0xcc, 0x8c, 0x80, 0x30, 0x83, 0x69, 0x19, 0x85, 0x30, 0x9c, 0xcc, 0x30,
+	0xc2, 0xfc, 0x52, 0x82, 0x79, 0x0e, 0x9c, 0x3a, 0x0d, 0xa4, 0xd4, 0x69, 0xb0, 0x06, 0x25, 0xc3,
+	0x0d, 0x32, 0x4a, 0x95, 0x17, 0x0d, 0x97, 0xa5, 0xf2, 0x59, 0xc4, 0xbf, 0x80, 0x3d, 0xe9, 0xcc,
+	0x0e, 0xa0, 0x4a, 0x0f, 0xd6, 0x0f, 0xed, 0x6b, 0xcb, 0xb4, 0xf5, 0x16, 0x8f, 0x60, 0xa4, 0xf8,
+	0x04, 0x8a, 0x2d, 0xf6, 0x9d, 0xd1, 0x62, 0x33, 0xa2, 0x05, 0x95, 0x08, 0xe4, 0x99, 0x88, 0x1a,
+	0x0a, 0xc4, 0xa7, 0x44, 0x8e, 0x9b, 0x12, 0xca, 0x0f, 0x61, 0x23, 0xc5, 0x2c, 0x4b, 0xfa, 0xb7,
+	0x13, 0x76, 0x2b, 0xe9, 0x76, 0xa9, 0x4c, 0x64, 0x58, 0xf9, 0x85, 0x04, 0x77, 0xf7, 0x71, 0xdb,
+	0xb0, 0x4e, 0xec, 0x2b, 0x4c, 0xd1, 0xff, 0xfb, 0x55, 0x4c, 0xf9, 0x67, 0x0e, 0x56, 0x12, 0x5e,
+	0xb1, 0x78, 0xd7, 0xa0, 0xe4, 0x7a, 0x0e, 0xd6, 0x3b, 0x9a, 0xd1, 0x62, 0x0c, 0x28, 0xd2, 0x81,
+	0x17, 0x43, 0xf2, 0x88, 0x3e, 0x85, 0xf5, 0xc8, 0x09, 0x3f, 0x90, 0x96, 0xee, 0xe9, 0xbe, 0x1b,
+	0x9a, 0x65, 0x5b, 0x4d, 0xcc, 0x9c, 0x59, 0x0d, 0x31, 0x27, 0x0c, 0x72, 0x84, 0xfb, 0x5f, 0xf8,
+	0x00, 0xf4, 0x0c, 0xee, 0x8a, 0x15, 0x90, 0xd9, 0x38, 0xa7, 0x2e, 0x89, 0x44, 0xd1, 0x21, 0xcc,
+	0xb9, 0xb8, 0xdd, 0xc1, 0x16, 0x09, 0xd9, 0x2d, 0x17, 0xc8, 0xcc, 0xb8, 0xc7, 0x11, 0xae, 0x16,
+	0x08, 0x1e, 0xe1, 0xfe, 0x9e, 0xd5, 0x22, 0xe6, 0xd4, 0x59, 0x26, 0x76, 0x84, 0xfb, 0x2e, 0x7a,
+	0x05, 0xcb, 0xd1, 0x2f, 0x5d, 0xeb, 0xea, 0x8e, 0xde, 0xc1, 0x1e, 0x76, 0xdc, 0xf2, 0x34, 0x2b,
+	0x78, 0xf4, 0x35, 0xd0, 0x66, 0xd8, 0xd6, 0x69, 0x88, 0x0b, 0x9d, 0xe3, 0x46, 0x95, 0xdf, 0x4a,
+	0xb0, 0x24, 0x32, 0x8e, 0xbe, 0x09, 0xc5, 0xae, 0xed, 0x1a, 0x5e, 0xb0, 0x44, 0xcf, 0xee, 0xae,
+	0x46, 0xc5, 0x3f, 0xa3, 0x8e, 0x9d, 0x32, 0x80, 0x1a, 0x42, 0x51, 0x15, 0xee, 0x44, 0x29, 0x8a,
+	0x52, 0x4b, 0xd9, 0x70, 0x1b, 0xc7, 0x2c, 0x51, 0x33, 0xf7, 0x61, 0x9e, 0xc3, 0xb3, 0x22, 0xcc,
+	0xc5, 0x91, 0xca, 0xbf, 0xf2, 0xb0, 0xf2, 0x99, 0x61, 0x19, 0xee, 0xc5, 0x4d, 0xa2, 0x68, 0x9c,
+	0x69, 0x53, 0x3c, 0xd3, 0x38, 0x82, 0x16, 0x06, 0x08, 0xba, 0x01, 0x60, 0xe1, 0x6b, 0x8d, 0x39,
+	0x31, 0x4d, 0xbe, 0x96, 0x2c, 0x7c, 0x4d, 0xf7, 0x20, 0xe8, 0x39, 0x94, 0xfd, 0xcf, 0x42, 0x5f,
+	0x66, 0x08, 0x78, 0xd9, 0xc2, 0xd7, 0xb5, 0xa4, 0x3b, 0x9f, 0x41, 0x85, 0x17, 0x14, 0x50, 0xbc,
+	0x48, 0x14, 0xac, 0xc7, 0x15, 0x24, 0x58, 0xfe, 0x09, 0xc8, 0xe9, 0x7a, 0xca, 0x25, 0xa2, 0x61,
+	0x25, 0x45, 0x03, 0x3a, 0x82, 0x45, 0x5f, 0x98, 0x23, 0x3c, 0x8c, 0x4a, 0xf8, 0x05, 0x0b, 0x5f,
+	0x9f, 0x45, 0x9c, 0x57, 0x64, 0x28, 0x27, 0xcb, 0xce, 0xb6, 0x87, 0xe1, 0xaa, 0x75, 0x60, 0x77,
+	0xfb, 0x37, 0x6f, 0xd5, 0x8a, 0x7b, 0xf5, 0x7e, 0xd5, 0x1a, 0xf7, 0xaa, 0x15, 0x2d, 0x08, 0x37,
+	0xa9, 0xfa, 0xef, 0x17, 0x84, 0x49, 0x2f, 0x08, 0xcf, 0xa0, 0x9c, 0x2c, 0x7b, 0xd4, 0xa9, 0x89,
+	0x5b, 0x0a, 0xbf, 0xfd, 0x58, 0x53, 0xb1, 0xeb, 0xd9, 0x0e, 0x16, 0x6e, 0xdb, 0x6e, 0x22, 0x63,
+	0x94, 0x8f, 0x61, 0x5d, 0xec, 0x73, 0x66, 0xb8, 0x7f, 0x93, 0x60, 0x29, 0x6c, 0xac, 0xcf, 0xf5,
+	0xf6, 0xf8, 0x7b, 0x96, 0x4f, 0x61, 0xca, 0xd3, 0xdb, 0x6e, 0x39, 0x4f, 0xea, 0xf8, 0x21, 0x57,
+	0x47, 0x91, 0xe5, 0xaa, 0xff, 0x5c, 0xb3, 0x3c, 0xa7, 0xaf, 0x12, 0x41, 0xf9, 0x39, 0x94, 0xc2,
+	0x21, 0xb4, 0x08, 0xf9, 0x60, 0x27, 0x5f, 0x52, 0xfd, 0x47, 0xbf, 0xf1, 0xb8, 0xd2, 0xcd, 0x1e,
+	0xdd, 0x36, 0x94, 0x54, 0xfa, 0xf2, 0xad, 0xdc, 0xc7, 0x92, 0xb2, 0x02, 0xcb, 0x03, 0x06, 0xd8,
+	0xef, 0x40, 0x83, 0xa5, 0xfa, 0x24, 0x63, 0x56, 0x7e, 0x2e, 0xc1, 0x72, 0x5d, 0x64, 0x1a, 0x7d,
+	0x87, 0x65, 0x83, 0x76, 0x3c, 0x1f, 0x71, 0xd9, 0x10, 0x4a, 0x8c, 0x2f, 0x1d, 0xbf, 0xcb, 0x91,
+	0x52, 0x53, 0x86, 0x4c, 0xa4, 0xd4, 0xe3, 0x5c, 0x04, 0x03, 0xda, 0x14, 0xc4, 0xb4, 0x49, 0x44,
+	0x31, 0x6e, 0xda, 0xc4, 0x0d, 0x30, 0xda, 0xfc, 0x5e, 0x22, 0xbc, 0xf9, 0xff, 0x48, 0x60, 0xc0,
+	0xc1, 0x64, 0x1c, 0x59, 0x1c, 0x4c, 0x4a, 0x8c, 0x2f, 0xb7, 0x7f, 0x91, 0xa0, 0xec, 0x1f, 0x08,
+	0x9c, 0xeb, 0xed, 0x76, 0x10, 0x46, 0x2c, 0x8d, 0x53, 0xa6, 0xe1, 0x7a, 0x2c, 0x89, 0x6b, 0x83,
+	0x5d, 0xa9, 0x2f, 0xc7, 0xa0, 0x2a, 0x01, 0xa2, 0x03, 0x16, 0x48, 0x8e, 0x04, 0xb2, 0xc3, 0x05,
+	0x92, 0x66, 0x65, 0x7c, 0xb1, 0x9c, 0xc0, 0xaa, 0xc0, 0x48, 0x78, 0xb4, 0x11, 0x8f, 0x65, 0x5d,
+	0x1c, 0x0b, 0xc5, 0xd2, 0x60, 0x94, 0xdf, 0x48, 0x30, 0x7f, 0x6c, 0xbc, 0xc1, 0xcd, 0x7e, 0xd3,
+	0xc4, 0x6a, 0xcf, 0xc4, 0xfe, 0x61, 0x87, 0xa5, 0x77, 0x30, 0xf3, 0x86, 0x3c, 0x0b, 0x4f, 0x80,
+	0x72, 0xe2, 0x13, 0xa0, 0x67, 0x30, 0xad, 0x37, 0xbd, 0xe0, 0xd4, 0x62, 0x61, 0x77, 0x7d, 0x20,
+	0x3f, 0xcc, 0xd4, 0x1e, 0xc1, 0xa8, 0x0c, 0x8b, 0x56, 0xa1, 0xa8, 0xb7, 0xb1, 0xd6, 0xd2, 0xfb,
+	0x2e, 0x61, 0x54, 0x41, 0x9d, 0xd1, 0xdb, 0xf8, 0x50, 0xef, 0xbb, 0xca, 0xaf, 0x25, 0xd8, 0x08,
+	0x17, 0x54, 0xce, 0xd5, 0xb1, 0x4f, 0x84, 0x2a, 0x4c, 0x39, 0x3d, 0x13, 0x0b, 0xcf, 0x5b, 0x78,
+	0xcb, 0x04, 0xa7, 0x54, 0xe0, 0x6b, 0x69, 0x9e, 0xb1, 0xc9, 0xfb, 0x53, 0x09, 0xee, 0x1d, 0x92,
+	0x63, 0x41, 0x01, 0x6a, 0xfc, 0x33, 0x39, 0xa8, 0x5d, 0x3e, 0xaa, 0x9d, 0xf2, 0x00, 0x94, 0x61,
+	0x1e, 0x30, 0x47, 0xdf, 0xc2, 0xa6, 0xcf, 0x8e, 0xff, 0x86, 0x97, 0xca, 0x39, 0x54, 0xd2, 0x6d,
+	0x45, 0x87, 0x74, 0x7e, 0x8a, 0xc5, 0x87, 0x74, 0x7c, 0xae, 0x29, 0x50, 0x79, 0x07, 0xab, 0x61,
+	0x31, 0x54, 0xec, 0x61, 0xcb, 0x9b, 0xc4, 0xfe, 0x09, 0xc1, 0x14, 0x21, 0x69, 0x9e, 0x90, 0x94,
+	0x3c, 0x2b, 0xeb, 0x20, 0x8b, 0x2c, 0xb3, 0xcc, 0xb6, 0x60, 0xb5, 0x3e, 0x71, 0xbf, 0x94, 0xa7,
+	0x20, 0x8b, 0xac, 0xb0, 0x6c, 0x06, 0x5e, 0x4b, 0x31, 0xaf, 0xff, 0x2d, 0x91, 0x84, 0x05, 0x5b,
+	0xd4, 0x49, 0x25, 0x6c, 0x9c, 0x7f, 0xe7, 0x3a, 0xcc, 0x39, 0xc4, 0x0b, 0xad, 0x67, 0x79, 0x86,
+	0x49, 0xba, 0x94, 0x51, 0x4f, 0xd5, 0x67, 0xa9, 0xe4, 0x2b, 0x5f, 0x90, 0x55, 0x2c, 0x11, 0x3a,
+	0xab, 0xd8, 0x5f, 0xe3, 0x99, 0x39, 0xc6, 0x6d, 0xdd, 0xfc, 0xdc, 0x36, 0x5b, 0x37, 0x3a, 0x33,
+	0xb1, 0x3b, 0xa0, 0x02, 0x7f, 0x07, 0x14, 0x0f, 0x35, 0x16, 0x8b, 0x68, 0x73, 0x71, 0x6c, 0x37,
+	0x2f, 0x6f, 0xf4, 0xe6, 0xe2, 0x47, 0xb0, 0x3c, 0xe0, 0x2c, 0xe3, 0xf7, 0xc1, 0x00, 0x31, 0xa4,
+	0x4c, 0x62, 0x4c, 0x25, 0x48, 0xe1, 0xf7, 0xb8, 0xa6, 0x9f, 0x20, 0xed, 0xc2, 0x36, 0x5b, 0xec,
+	0x38, 0xbe, 0x64, 0x06, 0x29, 0x7b, 0x52, 0x83, 0x5b, 0x03, 0x7f, 0x2f, 0x34, 0x0b, 0x33, 0x2f,
+	0xbe, 0x78, 0xbd, 0x77, 0xfc, 0xe2, 0x70, 0xf1, 0x03, 0x84, 0x60, 0xa1, 0xf6, 0xbd, 0xd3, 0x17,
+	0x6a, 0x4d, 0x7b, 0xb9, 0xff, 0xdd, 0xda, 0xc1, 0xf9, 0xd9, 0xa2, 0x84, 0x6e, 0xc3, 0xfc, 0xde,
+	0xfe, 0x4b, 0xf5, 0x5c, 0x7b, 0x75, 0x7a, 0xfc, 0x72, 0xef, 0xf0, 0x6c, 0x31, 0xb7, 0xfb, 0x47,
+	0x04, 0xe8, 0x84, 0xa5, 0xb2, 0xf6, 0xce, 0xc3, 0x96, 0x1f, 0x99, 0x8b, 0x2e, 0xe0, 0x8e, 0xe0,
+	0xa6, 0x11, 0x6d, 0x89, 0x1b, 0x97, 0xc4, 0x65, 0xa3, 0xbc, 0x9d, 0x0d, 0x64, 0xb9, 0xba, 0x80,
+	0x3b, 0xf5, 0x4c, 0x4b, 0xf5, 0x51, 0x2d, 0x0d, 0xbb, 0x98, 0xc4, 0x80, 0x92, 0xf7, 0x76, 0xe8,
+	0x51, 0x62, 0xc3, 0x24, 0xbc, 0x9e, 0x94, 0xb7, 0x32, 0x71, 0xbc, 0x19, 0xfe, 0xb6, 0x47, 0x60,
+	0x46, 0x78, 0x4b, 0x26, 0x6f, 0x65, 0xe2, 0x98, 0x19, 0x0b, 0x96, 0x85, 0x57, 0x1c, 0xe8, 0x31,
+	0xa7, 0x61, 0xd8, 0xed, 0x8b, 0xfc, 0x64, 0x14, 0x28, 0xb3, 0x77, 0x09, 0x4b, 0xa2, 0xee, 0x1a,
+	0xf1, 0xf9, 0x1f, 0x72, 0x68, 0x20, 0x3f, 0x1e, 0x01, 0xc9, 0x8c, 0xfd, 0x00, 0x6e, 0x0d, 0xdc,
+	0x64, 0xa0, 0xfb, 0x9c, 0xb4, 0xf8, 0xf6, 0x45, 0x7e, 0x30, 0x1c, 0xc4, 0xb4, 0x6b, 0xb0, 0x38,
+	0x78, 0x48, 0x8a, 0x78, 0xc9, 0x94, 0xa3, 0x73, 0xf9, 0x61, 0x06, 0x6a, 0xc0, 0xfd, 0xe8, 0xcc,
+	0x45, 0xe4, 0x7e, 0xe2, 0x20, 0x4e, 0x7e, 0x30, 0x1c, 0x34, 0xe8, 0x7e, 0x4c, 0xbd, 0xc8, 0xfd,
+	0xa4, 0xfe, 0x87, 0x19, 0x28, 0x66, 0xe0, 0x35, 0xcc, 0x73, 0x47, 0x06, 0xe8, 0x5e, 0xe6, 0x79,
+	0x85, 0xac, 0x0c, 0x83, 0x44, 0x7a, 0xeb, 0x43, 0xf4, 0xd6, 0xb3, 0xf5, 0xd6, 0xd3, 0xf4, 0x72,
+	0xbd, 0x6a, 0xd2, 0xdf, 0x44, 0xb7, 0x2a, 0x2b, 0xc3, 0x20, 0x9c, 0xbf, 0xa9, 0x7a, 0xeb, 0xd9,
+	0x7a, 0xc5, 0xad, 0x67, 0x03, 0x6e, 0x27, 0x7a, 0x26, 0xf4, 0x70, 0xa4, 0xc6, 0x4d, 0x7e, 0x94,
+	0x05, 0x63, 0x36, 0xbe, 0x84, 0xbb, 0xe2, 0x5e, 0x00, 0x3d, 0x11, 0x57, 0x4a, 0xd4, 0xca, 0xc8,
+	0x1f, 0x8e, 0x84, 0x65, 0x26, 0x7f, 0x0c, 0x72, 0xfa, 0xce, 0x1e, 0x55, 0xf9, 0xb5, 0x26, 0xab,
+	0x09, 0x91, 0x77, 0x46, 0xc6, 0x33, 0xf3, 0xd7, 0xb4, 0xa9, 0x16, 0x1a, 0xff, 0x28, 0x91, 0xb5,
+	0x61, 0xa6, 0xbf, 0x3e, 0x22, 0x3a, 0x5a, 0xf0, 0x93, 0xfb, 0xed, 0x81, 0x05, 0x3f, 0xb5, 0x15,
+	0x90, 0xb7, 0x32, 0x71, 0x91, 0x99, 0x7a, 0x96, 0x99, 0xfa, 0x88, 0x66, 0xea, 0x43, 0xcd, 0x24,
+	0xf7, 0xa2, 0xc9, 0x68, 0xc4, 0xfb, 0x74, 0x79, 0x2b, 0x13, 0x27, 0x30, 0x13, 0xee, 0x03, 0xd3,
+	0xcc, 0x0c, 0x6e, 0x7a, 0xe5, 0xad, 0x4c, 0x9c, 0x60, 0x0a, 0xfb, 0x5b, 0xb4, 0xb4, 0x29, 0x1c,
+	0xdb, 0x6b, 0xca, 0xca, 0x30, 0x08, 0xd5, 0xbb, 0xff, 0xe8, 0xfb, 0x0f, 0xfc, 0xdf, 0xd7, 0xdb,
+	0xaa, 0x61, 0xef, 0x90, 0x87, 0x9d, 0xae, 0x63, 0x5c, 0xe9, 0x1e, 0xde, 0x89, 0xc9, 0x76, 0x1b,
+	0x8d, 0x69, 0xb2, 0xd7, 0xfb, 0xc6, 0x7f, 0x06, 0x00, 0xac, 0x00, 0x23, 0xb9, 0xaa, 0x26, 0x00,
+	0x00,
+}
@@ -0,0 +1,995 @@
+// Code generated by protoc-gen-go-drpc. DO NOT EDIT.
+// protoc-gen-go-drpc version: v0.0.23
+// source: metainfoext.proto
+
+package metainfoextpb
+
+import (
+	bytes "bytes"
+	context "context"
+	errors "errors"
+
+	jsonpb "github.com/gogo/protobuf/jsonpb"
+	proto "github.com/gogo/protobuf/proto"
+
+	drpc "storj.io/drpc"
+	drpcerr "storj.io/drpc/drpcerr"
+)
+
+type drpcEncoding_File_metainfoext_proto struct{}
+
+func (drpcEncoding_File_metainfoext_proto) Marshal(msg drpc.Message) ([]byte, error) {
+	return proto.Marshal(msg.(proto.Message))
+}
+
+func (drpcEncoding_File_metainfoext_proto) Unmarshal(buf []byte, msg drpc.Message) error {
+	return proto.Unmarshal(buf, msg.(proto.Message))
+}
+
+func (drpcEncoding_File_metainfoext_proto) JSONMarshal(msg drpc.Message) ([]byte, error) {
+	var buf bytes.Buffer
+	err := new(jsonpb.Marshaler).Marshal(&buf, msg.(proto.Message))
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (drpcEncoding_File_metainfoext_proto) JSONUnmarshal(buf []byte, msg drpc.Message) error {
+	return jsonpb.Unmarshal(bytes.NewReader(buf), msg.(proto.Message))
+}
+
+type DRPCMetainfoExtensionsClient interface {
+	DRPCConn() drpc.Conn
+
+	SetBucketVersioning(ctx context.Context, in *SetBucketVersioningRequest) (*SetBucketVersioningResponse, error)
+	GetBucketVersioning(ctx context.Context, in *GetBucketVersioningRequest) (*GetBucketVersioningResponse, error)
+	ListObjectVersions(ctx context.Context, in *ListObjectVersionsRequest) (*ListObjectVersionsResponse, error)
+	ListPrefixVersions(ctx context.Context, in *ListPrefixVersionsRequest) (*ListPrefixVersionsResponse, error)
+	DownloadObjectVersion(ctx context.Context, in *DownloadObjectVersionRequest) (*DownloadObjectVersionResponse, error)
+	RestoreObjectVersion(ctx context.Context, in *RestoreObjectVersionRequest) (*RestoreObjectVersionResponse, error)
+	BeginMoveObject(ctx context.Context, in *BeginMoveObjectRequest) (*BeginMoveObjectResponse, error)
+	FinishMoveObject(ctx context.Context, in *FinishMoveObjectRequest) (*FinishMoveObjectResponse, error)
+	BeginCopyObject(ctx context.Context, in *BeginCopyObjectRequest) (*BeginCopyObjectResponse, error)
+	FinishCopyObject(ctx context.Context, in *FinishCopyObjectRequest) (*FinishCopyObjectResponse, error)
+	SetBucketTags(ctx context.Context, in *SetBucketTagsRequest) (*SetBucketTagsResponse, error)
+	GetBucketTags(ctx context.Context, in *GetBucketTagsRequest) (*GetBucketTagsResponse, error)
+	SetObjectTags(ctx context.Context, in *SetObjectTagsRequest) (*SetObjectTagsResponse, error)
+	GetObjectTags(ctx context.Context, in *GetObjectTagsRequest) (*GetObjectTagsResponse, error)
+	ListTaggedObjects(ctx context.Context, in *ListTaggedObjectsRequest) (*ListTaggedObjectsResponse, error)
+	SetBucketLifecycleRule(ctx context.Context, in *SetBucketLifecycleRuleRequest) (*SetBucketLifecycleRuleResponse, error)
+	DeleteBucketLifecycleRules(ctx context.Context, in *DeleteBucketLifecycleRulesRequest) (*DeleteBucketLifecycleRulesResponse, error)
+	ListBucketLifecycleRules(ctx context.Context, in *ListBucketLifecycleRulesRequest) (*ListBucketLifecycleRulesResponse, error)
+	SetBucketRetention(ctx context.Context, in *SetBucketRetentionRequest) (*SetBucketRetentionResponse, error)
+	GetBucketRetention(ctx context.Context, in *GetBucketRetentionRequest) (*GetBucketRetentionResponse, error)
+	SetObjectRetention(ctx context.Context, in *SetObjectRetentionRequest) (*SetObjectRetentionResponse, error)
+	SetObjectLegalHold(ctx context.Context, in *SetObjectLegalHoldRequest) (*SetObjectLegalHoldResponse, error)
+	GetObjectLock(ctx context.Context, in *GetObjectLockRequest) (*GetObjectLockResponse, error)
+}
+
+type drpcMetainfoExtensionsClient struct {
+	cc drpc.Conn
+}
+
+func NewDRPCMetainfoExtensionsClient(cc drpc.Conn) DRPCMetainfoExtensionsClient {
+	return &drpcMetainfoExtensionsClient{cc}
+}
+
+func (c *drpcMetainfoExtensionsClient) DRPCConn() drpc.Conn { return c.cc }
+
+func (c *drpcMetainfoExtensionsClient) SetBucketVersioning(ctx context.Context, in *SetBucketVersioningRequest) (*SetBucketVersioningResponse, error) {
+	out := new(SetBucketVersioningResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.MetainfoExtensions/SetBucketVersioning", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoExtensionsClient) GetBucketVersioning(ctx context.Context, in *GetBucketVersioningRequest) (*GetBucketVersioningResponse, error) {
+	out := new(GetBucketVersioningResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.MetainfoExtensions/GetBucketVersioning", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoExtensionsClient) ListObjectVersions(ctx context.Context, in *ListObjectVersionsRequest) (*ListObjectVersionsResponse, error) {
+	out := new(ListObjectVersionsResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.MetainfoExtensions/ListObjectVersions", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoExtensionsClient) ListPrefixVersions(ctx context.Context, in *ListPrefixVersionsRequest) (*ListPrefixVersionsResponse, error) {
+	out := new(ListPrefixVersionsResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.MetainfoExtensions/ListPrefixVersions", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoExtensionsClient) DownloadObjectVersion(ctx context.Context, in *DownloadObjectVersionRequest) (*DownloadObjectVersionResponse, error) {
+	out := new(DownloadObjectVersionResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.MetainfoExtensions/DownloadObjectVersion", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoExtensionsClient) RestoreObjectVersion(ctx context.Context, in *RestoreObjectVersionRequest) (*RestoreObjectVersionResponse, error) {
+	out := new(RestoreObjectVersionResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.MetainfoExtensions/RestoreObjectVersion", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoExtensionsClient) BeginMoveObject(ctx context.Context, in *BeginMoveObjectRequest) (*BeginMoveObjectResponse, error) {
+	out := new(BeginMoveObjectResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.MetainfoExtensions/BeginMoveObject", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoExtensionsClient) FinishMoveObject(ctx context.Context, in *FinishMoveObjectRequest) (*FinishMoveObjectResponse, error) {
+	out := new(FinishMoveObjectResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.MetainfoExtensions/FinishMoveObject", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoExtensionsClient) BeginCopyObject(ctx context.Context, in *BeginCopyObjectRequest) (*BeginCopyObjectResponse, error) {
+	out := new(BeginCopyObjectResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.MetainfoExtensions/BeginCopyObject", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoExtensionsClient) FinishCopyObject(ctx context.Context, in *FinishCopyObjectRequest) (*FinishCopyObjectResponse, error) {
+	out := new(FinishCopyObjectResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.MetainfoExtensions/FinishCopyObject", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoExtensionsClient) SetBucketTags(ctx context.Context, in *SetBucketTagsRequest) (*SetBucketTagsResponse, error) {
+	out := new(SetBucketTagsResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.MetainfoExtensions/SetBucketTags", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoExtensionsClient) GetBucketTags(ctx context.Context, in *GetBucketTagsRequest) (*GetBucketTagsResponse, error) {
+	out := new(GetBucketTagsResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.MetainfoExtensions/GetBucketTags", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoExtensionsClient) SetObjectTags(ctx context.Context, in *SetObjectTagsRequest) (*SetObjectTagsResponse, error) {
+	out := new(SetObjectTagsResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.MetainfoExtensions/SetObjectTags", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoExtensionsClient) GetObjectTags(ctx context.Context, in *GetObjectTagsRequest) (*GetObjectTagsResponse, error) {
+	out := new(GetObjectTagsResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.MetainfoExtensions/GetObjectTags", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoExtensionsClient) ListTaggedObjects(ctx context.Context, in *ListTaggedObjectsRequest) (*ListTaggedObjectsResponse, error) {
+	out := new(ListTaggedObjectsResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.MetainfoExtensions/ListTaggedObjects", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoExtensionsClient) SetBucketLifecycleRule(ctx context.Context, in *SetBucketLifecycleRuleRequest) (*SetBucketLifecycleRuleResponse, error) {
+	out := new(SetBucketLifecycleRuleResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.MetainfoExtensions/SetBucketLifecycleRule", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoExtensionsClient) DeleteBucketLifecycleRules(ctx context.Context, in *DeleteBucketLifecycleRulesRequest) (*DeleteBucketLifecycleRulesResponse, error) {
+	out := new(DeleteBucketLifecycleRulesResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.MetainfoExtensions/DeleteBucketLifecycleRules", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoExtensionsClient) ListBucketLifecycleRules(ctx context.Context, in *ListBucketLifecycleRulesRequest) (*ListBucketLifecycleRulesResponse, error) {
+	out := new(ListBucketLifecycleRulesResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.MetainfoExtensions/ListBucketLifecycleRules", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoExtensionsClient) SetBucketRetention(ctx context.Context, in *SetBucketRetentionRequest) (*SetBucketRetentionResponse, error) {
+	out := new(SetBucketRetentionResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.MetainfoExtensions/SetBucketRetention", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoExtensionsClient) GetBucketRetention(ctx context.Context, in *GetBucketRetentionRequest) (*GetBucketRetentionResponse, error) {
+	out := new(GetBucketRetentionResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.MetainfoExtensions/GetBucketRetention", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoExtensionsClient) SetObjectRetention(ctx context.Context, in *SetObjectRetentionRequest) (*SetObjectRetentionResponse, error) {
+	out := new(SetObjectRetentionResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.MetainfoExtensions/SetObjectRetention", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoExtensionsClient) SetObjectLegalHold(ctx context.Context, in *SetObjectLegalHoldRequest) (*SetObjectLegalHoldResponse, error) {
+	out := new(SetObjectLegalHoldResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.MetainfoExtensions/SetObjectLegalHold", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMetainfoExtensionsClient) GetObjectLock(ctx context.Context, in *GetObjectLockRequest) (*GetObjectLockResponse, error) {
+	out := new(GetObjectLockResponse)
+	err := c.cc.Invoke(ctx, "/metainfoext.MetainfoExtensions/GetObjectLock", drpcEncoding_File_metainfoext_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type DRPCMetainfoExtensionsServer interface {
+	SetBucketVersioning(context.Context, *SetBucketVersioningRequest) (*SetBucketVersioningResponse, error)
+	GetBucketVersioning(context.Context, *GetBucketVersioningRequest) (*GetBucketVersioningResponse, error)
+	ListObjectVersions(context.Context, *ListObjectVersionsRequest) (*ListObjectVersionsResponse, error)
+	ListPrefixVersions(context.Context, *ListPrefixVersionsRequest) (*ListPrefixVersionsResponse, error)
+	DownloadObjectVersion(context.Context, *DownloadObjectVersionRequest) (*DownloadObjectVersionResponse, error)
+	RestoreObjectVersion(context.Context, *RestoreObjectVersionRequest) (*RestoreObjectVersionResponse, error)
+	BeginMoveObject(context.Context, *BeginMoveObjectRequest) (*BeginMoveObjectResponse, error)
+	FinishMoveObject(context.Context, *FinishMoveObjectRequest) (*FinishMoveObjectResponse, error)
+	BeginCopyObject(context.Context, *BeginCopyObjectRequest) (*BeginCopyObjectResponse, error)
+	FinishCopyObject(context.Context, *FinishCopyObjectRequest) (*FinishCopyObjectResponse, error)
+	SetBucketTags(context.Context, *SetBucketTagsRequest) (*SetBucketTagsResponse, error)
+	GetBucketTags(context.Context, *GetBucketTagsRequest) (*GetBucketTagsResponse, error)
+	SetObjectTags(context.Context, *SetObjectTagsRequest) (*SetObjectTagsResponse, error)
+	GetObjectTags(context.Context, *GetObjectTagsRequest) (*GetObjectTagsResponse, error)
+	ListTaggedObjects(context.Context, *ListTaggedObjectsRequest) (*ListTaggedObjectsResponse, error)
+	SetBucketLifecycleRule(context.Context, *SetBucketLifecycleRuleRequest) (*SetBucketLifecycleRuleResponse, error)
+	DeleteBucketLifecycleRules(context.Context, *DeleteBucketLifecycleRulesRequest) (*DeleteBucketLifecycleRulesResponse, error)
+	ListBucketLifecycleRules(context.Context, *ListBucketLifecycleRulesRequest) (*ListBucketLifecycleRulesResponse, error)
+	SetBucketRetention(context.Context, *SetBucketRetentionRequest) (*SetBucketRetentionResponse, error)
+	GetBucketRetention(context.Context, *GetBucketRetentionRequest) (*GetBucketRetentionResponse, error)
+	SetObjectRetention(context.Context, *SetObjectRetentionRequest) (*SetObjectRetentionResponse, error)
+	SetObjectLegalHold(context.Context, *SetObjectLegalHoldRequest) (*SetObjectLegalHoldResponse, error)
+	GetObjectLock(context.Context, *GetObjectLockRequest) (*GetObjectLockResponse, error)
+}
+
+type DRPCMetainfoExtensionsUnimplementedServer struct{}
+
+func (s *DRPCMetainfoExtensionsUnimplementedServer) SetBucketVersioning(context.Context, *SetBucketVersioningRequest) (*SetBucketVersioningResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCMetainfoExtensionsUnimplementedServer) GetBucketVersioning(context.Context, *GetBucketVersioningRequest) (*GetBucketVersioningResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCMetainfoExtensionsUnimplementedServer) ListObjectVersions(context.Context, *ListObjectVersionsRequest) (*ListObjectVersionsResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCMetainfoExtensionsUnimplementedServer) ListPrefixVersions(context.Context, *ListPrefixVersionsRequest) (*ListPrefixVersionsResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCMetainfoExtensionsUnimplementedServer) DownloadObjectVersion(context.Context, *DownloadObjectVersionRequest) (*DownloadObjectVersionResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCMetainfoExtensionsUnimplementedServer) RestoreObjectVersion(context.Context, *RestoreObjectVersionRequest) (*RestoreObjectVersionResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCMetainfoExtensionsUnimplementedServer) BeginMoveObject(context.Context, *BeginMoveObjectRequest) (*BeginMoveObjectResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCMetainfoExtensionsUnimplementedServer) FinishMoveObject(context.Context, *FinishMoveObjectRequest) (*FinishMoveObjectResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCMetainfoExtensionsUnimplementedServer) BeginCopyObject(context.Context, *BeginCopyObjectRequest) (*BeginCopyObjectResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCMetainfoExtensionsUnimplementedServer) FinishCopyObject(context.Context, *FinishCopyObjectRequest) (*FinishCopyObjectResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCMetainfoExtensionsUnimplementedServer) SetBucketTags(context.Context, *SetBucketTagsRequest) (*SetBucketTagsResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCMetainfoExtensionsUnimplementedServer) GetBucketTags(context.Context, *GetBucketTagsRequest) (*GetBucketTagsResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCMetainfoExtensionsUnimplementedServer) SetObjectTags(context.Context, *SetObjectTagsRequest) (*SetObjectTagsResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCMetainfoExtensionsUnimplementedServer) GetObjectTags(context.Context, *GetObjectTagsRequest) (*GetObjectTagsResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCMetainfoExtensionsUnimplementedServer) ListTaggedObjects(context.Context, *ListTaggedObjectsRequest) (*ListTaggedObjectsResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCMetainfoExtensionsUnimplementedServer) SetBucketLifecycleRule(context.Context, *SetBucketLifecycleRuleRequest) (*SetBucketLifecycleRuleResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCMetainfoExtensionsUnimplementedServer) DeleteBucketLifecycleRules(context.Context, *DeleteBucketLifecycleRulesRequest) (*DeleteBucketLifecycleRulesResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCMetainfoExtensionsUnimplementedServer) ListBucketLifecycleRules(context.Context, *ListBucketLifecycleRulesRequest) (*ListBucketLifecycleRulesResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCMetainfoExtensionsUnimplementedServer) SetBucketRetention(context.Context, *SetBucketRetentionRequest) (*SetBucketRetentionResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCMetainfoExtensionsUnimplementedServer) GetBucketRetention(context.Context, *GetBucketRetentionRequest) (*GetBucketRetentionResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCMetainfoExtensionsUnimplementedServer) SetObjectRetention(context.Context, *SetObjectRetentionRequest) (*SetObjectRetentionResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCMetainfoExtensionsUnimplementedServer) SetObjectLegalHold(context.Context, *SetObjectLegalHoldRequest) (*SetObjectLegalHoldResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCMetainfoExtensionsUnimplementedServer) GetObjectLock(context.Context, *GetObjectLockRequest) (*GetObjectLockResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+type DRPCMetainfoExtensionsDescription struct{}
+
+func (DRPCMetainfoExtensionsDescription) NumMethods() int { return 23 }
+
+func (DRPCMetainfoExtensionsDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, interface{}, bool) {
+	switch n {
+	case 0:
+		return "/metainfoext.MetainfoExtensions/SetBucketVersioning", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoExtensionsServer).
+					SetBucketVersioning(
+						ctx,
+						in1.(*SetBucketVersioningRequest),
+					)
+			}, DRPCMetainfoExtensionsServer.SetBucketVersioning, true
+	case 1:
+		return "/metainfoext.MetainfoExtensions/GetBucketVersioning", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoExtensionsServer).
+					GetBucketVersioning(
+						ctx,
+						in1.(*GetBucketVersioningRequest),
+					)
+			}, DRPCMetainfoExtensionsServer.GetBucketVersioning, true
+	case 2:
+		return "/metainfoext.MetainfoExtensions/ListObjectVersions", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoExtensionsServer).
+					ListObjectVersions(
+						ctx,
+						in1.(*ListObjectVersionsRequest),
+					)
+			}, DRPCMetainfoExtensionsServer.ListObjectVersions, true
+	case 3:
+		return "/metainfoext.MetainfoExtensions/ListPrefixVersions", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoExtensionsServer).
+					ListPrefixVersions(
+						ctx,
+						in1.(*ListPrefixVersionsRequest),
+					)
+			}, DRPCMetainfoExtensionsServer.ListPrefixVersions, true
+	case 4:
+		return "/metainfoext.MetainfoExtensions/DownloadObjectVersion", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoExtensionsServer).
+					DownloadObjectVersion(
+						ctx,
+						in1.(*DownloadObjectVersionRequest),
+					)
+			}, DRPCMetainfoExtensionsServer.DownloadObjectVersion, true
+	case 5:
+		return "/metainfoext.MetainfoExtensions/RestoreObjectVersion", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoExtensionsServer).
+					RestoreObjectVersion(
+						ctx,
+						in1.(*RestoreObjectVersionRequest),
+					)
+			}, DRPCMetainfoExtensionsServer.RestoreObjectVersion, true
+	case 6:
+		return "/metainfoext.MetainfoExtensions/BeginMoveObject", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoExtensionsServer).
+					BeginMoveObject(
+						ctx,
+						in1.(*BeginMoveObjectRequest),
+					)
+			}, DRPCMetainfoExtensionsServer.BeginMoveObject, true
+	case 7:
+		return "/metainfoext.MetainfoExtensions/FinishMoveObject", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoExtensionsServer).
+					FinishMoveObject(
+						ctx,
+						in1.(*FinishMoveObjectRequest),
+					)
+			}, DRPCMetainfoExtensionsServer.FinishMoveObject, true
+	case 8:
+		return "/metainfoext.MetainfoExtensions/BeginCopyObject", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoExtensionsServer).
+					BeginCopyObject(
+						ctx,
+						in1.(*BeginCopyObjectRequest),
+					)
+			}, DRPCMetainfoExtensionsServer.BeginCopyObject, true
+	case 9:
+		return "/metainfoext.MetainfoExtensions/FinishCopyObject", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoExtensionsServer).
+					FinishCopyObject(
+						ctx,
+						in1.(*FinishCopyObjectRequest),
+					)
+			}, DRPCMetainfoExtensionsServer.FinishCopyObject, true
+	case 10:
+		return "/metainfoext.MetainfoExtensions/SetBucketTags", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoExtensionsServer).
+					SetBucketTags(
+						ctx,
+						in1.(*SetBucketTagsRequest),
+					)
+			}, DRPCMetainfoExtensionsServer.SetBucketTags, true
+	case 11:
+		return "/metainfoext.MetainfoExtensions/GetBucketTags", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoExtensionsServer).
+					GetBucketTags(
+						ctx,
+						in1.(*GetBucketTagsRequest),
+					)
+			}, DRPCMetainfoExtensionsServer.GetBucketTags, true
+	case 12:
+		return "/metainfoext.MetainfoExtensions/SetObjectTags", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoExtensionsServer).
+					SetObjectTags(
+						ctx,
+						in1.(*SetObjectTagsRequest),
+					)
+			}, DRPCMetainfoExtensionsServer.SetObjectTags, true
+	case 13:
+		return "/metainfoext.MetainfoExtensions/GetObjectTags", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoExtensionsServer).
+					GetObjectTags(
+						ctx,
+						in1.(*GetObjectTagsRequest),
+					)
+			}, DRPCMetainfoExtensionsServer.GetObjectTags, true
+	case 14:
+		return "/metainfoext.MetainfoExtensions/ListTaggedObjects", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoExtensionsServer).
+					ListTaggedObjects(
+						ctx,
+						in1.(*ListTaggedObjectsRequest),
+					)
+			}, DRPCMetainfoExtensionsServer.ListTaggedObjects, true
+	case 15:
+		return "/metainfoext.MetainfoExtensions/SetBucketLifecycleRule", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoExtensionsServer).
+					SetBucketLifecycleRule(
+						ctx,
+						in1.(*SetBucketLifecycleRuleRequest),
+					)
+			}, DRPCMetainfoExtensionsServer.SetBucketLifecycleRule, true
+	case 16:
+		return "/metainfoext.MetainfoExtensions/DeleteBucketLifecycleRules", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoExtensionsServer).
+					DeleteBucketLifecycleRules(
+						ctx,
+						in1.(*DeleteBucketLifecycleRulesRequest),
+					)
+			}, DRPCMetainfoExtensionsServer.DeleteBucketLifecycleRules, true
+	case 17:
+		return "/metainfoext.MetainfoExtensions/ListBucketLifecycleRules", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoExtensionsServer).
+					ListBucketLifecycleRules(
+						ctx,
+						in1.(*ListBucketLifecycleRulesRequest),
+					)
+			}, DRPCMetainfoExtensionsServer.ListBucketLifecycleRules, true
+	case 18:
+		return "/metainfoext.MetainfoExtensions/SetBucketRetention", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoExtensionsServer).
+					SetBucketRetention(
+						ctx,
+						in1.(*SetBucketRetentionRequest),
+					)
+			}, DRPCMetainfoExtensionsServer.SetBucketRetention, true
+	case 19:
+		return "/metainfoext.MetainfoExtensions/GetBucketRetention", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoExtensionsServer).
+					GetBucketRetention(
+						ctx,
+						in1.(*GetBucketRetentionRequest),
+					)
+			}, DRPCMetainfoExtensionsServer.GetBucketRetention, true
+	case 20:
+		return "/metainfoext.MetainfoExtensions/SetObjectRetention", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoExtensionsServer).
+					SetObjectRetention(
+						ctx,
+						in1.(*SetObjectRetentionRequest),
+					)
+			}, DRPCMetainfoExtensionsServer.SetObjectRetention, true
+	case 21:
+		return "/metainfoext.MetainfoExtensions/SetObjectLegalHold", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoExtensionsServer).
+					SetObjectLegalHold(
+						ctx,
+						in1.(*SetObjectLegalHoldRequest),
+					)
+			}, DRPCMetainfoExtensionsServer.SetObjectLegalHold, true
+	case 22:
+		return "/metainfoext.MetainfoExtensions/GetObjectLock", drpcEncoding_File_metainfoext_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMetainfoExtensionsServer).
+					GetObjectLock(
+						ctx,
+						in1.(*GetObjectLockRequest),
+					)
+			}, DRPCMetainfoExtensionsServer.GetObjectLock, true
+	default:
+		return "", nil, nil, nil, false
+	}
+}
+
+func DRPCRegisterMetainfoExtensions(mux drpc.Mux, impl DRPCMetainfoExtensionsServer) error {
+	return mux.Register(impl, DRPCMetainfoExtensionsDescription{})
+}
+
+type DRPCMetainfoExtensions_SetBucketVersioningStream interface {
+	drpc.Stream
+	SendAndClose(*SetBucketVersioningResponse) error
+}
+
+type drpcMetainfoExtensions_SetBucketVersioningStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfoExtensions_SetBucketVersioningStream) SendAndClose(m *SetBucketVersioningResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfoExtensions_GetBucketVersioningStream interface {
+	drpc.Stream
+	SendAndClose(*GetBucketVersioningResponse) error
+}
+
+type drpcMetainfoExtensions_GetBucketVersioningStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfoExtensions_GetBucketVersioningStream) SendAndClose(m *GetBucketVersioningResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfoExtensions_ListObjectVersionsStream interface {
+	drpc.Stream
+	SendAndClose(*ListObjectVersionsResponse) error
+}
+
+type drpcMetainfoExtensions_ListObjectVersionsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfoExtensions_ListObjectVersionsStream) SendAndClose(m *ListObjectVersionsResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfoExtensions_ListPrefixVersionsStream interface {
+	drpc.Stream
+	SendAndClose(*ListPrefixVersionsResponse) error
+}
+
+type drpcMetainfoExtensions_ListPrefixVersionsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfoExtensions_ListPrefixVersionsStream) SendAndClose(m *ListPrefixVersionsResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfoExtensions_DownloadObjectVersionStream interface {
+	drpc.Stream
+	SendAndClose(*DownloadObjectVersionResponse) error
+}
+
+type drpcMetainfoExtensions_DownloadObjectVersionStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfoExtensions_DownloadObjectVersionStream) SendAndClose(m *DownloadObjectVersionResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfoExtensions_RestoreObjectVersionStream interface {
+	drpc.Stream
+	SendAndClose(*RestoreObjectVersionResponse) error
+}
+
+type drpcMetainfoExtensions_RestoreObjectVersionStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfoExtensions_RestoreObjectVersionStream) SendAndClose(m *RestoreObjectVersionResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfoExtensions_BeginMoveObjectStream interface {
+	drpc.Stream
+	SendAndClose(*BeginMoveObjectResponse) error
+}
+
+type drpcMetainfoExtensions_BeginMoveObjectStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfoExtensions_BeginMoveObjectStream) SendAndClose(m *BeginMoveObjectResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfoExtensions_FinishMoveObjectStream interface {
+	drpc.Stream
+	SendAndClose(*FinishMoveObjectResponse) error
+}
+
+type drpcMetainfoExtensions_FinishMoveObjectStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfoExtensions_FinishMoveObjectStream) SendAndClose(m *FinishMoveObjectResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfoExtensions_BeginCopyObjectStream interface {
+	drpc.Stream
+	SendAndClose(*BeginCopyObjectResponse) error
+}
+
+type drpcMetainfoExtensions_BeginCopyObjectStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfoExtensions_BeginCopyObjectStream) SendAndClose(m *BeginCopyObjectResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfoExtensions_FinishCopyObjectStream interface {
+	drpc.Stream
+	SendAndClose(*FinishCopyObjectResponse) error
+}
+
+type drpcMetainfoExtensions_FinishCopyObjectStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfoExtensions_FinishCopyObjectStream) SendAndClose(m *FinishCopyObjectResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfoExtensions_SetBucketTagsStream interface {
+	drpc.Stream
+	SendAndClose(*SetBucketTagsResponse) error
+}
+
+type drpcMetainfoExtensions_SetBucketTagsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfoExtensions_SetBucketTagsStream) SendAndClose(m *SetBucketTagsResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfoExtensions_GetBucketTagsStream interface {
+	drpc.Stream
+	SendAndClose(*GetBucketTagsResponse) error
+}
+
+type drpcMetainfoExtensions_GetBucketTagsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfoExtensions_GetBucketTagsStream) SendAndClose(m *GetBucketTagsResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfoExtensions_SetObjectTagsStream interface {
+	drpc.Stream
+	SendAndClose(*SetObjectTagsResponse) error
+}
+
+type drpcMetainfoExtensions_SetObjectTagsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfoExtensions_SetObjectTagsStream) SendAndClose(m *SetObjectTagsResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfoExtensions_GetObjectTagsStream interface {
+	drpc.Stream
+	SendAndClose(*GetObjectTagsResponse) error
+}
+
+type drpcMetainfoExtensions_GetObjectTagsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfoExtensions_GetObjectTagsStream) SendAndClose(m *GetObjectTagsResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfoExtensions_ListTaggedObjectsStream interface {
+	drpc.Stream
+	SendAndClose(*ListTaggedObjectsResponse) error
+}
+
+type drpcMetainfoExtensions_ListTaggedObjectsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfoExtensions_ListTaggedObjectsStream) SendAndClose(m *ListTaggedObjectsResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfoExtensions_SetBucketLifecycleRuleStream interface {
+	drpc.Stream
+	SendAndClose(*SetBucketLifecycleRuleResponse) error
+}
+
+type drpcMetainfoExtensions_SetBucketLifecycleRuleStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfoExtensions_SetBucketLifecycleRuleStream) SendAndClose(m *SetBucketLifecycleRuleResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfoExtensions_DeleteBucketLifecycleRulesStream interface {
+	drpc.Stream
+	SendAndClose(*DeleteBucketLifecycleRulesResponse) error
+}
+
+type drpcMetainfoExtensions_DeleteBucketLifecycleRulesStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfoExtensions_DeleteBucketLifecycleRulesStream) SendAndClose(m *DeleteBucketLifecycleRulesResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfoExtensions_ListBucketLifecycleRulesStream interface {
+	drpc.Stream
+	SendAndClose(*ListBucketLifecycleRulesResponse) error
+}
+
+type drpcMetainfoExtensions_ListBucketLifecycleRulesStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfoExtensions_ListBucketLifecycleRulesStream) SendAndClose(m *ListBucketLifecycleRulesResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfoExtensions_SetBucketRetentionStream interface {
+	drpc.Stream
+	SendAndClose(*SetBucketRetentionResponse) error
+}
+
+type drpcMetainfoExtensions_SetBucketRetentionStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfoExtensions_SetBucketRetentionStream) SendAndClose(m *SetBucketRetentionResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfoExtensions_GetBucketRetentionStream interface {
+	drpc.Stream
+	SendAndClose(*GetBucketRetentionResponse) error
+}
+
+type drpcMetainfoExtensions_GetBucketRetentionStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfoExtensions_GetBucketRetentionStream) SendAndClose(m *GetBucketRetentionResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfoExtensions_SetObjectRetentionStream interface {
+	drpc.Stream
+	SendAndClose(*SetObjectRetentionResponse) error
+}
+
+type drpcMetainfoExtensions_SetObjectRetentionStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfoExtensions_SetObjectRetentionStream) SendAndClose(m *SetObjectRetentionResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfoExtensions_SetObjectLegalHoldStream interface {
+	drpc.Stream
+	SendAndClose(*SetObjectLegalHoldResponse) error
+}
+
+type drpcMetainfoExtensions_SetObjectLegalHoldStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfoExtensions_SetObjectLegalHoldStream) SendAndClose(m *SetObjectLegalHoldResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMetainfoExtensions_GetObjectLockStream interface {
+	drpc.Stream
+	SendAndClose(*GetObjectLockResponse) error
+}
+
+type drpcMetainfoExtensions_GetObjectLockStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMetainfoExtensions_GetObjectLockStream) SendAndClose(m *GetObjectLockResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_metainfoext_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
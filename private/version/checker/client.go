@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"reflect"
 	"strings"
 	"time"
@@ -17,6 +18,7 @@ import (
 	"github.com/zeebo/errs"
 
 	"storj.io/private/version"
+	"storj.io/storj/private/version/release"
 )
 
 var (
@@ -124,6 +126,43 @@ func (client *Client) Process(ctx context.Context, processName string) (process
 	return process, nil
 }
 
+// Manifest returns the signed release manifest of the minimum or suggested
+// version of the named process, for the given platform.
+func (client *Client) Manifest(ctx context.Context, processName, versionType, os, arch string) (signed release.SignedManifest, err error) {
+	defer mon.Task()(&ctx, processName, versionType)(&err)
+
+	httpClient := http.Client{
+		Timeout: client.config.RequestTimeout,
+	}
+
+	manifestURL := strings.TrimSuffix(client.config.ServerAddress, "/") +
+		"/processes/" + url.PathEscape(processName) + "/" + url.PathEscape(versionType) + "/manifest?" +
+		url.Values{"os": {os}, "arch": {arch}}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestURL, nil)
+	if err != nil {
+		return release.SignedManifest{}, Error.Wrap(err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return release.SignedManifest{}, Error.Wrap(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return release.SignedManifest{}, Error.Wrap(err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return release.SignedManifest{}, Error.New("non-success http status code: %d; body: %s\n", resp.StatusCode, body)
+	}
+
+	err = json.Unmarshal(body, &signed)
+	return signed, Error.Wrap(err)
+}
+
 func kebabToPascal(str string) string {
 	return strings.ReplaceAll(strings.Title(str), "-", "")
 }
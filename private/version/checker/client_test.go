@@ -4,6 +4,7 @@
 package checker_test
 
 import (
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"reflect"
@@ -15,6 +16,7 @@ import (
 	"storj.io/common/testcontext"
 	"storj.io/private/version"
 	"storj.io/storj/private/version/checker"
+	"storj.io/storj/private/version/release"
 	"storj.io/storj/versioncontrol"
 )
 
@@ -86,6 +88,60 @@ func TestClient_Process(t *testing.T) {
 	}
 }
 
+func TestClient_Manifest(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	keyPath := ctx.File("release.key")
+	publicKey, err := release.GenerateKey(keyPath)
+	require.NoError(t, err)
+
+	hash := sha256.Sum256([]byte("archive"))
+	versions := newTestVersions(t)
+	versions.Storagenode.Suggested.URL = "https://example.test/storagenode_{os}_{arch}.zip"
+	versions.Storagenode.Suggested.Hashes = []string{"linux_amd64:" + hex.EncodeToString(hash[:])}
+
+	peer, err := versioncontrol.New(zaptest.NewLogger(t), &versioncontrol.Config{
+		Address: "127.0.0.1:0",
+		Versions: versioncontrol.OldVersionConfig{
+			Satellite:   "v0.0.1",
+			Storagenode: "v0.0.1",
+			Uplink:      "v0.0.1",
+			Gateway:     "v0.0.1",
+			Identity:    "v0.0.1",
+		},
+		Binary:     versions,
+		SigningKey: keyPath,
+	})
+	require.NoError(t, err)
+	ctx.Go(func() error {
+		return peer.Run(ctx)
+	})
+	defer ctx.Check(peer.Close)
+
+	client := checker.New(checker.ClientConfig{
+		ServerAddress: "http://" + peer.Addr(),
+	})
+
+	signed, err := client.Manifest(ctx, "storagenode", "suggested", "linux", "amd64")
+	require.NoError(t, err)
+	manifest, err := signed.Verify(publicKey)
+	require.NoError(t, err)
+	require.Equal(t, release.Manifest{
+		Service: "storagenode",
+		Version: versions.Storagenode.Suggested.Version,
+		OS:      "linux",
+		Arch:    "amd64",
+		URL:     "https://example.test/storagenode_linux_amd64.zip",
+		SHA256:  hex.EncodeToString(hash[:]),
+	}, manifest)
+	require.NoError(t, manifest.CheckHash(hash[:]))
+
+	// binaries without a hash don't have a manifest.
+	_, err = client.Manifest(ctx, "storagenode", "suggested", "linux", "arm")
+	require.Error(t, err)
+}
+
 func newTestPeer(t *testing.T, ctx *testcontext.Context) *versioncontrol.Peer {
 	t.Helper()
 
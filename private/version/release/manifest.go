@@ -0,0 +1,123 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package release implements the signed manifests of release binaries.
+package release
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+
+	"github.com/zeebo/errs"
+)
+
+// Error is the error class for release manifest errors.
+var Error = errs.Class("release")
+
+// Manifest describes a release binary archive.
+type Manifest struct {
+	Service string `json:"service"`
+	Version string `json:"version"`
+	OS      string `json:"os"`
+	Arch    string `json:"arch"`
+	URL     string `json:"url"`
+	// SHA256 is the hex-encoded hash of the archive at URL.
+	SHA256 string `json:"sha256"`
+}
+
+// SignedManifest is a manifest with the signature of the release key.
+type SignedManifest struct {
+	// Manifest is kept in its encoded form, since the signature is of exactly
+	// these bytes.
+	Manifest  json.RawMessage `json:"manifest"`
+	Signature []byte          `json:"signature"`
+}
+
+// Sign signs the manifest with the release key.
+func Sign(manifest Manifest, key ed25519.PrivateKey) (SignedManifest, error) {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return SignedManifest{}, Error.Wrap(err)
+	}
+	return SignedManifest{
+		Manifest:  data,
+		Signature: ed25519.Sign(key, data),
+	}, nil
+}
+
+// Verify checks the signature of the manifest and returns it.
+func (signed SignedManifest) Verify(key ed25519.PublicKey) (Manifest, error) {
+	if !ed25519.Verify(key, signed.Manifest, signed.Signature) {
+		return Manifest{}, Error.New("invalid manifest signature")
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(signed.Manifest, &manifest); err != nil {
+		return Manifest{}, Error.Wrap(err)
+	}
+	return manifest, nil
+}
+
+// CheckHash checks that hash, as calculated by sha256, is the hash in the manifest.
+func (manifest Manifest) CheckHash(hash []byte) error {
+	expected, err := hex.DecodeString(manifest.SHA256)
+	if err != nil {
+		return Error.New("invalid manifest hash: %v", err)
+	}
+	if len(expected) != sha256.Size || !bytes.Equal(expected, hash) {
+		return Error.New("hash mismatch: wants %s got %x", manifest.SHA256, hash)
+	}
+	return nil
+}
+
+// ParsePublicKey parses a hex-encoded release public key.
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	key, err := hex.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, Error.New("invalid public key: %v", err)
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, Error.New("invalid public key length: %d", len(key))
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// LoadPrivateKey loads a hex-encoded release private key from a file.
+func LoadPrivateKey(path string) (ed25519.PrivateKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	key, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, Error.New("invalid private key: %v", err)
+	}
+	switch len(key) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(key), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(key), nil
+	default:
+		return nil, Error.New("invalid private key length: %d", len(key))
+	}
+}
+
+// GenerateKey creates a new release key, saves the private key to a file and
+// returns the public key.
+func GenerateKey(path string) (ed25519.PublicKey, error) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(hex.EncodeToString(private.Seed())+"\n"), 0600); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return public, nil
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package release_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/version/release"
+)
+
+func TestSignedManifest(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	keyPath := ctx.File("release.key")
+	publicKey, err := release.GenerateKey(keyPath)
+	require.NoError(t, err)
+	privateKey, err := release.LoadPrivateKey(keyPath)
+	require.NoError(t, err)
+
+	parsed, err := release.ParsePublicKey(hex.EncodeToString(publicKey))
+	require.NoError(t, err)
+	require.Equal(t, publicKey, parsed)
+
+	hash := sha256.Sum256([]byte("archive"))
+	manifest := release.Manifest{
+		Service: "storagenode",
+		Version: "v1.2.3",
+		OS:      "linux",
+		Arch:    "amd64",
+		URL:     "https://example.test/storagenode_linux_amd64.zip",
+		SHA256:  hex.EncodeToString(hash[:]),
+	}
+
+	signed, err := release.Sign(manifest, privateKey)
+	require.NoError(t, err)
+
+	// the manifest has to survive being sent.
+	data, err := json.Marshal(signed)
+	require.NoError(t, err)
+	var received release.SignedManifest
+	require.NoError(t, json.Unmarshal(data, &received))
+
+	verified, err := received.Verify(publicKey)
+	require.NoError(t, err)
+	require.Equal(t, manifest, verified)
+	require.NoError(t, verified.CheckHash(hash[:]))
+
+	other := sha256.Sum256([]byte("other archive"))
+	require.Error(t, verified.CheckHash(other[:]))
+
+	// a changed manifest isn't accepted.
+	tampered := received
+	tampered.Manifest = []byte(`{"service":"storagenode","version":"v1.2.3","url":"https://example.test/evil.zip"}`)
+	_, err = tampered.Verify(publicKey)
+	require.Error(t, err)
+
+	// nor one signed with another key.
+	otherPublicKey, err := release.GenerateKey(ctx.File("other.key"))
+	require.NoError(t, err)
+	_, err = received.Verify(otherPublicKey)
+	require.Error(t, err)
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/macaroon"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/revocation"
+)
+
+var (
+	// ErrUsageAPI - console usage api error type.
+	ErrUsageAPI = errs.Class("console usage")
+)
+
+// BucketUsage contains the usage of a single bucket for a period.
+type BucketUsage struct {
+	BucketName string `json:"bucketName"`
+
+	StorageGBHours float64 `json:"storageGbHours"`
+	EgressGB       float64 `json:"egressGb"`
+	ObjectHours    float64 `json:"objectHours"`
+	SegmentHours   float64 `json:"segmentHours"`
+
+	Since  time.Time `json:"since"`
+	Before time.Time `json:"before"`
+}
+
+// Usage is an api controller that exposes usage related functionality to
+// clients authenticated with an api key instead of a session.
+type Usage struct {
+	log         *zap.Logger
+	service     *console.Service
+	revocations revocation.DB
+}
+
+// NewUsage is a constructor for api usage controller.
+func NewUsage(log *zap.Logger, service *console.Service, revocations revocation.DB) *Usage {
+	return &Usage{
+		log:         log,
+		service:     service,
+		revocations: revocations,
+	}
+}
+
+// BucketUsage returns the usage of every bucket the api key from the
+// Authorization header has access to, for the period set by the since and
+// before query params in unix seconds.
+func (u *Usage) BucketUsage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		u.serveJSONError(w, http.StatusUnauthorized, errs.New("missing api key"))
+		return
+	}
+
+	apiKey, err := macaroon.ParseAPIKey(strings.TrimPrefix(header, "Bearer "))
+	if err != nil {
+		u.serveJSONError(w, http.StatusUnauthorized, errs.New("invalid api key: %v", err))
+		return
+	}
+
+	sinceStamp, err := strconv.ParseInt(r.URL.Query().Get("since"), 10, 64)
+	if err != nil {
+		u.serveJSONError(w, http.StatusBadRequest, errs.New("invalid since param: %v", err))
+		return
+	}
+	beforeStamp, err := strconv.ParseInt(r.URL.Query().Get("before"), 10, 64)
+	if err != nil {
+		u.serveJSONError(w, http.StatusBadRequest, errs.New("invalid before param: %v", err))
+		return
+	}
+
+	since := time.Unix(sinceStamp, 0).UTC()
+	before := time.Unix(beforeStamp, 0).UTC()
+	if !since.Before(before) {
+		u.serveJSONError(w, http.StatusBadRequest, errs.New("since must be earlier than before"))
+		return
+	}
+
+	rollups, err := u.service.GetBucketUsageRollupsByAPIKey(ctx, apiKey, u.revocations, since, before)
+	if err != nil {
+		if console.ErrUnauthorized.Has(err) {
+			u.serveJSONError(w, http.StatusUnauthorized, err)
+			return
+		}
+
+		u.serveJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	usage := make([]BucketUsage, 0, len(rollups))
+	for _, rollup := range rollups {
+		usage = append(usage, BucketUsage{
+			BucketName:     string(rollup.BucketName),
+			StorageGBHours: rollup.TotalStoredData,
+			EgressGB:       rollup.GetEgress,
+			ObjectHours:    rollup.ObjectCount,
+			SegmentHours:   rollup.TotalSegments,
+			Since:          rollup.Since,
+			Before:         rollup.Before,
+		})
+	}
+
+	err = json.NewEncoder(w).Encode(usage)
+	if err != nil {
+		u.log.Error("error encoding bucket usage", zap.Error(ErrUsageAPI.Wrap(err)))
+	}
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (u *Usage) serveJSONError(w http.ResponseWriter, status int, err error) {
+	serveJSONError(u.log, w, status, err)
+}
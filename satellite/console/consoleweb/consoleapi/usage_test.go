@@ -0,0 +1,97 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/macaroon"
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite/accounting"
+	"storj.io/storj/satellite/console/consoleweb/consoleapi"
+	"storj.io/storj/satellite/metabase"
+)
+
+func TestBucketUsage(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		projectID := planet.Uplinks[0].Projects[0].ID
+		apiKey := planet.Uplinks[0].APIKey[sat.ID()]
+
+		now := time.Now().UTC()
+		since := now.Add(-4 * time.Hour)
+
+		for i := 3; i >= 1; i-- {
+			tallies := make(map[metabase.BucketLocation]*accounting.BucketTally)
+			for _, bucket := range []string{"alpha", "beta"} {
+				location := metabase.BucketLocation{ProjectID: projectID, BucketName: bucket}
+				tallies[location] = &accounting.BucketTally{
+					BucketLocation: location,
+					ObjectCount:    10,
+					TotalSegments:  20,
+					TotalBytes:     1e9,
+				}
+			}
+			err := sat.DB.ProjectAccounting().SaveTallies(ctx, now.Add(-time.Duration(i)*time.Hour), tallies)
+			require.NoError(t, err)
+		}
+
+		query := func(header string) (int, []consoleapi.BucketUsage) {
+			req, err := http.NewRequestWithContext(ctx, "GET", "http://"+sat.API.Console.Listener.Addr().String()+
+				"/api/v0/usage/buckets?since="+strconv.FormatInt(since.Unix(), 10)+"&before="+strconv.FormatInt(now.Unix(), 10), nil)
+			require.NoError(t, err)
+			if header != "" {
+				req.Header.Set("Authorization", header)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer func() { require.NoError(t, resp.Body.Close()) }()
+
+			if resp.StatusCode != http.StatusOK {
+				return resp.StatusCode, nil
+			}
+
+			var usage []consoleapi.BucketUsage
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&usage))
+			return resp.StatusCode, usage
+		}
+
+		status, usage := query("Bearer " + apiKey.Serialize())
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, usage, 2)
+		for _, bucket := range usage {
+			require.NotZero(t, bucket.StorageGBHours)
+			require.NotZero(t, bucket.ObjectHours)
+			require.NotZero(t, bucket.SegmentHours)
+		}
+
+		restricted, err := apiKey.Restrict(macaroon.Caveat{
+			AllowedPaths: []*macaroon.Caveat_Path{{Bucket: []byte("beta")}},
+		})
+		require.NoError(t, err)
+
+		status, usage = query("Bearer " + restricted.Serialize())
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, usage, 1)
+		require.Equal(t, "beta", usage[0].BucketName)
+
+		status, _ = query("")
+		require.Equal(t, http.StatusUnauthorized, status)
+
+		unknown, err := macaroon.NewAPIKey([]byte("secret"))
+		require.NoError(t, err)
+		status, _ = query("Bearer " + unknown.Serialize())
+		require.Equal(t, http.StatusUnauthorized, status)
+	})
+}
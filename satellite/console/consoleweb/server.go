@@ -41,6 +41,7 @@ import (
 	"storj.io/storj/satellite/console/consoleweb/consolewebauth"
 	"storj.io/storj/satellite/mailservice"
 	"storj.io/storj/satellite/payments/paymentsconfig"
+	"storj.io/storj/satellite/revocation"
 	"storj.io/storj/satellite/rewards"
 )
 
@@ -140,6 +141,7 @@ type Server struct {
 	mailService *mailservice.Service
 	partners    *rewards.PartnersService
 	analytics   *analytics.Service
+	revocations revocation.DB
 
 	listener    net.Listener
 	server      http.Server
@@ -161,7 +163,7 @@ type Server struct {
 }
 
 // NewServer creates new instance of console server.
-func NewServer(logger *zap.Logger, config Config, service *console.Service, mailService *mailservice.Service, partners *rewards.PartnersService, analytics *analytics.Service, listener net.Listener, stripePublicKey string, pricing paymentsconfig.PricingValues, nodeURL storj.NodeURL) *Server {
+func NewServer(logger *zap.Logger, config Config, service *console.Service, mailService *mailservice.Service, partners *rewards.PartnersService, analytics *analytics.Service, revocations revocation.DB, listener net.Listener, stripePublicKey string, pricing paymentsconfig.PricingValues, nodeURL storj.NodeURL) *Server {
 	server := Server{
 		log:             logger,
 		config:          config,
@@ -170,6 +172,7 @@ func NewServer(logger *zap.Logger, config Config, service *console.Service, mail
 		mailService:     mailService,
 		partners:        partners,
 		analytics:       analytics,
+		revocations:     revocations,
 		stripePublicKey: stripePublicKey,
 		rateLimiter:     web.NewIPRateLimiter(config.RateLimit),
 		nodeURL:         nodeURL,
@@ -213,6 +216,12 @@ func NewServer(logger *zap.Logger, config Config, service *console.Service, mail
 		server.withAuth(http.HandlerFunc(usageLimitsController.TotalUsageLimits)),
 	).Methods(http.MethodGet)
 
+	usageController := consoleapi.NewUsage(logger, service, revocations)
+	router.Handle(
+		"/api/v0/usage/buckets",
+		server.rateLimiter.Limit(http.HandlerFunc(usageController.BucketUsage)),
+	).Methods(http.MethodGet)
+
 	authController := consoleapi.NewAuth(logger, service, mailService, server.cookieAuth, partners, server.analytics, server.config.ExternalAddress, config.LetUsKnowURL, config.TermsAndConditionsURL, config.ContactInfoURL)
 	authRouter := router.PathPrefix("/api/v0/auth").Subrouter()
 	authRouter.Handle("/account", server.withAuth(http.HandlerFunc(authController.GetAccount))).Methods(http.MethodGet)
@@ -29,6 +29,7 @@ import (
 	"storj.io/storj/satellite/analytics"
 	"storj.io/storj/satellite/console/consoleauth"
 	"storj.io/storj/satellite/payments"
+	"storj.io/storj/satellite/revocation"
 	"storj.io/storj/satellite/rewards"
 )
 
@@ -1602,6 +1603,52 @@ func (s *Service) GetBucketUsageRollups(ctx context.Context, projectID uuid.UUID
 	return result, nil
 }
 
+// GetBucketUsageRollupsByAPIKey retrieves summed usage rollups of the buckets the api key
+// is allowed to access for a given period. The api key is used instead of the session,
+// so that usage can be queried by tools that only have an access grant.
+func (s *Service) GetBucketUsageRollupsByAPIKey(ctx context.Context, apiKey *macaroon.APIKey, revoker revocation.DB, since, before time.Time) (_ []accounting.BucketUsageRollup, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	info, err := s.store.APIKeys().GetByHead(ctx, apiKey.Head())
+	if err != nil {
+		return nil, ErrUnauthorized.New(unauthorizedErrMsg)
+	}
+
+	action := macaroon.Action{
+		Op:   macaroon.ActionProjectInfo,
+		Time: time.Now(),
+	}
+	if err := apiKey.Check(ctx, info.Secret, action, revoker); err != nil {
+		return nil, ErrUnauthorized.New(unauthorizedErrMsg)
+	}
+
+	allowed, err := apiKey.GetAllowedBuckets(ctx, macaroon.Action{
+		Op:   macaroon.ActionRead,
+		Time: action.Time,
+	})
+	if err != nil {
+		return nil, ErrUnauthorized.Wrap(err)
+	}
+
+	rollups, err := s.projectAccounting.GetBucketUsageRollups(ctx, info.ProjectID, since, before)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if allowed.All {
+		return rollups, nil
+	}
+
+	result := rollups[:0]
+	for _, rollup := range rollups {
+		if _, ok := allowed.Buckets[string(rollup.BucketName)]; ok {
+			result = append(result, rollup)
+		}
+	}
+
+	return result, nil
+}
+
 // GetProjectUsageLimits returns project limits and current usage.
 //
 // Among others,it can return one of the following errors returned by
@@ -488,6 +488,7 @@ func (db *DB) CommitObject(ctx context.Context, opts CommitObject) (object Objec
 				total_encrypted_size = $11,
 				fixed_segment_size   = $12,
 				zombie_deletion_deadline = NULL,
+				retain_until             = `+bucketRetainUntil("$1", "$2")+`,
 
 				-- TODO should we allow to override existing encryption parameters or return error if don't match with opts?
 				encryption = CASE
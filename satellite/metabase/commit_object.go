@@ -99,7 +99,8 @@ func (db *DB) CommitObjectWithSegments(ctx context.Context, opts CommitObjectWit
 				total_plain_size     = $10,
 				total_encrypted_size = $11,
 				fixed_segment_size   = $12,
-				zombie_deletion_deadline = NULL
+				zombie_deletion_deadline = NULL,
+				retain_until             = `+bucketRetainUntil("$1", "$2")+`
 			WHERE
 				project_id   = $1 AND
 				bucket_name  = $2 AND
@@ -276,6 +276,10 @@ type Version int64
 // NextVersion means that the version should be chosen automatically.
 const NextVersion = Version(0)
 
+// MaxVersion is the largest version, versions are stored as INT4. A cursor with
+// MaxVersion continues after all versions of its key.
+const MaxVersion = Version(math.MaxInt32)
+
 // ObjectStatus defines the statuses that the object might be in.
 type ObjectStatus byte
 
@@ -285,9 +289,13 @@ const (
 	Pending = ObjectStatus(1)
 	// Committed means that the object is finished and should be visible for general listing.
 	Committed = ObjectStatus(3)
+	// DeleteMarker means that the object was deleted in a bucket with versioning enabled.
+	// It hides the older versions of the object without removing them.
+	DeleteMarker = ObjectStatus(4)
 
-	pendingStatus   = "1"
-	committedStatus = "3"
+	pendingStatus      = "1"
+	committedStatus    = "3"
+	deleteMarkerStatus = "4"
 )
 
 // Pieces defines information for pieces.
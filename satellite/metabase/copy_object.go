@@ -0,0 +1,381 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/private/dbutil/pgutil"
+	"storj.io/private/dbutil/txutil"
+	"storj.io/private/tagsql"
+)
+
+// BeginCopyObject contains arguments necessary for starting an object copy.
+type BeginCopyObject struct {
+	Version Version
+	ObjectLocation
+}
+
+// BeginCopyObjectResult holds data needed to finish copy object.
+type BeginCopyObjectResult BeginMoveObjectResult
+
+// BeginCopyObject collects all the information necessary to re-encrypt an
+// object for a copy without touching its pieces on storage nodes.
+func (db *DB) BeginCopyObject(ctx context.Context, opts BeginCopyObject) (result BeginCopyObjectResult, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.ObjectLocation.Verify(); err != nil {
+		return BeginCopyObjectResult{}, err
+	}
+
+	if opts.Version <= 0 {
+		return BeginCopyObjectResult{}, ErrInvalidRequest.New("Version invalid: %v", opts.Version)
+	}
+
+	keys, err := db.objectEncryptionKeys(ctx, opts.ObjectLocation, opts.Version)
+	return BeginCopyObjectResult(keys), err
+}
+
+// FinishCopyObject contains arguments necessary for finishing an object copy.
+type FinishCopyObject struct {
+	ObjectStream
+
+	NewStreamID                  uuid.UUID
+	NewBucket                    string
+	NewEncryptedObjectKey        []byte
+	NewEncryptedMetadataKeyNonce []byte
+	NewEncryptedMetadataKey      []byte
+	NewSegmentKeys               []EncryptedKeyAndNonce
+
+	// Overwrite replaces the objects at the new location. In buckets with
+	// versioning enabled the copy becomes the latest version instead.
+	Overwrite bool
+}
+
+// FinishCopyObjectResult is the result of finishing an object copy.
+type FinishCopyObjectResult struct {
+	Object Object
+	// Overwritten are the objects deleted at the new location, their pieces
+	// have to be deleted from the storage nodes.
+	Overwritten DeleteObjectResult
+}
+
+// Verify verifies finish copy object fields.
+func (opts *FinishCopyObject) Verify() error {
+	if opts.NewStreamID.IsZero() {
+		return ErrInvalidRequest.New("NewStreamID is missing")
+	}
+	if opts.NewStreamID == opts.StreamID {
+		return ErrInvalidRequest.New("NewStreamID must differ from StreamID")
+	}
+
+	move := FinishMoveObject{
+		ObjectStream:                 opts.ObjectStream,
+		NewBucket:                    opts.NewBucket,
+		NewEncryptedObjectKey:        opts.NewEncryptedObjectKey,
+		NewEncryptedMetadataKeyNonce: opts.NewEncryptedMetadataKeyNonce,
+		NewEncryptedMetadataKey:      opts.NewEncryptedMetadataKey,
+		NewSegmentKeys:               opts.NewSegmentKeys,
+	}
+	return move.Verify()
+}
+
+// FinishCopyObject creates a copy of a committed object at a new bucket and key.
+// The copy gets its own segments which reference the same pieces as the source
+// segments. The relation between both streams is recorded so that deleting one
+// of them does not delete pieces still used by the other.
+//
+// Existing objects at the new location are only replaced with Overwrite, and
+// not when they are protected by a retention or a legal hold.
+func (db *DB) FinishCopyObject(ctx context.Context, opts FinishCopyObject) (result FinishCopyObjectResult, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return FinishCopyObjectResult{}, err
+	}
+
+	newLocation := ObjectLocation{
+		ProjectID:  opts.ProjectID,
+		BucketName: opts.NewBucket,
+		ObjectKey:  ObjectKey(opts.NewEncryptedObjectKey),
+	}
+
+	err = txutil.WithTx(ctx, db.db, nil, func(ctx context.Context, tx tagsql.Tx) error {
+		var versioned bool
+		err := tx.QueryRowContext(ctx, `
+			SELECT EXISTS (
+				SELECT 1 FROM bucket_versioning
+				WHERE
+					project_id  = $1 AND
+					bucket_name = $2
+			)
+		`, opts.ProjectID, []byte(opts.NewBucket)).Scan(&versioned)
+		if err != nil {
+			return Error.New("unable to query bucket versioning: %w", err)
+		}
+
+		if opts.Overwrite && !versioned {
+			if newLocation == opts.Location() {
+				return ErrInvalidRequest.New("object can't be copied onto itself")
+			}
+			result.Overwritten, err = db.deleteObjectAnyStatusAllVersions(ctx, tx, newLocation)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !opts.Overwrite || (!versioned && len(result.Overwritten.Objects) == 0) {
+			var exists bool
+			err := tx.QueryRowContext(ctx, `
+				SELECT EXISTS (
+					SELECT 1 FROM objects
+					WHERE
+						project_id   = $1 AND
+						bucket_name  = $2 AND
+						object_key   = $3 AND
+						status       = `+committedStatus+`
+				)
+			`, opts.ProjectID, []byte(opts.NewBucket), opts.NewEncryptedObjectKey).Scan(&exists)
+			if err != nil {
+				return Error.New("unable to query target object: %w", err)
+			}
+			switch {
+			case exists && opts.Overwrite:
+				// unlocked objects would have been deleted.
+				mon.Meter("object_delete_locked").Mark(1)
+				return ErrObjectLocked.New("")
+			case exists:
+				return ErrObjectAlreadyExists.New("")
+			}
+		}
+
+		result.Object, err = insertObjectCopy(ctx, tx, opts)
+		return err
+	})
+	if err != nil {
+		return FinishCopyObjectResult{}, err
+	}
+
+	mon.Meter("finish_copy_object").Mark(1)
+	mon.Meter("object_delete").Mark(len(result.Overwritten.Objects))
+	mon.Meter("segment_delete").Mark(len(result.Overwritten.Segments))
+
+	return result, nil
+}
+
+// insertObjectCopy inserts a new version of the object at the new location using
+// the segments and pieces of the source object stream.
+func insertObjectCopy(ctx context.Context, tx tagsql.Tx, opts FinishCopyObject) (object Object, err error) {
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO objects (
+			project_id, bucket_name, object_key, version, stream_id,
+			expires_at, status, segment_count,
+			encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
+			total_plain_size, total_encrypted_size, fixed_segment_size,
+			encryption,
+			zombie_deletion_deadline,
+			retain_until,
+			tags
+		)
+		SELECT
+			$1, $6, $7,
+			coalesce((
+				SELECT max(version) + 1 FROM objects
+				WHERE
+					project_id  = $1 AND
+					bucket_name = $6 AND
+					object_key  = $7
+			), 1),
+			$8,
+			expires_at, status, segment_count,
+			$9, encrypted_metadata, $10,
+			total_plain_size, total_encrypted_size, fixed_segment_size,
+			encryption,
+			NULL,
+			`+bucketRetainUntil("$1", "$6")+`,
+			tags
+		FROM objects
+		WHERE
+			project_id   = $1 AND
+			bucket_name  = $2 AND
+			object_key   = $3 AND
+			version      = $4 AND
+			stream_id    = $5 AND
+			status       = `+committedStatus+`
+		RETURNING
+			version, created_at, expires_at,
+			status, segment_count,
+			encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
+			total_plain_size, total_encrypted_size, fixed_segment_size,
+			encryption
+	`, opts.ProjectID, []byte(opts.BucketName), []byte(opts.ObjectKey), opts.Version, opts.StreamID,
+		[]byte(opts.NewBucket), opts.NewEncryptedObjectKey, opts.NewStreamID,
+		opts.NewEncryptedMetadataKeyNonce, opts.NewEncryptedMetadataKey).
+		Scan(
+			&object.Version, &object.CreatedAt, &object.ExpiresAt,
+			&object.Status, &object.SegmentCount,
+			&object.EncryptedMetadataNonce, &object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey,
+			&object.TotalPlainSize, &object.TotalEncryptedSize, &object.FixedSegmentSize,
+			encryptionParameters{&object.Encryption},
+		)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Object{}, storj.ErrObjectNotFound.Wrap(Error.New("object not found"))
+		}
+		return Object{}, Error.New("unable to copy object: %w", err)
+	}
+
+	if int(object.SegmentCount) != len(opts.NewSegmentKeys) {
+		return Object{}, ErrInvalidRequest.New("wrong amount of segments keys received (received %d, need %d)",
+			len(opts.NewSegmentKeys), object.SegmentCount)
+	}
+
+	if len(opts.NewSegmentKeys) > 0 {
+		var positions []int64
+		var nonces, keys [][]byte
+		for _, key := range opts.NewSegmentKeys {
+			positions = append(positions, int64(key.Position.Encode()))
+			nonces = append(nonces, key.EncryptedKeyNonce)
+			keys = append(keys, key.EncryptedKey)
+		}
+
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO segments (
+				stream_id, position,
+				root_piece_id, encrypted_key_nonce, encrypted_key,
+				encrypted_size, plain_offset, plain_size, encrypted_etag,
+				redundancy,
+				inline_data, remote_alias_pieces,
+				expires_at
+			)
+			SELECT
+				$2, segments.position,
+				segments.root_piece_id, P.encrypted_key_nonce, P.encrypted_key,
+				segments.encrypted_size, segments.plain_offset, segments.plain_size, segments.encrypted_etag,
+				segments.redundancy,
+				segments.inline_data, segments.remote_alias_pieces,
+				segments.expires_at
+			FROM segments
+			JOIN (SELECT unnest($3::INT8[]), unnest($4::BYTEA[]), unnest($5::BYTEA[])) as P(position, encrypted_key_nonce, encrypted_key)
+				ON segments.position = P.position
+			WHERE segments.stream_id = $1
+		`, opts.StreamID, opts.NewStreamID, pgutil.Int8Array(positions), pgutil.ByteaArray(nonces), pgutil.ByteaArray(keys))
+		if err != nil {
+			return Object{}, Error.New("unable to copy segments: %w", err)
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return Object{}, Error.New("failed to get rows affected: %w", err)
+		}
+		if affected != int64(len(opts.NewSegmentKeys)) {
+			return Object{}, Error.New("segment is missing")
+		}
+	}
+
+	// copies of copies all refer to the original ancestor, so that all streams
+	// sharing the same pieces can be found with a single lookup.
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO segment_copies (stream_id, ancestor_stream_id)
+		VALUES ($1, coalesce((SELECT ancestor_stream_id FROM segment_copies WHERE stream_id = $2), $2))
+	`, opts.NewStreamID, opts.StreamID)
+	if err != nil {
+		return Object{}, Error.New("unable to record segment copy: %w", err)
+	}
+
+	object.ProjectID = opts.ProjectID
+	object.BucketName = opts.NewBucket
+	object.ObjectKey = ObjectKey(opts.NewEncryptedObjectKey)
+	object.StreamID = opts.NewStreamID
+
+	return object, nil
+}
+
+// withoutSharedSegments removes the segments whose pieces are still referenced by
+// a copy of one of the deleted streams, so that their pieces are not deleted from
+// the storage nodes. It also forgets the copy relations of the deleted streams.
+// It has to run in the transaction that deletes the streams, so that a copy
+// committed concurrently can't lose the pieces it shares.
+func withoutSharedSegments(ctx context.Context, tx tagsql.Tx, streamIDs []uuid.UUID, segments []DeletedSegmentInfo) (_ []DeletedSegmentInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(streamIDs) == 0 {
+		return segments, nil
+	}
+
+	ids := make([][]byte, len(streamIDs))
+	for i := range streamIDs {
+		ids[i] = streamIDs[i][:]
+	}
+
+	rootPieceIDs := make([][]byte, len(segments))
+	for i, segment := range segments {
+		rootPieceIDs[i] = segment.RootPieceID.Bytes()
+	}
+
+	shared := map[storj.PieceID]struct{}{}
+	if len(segments) > 0 {
+		err = withRows(tx.QueryContext(ctx, `
+			SELECT DISTINCT root_piece_id FROM segments
+			WHERE
+				root_piece_id = ANY($2::BYTEA[]) AND
+				stream_id IN (
+					SELECT stream_id FROM segment_copies WHERE ancestor_stream_id = ANY($1::BYTEA[])
+					UNION
+					SELECT ancestor_stream_id FROM segment_copies WHERE stream_id = ANY($1::BYTEA[])
+					UNION
+					SELECT others.stream_id FROM segment_copies AS copies
+					JOIN segment_copies AS others ON copies.ancestor_stream_id = others.ancestor_stream_id
+					WHERE copies.stream_id = ANY($1::BYTEA[])
+				)
+		`, pgutil.ByteaArray(ids), pgutil.ByteaArray(rootPieceIDs)))(func(rows tagsql.Rows) error {
+			for rows.Next() {
+				var rootPieceID storj.PieceID
+				if err := rows.Scan(&rootPieceID); err != nil {
+					return Error.New("unable to scan shared segment: %w", err)
+				}
+				shared[rootPieceID] = struct{}{}
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, Error.New("unable to query shared segments: %w", err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM segment_copies WHERE stream_id = ANY($1::BYTEA[])
+	`, pgutil.ByteaArray(ids))
+	if err != nil {
+		return nil, Error.New("unable to delete segment copies: %w", err)
+	}
+
+	if len(shared) == 0 {
+		return segments, nil
+	}
+
+	filtered := segments[:0]
+	for _, segment := range segments {
+		if _, ok := shared[segment.RootPieceID]; !ok {
+			filtered = append(filtered, segment)
+		}
+	}
+	if len(filtered) == 0 {
+		return nil, nil
+	}
+	return filtered, nil
+}
+
+// deletedStreamIDs returns the stream ids of the deleted objects.
+func deletedStreamIDs(objects []Object) []uuid.UUID {
+	streamIDs := make([]uuid.UUID, len(objects))
+	for i, object := range objects {
+		streamIDs[i] = object.StreamID
+	}
+	return streamIDs
+}
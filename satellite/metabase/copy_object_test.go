@@ -0,0 +1,406 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestFinishCopyObject(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+		newBucketName := "New bucket name"
+		newObjectKey := metabase.ObjectKey(testrand.Bytes(16))
+
+		for _, test := range metabasetest.InvalidObjectStreams(obj) {
+			test := test
+			t.Run(test.Name, func(t *testing.T) {
+				defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+				metabasetest.FinishCopyObject{
+					Opts: metabase.FinishCopyObject{
+						ObjectStream:          test.ObjectStream,
+						NewStreamID:           testrand.UUID(),
+						NewBucket:             newBucketName,
+						NewEncryptedObjectKey: []byte(newObjectKey),
+					},
+					ErrClass: test.ErrClass,
+					ErrText:  test.ErrText,
+				}.Check(ctx, t, db)
+				metabasetest.Verify{}.Check(ctx, t, db)
+			})
+		}
+
+		t.Run("missing NewStreamID", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.FinishCopyObject{
+				Opts: metabase.FinishCopyObject{
+					ObjectStream:          obj,
+					NewBucket:             newBucketName,
+					NewEncryptedObjectKey: []byte(newObjectKey),
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "NewStreamID is missing",
+			}.Check(ctx, t, db)
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("object not found", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.FinishCopyObject{
+				Opts: metabase.FinishCopyObject{
+					ObjectStream:          obj,
+					NewStreamID:           testrand.UUID(),
+					NewBucket:             newBucketName,
+					NewEncryptedObjectKey: []byte(newObjectKey),
+				},
+				ErrClass: &storj.ErrObjectNotFound,
+				ErrText:  "metabase: object not found",
+			}.Check(ctx, t, db)
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("copy and delete both", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 2)
+
+			copyStream := metabase.ObjectStream{
+				ProjectID:  obj.ProjectID,
+				BucketName: newBucketName,
+				ObjectKey:  newObjectKey,
+				Version:    1,
+				StreamID:   testrand.UUID(),
+			}
+
+			segmentKeys, segments := copySegments(obj, copyStream, 2)
+
+			copied := metabasetest.FinishCopyObject{
+				Opts: metabase.FinishCopyObject{
+					ObjectStream:          obj,
+					NewStreamID:           copyStream.StreamID,
+					NewBucket:             copyStream.BucketName,
+					NewEncryptedObjectKey: []byte(copyStream.ObjectKey),
+					NewSegmentKeys:        segmentKeys,
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(object),
+					metabase.RawObject(copied),
+				},
+				Segments: segments,
+				SegmentCopies: []metabase.RawSegmentCopy{
+					{StreamID: copyStream.StreamID, AncestorStreamID: obj.StreamID},
+				},
+			}.Check(ctx, t, db)
+
+			// deleting the original must not delete the pieces used by the copy
+			metabasetest.DeleteObjectExactVersion{
+				Opts: metabase.DeleteObjectExactVersion{
+					ObjectLocation: obj.Location(),
+					Version:        obj.Version,
+				},
+				Result: metabase.DeleteObjectResult{
+					Objects: []metabase.Object{object},
+				},
+			}.Check(ctx, t, db)
+
+			// deleting the last copy returns the pieces for deletion
+			metabasetest.DeleteObjectExactVersion{
+				Opts: metabase.DeleteObjectExactVersion{
+					ObjectLocation: copyStream.Location(),
+					Version:        copied.Version,
+				},
+				Result: metabase.DeleteObjectResult{
+					Objects: []metabase.Object{copied},
+					Segments: []metabase.DeletedSegmentInfo{
+						{RootPieceID: segments[2].RootPieceID, Pieces: segments[2].Pieces},
+						{RootPieceID: segments[3].RootPieceID, Pieces: segments[3].Pieces},
+					},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("overwrite copy", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 2)
+
+			copyStream := metabase.ObjectStream{
+				ProjectID:  obj.ProjectID,
+				BucketName: newBucketName,
+				ObjectKey:  newObjectKey,
+				Version:    1,
+				StreamID:   testrand.UUID(),
+			}
+			segmentKeys, segments := copySegments(obj, copyStream, 2)
+
+			copied := metabasetest.FinishCopyObject{
+				Opts: metabase.FinishCopyObject{
+					ObjectStream:          obj,
+					NewStreamID:           copyStream.StreamID,
+					NewBucket:             copyStream.BucketName,
+					NewEncryptedObjectKey: []byte(copyStream.ObjectKey),
+					NewSegmentKeys:        segmentKeys,
+				},
+			}.Check(ctx, t, db)
+
+			// an upload overwriting the copy deletes it with all its versions,
+			// but the pieces are still used by the original
+			metabasetest.DeleteObjectAnyStatusAllVersions{
+				Opts: metabase.DeleteObjectAnyStatusAllVersions{
+					ObjectLocation: copyStream.Location(),
+				},
+				Result: metabase.DeleteObjectResult{
+					Objects: []metabase.Object{copied},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(object),
+				},
+				Segments: []metabase.RawSegment{segments[0], segments[2]},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("expired copy", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.CreateExpiredObject(ctx, t, db, obj, 2, time.Now().Add(-time.Hour))
+
+			copyStream := metabase.ObjectStream{
+				ProjectID:  obj.ProjectID,
+				BucketName: newBucketName,
+				ObjectKey:  newObjectKey,
+				Version:    1,
+				StreamID:   testrand.UUID(),
+			}
+			segmentKeys, _ := copySegments(obj, copyStream, 2)
+
+			metabasetest.FinishCopyObject{
+				Opts: metabase.FinishCopyObject{
+					ObjectStream:          obj,
+					NewStreamID:           copyStream.StreamID,
+					NewBucket:             copyStream.BucketName,
+					NewEncryptedObjectKey: []byte(copyStream.ObjectKey),
+					NewSegmentKeys:        segmentKeys,
+				},
+			}.Check(ctx, t, db)
+
+			// the copy expires together with the original and the copy
+			// relation must not be left behind
+			metabasetest.DeleteExpiredObjects{
+				Opts: metabase.DeleteExpiredObjects{
+					ExpiredBefore: time.Now(),
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("existing target", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 1)
+
+			targetStream := metabasetest.RandObjectStream()
+			targetStream.ProjectID = obj.ProjectID
+			targetStream.BucketName = newBucketName
+			targetStream.ObjectKey = newObjectKey
+			target := metabasetest.CreateObject(ctx, t, db, targetStream, 1)
+
+			copyStream := metabase.ObjectStream{
+				ProjectID:  obj.ProjectID,
+				BucketName: newBucketName,
+				ObjectKey:  newObjectKey,
+				Version:    1,
+				StreamID:   testrand.UUID(),
+			}
+			segmentKeys, segments := copySegments(obj, copyStream, 1)
+
+			opts := metabase.FinishCopyObject{
+				ObjectStream:          obj,
+				NewStreamID:           copyStream.StreamID,
+				NewBucket:             copyStream.BucketName,
+				NewEncryptedObjectKey: []byte(copyStream.ObjectKey),
+				NewSegmentKeys:        segmentKeys,
+			}
+
+			// without overwrite the target is kept.
+			metabasetest.FinishCopyObject{
+				Opts:     opts,
+				ErrClass: &metabase.ErrObjectAlreadyExists,
+			}.Check(ctx, t, db)
+
+			opts.Overwrite = true
+			copied := metabasetest.FinishCopyObject{
+				Opts: opts,
+				Overwritten: metabase.DeleteObjectResult{
+					Objects: []metabase.Object{target},
+					Segments: []metabase.DeletedSegmentInfo{{
+						RootPieceID: storj.PieceID{1},
+						Pieces:      metabase.Pieces{{Number: 0, StorageNode: storj.NodeID{2}}},
+					}},
+				},
+			}.Check(ctx, t, db)
+			require.Equal(t, metabase.Version(1), copied.Version)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(object),
+					metabase.RawObject(copied),
+				},
+				Segments: segments,
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("locked target", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 1)
+
+			targetStream := metabasetest.RandObjectStream()
+			targetStream.ProjectID = obj.ProjectID
+			targetStream.BucketName = newBucketName
+			targetStream.ObjectKey = newObjectKey
+			target := metabasetest.CreateObject(ctx, t, db, targetStream, 1)
+
+			metabasetest.SetObjectLegalHold{
+				Opts: metabase.SetObjectLegalHold{
+					ObjectLocation: targetStream.Location(),
+					Version:        targetStream.Version,
+					Enabled:        true,
+				},
+			}.Check(ctx, t, db)
+
+			segmentKeys, _ := copySegments(obj, targetStream, 1)
+			metabasetest.FinishCopyObject{
+				Opts: metabase.FinishCopyObject{
+					ObjectStream:          obj,
+					NewStreamID:           testrand.UUID(),
+					NewBucket:             newBucketName,
+					NewEncryptedObjectKey: []byte(newObjectKey),
+					NewSegmentKeys:        segmentKeys,
+					Overwrite:             true,
+				},
+				ErrClass: &metabase.ErrObjectLocked,
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(object),
+					metabase.RawObject(target),
+				},
+				Segments: []metabase.RawSegment{
+					metabasetest.DefaultRawSegment(obj, metabase.SegmentPosition{}),
+					metabasetest.DefaultRawSegment(targetStream, metabase.SegmentPosition{}),
+				},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("versioned target", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.SetBucketVersioning{
+				Opts: metabase.SetBucketVersioning{
+					BucketLocation: metabase.BucketLocation{ProjectID: obj.ProjectID, BucketName: newBucketName},
+					Enabled:        true,
+				},
+			}.Check(ctx, t, db)
+			defer metabasetest.SetBucketVersioning{
+				Opts: metabase.SetBucketVersioning{
+					BucketLocation: metabase.BucketLocation{ProjectID: obj.ProjectID, BucketName: newBucketName},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.CreateObject(ctx, t, db, obj, 1)
+
+			targetStream := metabasetest.RandObjectStream()
+			targetStream.ProjectID = obj.ProjectID
+			targetStream.BucketName = newBucketName
+			targetStream.ObjectKey = newObjectKey
+			metabasetest.CreateObject(ctx, t, db, targetStream, 1)
+
+			copyStream := targetStream
+			copyStream.StreamID = testrand.UUID()
+			segmentKeys, _ := copySegments(obj, copyStream, 1)
+
+			// the target is kept as an older version.
+			copied := metabasetest.FinishCopyObject{
+				Opts: metabase.FinishCopyObject{
+					ObjectStream:          obj,
+					NewStreamID:           copyStream.StreamID,
+					NewBucket:             newBucketName,
+					NewEncryptedObjectKey: []byte(newObjectKey),
+					NewSegmentKeys:        segmentKeys,
+					Overwrite:             true,
+				},
+			}.Check(ctx, t, db)
+			require.Equal(t, targetStream.Version+1, copied.Version)
+		})
+
+		t.Run("onto itself", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 1)
+
+			segmentKeys, _ := copySegments(obj, obj, 1)
+			metabasetest.FinishCopyObject{
+				Opts: metabase.FinishCopyObject{
+					ObjectStream:          obj,
+					NewStreamID:           testrand.UUID(),
+					NewBucket:             obj.BucketName,
+					NewEncryptedObjectKey: []byte(obj.ObjectKey),
+					NewSegmentKeys:        segmentKeys,
+					Overwrite:             true,
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "object can't be copied onto itself",
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects:  []metabase.RawObject{metabase.RawObject(object)},
+				Segments: []metabase.RawSegment{metabasetest.DefaultRawSegment(obj, metabase.SegmentPosition{})},
+			}.Check(ctx, t, db)
+		})
+	})
+}
+
+// copySegments returns the new segment keys for copying the segments of
+// the object and the segments expected after the copy, interleaved with
+// the ones of the source object.
+func copySegments(obj, copyStream metabase.ObjectStream, numberOfSegments uint32) (segmentKeys []metabase.EncryptedKeyAndNonce, segments []metabase.RawSegment) {
+	for i := uint32(0); i < numberOfSegments; i++ {
+		position := metabase.SegmentPosition{Index: i}
+		segments = append(segments, metabasetest.DefaultRawSegment(obj, position))
+
+		keys := metabase.EncryptedKeyAndNonce{
+			Position:          position,
+			EncryptedKeyNonce: testrand.Nonce().Bytes(),
+			EncryptedKey:      testrand.Bytes(32),
+		}
+		segmentKeys = append(segmentKeys, keys)
+
+		segment := metabasetest.DefaultRawSegment(copyStream, position)
+		segment.EncryptedKeyNonce = keys.EncryptedKeyNonce
+		segment.EncryptedKey = keys.EncryptedKey
+		segments = append(segments, segment)
+	}
+	return segmentKeys, segments
+}
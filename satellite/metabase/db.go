@@ -104,6 +104,11 @@ func (db *DB) DestroyTables(ctx context.Context) error {
 		DROP TABLE IF EXISTS objects;
 		DROP TABLE IF EXISTS segments;
 		DROP TABLE IF EXISTS node_aliases;
+		DROP TABLE IF EXISTS segment_copies;
+		DROP TABLE IF EXISTS bucket_versioning;
+		DROP TABLE IF EXISTS bucket_retention;
+		DROP TABLE IF EXISTS bucket_lifecycle_rules;
+		DROP TABLE IF EXISTS bucket_tags;
 		DROP SEQUENCE IF EXISTS node_alias_seq;
 	`)
 	db.aliasCache = NewNodeAliasCache(db)
@@ -310,6 +315,75 @@ func (db *DB) PostgresMigration() *migrate.Migration {
 					`ALTER TABLE segments ALTER COLUMN created_at SET NOT NULL`,
 				},
 			},
+			{
+				DB:          &db.db,
+				Description: "add segment_copies table for server-side copies",
+				Version:     14,
+				Action: migrate.SQL{
+					`CREATE TABLE segment_copies (
+						stream_id          BYTEA NOT NULL PRIMARY KEY,
+						ancestor_stream_id BYTEA NOT NULL
+					)`,
+					`CREATE INDEX segment_copies_ancestor_stream_id_index ON segment_copies (ancestor_stream_id)`,
+				},
+			},
+			{
+				DB:          &db.db,
+				Description: "add bucket_versioning table",
+				Version:     15,
+				Action: migrate.SQL{
+					`CREATE TABLE bucket_versioning (
+						project_id  BYTEA NOT NULL,
+						bucket_name BYTEA NOT NULL,
+						PRIMARY KEY (project_id, bucket_name)
+					)`,
+				},
+			},
+			{
+				DB:          &db.db,
+				Description: "add object retention and legal hold",
+				Version:     16,
+				Action: migrate.SQL{
+					`ALTER TABLE objects ADD COLUMN retain_until TIMESTAMPTZ`,
+					`ALTER TABLE objects ADD COLUMN legal_hold BOOLEAN NOT NULL DEFAULT false`,
+					`CREATE TABLE bucket_retention (
+						project_id     BYTEA NOT NULL,
+						bucket_name    BYTEA NOT NULL,
+						retention_days INT4  NOT NULL,
+						PRIMARY KEY (project_id, bucket_name)
+					)`,
+				},
+			},
+			{
+				DB:          &db.db,
+				Description: "add bucket lifecycle rules",
+				Version:     17,
+				Action: migrate.SQL{
+					`CREATE TABLE bucket_lifecycle_rules (
+						project_id  BYTEA NOT NULL,
+						bucket_name BYTEA NOT NULL,
+						name        TEXT  NOT NULL,
+						prefix      BYTEA NOT NULL,
+						action      INT2  NOT NULL,
+						age_days    INT4  NOT NULL,
+						PRIMARY KEY (project_id, bucket_name, name)
+					)`,
+				},
+			},
+			{
+				DB:          &db.db,
+				Description: "add bucket and object tags",
+				Version:     18,
+				Action: migrate.SQL{
+					`ALTER TABLE objects ADD COLUMN tags TEXT[]`,
+					`CREATE TABLE bucket_tags (
+						project_id  BYTEA  NOT NULL,
+						bucket_name BYTEA  NOT NULL,
+						tags        TEXT[] NOT NULL,
+						PRIMARY KEY (project_id, bucket_name)
+					)`,
+				},
+			},
 		},
 	}
 }
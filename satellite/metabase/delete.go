@@ -11,8 +11,10 @@ import (
 	"github.com/zeebo/errs"
 
 	"storj.io/common/storj"
+	"storj.io/common/uuid"
 	"storj.io/private/dbutil"
 	"storj.io/private/dbutil/pgutil"
+	"storj.io/private/dbutil/txutil"
 	"storj.io/private/tagsql"
 )
 
@@ -20,6 +22,9 @@ import (
 type DeleteObjectExactVersion struct {
 	Version Version
 	ObjectLocation
+
+	// StreamID is optional, when set only the version with this stream is deleted.
+	StreamID uuid.UUID
 }
 
 // Verify delete object fields.
@@ -91,46 +96,64 @@ type DeleteObjectLatestVersion struct {
 	ObjectLocation
 }
 
-// DeleteObjectExactVersion deletes an exact object version.
+// DeleteObjectExactVersion deletes an exact object version. Deleting a delete
+// marker makes the previous version of the object visible again. ErrObjectLocked
+// is returned for versions protected by a retention or a legal hold.
 func (db *DB) DeleteObjectExactVersion(ctx context.Context, opts DeleteObjectExactVersion) (result DeleteObjectResult, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	if err := opts.Verify(); err != nil {
 		return DeleteObjectResult{}, err
 	}
-	err = withRows(db.db.QueryContext(ctx, `
-			WITH deleted_objects AS (
-				DELETE FROM objects
-				WHERE
-					project_id   = $1 AND
-					bucket_name  = $2 AND
-					object_key   = $3 AND
-					version      = $4 AND
-					status       = `+committedStatus+`
-				RETURNING
-					version, stream_id,
-					created_at, expires_at,
-					status, segment_count,
-					encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
-					total_plain_size, total_encrypted_size, fixed_segment_size,
-					encryption
-			), deleted_segments AS (
-				DELETE FROM segments
-				WHERE segments.stream_id in (SELECT deleted_objects.stream_id FROM deleted_objects)
-				RETURNING segments.stream_id,segments.root_piece_id, segments.remote_alias_pieces
-			)
-			SELECT
-				deleted_objects.version, deleted_objects.stream_id,
-				deleted_objects.created_at, deleted_objects.expires_at,
-				deleted_objects.status, deleted_objects.segment_count,
-				deleted_objects.encrypted_metadata_nonce, deleted_objects.encrypted_metadata, deleted_objects.encrypted_metadata_encrypted_key,
-				deleted_objects.total_plain_size, deleted_objects.total_encrypted_size, deleted_objects.fixed_segment_size,
-				deleted_objects.encryption,
-				deleted_segments.root_piece_id, deleted_segments.remote_alias_pieces
-			FROM deleted_objects
-			LEFT JOIN deleted_segments ON deleted_objects.stream_id = deleted_segments.stream_id
-		`, opts.ProjectID, []byte(opts.BucketName), []byte(opts.ObjectKey), opts.Version))(func(rows tagsql.Rows) error {
-		result.Objects, result.Segments, err = db.scanObjectDeletion(ctx, opts.ObjectLocation, rows)
+
+	var streamID []byte
+	if !opts.StreamID.IsZero() {
+		streamID = opts.StreamID[:]
+	}
+
+	err = txutil.WithTx(ctx, db.db, nil, func(ctx context.Context, tx tagsql.Tx) error {
+		err := withRows(tx.QueryContext(ctx, `
+				WITH deleted_objects AS (
+					DELETE FROM objects
+					WHERE
+						project_id   = $1 AND
+						bucket_name  = $2 AND
+						object_key   = $3 AND
+						version      = $4 AND
+						($5::BYTEA IS NULL OR stream_id = $5) AND
+						status       IN (`+committedStatus+`, `+deleteMarkerStatus+`) AND
+						`+objectUnlocked+`
+					RETURNING
+						version, stream_id,
+						created_at, expires_at,
+						status, segment_count,
+						encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
+						total_plain_size, total_encrypted_size, fixed_segment_size,
+						encryption
+				), deleted_segments AS (
+					DELETE FROM segments
+					WHERE segments.stream_id in (SELECT deleted_objects.stream_id FROM deleted_objects)
+					RETURNING segments.stream_id,segments.root_piece_id, segments.remote_alias_pieces
+				)
+				SELECT
+					deleted_objects.version, deleted_objects.stream_id,
+					deleted_objects.created_at, deleted_objects.expires_at,
+					deleted_objects.status, deleted_objects.segment_count,
+					deleted_objects.encrypted_metadata_nonce, deleted_objects.encrypted_metadata, deleted_objects.encrypted_metadata_encrypted_key,
+					deleted_objects.total_plain_size, deleted_objects.total_encrypted_size, deleted_objects.fixed_segment_size,
+					deleted_objects.encryption,
+					deleted_segments.root_piece_id, deleted_segments.remote_alias_pieces
+				FROM deleted_objects
+				LEFT JOIN deleted_segments ON deleted_objects.stream_id = deleted_segments.stream_id
+			`, opts.ProjectID, []byte(opts.BucketName), []byte(opts.ObjectKey), opts.Version, streamID))(func(rows tagsql.Rows) error {
+			result.Objects, result.Segments, err = db.scanObjectDeletion(ctx, opts.ObjectLocation, rows)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Segments, err = withoutSharedSegments(ctx, tx, deletedStreamIDs(result.Objects), result.Segments)
 		return err
 	})
 	if err != nil {
@@ -138,7 +161,8 @@ func (db *DB) DeleteObjectExactVersion(ctx context.Context, opts DeleteObjectExa
 	}
 
 	if len(result.Objects) == 0 {
-		return DeleteObjectResult{}, storj.ErrObjectNotFound.Wrap(Error.New("no rows deleted"))
+		return DeleteObjectResult{}, db.lockedObjectError(ctx, opts.ObjectLocation, opts.Version,
+			storj.ErrObjectNotFound.Wrap(Error.New("no rows deleted")))
 	}
 
 	mon.Meter("object_delete").Mark(len(result.Objects))
@@ -219,7 +243,8 @@ func (db *DB) DeletePendingObject(ctx context.Context, opts DeletePendingObject)
 	return result, nil
 }
 
-// DeleteObjectLatestVersion deletes latest object version.
+// DeleteObjectLatestVersion deletes latest object version. ErrObjectLocked is
+// returned when it is protected by a retention or a legal hold.
 func (db *DB) DeleteObjectLatestVersion(ctx context.Context, opts DeleteObjectLatestVersion) (result DeleteObjectResult, err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -237,7 +262,16 @@ func (db *DB) DeleteObjectLatestVersion(ctx context.Context, opts DeleteObjectLa
 					project_id   = $1 AND
 					bucket_name  = $2 AND
 					object_key   = $3 AND
-					status       = ` + committedStatus + `
+					status       = ` + committedStatus + ` AND
+					version = (
+						SELECT max(version) FROM objects
+						WHERE
+							project_id   = $1 AND
+							bucket_name  = $2 AND
+							object_key   = $3 AND
+							status       = ` + committedStatus + `
+					) AND
+					` + objectUnlocked + `
 				ORDER BY version DESC
 				LIMIT 1
 				RETURNING
@@ -280,7 +314,8 @@ func (db *DB) DeleteObjectLatestVersion(ctx context.Context, opts DeleteObjectLa
 							status       = ` + committedStatus + `
 						ORDER BY version DESC LIMIT 1
 					) AND
-					status       = ` + committedStatus + `
+					status       = ` + committedStatus + ` AND
+					` + objectUnlocked + `
 				RETURNING
 					version, stream_id,
 					created_at, expires_at,
@@ -307,17 +342,25 @@ func (db *DB) DeleteObjectLatestVersion(ctx context.Context, opts DeleteObjectLa
 	default:
 		return DeleteObjectResult{}, Error.New("unhandled database: %v", db.impl)
 	}
-	err = withRows(db.db.QueryContext(ctx, query, opts.ProjectID, []byte(opts.BucketName), []byte(opts.ObjectKey)))(func(rows tagsql.Rows) error {
-		result.Objects, result.Segments, err = db.scanObjectDeletion(ctx, opts.ObjectLocation, rows)
+	err = txutil.WithTx(ctx, db.db, nil, func(ctx context.Context, tx tagsql.Tx) error {
+		err := withRows(tx.QueryContext(ctx, query, opts.ProjectID, []byte(opts.BucketName), []byte(opts.ObjectKey)))(func(rows tagsql.Rows) error {
+			result.Objects, result.Segments, err = db.scanObjectDeletion(ctx, opts.ObjectLocation, rows)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Segments, err = withoutSharedSegments(ctx, tx, deletedStreamIDs(result.Objects), result.Segments)
 		return err
 	})
-
 	if err != nil {
 		return DeleteObjectResult{}, err
 	}
 
 	if len(result.Objects) == 0 {
-		return DeleteObjectResult{}, storj.ErrObjectNotFound.Wrap(Error.New("no rows deleted"))
+		return DeleteObjectResult{}, db.lockedObjectError(ctx, opts.ObjectLocation, NextVersion,
+			storj.ErrObjectNotFound.Wrap(Error.New("no rows deleted")))
 	}
 
 	mon.Meter("object_delete").Mark(len(result.Objects))
@@ -326,7 +369,8 @@ func (db *DB) DeleteObjectLatestVersion(ctx context.Context, opts DeleteObjectLa
 	return result, nil
 }
 
-// DeleteObjectAnyStatusAllVersions deletes all object versions.
+// DeleteObjectAnyStatusAllVersions deletes all object versions. Nothing is deleted
+// when any of the versions is protected by a retention or a legal hold.
 func (db *DB) DeleteObjectAnyStatusAllVersions(ctx context.Context, opts DeleteObjectAnyStatusAllVersions) (result DeleteObjectResult, err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -334,13 +378,43 @@ func (db *DB) DeleteObjectAnyStatusAllVersions(ctx context.Context, opts DeleteO
 		return DeleteObjectResult{}, err
 	}
 
-	err = withRows(db.db.QueryContext(ctx, `
+	err = txutil.WithTx(ctx, db.db, nil, func(ctx context.Context, tx tagsql.Tx) error {
+		result, err = db.deleteObjectAnyStatusAllVersions(ctx, tx, opts.ObjectLocation)
+		return err
+	})
+	if err != nil {
+		return DeleteObjectResult{}, err
+	}
+
+	if len(result.Objects) == 0 {
+		return DeleteObjectResult{}, db.lockedObjectError(ctx, opts.ObjectLocation, NextVersion,
+			storj.ErrObjectNotFound.Wrap(Error.New("no rows deleted")))
+	}
+
+	mon.Meter("object_delete").Mark(len(result.Objects))
+	mon.Meter("segment_delete").Mark(len(result.Segments))
+
+	return result, nil
+}
+
+// deleteObjectAnyStatusAllVersions deletes all object versions within the
+// transaction. Nothing is deleted when any of the versions is locked.
+func (db *DB) deleteObjectAnyStatusAllVersions(ctx context.Context, tx tagsql.Tx, location ObjectLocation) (result DeleteObjectResult, err error) {
+	err = withRows(tx.QueryContext(ctx, `
 			WITH deleted_objects AS (
 				DELETE FROM objects
 				WHERE
 				project_id   = $1 AND
 				bucket_name  = $2 AND
-				object_key   = $3
+				object_key   = $3 AND
+				NOT EXISTS (
+					SELECT 1 FROM objects AS locked
+					WHERE
+						locked.project_id  = $1 AND
+						locked.bucket_name = $2 AND
+						locked.object_key  = $3 AND
+						(locked.legal_hold OR locked.retain_until > now())
+				)
 				RETURNING
 					version, stream_id,
 					created_at, expires_at,
@@ -363,26 +437,23 @@ func (db *DB) DeleteObjectAnyStatusAllVersions(ctx context.Context, opts DeleteO
 				deleted_segments.root_piece_id, deleted_segments.remote_alias_pieces
 			FROM deleted_objects
 			LEFT JOIN deleted_segments ON deleted_objects.stream_id = deleted_segments.stream_id
-		`, opts.ProjectID, []byte(opts.BucketName), []byte(opts.ObjectKey)))(func(rows tagsql.Rows) error {
-		result.Objects, result.Segments, err = db.scanObjectDeletion(ctx, opts.ObjectLocation, rows)
+		`, location.ProjectID, []byte(location.BucketName), []byte(location.ObjectKey)))(func(rows tagsql.Rows) error {
+		result.Objects, result.Segments, err = db.scanObjectDeletion(ctx, location, rows)
 		return err
 	})
-
 	if err != nil {
 		return DeleteObjectResult{}, err
 	}
 
-	if len(result.Objects) == 0 {
-		return DeleteObjectResult{}, storj.ErrObjectNotFound.Wrap(Error.New("no rows deleted"))
+	result.Segments, err = withoutSharedSegments(ctx, tx, deletedStreamIDs(result.Objects), result.Segments)
+	if err != nil {
+		return DeleteObjectResult{}, err
 	}
-
-	mon.Meter("object_delete").Mark(len(result.Objects))
-	mon.Meter("segment_delete").Mark(len(result.Segments))
-
 	return result, nil
 }
 
 // DeleteObjectsAllVersions deletes all versions of multiple objects from the same bucket.
+// Versions protected by a retention or a legal hold are skipped.
 func (db *DB) DeleteObjectsAllVersions(ctx context.Context, opts DeleteObjectsAllVersions) (result DeleteObjectResult, err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -409,43 +480,51 @@ func (db *DB) DeleteObjectsAllVersions(ctx context.Context, opts DeleteObjectsAl
 	sort.Slice(objectKeys, func(i, j int) bool {
 		return bytes.Compare(objectKeys[i], objectKeys[j]) < 0
 	})
-	err = withRows(db.db.QueryContext(ctx, `
-				WITH deleted_objects AS (
-					DELETE FROM objects
-					WHERE
-					project_id   = $1 AND
-					bucket_name  = $2 AND
-					object_key   = ANY ($3) AND
-					status       = `+committedStatus+`
-					RETURNING
-						project_id, bucket_name,
-						object_key, version, stream_id,
-						created_at, expires_at,
-						status, segment_count,
-						encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
-						total_plain_size, total_encrypted_size, fixed_segment_size,
-						encryption
-				), deleted_segments AS (
-					DELETE FROM segments
-					WHERE segments.stream_id in (SELECT deleted_objects.stream_id FROM deleted_objects)
-					RETURNING segments.stream_id,segments.root_piece_id, segments.remote_alias_pieces
-				)
-				SELECT
-					deleted_objects.project_id, deleted_objects.bucket_name,
-					deleted_objects.object_key,deleted_objects.version, deleted_objects.stream_id,
-					deleted_objects.created_at, deleted_objects.expires_at,
-					deleted_objects.status, deleted_objects.segment_count,
-					deleted_objects.encrypted_metadata_nonce, deleted_objects.encrypted_metadata, deleted_objects.encrypted_metadata_encrypted_key,
-					deleted_objects.total_plain_size, deleted_objects.total_encrypted_size, deleted_objects.fixed_segment_size,
-					deleted_objects.encryption,
-					deleted_segments.root_piece_id, deleted_segments.remote_alias_pieces
-				FROM deleted_objects
-				LEFT JOIN deleted_segments ON deleted_objects.stream_id = deleted_segments.stream_id
-			`, projectID, []byte(bucketName), pgutil.ByteaArray(objectKeys)))(func(rows tagsql.Rows) error {
-		result.Objects, result.Segments, err = db.scanMultipleObjectsDeletion(ctx, rows)
+	err = txutil.WithTx(ctx, db.db, nil, func(ctx context.Context, tx tagsql.Tx) error {
+		err := withRows(tx.QueryContext(ctx, `
+					WITH deleted_objects AS (
+						DELETE FROM objects
+						WHERE
+						project_id   = $1 AND
+						bucket_name  = $2 AND
+						object_key   = ANY ($3) AND
+						status       = `+committedStatus+` AND
+						`+objectUnlocked+`
+						RETURNING
+							project_id, bucket_name,
+							object_key, version, stream_id,
+							created_at, expires_at,
+							status, segment_count,
+							encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
+							total_plain_size, total_encrypted_size, fixed_segment_size,
+							encryption
+					), deleted_segments AS (
+						DELETE FROM segments
+						WHERE segments.stream_id in (SELECT deleted_objects.stream_id FROM deleted_objects)
+						RETURNING segments.stream_id,segments.root_piece_id, segments.remote_alias_pieces
+					)
+					SELECT
+						deleted_objects.project_id, deleted_objects.bucket_name,
+						deleted_objects.object_key,deleted_objects.version, deleted_objects.stream_id,
+						deleted_objects.created_at, deleted_objects.expires_at,
+						deleted_objects.status, deleted_objects.segment_count,
+						deleted_objects.encrypted_metadata_nonce, deleted_objects.encrypted_metadata, deleted_objects.encrypted_metadata_encrypted_key,
+						deleted_objects.total_plain_size, deleted_objects.total_encrypted_size, deleted_objects.fixed_segment_size,
+						deleted_objects.encryption,
+						deleted_segments.root_piece_id, deleted_segments.remote_alias_pieces
+					FROM deleted_objects
+					LEFT JOIN deleted_segments ON deleted_objects.stream_id = deleted_segments.stream_id
+				`, projectID, []byte(bucketName), pgutil.ByteaArray(objectKeys)))(func(rows tagsql.Rows) error {
+			result.Objects, result.Segments, err = db.scanMultipleObjectsDeletion(ctx, rows)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Segments, err = withoutSharedSegments(ctx, tx, deletedStreamIDs(result.Objects), result.Segments)
 		return err
 	})
-
 	if err != nil {
 		return DeleteObjectResult{}, err
 	}
@@ -8,8 +8,10 @@ import (
 	"database/sql"
 	"errors"
 
+	"storj.io/common/storj"
 	"storj.io/common/uuid"
 	"storj.io/private/dbutil"
+	"storj.io/private/dbutil/txutil"
 	"storj.io/private/tagsql"
 )
 
@@ -27,7 +29,8 @@ type DeleteBucketObjects struct {
 	DeletePieces func(ctx context.Context, segments []DeletedSegmentInfo) error
 }
 
-// DeleteBucketObjects deletes all objects in the specified bucket.
+// DeleteBucketObjects deletes all objects in the specified bucket, except the
+// ones protected by a retention or a legal hold.
 // Deletion performs in batches, so in case of error while processing,
 // this method will return the number of objects deleted to the moment
 // when an error occurs.
@@ -46,12 +49,16 @@ func (db *DB) DeleteBucketObjects(ctx context.Context, opts DeleteBucketObjects)
 		query = `
 		WITH deleted_objects AS (
 			DELETE FROM objects
-			WHERE project_id = $1 AND bucket_name = $2 LIMIT $3
+			WHERE project_id = $1 AND bucket_name = $2 AND ` + objectUnlocked + ` LIMIT $3
 			RETURNING objects.stream_id
+		), deleted_segments AS (
+			DELETE FROM segments
+			WHERE segments.stream_id IN (SELECT deleted_objects.stream_id FROM deleted_objects)
+			RETURNING segments.stream_id, segments.root_piece_id, segments.remote_alias_pieces
 		)
-		DELETE FROM segments
-		WHERE segments.stream_id in (SELECT deleted_objects.stream_id FROM deleted_objects)
-		RETURNING segments.stream_id, segments.root_piece_id, segments.remote_alias_pieces
+		SELECT deleted_objects.stream_id, deleted_segments.root_piece_id, deleted_segments.remote_alias_pieces
+		FROM deleted_objects
+		LEFT JOIN deleted_segments ON deleted_objects.stream_id = deleted_segments.stream_id
 	`
 	case dbutil.Postgres:
 		query = `
@@ -59,51 +66,74 @@ func (db *DB) DeleteBucketObjects(ctx context.Context, opts DeleteBucketObjects)
 			DELETE FROM objects
 			WHERE stream_id IN (
 				SELECT stream_id FROM objects
-				WHERE project_id = $1 AND bucket_name = $2
+				WHERE project_id = $1 AND bucket_name = $2 AND ` + objectUnlocked + `
 				LIMIT $3
 			)
 			RETURNING objects.stream_id
+		), deleted_segments AS (
+			DELETE FROM segments
+			WHERE segments.stream_id IN (SELECT deleted_objects.stream_id FROM deleted_objects)
+			RETURNING segments.stream_id, segments.root_piece_id, segments.remote_alias_pieces
 		)
-		DELETE FROM segments
-		WHERE segments.stream_id in (SELECT deleted_objects.stream_id FROM deleted_objects)
-		RETURNING segments.stream_id, segments.root_piece_id, segments.remote_alias_pieces
+		SELECT deleted_objects.stream_id, deleted_segments.root_piece_id, deleted_segments.remote_alias_pieces
+		FROM deleted_objects
+		LEFT JOIN deleted_segments ON deleted_objects.stream_id = deleted_segments.stream_id
 	`
 	default:
 		return 0, Error.New("unhandled database: %v", db.impl)
 	}
 
-	// TODO: fix the count for objects without segments
+	// objects without segments, like delete markers, are returned once with
+	// NULL segment columns, so every deleted object is counted.
 	deletedSegments := make([]DeletedSegmentInfo, 0, 100)
 	for {
 		if err := ctx.Err(); err != nil {
 			return 0, err
 		}
 
-		deletedSegments = deletedSegments[:0]
 		deletedObjects := 0
-		err = withRows(db.db.QueryContext(ctx, query,
-			opts.Bucket.ProjectID, []byte(opts.Bucket.BucketName), opts.BatchSize))(func(rows tagsql.Rows) error {
+		err = txutil.WithTx(ctx, db.db, nil, func(ctx context.Context, tx tagsql.Tx) error {
+			deletedSegments = deletedSegments[:0]
 			ids := map[uuid.UUID]struct{}{} // TODO: avoid map here
-			for rows.Next() {
-				var streamID uuid.UUID
-				var segment DeletedSegmentInfo
-				var aliasPieces AliasPieces
-				err := rows.Scan(&streamID, &segment.RootPieceID, &aliasPieces)
-				if err != nil {
-					return Error.Wrap(err)
+			err := withRows(tx.QueryContext(ctx, query,
+				opts.Bucket.ProjectID, []byte(opts.Bucket.BucketName), opts.BatchSize))(func(rows tagsql.Rows) error {
+				for rows.Next() {
+					var streamID uuid.UUID
+					var rootPieceID *storj.PieceID
+					var aliasPieces AliasPieces
+					err := rows.Scan(&streamID, &rootPieceID, &aliasPieces)
+					if err != nil {
+						return Error.Wrap(err)
+					}
+					ids[streamID] = struct{}{}
+					if rootPieceID == nil {
+						continue
+					}
+
+					segment := DeletedSegmentInfo{RootPieceID: *rootPieceID}
+					segment.Pieces, err = db.aliasCache.ConvertAliasesToPieces(ctx, aliasPieces)
+					if err != nil {
+						return Error.Wrap(err)
+					}
+					deletedSegments = append(deletedSegments, segment)
 				}
-				segment.Pieces, err = db.aliasCache.ConvertAliasesToPieces(ctx, aliasPieces)
-				if err != nil {
-					return Error.Wrap(err)
-				}
-
-				ids[streamID] = struct{}{}
-				deletedSegments = append(deletedSegments, segment)
+				return nil
+			})
+			if err != nil {
+				return err
 			}
 			deletedObjects = len(ids)
-			deletedObjectCount += int64(deletedObjects)
-			return nil
+
+			streamIDs := make([]uuid.UUID, 0, len(ids))
+			for streamID := range ids {
+				streamIDs = append(streamIDs, streamID)
+			}
+			deletedSegments, err = withoutSharedSegments(ctx, tx, streamIDs, deletedSegments)
+			return err
 		})
+		if err == nil {
+			deletedObjectCount += int64(deletedObjects)
+		}
 
 		mon.Meter("object_delete").Mark(deletedObjects)
 		mon.Meter("segment_delete").Mark(len(deletedSegments))
@@ -115,11 +145,11 @@ func (db *DB) DeleteBucketObjects(ctx context.Context, opts DeleteBucketObjects)
 			return deletedObjectCount, Error.Wrap(err)
 		}
 
-		if len(deletedSegments) == 0 {
+		if deletedObjects == 0 {
 			return deletedObjectCount, nil
 		}
 
-		if opts.DeletePieces != nil {
+		if opts.DeletePieces != nil && len(deletedSegments) > 0 {
 			err = opts.DeletePieces(ctx, deletedSegments)
 			if err != nil {
 				return deletedObjectCount, Error.Wrap(err)
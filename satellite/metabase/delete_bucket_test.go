@@ -113,9 +113,26 @@ func TestDeleteBucketObjects(t *testing.T) {
 						return errors.New("expected no segments")
 					},
 				},
-				// TODO: fix the count for objects without segments
-				// this should be 1.
-				Deleted: 0,
+				Deleted: 1,
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("empty objects in batches", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.CreateObject(ctx, t, db, obj1, 0)
+			metabasetest.CreateObject(ctx, t, db, obj2, 0)
+			metabasetest.CreateObject(ctx, t, db, obj3, 1)
+
+			// batches without segments don't stop the deletion.
+			metabasetest.DeleteBucketObjects{
+				Opts: metabase.DeleteBucketObjects{
+					Bucket:    obj1.Location().Bucket(),
+					BatchSize: 1,
+				},
+				Deleted: 3,
 			}.Check(ctx, t, db)
 
 			metabasetest.Verify{}.Check(ctx, t, db)
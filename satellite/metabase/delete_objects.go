@@ -28,6 +28,7 @@ type DeleteExpiredObjects struct {
 }
 
 // DeleteExpiredObjects deletes all objects that expired before expiredBefore.
+// Objects protected by a retention or a legal hold are kept until it is lifted.
 func (db *DB) DeleteExpiredObjects(ctx context.Context, opts DeleteExpiredObjects) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -41,6 +42,7 @@ func (db *DB) DeleteExpiredObjects(ctx context.Context, opts DeleteExpiredObject
 			WHERE
 				(project_id, bucket_name, object_key, version) > ($1, $2, $3, $4)
 				AND expires_at < $5
+				AND ` + objectUnlocked + `
 				ORDER BY project_id, bucket_name, object_key, version
 			LIMIT $6;`
 
@@ -188,6 +190,10 @@ func (db *DB) deleteObjectsAndSegments(ctx context.Context, objects []ObjectStre
 				DELETE FROM segments
 				WHERE segments.stream_id = $1::BYTEA
 			`, obj.StreamID)
+			batch.Queue(`
+				DELETE FROM segment_copies
+				WHERE segment_copies.stream_id = $1::BYTEA
+			`, obj.StreamID)
 			batch.Queue(`COMMIT TRANSACTION`)
 		}
 
@@ -201,7 +207,7 @@ func (db *DB) deleteObjectsAndSegments(ctx context.Context, objects []ObjectStre
 			result, err := results.Exec()
 			errlist.Add(err)
 
-			switch i % 3 {
+			switch i % 5 {
 			case 0: // start transcation
 			case 1: // delete objects
 				if err == nil {
@@ -211,7 +217,8 @@ func (db *DB) deleteObjectsAndSegments(ctx context.Context, objects []ObjectStre
 				if err == nil {
 					segmentsDeleted += result.RowsAffected()
 				}
-			case 3: // commit transaction
+			case 3: // delete segment copies
+			case 4: // commit transaction
 			}
 		}
 
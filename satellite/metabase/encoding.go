@@ -186,3 +186,52 @@ func (pieces *Pieces) Scan(value interface{}) error {
 	*pieces = scan
 	return nil
 }
+
+// Value implements sql/driver.Valuer interface.
+func (tags Tags) Value() (driver.Value, error) {
+	if len(tags) == 0 {
+		arr := &pgtype.TextArray{Status: pgtype.Null}
+		return arr.Value()
+	}
+
+	pairs := tags.pairs()
+	elems := make([]pgtype.Text, len(pairs))
+	for i, pair := range pairs {
+		elems[i].String = pair
+		elems[i].Status = pgtype.Present
+	}
+
+	arr := &pgtype.TextArray{
+		Elements:   elems,
+		Dimensions: []pgtype.ArrayDimension{{Length: int32(len(pairs)), LowerBound: 1}},
+		Status:     pgtype.Present,
+	}
+	return arr.Value()
+}
+
+// Scan implements sql.Scanner interface.
+func (tags *Tags) Scan(value interface{}) error {
+	var arr pgtype.TextArray
+	if err := arr.Scan(value); err != nil {
+		return err
+	}
+
+	if len(arr.Dimensions) == 0 {
+		*tags = nil
+		return nil
+	} else if len(arr.Dimensions) != 1 {
+		return unexpectedDimension{}
+	}
+
+	scan := make(Tags, len(arr.Elements))
+	for _, elem := range arr.Elements {
+		key, value, ok := splitTag(elem.String)
+		if !ok {
+			return Error.New("invalid tag %q", elem.String)
+		}
+		scan[key] = value
+	}
+
+	*tags = scan
+	return nil
+}
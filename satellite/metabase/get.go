@@ -120,6 +120,7 @@ type GetObjectLatestVersion struct {
 }
 
 // GetObjectLatestVersion returns object information for latest version.
+// When the latest version is a delete marker the object is reported as not found.
 func (db *DB) GetObjectLatestVersion(ctx context.Context, opts GetObjectLatestVersion) (_ Object, err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -130,7 +131,7 @@ func (db *DB) GetObjectLatestVersion(ctx context.Context, opts GetObjectLatestVe
 	object := Object{}
 	err = db.db.QueryRowContext(ctx, `
 		SELECT
-			stream_id, version,
+			stream_id, version, status,
 			created_at, expires_at,
 			segment_count,
 			encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
@@ -141,12 +142,12 @@ func (db *DB) GetObjectLatestVersion(ctx context.Context, opts GetObjectLatestVe
 			project_id   = $1 AND
 			bucket_name  = $2 AND
 			object_key   = $3 AND
-			status       = `+committedStatus+`
+			status       IN (`+committedStatus+`, `+deleteMarkerStatus+`)
 		ORDER BY version desc
 		LIMIT 1
 	`, opts.ProjectID, []byte(opts.BucketName), []byte(opts.ObjectKey)).
 		Scan(
-			&object.StreamID, &object.Version,
+			&object.StreamID, &object.Version, &object.Status,
 			&object.CreatedAt, &object.ExpiresAt,
 			&object.SegmentCount,
 			&object.EncryptedMetadataNonce, &object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey,
@@ -159,13 +160,14 @@ func (db *DB) GetObjectLatestVersion(ctx context.Context, opts GetObjectLatestVe
 		}
 		return Object{}, Error.New("unable to query object status: %w", err)
 	}
+	if object.Status == DeleteMarker {
+		return Object{}, storj.ErrObjectNotFound.Wrap(Error.New("object is deleted"))
+	}
 
 	object.ProjectID = opts.ProjectID
 	object.BucketName = opts.BucketName
 	object.ObjectKey = opts.ObjectKey
 
-	object.Status = Committed
-
 	return object, nil
 }
 
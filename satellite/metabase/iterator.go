@@ -6,6 +6,7 @@ package metabase
 import (
 	"bytes"
 	"context"
+	"strconv"
 	"strings"
 
 	"github.com/zeebo/errs"
@@ -26,6 +27,9 @@ type objectsIterator struct {
 	batchSize       int
 	recursive       bool
 	includeMetadata bool
+	latestOnly      bool
+	tags            Tags
+	deleteMarkers   bool
 
 	curIndex int
 	curRows  tagsql.Rows
@@ -77,6 +81,16 @@ func iterateAllVersions(ctx context.Context, db *DB, opts IterateObjects, fn fun
 func iterateAllVersionsWithStatus(ctx context.Context, db *DB, opts IterateObjectsWithStatus, fn func(context.Context, ObjectsIterator) error) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	return iterateWithStatus(ctx, db, opts, false, fn)
+}
+
+func iterateLatestVersionsWithStatus(ctx context.Context, db *DB, opts IterateObjectsWithStatus, fn func(context.Context, ObjectsIterator) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return iterateWithStatus(ctx, db, opts, true, fn)
+}
+
+func iterateWithStatus(ctx context.Context, db *DB, opts IterateObjectsWithStatus, latestOnly bool, fn func(context.Context, ObjectsIterator) error) (err error) {
 	it := &objectsIterator{
 		db: db,
 
@@ -88,6 +102,9 @@ func iterateAllVersionsWithStatus(ctx context.Context, db *DB, opts IterateObjec
 		batchSize:       opts.BatchSize,
 		recursive:       opts.Recursive,
 		includeMetadata: opts.IncludeMetadata,
+		latestOnly:      latestOnly,
+		tags:            opts.Tags,
+		deleteMarkers:   opts.IncludeDeleteMarkers,
 
 		curIndex: 0,
 		cursor:   firstIterateCursor(opts.Recursive, opts.Cursor, opts.Prefix),
@@ -315,7 +332,40 @@ func doNextQueryAllVersionsWithStatus(ctx context.Context, it *objectsIterator)
 		cursorCompare = ">="
 	}
 
+	// versions hidden by a newer version or a delete marker are skipped.
+	latestOnlyFilter := ""
+	if it.latestOnly && it.status == Committed {
+		latestOnlyFilter = `AND NOT EXISTS (
+			SELECT 1 FROM objects AS newer
+			WHERE
+				newer.project_id  = objects.project_id AND
+				newer.bucket_name = objects.bucket_name AND
+				newer.object_key  = objects.object_key AND
+				newer.version     > objects.version AND
+				newer.status      IN (` + committedStatus + `, ` + deleteMarkerStatus + `)
+		)`
+	}
+
+	args := []interface{}{
+		it.projectID, it.bucketName,
+		it.status,
+		[]byte(it.cursor.Key), int(it.cursor.Version),
+		it.batchSize,
+	}
+
+	statusFilter := "status = $3"
+	if it.deleteMarkers {
+		statusFilter = "status IN ($3, " + deleteMarkerStatus + ")"
+	}
+
+	tagsFilter := ""
+	if len(it.tags) > 0 {
+		args = append(args, it.tags)
+		tagsFilter = "AND tags @> $" + strconv.Itoa(len(args))
+	}
+
 	if it.prefixLimit == "" {
+		args = append(args, nextBucket(it.bucketName))
 		return it.db.db.QueryContext(ctx, `
 			SELECT
 				object_key, stream_id, version, status,
@@ -327,20 +377,18 @@ func doNextQueryAllVersionsWithStatus(ctx context.Context, it *objectsIterator)
 			FROM objects
 			WHERE
 				(project_id, bucket_name, object_key, version) `+cursorCompare+` ($1, $2, $4, $5)
-				AND (project_id, bucket_name) < ($1, $7)
-				AND status = $3
+				AND (project_id, bucket_name) < ($1, $`+strconv.Itoa(len(args))+`)
+				AND `+statusFilter+`
+				`+latestOnlyFilter+`
+				`+tagsFilter+`
 				ORDER BY (project_id, bucket_name, object_key, version) ASC
 			LIMIT $6
-			`, it.projectID, it.bucketName,
-			it.status,
-			[]byte(it.cursor.Key), int(it.cursor.Version),
-			it.batchSize,
-			nextBucket(it.bucketName),
-		)
+			`, args...)
 	}
 
 	// TODO this query should use SUBSTRING(object_key from $8) but there is a problem how it
 	// works with CRDB.
+	args = append(args, []byte(it.prefixLimit))
 	return it.db.db.QueryContext(ctx, `
 		SELECT
 			object_key, stream_id, version, status,
@@ -352,17 +400,13 @@ func doNextQueryAllVersionsWithStatus(ctx context.Context, it *objectsIterator)
 		FROM objects
 		WHERE
 			(project_id, bucket_name, object_key, version) `+cursorCompare+` ($1, $2, $4, $5)
-			AND (project_id, bucket_name, object_key) < ($1, $2, $6)
-			AND status = $3
+			AND (project_id, bucket_name, object_key) < ($1, $2, $`+strconv.Itoa(len(args))+`)
+			AND `+statusFilter+`
+			`+latestOnlyFilter+`
+			`+tagsFilter+`
 			ORDER BY (project_id, bucket_name, object_key, version) ASC
-		LIMIT $7
-	`, it.projectID, it.bucketName,
-		it.status,
-		[]byte(it.cursor.Key), int(it.cursor.Version),
-		[]byte(it.prefixLimit),
-		it.batchSize,
-		// len(it.prefix)+1, // TODO uncomment when CRDB issue will be fixed
-	)
+		LIMIT $6
+	`, args...)
 }
 
 // nextBucket returns the lexicographically next bucket.
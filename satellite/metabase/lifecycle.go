@@ -0,0 +1,198 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"storj.io/private/tagsql"
+)
+
+// LifecycleAction defines what a lifecycle rule does with the matching objects.
+type LifecycleAction int
+
+const (
+	// LifecycleExpireObjects deletes committed objects older than the rule age.
+	LifecycleExpireObjects = LifecycleAction(1)
+	// LifecycleAbortUploads deletes pending objects older than the rule age.
+	LifecycleAbortUploads = LifecycleAction(2)
+)
+
+// String returns textual representation of the action.
+func (action LifecycleAction) String() string {
+	switch action {
+	case LifecycleExpireObjects:
+		return "expire-objects"
+	case LifecycleAbortUploads:
+		return "abort-uploads"
+	default:
+		return "unknown"
+	}
+}
+
+// LifecycleRule describes which objects of a bucket are deleted automatically.
+type LifecycleRule struct {
+	BucketLocation
+	Name string
+
+	// Prefix is matched against the encrypted object key. Keys are encrypted
+	// per path component, so a prefix ending with a "/" matches all objects
+	// under the same encrypted prefix.
+	Prefix  ObjectKey
+	Action  LifecycleAction
+	AgeDays int
+}
+
+// Verify verifies lifecycle rule fields.
+func (rule *LifecycleRule) Verify() error {
+	if err := rule.BucketLocation.Verify(); err != nil {
+		return err
+	}
+	switch {
+	case rule.Name == "":
+		return ErrInvalidRequest.New("Name missing")
+	case rule.Action != LifecycleExpireObjects && rule.Action != LifecycleAbortUploads:
+		return ErrInvalidRequest.New("Action invalid: %d", rule.Action)
+	case rule.AgeDays <= 0:
+		return ErrInvalidRequest.New("AgeDays invalid: %d", rule.AgeDays)
+	}
+	return nil
+}
+
+// Applies returns whether the rule deletes the object at the specified time.
+func (rule *LifecycleRule) Applies(object LoopObjectEntry, now time.Time) bool {
+	if object.ProjectID != rule.ProjectID || object.BucketName != rule.BucketName {
+		return false
+	}
+	if !strings.HasPrefix(string(object.ObjectKey), string(rule.Prefix)) {
+		return false
+	}
+
+	switch rule.Action {
+	case LifecycleExpireObjects:
+		if object.Status != Committed {
+			return false
+		}
+	case LifecycleAbortUploads:
+		if object.Status != Pending {
+			return false
+		}
+	default:
+		return false
+	}
+
+	return object.CreatedAt.Before(now.AddDate(0, 0, -rule.AgeDays))
+}
+
+// SetBucketLifecycleRule contains arguments necessary for adding or replacing a
+// lifecycle rule of a bucket.
+type SetBucketLifecycleRule struct {
+	Rule LifecycleRule
+}
+
+// SetBucketLifecycleRule adds a lifecycle rule to the bucket, replacing the rule
+// with the same name.
+func (db *DB) SetBucketLifecycleRule(ctx context.Context, opts SetBucketLifecycleRule) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Rule.Verify(); err != nil {
+		return err
+	}
+
+	_, err = db.db.ExecContext(ctx, `
+		INSERT INTO bucket_lifecycle_rules (
+			project_id, bucket_name, name,
+			prefix, action, age_days
+		) VALUES (
+			$1, $2, $3,
+			$4, $5, $6
+		)
+		ON CONFLICT (project_id, bucket_name, name) DO UPDATE SET
+			prefix   = $4,
+			action   = $5,
+			age_days = $6
+	`, opts.Rule.ProjectID, []byte(opts.Rule.BucketName), opts.Rule.Name,
+		[]byte(opts.Rule.Prefix), opts.Rule.Action, opts.Rule.AgeDays)
+	if err != nil {
+		return Error.New("unable to set lifecycle rule: %w", err)
+	}
+	return nil
+}
+
+// DeleteBucketLifecycleRules contains arguments necessary for deleting lifecycle
+// rules of a bucket.
+type DeleteBucketLifecycleRules struct {
+	BucketLocation
+
+	// Name of the rule to delete, all rules of the bucket are deleted when empty.
+	Name string
+}
+
+// DeleteBucketLifecycleRules deletes lifecycle rules of the bucket.
+func (db *DB) DeleteBucketLifecycleRules(ctx context.Context, opts DeleteBucketLifecycleRules) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.BucketLocation.Verify(); err != nil {
+		return err
+	}
+
+	_, err = db.db.ExecContext(ctx, `
+		DELETE FROM bucket_lifecycle_rules
+		WHERE
+			project_id  = $1 AND
+			bucket_name = $2 AND
+			($3 = '' OR name = $3)
+	`, opts.ProjectID, []byte(opts.BucketName), opts.Name)
+	if err != nil {
+		return Error.New("unable to delete lifecycle rules: %w", err)
+	}
+	return nil
+}
+
+// ListLifecycleRules contains arguments necessary for listing lifecycle rules.
+type ListLifecycleRules struct {
+	// Bucket limits the listing to a single bucket, all rules are listed when empty.
+	Bucket BucketLocation
+}
+
+// ListLifecycleRules lists lifecycle rules ordered by bucket and name.
+func (db *DB) ListLifecycleRules(ctx context.Context, opts ListLifecycleRules) (rules []LifecycleRule, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	allBuckets := opts.Bucket == BucketLocation{}
+	if !allBuckets {
+		if err := opts.Bucket.Verify(); err != nil {
+			return nil, err
+		}
+	}
+
+	err = withRows(db.db.QueryContext(ctx, `
+		SELECT
+			project_id, bucket_name, name,
+			prefix, action, age_days
+		FROM bucket_lifecycle_rules
+		WHERE
+			$1 OR (project_id = $2 AND bucket_name = $3)
+		ORDER BY project_id, bucket_name, name
+	`, allBuckets, opts.Bucket.ProjectID, []byte(opts.Bucket.BucketName)))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var rule LifecycleRule
+			err := rows.Scan(
+				&rule.ProjectID, &rule.BucketName, &rule.Name,
+				&rule.Prefix, &rule.Action, &rule.AgeDays,
+			)
+			if err != nil {
+				return Error.New("unable to scan lifecycle rule: %w", err)
+			}
+			rules = append(rules, rule)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.New("unable to list lifecycle rules: %w", err)
+	}
+	return rules, nil
+}
@@ -0,0 +1,156 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestBucketLifecycleRules(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		projectID := testrand.UUID()
+		first := metabase.BucketLocation{ProjectID: projectID, BucketName: "a-bucket"}
+		second := metabase.BucketLocation{ProjectID: projectID, BucketName: "b-bucket"}
+
+		t.Run("invalid rule", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			for _, test := range []struct {
+				Rule    metabase.LifecycleRule
+				ErrText string
+			}{
+				{
+					Rule:    metabase.LifecycleRule{BucketLocation: first, Action: metabase.LifecycleExpireObjects, AgeDays: 1},
+					ErrText: "Name missing",
+				},
+				{
+					Rule:    metabase.LifecycleRule{BucketLocation: first, Name: "rule", AgeDays: 1},
+					ErrText: "Action invalid: 0",
+				},
+				{
+					Rule:    metabase.LifecycleRule{BucketLocation: first, Name: "rule", Action: metabase.LifecycleAbortUploads},
+					ErrText: "AgeDays invalid: 0",
+				},
+			} {
+				metabasetest.SetBucketLifecycleRule{
+					Opts:     metabase.SetBucketLifecycleRule{Rule: test.Rule},
+					ErrClass: &metabase.ErrInvalidRequest,
+					ErrText:  test.ErrText,
+				}.Check(ctx, t, db)
+			}
+
+			metabasetest.ListLifecycleRules{}.Check(ctx, t, db)
+		})
+
+		t.Run("set, list and delete", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			logs := metabase.LifecycleRule{
+				BucketLocation: first,
+				Name:           "logs",
+				Prefix:         "logs/",
+				Action:         metabase.LifecycleExpireObjects,
+				AgeDays:        30,
+			}
+			uploads := metabase.LifecycleRule{
+				BucketLocation: first,
+				Name:           "uploads",
+				Action:         metabase.LifecycleAbortUploads,
+				AgeDays:        7,
+			}
+			other := metabase.LifecycleRule{
+				BucketLocation: second,
+				Name:           "logs",
+				Action:         metabase.LifecycleExpireObjects,
+				AgeDays:        1,
+			}
+
+			for _, rule := range []metabase.LifecycleRule{logs, uploads, other} {
+				metabasetest.SetBucketLifecycleRule{
+					Opts: metabase.SetBucketLifecycleRule{Rule: rule},
+				}.Check(ctx, t, db)
+			}
+
+			// setting a rule with the same name replaces it.
+			logs.AgeDays = 60
+			metabasetest.SetBucketLifecycleRule{
+				Opts: metabase.SetBucketLifecycleRule{Rule: logs},
+			}.Check(ctx, t, db)
+
+			metabasetest.ListLifecycleRules{
+				Result: []metabase.LifecycleRule{logs, uploads, other},
+			}.Check(ctx, t, db)
+
+			metabasetest.ListLifecycleRules{
+				Opts:   metabase.ListLifecycleRules{Bucket: first},
+				Result: []metabase.LifecycleRule{logs, uploads},
+			}.Check(ctx, t, db)
+
+			metabasetest.DeleteBucketLifecycleRules{
+				Opts: metabase.DeleteBucketLifecycleRules{BucketLocation: first, Name: "uploads"},
+			}.Check(ctx, t, db)
+
+			metabasetest.ListLifecycleRules{
+				Result: []metabase.LifecycleRule{logs, other},
+			}.Check(ctx, t, db)
+
+			metabasetest.DeleteBucketLifecycleRules{
+				Opts: metabase.DeleteBucketLifecycleRules{BucketLocation: first},
+			}.Check(ctx, t, db)
+
+			metabasetest.ListLifecycleRules{
+				Result: []metabase.LifecycleRule{other},
+			}.Check(ctx, t, db)
+		})
+	})
+}
+
+func TestLifecycleRuleApplies(t *testing.T) {
+	now := time.Now()
+	obj := metabasetest.RandObjectStream()
+	obj.ObjectKey = "logs/2021/app.log"
+
+	rule := metabase.LifecycleRule{
+		BucketLocation: obj.Location().Bucket(),
+		Name:           "logs",
+		Prefix:         "logs/",
+		Action:         metabase.LifecycleExpireObjects,
+		AgeDays:        30,
+	}
+
+	old := metabase.LoopObjectEntry{
+		ObjectStream: obj,
+		Status:       metabase.Committed,
+		CreatedAt:    now.AddDate(0, 0, -31),
+	}
+	require.True(t, rule.Applies(old, now))
+
+	recent := old
+	recent.CreatedAt = now.AddDate(0, 0, -29)
+	require.False(t, rule.Applies(recent, now))
+
+	pending := old
+	pending.Status = metabase.Pending
+	require.False(t, rule.Applies(pending, now))
+
+	otherPrefix := old
+	otherPrefix.ObjectKey = "data/app.log"
+	require.False(t, rule.Applies(otherPrefix, now))
+
+	otherBucket := old
+	otherBucket.BucketName = "other"
+	require.False(t, rule.Applies(otherBucket, now))
+
+	rule.Action = metabase.LifecycleAbortUploads
+	require.True(t, rule.Applies(pending, now))
+	require.False(t, rule.Applies(old, now))
+}
@@ -102,6 +102,12 @@ type IterateObjectsWithStatus struct {
 	Cursor          IterateCursor
 	Status          ObjectStatus
 	IncludeMetadata bool
+
+	// Tags limits the iteration to objects that have all of the tags.
+	Tags Tags
+	// IncludeDeleteMarkers includes the delete markers in the iteration of
+	// committed objects.
+	IncludeDeleteMarkers bool
 }
 
 // IterateObjectsAllVersionsWithStatus iterates through all versions of all objects with specified status.
@@ -113,6 +119,16 @@ func (db *DB) IterateObjectsAllVersionsWithStatus(ctx context.Context, opts Iter
 	return iterateAllVersionsWithStatus(ctx, db, opts, fn)
 }
 
+// IterateObjectsLatestVersion iterates through the latest version of all objects with
+// specified status. Committed objects whose latest version is a delete marker are skipped.
+func (db *DB) IterateObjectsLatestVersion(ctx context.Context, opts IterateObjectsWithStatus, fn func(context.Context, ObjectsIterator) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	if err = opts.Verify(); err != nil {
+		return err
+	}
+	return iterateLatestVersionsWithStatus(ctx, db, opts, fn)
+}
+
 // Verify verifies get object request fields.
 func (opts *IterateObjectsWithStatus) Verify() error {
 	switch {
@@ -124,6 +140,10 @@ func (opts *IterateObjectsWithStatus) Verify() error {
 		return ErrInvalidRequest.New("BatchSize is negative")
 	case !(opts.Status == Pending || opts.Status == Committed):
 		return ErrInvalidRequest.New("Status %v is not supported", opts.Status)
+	case len(opts.Tags) > 0 && opts.Status != Committed:
+		return ErrInvalidRequest.New("Tags are only supported for committed objects")
+	case opts.IncludeDeleteMarkers && opts.Status != Committed:
+		return ErrInvalidRequest.New("Delete markers are only supported for committed objects")
 	}
 	return nil
 }
@@ -168,6 +168,352 @@ func (step UpdateObjectMetadata) Check(ctx *testcontext.Context, t testing.TB, d
 	checkError(t, err, step.ErrClass, step.ErrText)
 }
 
+// BeginMoveObject is for testing metabase.BeginMoveObject.
+type BeginMoveObject struct {
+	Opts     metabase.BeginMoveObject
+	Result   metabase.BeginMoveObjectResult
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step BeginMoveObject) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	result, err := db.BeginMoveObject(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+
+	diff := cmp.Diff(step.Result, result)
+	require.Zero(t, diff)
+}
+
+// FinishMoveObject is for testing metabase.FinishMoveObject.
+type FinishMoveObject struct {
+	Opts     metabase.FinishMoveObject
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step FinishMoveObject) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	err := db.FinishMoveObject(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+}
+
+// BeginCopyObject is for testing metabase.BeginCopyObject.
+type BeginCopyObject struct {
+	Opts     metabase.BeginCopyObject
+	Result   metabase.BeginCopyObjectResult
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step BeginCopyObject) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	result, err := db.BeginCopyObject(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+
+	diff := cmp.Diff(step.Result, result)
+	require.Zero(t, diff)
+}
+
+// FinishCopyObject is for testing metabase.FinishCopyObject.
+type FinishCopyObject struct {
+	Opts        metabase.FinishCopyObject
+	Overwritten metabase.DeleteObjectResult
+	ErrClass    *errs.Class
+	ErrText     string
+}
+
+// Check runs the test.
+func (step FinishCopyObject) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) metabase.Object {
+	result, err := db.FinishCopyObject(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+	if err == nil {
+		require.Equal(t, step.Opts.NewStreamID, result.Object.StreamID)
+		require.Equal(t, step.Opts.NewBucket, result.Object.BucketName)
+	}
+
+	sortObjects(result.Overwritten.Objects)
+	sortObjects(step.Overwritten.Objects)
+
+	sortDeletedSegments(result.Overwritten.Segments)
+	sortDeletedSegments(step.Overwritten.Segments)
+
+	diff := cmp.Diff(step.Overwritten, result.Overwritten, cmpopts.EquateApproxTime(5*time.Second), cmpopts.EquateEmpty())
+	require.Zero(t, diff)
+
+	return result.Object
+}
+
+// SetBucketVersioning is for testing metabase.SetBucketVersioning.
+type SetBucketVersioning struct {
+	Opts     metabase.SetBucketVersioning
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step SetBucketVersioning) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	err := db.SetBucketVersioning(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+}
+
+// GetBucketVersioning is for testing metabase.GetBucketVersioning.
+type GetBucketVersioning struct {
+	Opts     metabase.GetBucketVersioning
+	Result   bool
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step GetBucketVersioning) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	result, err := db.GetBucketVersioning(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+	require.Equal(t, step.Result, result)
+}
+
+// CreateDeleteMarker is for testing metabase.CreateDeleteMarker.
+type CreateDeleteMarker struct {
+	Opts     metabase.CreateDeleteMarker
+	Result   metabase.DeleteObjectResult
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step CreateDeleteMarker) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	result, err := db.CreateDeleteMarker(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+
+	diff := cmp.Diff(step.Result, result, cmpopts.EquateApproxTime(5*time.Second))
+	require.Zero(t, diff)
+}
+
+// ListObjectVersions is for testing metabase.ListObjectVersions.
+type ListObjectVersions struct {
+	Opts     metabase.ListObjectVersions
+	Result   metabase.ListObjectVersionsResult
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step ListObjectVersions) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	result, err := db.ListObjectVersions(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+
+	// delete markers get a random stream id.
+	diff := cmp.Diff(step.Result, result, cmpopts.EquateApproxTime(5*time.Second),
+		cmpopts.IgnoreFields(metabase.ObjectStream{}, "StreamID"))
+	require.Zero(t, diff)
+}
+
+// RestoreObjectVersion is for testing metabase.RestoreObjectVersion.
+type RestoreObjectVersion struct {
+	Opts     metabase.RestoreObjectVersion
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step RestoreObjectVersion) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) metabase.Object {
+	object, err := db.RestoreObjectVersion(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+	if err == nil {
+		require.Equal(t, step.Opts.NewStreamID, object.StreamID)
+		require.Equal(t, step.Opts.ObjectKey, object.ObjectKey)
+	}
+	return object
+}
+
+// SetBucketRetention is for testing metabase.SetBucketRetention.
+type SetBucketRetention struct {
+	Opts     metabase.SetBucketRetention
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step SetBucketRetention) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	err := db.SetBucketRetention(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+}
+
+// GetBucketRetention is for testing metabase.GetBucketRetention.
+type GetBucketRetention struct {
+	Opts     metabase.GetBucketRetention
+	Result   int
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step GetBucketRetention) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	result, err := db.GetBucketRetention(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+	require.Equal(t, step.Result, result)
+}
+
+// SetObjectRetention is for testing metabase.SetObjectRetention.
+type SetObjectRetention struct {
+	Opts     metabase.SetObjectRetention
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step SetObjectRetention) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	err := db.SetObjectRetention(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+}
+
+// SetObjectLegalHold is for testing metabase.SetObjectLegalHold.
+type SetObjectLegalHold struct {
+	Opts     metabase.SetObjectLegalHold
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step SetObjectLegalHold) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	err := db.SetObjectLegalHold(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+}
+
+// GetObjectLock is for testing metabase.GetObjectLock.
+type GetObjectLock struct {
+	Opts     metabase.GetObjectLock
+	Result   metabase.ObjectLock
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step GetObjectLock) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) metabase.ObjectLock {
+	result, err := db.GetObjectLock(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+
+	diff := cmp.Diff(step.Result, result, cmpopts.EquateApproxTime(5*time.Second))
+	require.Zero(t, diff)
+	return result
+}
+
+// SetBucketLifecycleRule is for testing metabase.SetBucketLifecycleRule.
+type SetBucketLifecycleRule struct {
+	Opts     metabase.SetBucketLifecycleRule
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step SetBucketLifecycleRule) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	err := db.SetBucketLifecycleRule(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+}
+
+// DeleteBucketLifecycleRules is for testing metabase.DeleteBucketLifecycleRules.
+type DeleteBucketLifecycleRules struct {
+	Opts     metabase.DeleteBucketLifecycleRules
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step DeleteBucketLifecycleRules) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	err := db.DeleteBucketLifecycleRules(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+}
+
+// ListLifecycleRules is for testing metabase.ListLifecycleRules.
+type ListLifecycleRules struct {
+	Opts     metabase.ListLifecycleRules
+	Result   []metabase.LifecycleRule
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step ListLifecycleRules) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	result, err := db.ListLifecycleRules(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+
+	diff := cmp.Diff(step.Result, result)
+	require.Zero(t, diff)
+}
+
+// SetBucketTags is for testing metabase.SetBucketTags.
+type SetBucketTags struct {
+	Opts     metabase.SetBucketTags
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step SetBucketTags) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	err := db.SetBucketTags(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+}
+
+// GetBucketTags is for testing metabase.GetBucketTags.
+type GetBucketTags struct {
+	Opts     metabase.GetBucketTags
+	Result   metabase.Tags
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step GetBucketTags) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	result, err := db.GetBucketTags(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+	require.Equal(t, step.Result, result)
+}
+
+// ListBucketTags is for testing metabase.ListBucketTags.
+type ListBucketTags struct {
+	Opts     metabase.ListBucketTags
+	Result   []metabase.BucketTags
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step ListBucketTags) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	result, err := db.ListBucketTags(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+
+	diff := cmp.Diff(step.Result, result)
+	require.Zero(t, diff)
+}
+
+// SetObjectTags is for testing metabase.SetObjectTags.
+type SetObjectTags struct {
+	Opts     metabase.SetObjectTags
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step SetObjectTags) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	err := db.SetObjectTags(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+}
+
+// GetObjectTags is for testing metabase.GetObjectTags.
+type GetObjectTags struct {
+	Opts     metabase.GetObjectTags
+	Result   metabase.Tags
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step GetObjectTags) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	result, err := db.GetObjectTags(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+	require.Equal(t, step.Result, result)
+}
+
 // UpdateSegmentPieces is for testing metabase.UpdateSegmentPieces.
 type UpdateSegmentPieces struct {
 	Opts     metabase.UpdateSegmentPieces
@@ -647,6 +993,26 @@ func (step IterateObjectsWithStatus) Check(ctx *testcontext.Context, t testing.T
 	require.Zero(t, diff)
 }
 
+// IterateObjectsLatestVersion is for testing metabase.IterateObjectsLatestVersion.
+type IterateObjectsLatestVersion struct {
+	Opts metabase.IterateObjectsWithStatus
+
+	Result   []metabase.ObjectEntry
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step IterateObjectsLatestVersion) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	var result IterateCollector
+
+	err := db.IterateObjectsLatestVersion(ctx, step.Opts, result.Add)
+	checkError(t, err, step.ErrClass, step.ErrText)
+
+	diff := cmp.Diff(step.Result, []metabase.ObjectEntry(result), cmpopts.EquateApproxTime(5*time.Second))
+	require.Zero(t, diff)
+}
+
 // IterateLoopObjects is for testing metabase.IterateLoopObjects.
 type IterateLoopObjects struct {
 	Opts metabase.IterateLoopObjects
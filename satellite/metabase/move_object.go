@@ -0,0 +1,253 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/private/dbutil/pgutil"
+	"storj.io/private/dbutil/txutil"
+	"storj.io/private/tagsql"
+)
+
+// ErrObjectAlreadyExists is used to indicate that an object already exists at the target location.
+var ErrObjectAlreadyExists = errs.Class("metabase: object already exists")
+
+// EncryptedKeyAndNonce holds single segment position, encrypted key and nonce.
+type EncryptedKeyAndNonce struct {
+	Position          SegmentPosition
+	EncryptedKeyNonce []byte
+	EncryptedKey      []byte
+}
+
+// BeginMoveObject contains arguments necessary for starting an object move.
+type BeginMoveObject struct {
+	Version Version
+	ObjectLocation
+}
+
+// BeginMoveObjectResult holds data needed to finish move object.
+//
+// The object key, metadata key and segment keys are all encrypted with keys
+// derived from the object path, so the client needs to re-encrypt them for
+// the new location before calling FinishMoveObject.
+type BeginMoveObjectResult struct {
+	StreamID                  uuid.UUID
+	EncryptedMetadataKey      []byte
+	EncryptedMetadataKeyNonce []byte
+	EncryptedKeysNonces       []EncryptedKeyAndNonce
+	EncryptionParameters      storj.EncryptionParameters
+}
+
+// BeginMoveObject collects all the information necessary to re-encrypt an
+// object for a move without touching its pieces on storage nodes.
+func (db *DB) BeginMoveObject(ctx context.Context, opts BeginMoveObject) (result BeginMoveObjectResult, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.ObjectLocation.Verify(); err != nil {
+		return BeginMoveObjectResult{}, err
+	}
+
+	if opts.Version <= 0 {
+		return BeginMoveObjectResult{}, ErrInvalidRequest.New("Version invalid: %v", opts.Version)
+	}
+
+	return db.objectEncryptionKeys(ctx, opts.ObjectLocation, opts.Version)
+}
+
+// objectEncryptionKeys returns the keys of a committed object version that are
+// encrypted with keys derived from its location.
+func (db *DB) objectEncryptionKeys(ctx context.Context, location ObjectLocation, version Version) (result BeginMoveObjectResult, err error) {
+	object, err := db.GetObjectExactVersion(ctx, GetObjectExactVersion{
+		Version:        version,
+		ObjectLocation: location,
+	})
+	if err != nil {
+		return BeginMoveObjectResult{}, err
+	}
+
+	result.StreamID = object.StreamID
+	result.EncryptedMetadataKey = object.EncryptedMetadataEncryptedKey
+	result.EncryptedMetadataKeyNonce = object.EncryptedMetadataNonce
+	result.EncryptionParameters = object.Encryption
+
+	err = withRows(db.db.QueryContext(ctx, `
+		SELECT
+			position, encrypted_key_nonce, encrypted_key
+		FROM segments
+		WHERE stream_id = $1
+		ORDER BY stream_id, position ASC
+	`, object.StreamID))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var keys EncryptedKeyAndNonce
+			err = rows.Scan(&keys.Position, &keys.EncryptedKeyNonce, &keys.EncryptedKey)
+			if err != nil {
+				return Error.New("failed to scan segments: %w", err)
+			}
+			result.EncryptedKeysNonces = append(result.EncryptedKeysNonces, keys)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return BeginMoveObjectResult{}, Error.New("unable to fetch object segments: %w", err)
+	}
+
+	return result, nil
+}
+
+// FinishMoveObject contains arguments necessary for finishing an object move.
+type FinishMoveObject struct {
+	ObjectStream
+
+	NewBucket                    string
+	NewEncryptedObjectKey        []byte
+	NewEncryptedMetadataKeyNonce []byte
+	NewEncryptedMetadataKey      []byte
+	NewSegmentKeys               []EncryptedKeyAndNonce
+}
+
+// Verify verifies finish move object fields.
+func (opts *FinishMoveObject) Verify() error {
+	if err := opts.ObjectStream.Verify(); err != nil {
+		return err
+	}
+
+	switch {
+	case opts.Version <= 0:
+		return ErrInvalidRequest.New("Version invalid: %v", opts.Version)
+	case len(opts.NewBucket) == 0:
+		return ErrInvalidRequest.New("NewBucket is missing")
+	case len(opts.NewEncryptedObjectKey) == 0:
+		return ErrInvalidRequest.New("NewEncryptedObjectKey is missing")
+	case (len(opts.NewEncryptedMetadataKeyNonce) == 0) != (len(opts.NewEncryptedMetadataKey) == 0):
+		return ErrInvalidRequest.New("EncryptedMetadataKeyNonce and EncryptedMetadataKey must be set together")
+	}
+
+	for _, keys := range opts.NewSegmentKeys {
+		if len(keys.EncryptedKeyNonce) == 0 {
+			return ErrInvalidRequest.New("EncryptedKeyNonce missing for segment %v", keys.Position.Encode())
+		}
+		if len(keys.EncryptedKey) == 0 {
+			return ErrInvalidRequest.New("EncryptedKey missing for segment %v", keys.Position.Encode())
+		}
+	}
+
+	return nil
+}
+
+// FinishMoveObject moves a committed object to a new bucket and key. Only the
+// object row and the segment encryption keys are updated, the segments keep
+// pointing to the same pieces on the storage nodes.
+func (db *DB) FinishMoveObject(ctx context.Context, opts FinishMoveObject) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return err
+	}
+
+	err = txutil.WithTx(ctx, db.db, nil, func(ctx context.Context, tx tagsql.Tx) error {
+		var exists bool
+		err := tx.QueryRowContext(ctx, `
+			SELECT EXISTS (
+				SELECT 1 FROM objects
+				WHERE
+					project_id   = $1 AND
+					bucket_name  = $2 AND
+					object_key   = $3 AND
+					status       = `+committedStatus+`
+			)
+		`, opts.ProjectID, []byte(opts.NewBucket), opts.NewEncryptedObjectKey).Scan(&exists)
+		if err != nil {
+			return Error.New("unable to query target object: %w", err)
+		}
+		if exists {
+			return ErrObjectAlreadyExists.New("")
+		}
+
+		var segmentCount int
+		err = tx.QueryRowContext(ctx, `
+			UPDATE objects SET
+				bucket_name = $6,
+				object_key  = $7,
+				version     = coalesce((
+					SELECT max(version) + 1 FROM objects
+					WHERE
+						project_id  = $1 AND
+						bucket_name = $6 AND
+						object_key  = $7
+				), 1),
+				encrypted_metadata_nonce         = $8,
+				encrypted_metadata_encrypted_key = $9
+			WHERE
+				project_id   = $1 AND
+				bucket_name  = $2 AND
+				object_key   = $3 AND
+				version      = $4 AND
+				stream_id    = $5 AND
+				status       = `+committedStatus+` AND
+				`+objectUnlocked+`
+			RETURNING segment_count
+		`, opts.ProjectID, []byte(opts.BucketName), []byte(opts.ObjectKey), opts.Version, opts.StreamID,
+			[]byte(opts.NewBucket), opts.NewEncryptedObjectKey,
+			opts.NewEncryptedMetadataKeyNonce, opts.NewEncryptedMetadataKey).
+			Scan(&segmentCount)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				// moving removes the object from its location, so it's not allowed for locked objects.
+				return db.lockedObjectError(ctx, opts.Location(), opts.Version,
+					storj.ErrObjectNotFound.Wrap(Error.New("object not found")))
+			}
+			return Error.New("unable to update object: %w", err)
+		}
+
+		if segmentCount != len(opts.NewSegmentKeys) {
+			return ErrInvalidRequest.New("wrong amount of segments keys received (received %d, need %d)",
+				len(opts.NewSegmentKeys), segmentCount)
+		}
+		if segmentCount == 0 {
+			return nil
+		}
+
+		var positions []int64
+		var nonces, keys [][]byte
+		for _, key := range opts.NewSegmentKeys {
+			positions = append(positions, int64(key.Position.Encode()))
+			nonces = append(nonces, key.EncryptedKeyNonce)
+			keys = append(keys, key.EncryptedKey)
+		}
+
+		updateResult, err := tx.ExecContext(ctx, `
+			UPDATE segments SET
+				encrypted_key_nonce = P.encrypted_key_nonce,
+				encrypted_key       = P.encrypted_key
+			FROM (SELECT unnest($2::INT8[]), unnest($3::BYTEA[]), unnest($4::BYTEA[])) as P(position, encrypted_key_nonce, encrypted_key)
+			WHERE segments.stream_id = $1 AND segments.position = P.position
+		`, opts.StreamID, pgutil.Int8Array(positions), pgutil.ByteaArray(nonces), pgutil.ByteaArray(keys))
+		if err != nil {
+			return Error.New("unable to update segment keys: %w", err)
+		}
+
+		affected, err := updateResult.RowsAffected()
+		if err != nil {
+			return Error.New("failed to get rows affected: %w", err)
+		}
+		if affected != int64(segmentCount) {
+			return Error.New("segment is missing")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	mon.Meter("finish_move_object").Mark(1)
+
+	return nil
+}
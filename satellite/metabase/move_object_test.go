@@ -0,0 +1,245 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+	"time"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestBeginMoveObject(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+
+		for _, test := range metabasetest.InvalidObjectLocations(obj.Location()) {
+			test := test
+			t.Run(test.Name, func(t *testing.T) {
+				defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+				metabasetest.BeginMoveObject{
+					Opts: metabase.BeginMoveObject{
+						Version:        1,
+						ObjectLocation: test.ObjectLocation,
+					},
+					ErrClass: test.ErrClass,
+					ErrText:  test.ErrText,
+				}.Check(ctx, t, db)
+				metabasetest.Verify{}.Check(ctx, t, db)
+			})
+		}
+
+		t.Run("Object missing", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.BeginMoveObject{
+				Opts: metabase.BeginMoveObject{
+					Version:        1,
+					ObjectLocation: obj.Location(),
+				},
+				ErrClass: &storj.ErrObjectNotFound,
+				ErrText:  "metabase: sql: no rows in result set",
+			}.Check(ctx, t, db)
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("Begin move object", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 2)
+
+			metabasetest.BeginMoveObject{
+				Opts: metabase.BeginMoveObject{
+					Version:        obj.Version,
+					ObjectLocation: obj.Location(),
+				},
+				Result: metabase.BeginMoveObjectResult{
+					StreamID:                  obj.StreamID,
+					EncryptedMetadataKey:      object.EncryptedMetadataEncryptedKey,
+					EncryptedMetadataKeyNonce: object.EncryptedMetadataNonce,
+					EncryptionParameters:      object.Encryption,
+					EncryptedKeysNonces: []metabase.EncryptedKeyAndNonce{
+						{
+							Position:          metabase.SegmentPosition{Index: 0},
+							EncryptedKeyNonce: []byte{4},
+							EncryptedKey:      []byte{3},
+						},
+						{
+							Position:          metabase.SegmentPosition{Index: 1},
+							EncryptedKeyNonce: []byte{4},
+							EncryptedKey:      []byte{3},
+						},
+					},
+				},
+			}.Check(ctx, t, db)
+		})
+	})
+}
+
+func TestFinishMoveObject(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+		newBucketName := "New bucket name"
+		newObjectKey := metabase.ObjectKey(testrand.Bytes(16))
+
+		for _, test := range metabasetest.InvalidObjectStreams(obj) {
+			test := test
+			t.Run(test.Name, func(t *testing.T) {
+				defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+				metabasetest.FinishMoveObject{
+					Opts: metabase.FinishMoveObject{
+						ObjectStream:          test.ObjectStream,
+						NewBucket:             newBucketName,
+						NewEncryptedObjectKey: []byte(newObjectKey),
+					},
+					ErrClass: test.ErrClass,
+					ErrText:  test.ErrText,
+				}.Check(ctx, t, db)
+				metabasetest.Verify{}.Check(ctx, t, db)
+			})
+		}
+
+		t.Run("missing NewBucket", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.FinishMoveObject{
+				Opts: metabase.FinishMoveObject{
+					ObjectStream:          obj,
+					NewEncryptedObjectKey: []byte(newObjectKey),
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "NewBucket is missing",
+			}.Check(ctx, t, db)
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("missing NewEncryptedObjectKey", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.FinishMoveObject{
+				Opts: metabase.FinishMoveObject{
+					ObjectStream: obj,
+					NewBucket:    newBucketName,
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "NewEncryptedObjectKey is missing",
+			}.Check(ctx, t, db)
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("object not found", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.FinishMoveObject{
+				Opts: metabase.FinishMoveObject{
+					ObjectStream:          obj,
+					NewBucket:             newBucketName,
+					NewEncryptedObjectKey: []byte(newObjectKey),
+				},
+				ErrClass: &storj.ErrObjectNotFound,
+				ErrText:  "metabase: object not found",
+			}.Check(ctx, t, db)
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("wrong number of segment keys", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 2)
+
+			metabasetest.FinishMoveObject{
+				Opts: metabase.FinishMoveObject{
+					ObjectStream:          obj,
+					NewBucket:             newBucketName,
+					NewEncryptedObjectKey: []byte(newObjectKey),
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "wrong amount of segments keys received (received 0, need 2)",
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{metabase.RawObject(object)},
+				Segments: []metabase.RawSegment{
+					metabasetest.DefaultRawSegment(obj, metabase.SegmentPosition{Index: 0}),
+					metabasetest.DefaultRawSegment(obj, metabase.SegmentPosition{Index: 1}),
+				},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("target already exists", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.CreateObject(ctx, t, db, obj, 0)
+
+			target := metabasetest.RandObjectStream()
+			target.ProjectID = obj.ProjectID
+			metabasetest.CreateObject(ctx, t, db, target, 0)
+
+			metabasetest.FinishMoveObject{
+				Opts: metabase.FinishMoveObject{
+					ObjectStream:          obj,
+					NewBucket:             target.BucketName,
+					NewEncryptedObjectKey: []byte(target.ObjectKey),
+				},
+				ErrClass: &metabase.ErrObjectAlreadyExists,
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("finish move object", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 2)
+
+			newMetadataKey := testrand.Bytes(32)
+			newMetadataKeyNonce := testrand.Nonce()
+			newSegmentKeys := []metabase.EncryptedKeyAndNonce{
+				{
+					Position:          metabase.SegmentPosition{Index: 0},
+					EncryptedKeyNonce: testrand.Nonce().Bytes(),
+					EncryptedKey:      testrand.Bytes(32),
+				},
+				{
+					Position:          metabase.SegmentPosition{Index: 1},
+					EncryptedKeyNonce: testrand.Nonce().Bytes(),
+					EncryptedKey:      testrand.Bytes(32),
+				},
+			}
+
+			metabasetest.FinishMoveObject{
+				Opts: metabase.FinishMoveObject{
+					ObjectStream:                 obj,
+					NewBucket:                    newBucketName,
+					NewEncryptedObjectKey:        []byte(newObjectKey),
+					NewEncryptedMetadataKeyNonce: newMetadataKeyNonce[:],
+					NewEncryptedMetadataKey:      newMetadataKey,
+					NewSegmentKeys:               newSegmentKeys,
+				},
+			}.Check(ctx, t, db)
+
+			object.BucketName = newBucketName
+			object.ObjectKey = newObjectKey
+			object.Version = 1
+			object.EncryptedMetadataNonce = newMetadataKeyNonce[:]
+			object.EncryptedMetadataEncryptedKey = newMetadataKey
+
+			var segments []metabase.RawSegment
+			for _, keys := range newSegmentKeys {
+				segment := metabasetest.DefaultRawSegment(obj, keys.Position)
+				segment.EncryptedKeyNonce = keys.EncryptedKeyNonce
+				segment.EncryptedKey = keys.EncryptedKey
+				segment.CreatedAt = time.Now()
+				segments = append(segments, segment)
+			}
+
+			metabasetest.Verify{
+				Objects:  []metabase.RawObject{metabase.RawObject(object)},
+				Segments: segments,
+			}.Check(ctx, t, db)
+		})
+	})
+}
@@ -67,10 +67,17 @@ type RawSegment struct {
 	Pieces     Pieces
 }
 
+// RawSegmentCopy defines the full segment_copies table row.
+type RawSegmentCopy struct {
+	StreamID         uuid.UUID
+	AncestorStreamID uuid.UUID
+}
+
 // RawState contains full state of a table.
 type RawState struct {
-	Objects  []RawObject
-	Segments []RawSegment
+	Objects       []RawObject
+	Segments      []RawSegment
+	SegmentCopies []RawSegmentCopy
 }
 
 // TestingGetState returns the state of the database.
@@ -87,6 +94,11 @@ func (db *DB) TestingGetState(ctx context.Context) (_ *RawState, err error) {
 		return nil, Error.New("GetState: %w", err)
 	}
 
+	state.SegmentCopies, err = db.testingGetAllSegmentCopies(ctx)
+	if err != nil {
+		return nil, Error.New("GetState: %w", err)
+	}
+
 	return state, nil
 }
 
@@ -96,6 +108,11 @@ func (db *DB) TestingDeleteAll(ctx context.Context) (err error) {
 		DELETE FROM objects;
 		DELETE FROM segments;
 		DELETE FROM node_aliases;
+		DELETE FROM segment_copies;
+		DELETE FROM bucket_versioning;
+		DELETE FROM bucket_retention;
+		DELETE FROM bucket_lifecycle_rules;
+		DELETE FROM bucket_tags;
 		SELECT setval('node_alias_seq', 1, false);
 	`)
 	db.aliasCache = NewNodeAliasCache(db)
@@ -229,3 +246,33 @@ func (db *DB) testingGetAllSegments(ctx context.Context) (_ []RawSegment, err er
 	}
 	return segs, nil
 }
+
+// testingGetAllSegmentCopies returns the state of the database.
+func (db *DB) testingGetAllSegmentCopies(ctx context.Context) (_ []RawSegmentCopy, err error) {
+	copies := []RawSegmentCopy{}
+
+	rows, err := db.db.QueryContext(ctx, `
+		SELECT stream_id, ancestor_stream_id
+		FROM segment_copies
+		ORDER BY stream_id ASC
+	`)
+	if err != nil {
+		return nil, Error.New("testingGetAllSegmentCopies query: %w", err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+	for rows.Next() {
+		var segmentCopy RawSegmentCopy
+		if err := rows.Scan(&segmentCopy.StreamID, &segmentCopy.AncestorStreamID); err != nil {
+			return nil, Error.New("testingGetAllSegmentCopies scan failed: %w", err)
+		}
+		copies = append(copies, segmentCopy)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, Error.New("testingGetAllSegmentCopies scan failed: %w", err)
+	}
+
+	if len(copies) == 0 {
+		return nil, nil
+	}
+	return copies, nil
+}
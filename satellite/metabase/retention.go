@@ -0,0 +1,359 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/private/tagsql"
+)
+
+// ErrObjectLocked is used to indicate that the object is protected by a retention period or a legal hold.
+var ErrObjectLocked = errs.Class("metabase: object is locked")
+
+// objectUnlocked is the condition for object rows that are allowed to be deleted.
+const objectUnlocked = `(legal_hold = false AND (retain_until IS NULL OR retain_until <= now()))`
+
+// bucketRetainUntil returns the expression for the retention date of an object
+// committed now into the bucket identified by the placeholders. It is NULL when
+// the bucket has no default retention.
+func bucketRetainUntil(projectID, bucketName string) string {
+	return `(
+		SELECT now() + retention_days * INTERVAL '1 day' FROM bucket_retention
+		WHERE
+			project_id  = ` + projectID + ` AND
+			bucket_name = ` + bucketName + `
+	)`
+}
+
+// SetBucketRetention contains arguments necessary for configuring the default
+// retention of newly committed objects in a bucket.
+type SetBucketRetention struct {
+	BucketLocation
+
+	// Days is the number of days that new objects can't be deleted or overwritten.
+	// Zero disables the default retention.
+	Days int
+}
+
+// Verify verifies set bucket retention fields.
+func (opts *SetBucketRetention) Verify() error {
+	if err := opts.BucketLocation.Verify(); err != nil {
+		return err
+	}
+	if opts.Days < 0 {
+		return ErrInvalidRequest.New("Days is negative")
+	}
+	return nil
+}
+
+// SetBucketRetention sets the default retention of the bucket. It doesn't affect
+// objects that are already committed.
+func (db *DB) SetBucketRetention(ctx context.Context, opts SetBucketRetention) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return err
+	}
+
+	if opts.Days > 0 {
+		_, err = db.db.ExecContext(ctx, `
+			INSERT INTO bucket_retention (project_id, bucket_name, retention_days)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (project_id, bucket_name) DO UPDATE SET retention_days = $3
+		`, opts.ProjectID, []byte(opts.BucketName), opts.Days)
+	} else {
+		_, err = db.db.ExecContext(ctx, `
+			DELETE FROM bucket_retention
+			WHERE
+				project_id  = $1 AND
+				bucket_name = $2
+		`, opts.ProjectID, []byte(opts.BucketName))
+	}
+	if err != nil {
+		return Error.New("unable to update bucket retention: %w", err)
+	}
+	return nil
+}
+
+// GetBucketRetention contains arguments necessary for fetching the default
+// retention of a bucket.
+type GetBucketRetention struct {
+	BucketLocation
+}
+
+// GetBucketRetention returns the default retention of the bucket in days, zero
+// means that the bucket has no default retention.
+func (db *DB) GetBucketRetention(ctx context.Context, opts GetBucketRetention) (days int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.BucketLocation.Verify(); err != nil {
+		return 0, err
+	}
+
+	err = db.db.QueryRowContext(ctx, `
+		SELECT retention_days FROM bucket_retention
+		WHERE
+			project_id  = $1 AND
+			bucket_name = $2
+	`, opts.ProjectID, []byte(opts.BucketName)).Scan(&days)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, Error.New("unable to query bucket retention: %w", err)
+	}
+	return days, nil
+}
+
+// ObjectLock describes how an object version is protected from deletion.
+type ObjectLock struct {
+	RetainUntil *time.Time
+	LegalHold   bool
+}
+
+// Locked returns whether the object can't be deleted at the specified time.
+func (lock ObjectLock) Locked(now time.Time) bool {
+	return lock.LegalHold || (lock.RetainUntil != nil && lock.RetainUntil.After(now))
+}
+
+// GetObjectLock contains arguments necessary for fetching the lock of an object version.
+type GetObjectLock struct {
+	ObjectLocation
+
+	// Version of the object, NextVersion means the latest committed version.
+	Version Version
+}
+
+// GetObjectLock returns the retention and legal hold of a committed object version.
+func (db *DB) GetObjectLock(ctx context.Context, opts GetObjectLock) (lock ObjectLock, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.ObjectLocation.Verify(); err != nil {
+		return ObjectLock{}, err
+	}
+	if opts.Version < 0 {
+		return ObjectLock{}, ErrInvalidRequest.New("Version invalid: %v", opts.Version)
+	}
+
+	err = db.db.QueryRowContext(ctx, `
+		SELECT retain_until, legal_hold
+		FROM objects
+		WHERE
+			project_id   = $1 AND
+			bucket_name  = $2 AND
+			object_key   = $3 AND
+			($4 = 0 OR version = $4) AND
+			status       = `+committedStatus+`
+		ORDER BY version DESC
+		LIMIT 1
+	`, opts.ProjectID, []byte(opts.BucketName), []byte(opts.ObjectKey), opts.Version).
+		Scan(&lock.RetainUntil, &lock.LegalHold)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ObjectLock{}, storj.ErrObjectNotFound.Wrap(Error.Wrap(err))
+		}
+		return ObjectLock{}, Error.New("unable to query object lock: %w", err)
+	}
+	return lock, nil
+}
+
+// SetObjectRetention contains arguments necessary for setting the retention of
+// an object version.
+type SetObjectRetention struct {
+	ObjectLocation
+	Version Version
+
+	RetainUntil time.Time
+}
+
+// Verify verifies set object retention fields.
+func (opts *SetObjectRetention) Verify() error {
+	if err := opts.ObjectLocation.Verify(); err != nil {
+		return err
+	}
+	switch {
+	case opts.Version <= 0:
+		return ErrInvalidRequest.New("Version invalid: %v", opts.Version)
+	case opts.RetainUntil.IsZero():
+		return ErrInvalidRequest.New("RetainUntil is missing")
+	}
+	return nil
+}
+
+// SetObjectRetention protects a committed object version from deletion until the
+// specified time. An existing retention can only be extended, never shortened.
+func (db *DB) SetObjectRetention(ctx context.Context, opts SetObjectRetention) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return err
+	}
+
+	result, err := db.db.ExecContext(ctx, `
+		UPDATE objects SET
+			retain_until = $5
+		WHERE
+			project_id   = $1 AND
+			bucket_name  = $2 AND
+			object_key   = $3 AND
+			version      = $4 AND
+			status       = `+committedStatus+` AND
+			(retain_until IS NULL OR retain_until <= $5)
+	`, opts.ProjectID, []byte(opts.BucketName), []byte(opts.ObjectKey), opts.Version, opts.RetainUntil)
+	if err != nil {
+		return Error.New("unable to set object retention: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return Error.New("failed to get rows affected: %w", err)
+	}
+	if affected == 0 {
+		_, err := db.GetObjectLock(ctx, GetObjectLock{ObjectLocation: opts.ObjectLocation, Version: opts.Version})
+		if err != nil {
+			return err
+		}
+		return ErrInvalidRequest.New("retention can't be shortened")
+	}
+
+	mon.Meter("object_retention_set").Mark(1)
+
+	return nil
+}
+
+// SetObjectLegalHold contains arguments necessary for placing or removing a
+// legal hold on an object version.
+type SetObjectLegalHold struct {
+	ObjectLocation
+	Version Version
+
+	Enabled bool
+}
+
+// SetObjectLegalHold places or removes a legal hold on a committed object version.
+// An object under legal hold can't be deleted regardless of its retention.
+func (db *DB) SetObjectLegalHold(ctx context.Context, opts SetObjectLegalHold) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.ObjectLocation.Verify(); err != nil {
+		return err
+	}
+	if opts.Version <= 0 {
+		return ErrInvalidRequest.New("Version invalid: %v", opts.Version)
+	}
+
+	result, err := db.db.ExecContext(ctx, `
+		UPDATE objects SET
+			legal_hold = $5
+		WHERE
+			project_id   = $1 AND
+			bucket_name  = $2 AND
+			object_key   = $3 AND
+			version      = $4 AND
+			status       = `+committedStatus+`
+	`, opts.ProjectID, []byte(opts.BucketName), []byte(opts.ObjectKey), opts.Version, opts.Enabled)
+	if err != nil {
+		return Error.New("unable to set object legal hold: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return Error.New("failed to get rows affected: %w", err)
+	}
+	if affected == 0 {
+		return storj.ErrObjectNotFound.Wrap(Error.New("object not found"))
+	}
+
+	mon.Meter("object_legal_hold_set").Mark(1)
+
+	return nil
+}
+
+// ListLockedObjects contains arguments necessary for listing the object
+// versions of a bucket that are protected from deletion.
+type ListLockedObjects struct {
+	Bucket BucketLocation
+	Limit  int
+}
+
+// LockedObject is an object version that is protected from deletion.
+type LockedObject struct {
+	ObjectKey ObjectKey
+	Version   Version
+	ObjectLock
+}
+
+// ListLockedObjects returns up to Limit object versions of the bucket that
+// are protected by a retention period or a legal hold, ordered by key and
+// version.
+func (db *DB) ListLockedObjects(ctx context.Context, opts ListLockedObjects) (locked []LockedObject, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Bucket.Verify(); err != nil {
+		return nil, err
+	}
+	if opts.Limit <= 0 {
+		return nil, ErrInvalidRequest.New("Limit is negative or zero")
+	}
+
+	err = withRows(db.db.QueryContext(ctx, `
+		SELECT object_key, version, retain_until, legal_hold
+		FROM objects
+		WHERE
+			project_id  = $1 AND
+			bucket_name = $2 AND
+			NOT `+objectUnlocked+`
+		ORDER BY object_key ASC, version ASC
+		LIMIT $3
+	`, opts.Bucket.ProjectID, []byte(opts.Bucket.BucketName), opts.Limit))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var object LockedObject
+			if err := rows.Scan(&object.ObjectKey, &object.Version, &object.RetainUntil, &object.LegalHold); err != nil {
+				return err
+			}
+			locked = append(locked, object)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.New("unable to list locked objects: %w", err)
+	}
+	return locked, nil
+}
+
+// lockedObjectError returns ErrObjectLocked when the object version, or any
+// committed version when version is NextVersion, is protected from deletion.
+// Otherwise it returns notFound.
+func (db *DB) lockedObjectError(ctx context.Context, location ObjectLocation, version Version, notFound error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var locked bool
+	err = db.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM objects
+			WHERE
+				project_id   = $1 AND
+				bucket_name  = $2 AND
+				object_key   = $3 AND
+				($4 = 0 OR version = $4) AND
+				status       = `+committedStatus+` AND
+				NOT `+objectUnlocked+`
+		)
+	`, location.ProjectID, []byte(location.BucketName), []byte(location.ObjectKey), version).Scan(&locked)
+	if err != nil {
+		return Error.New("unable to query object lock: %w", err)
+	}
+	if locked {
+		mon.Meter("object_delete_locked").Mark(1)
+		return ErrObjectLocked.New("")
+	}
+	return notFound
+}
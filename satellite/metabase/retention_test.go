@@ -0,0 +1,326 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestBucketRetention(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+		bucket := metabase.BucketLocation{ProjectID: obj.ProjectID, BucketName: obj.BucketName}
+
+		t.Run("invalid days", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.SetBucketRetention{
+				Opts:     metabase.SetBucketRetention{BucketLocation: bucket, Days: -1},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "Days is negative",
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("set and reset", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.GetBucketRetention{
+				Opts:   metabase.GetBucketRetention{BucketLocation: bucket},
+				Result: 0,
+			}.Check(ctx, t, db)
+
+			metabasetest.SetBucketRetention{
+				Opts: metabase.SetBucketRetention{BucketLocation: bucket, Days: 30},
+			}.Check(ctx, t, db)
+
+			metabasetest.GetBucketRetention{
+				Opts:   metabase.GetBucketRetention{BucketLocation: bucket},
+				Result: 30,
+			}.Check(ctx, t, db)
+
+			metabasetest.SetBucketRetention{
+				Opts: metabase.SetBucketRetention{BucketLocation: bucket, Days: 0},
+			}.Check(ctx, t, db)
+
+			metabasetest.GetBucketRetention{
+				Opts:   metabase.GetBucketRetention{BucketLocation: bucket},
+				Result: 0,
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("applied on commit", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.SetBucketRetention{
+				Opts: metabase.SetBucketRetention{BucketLocation: bucket, Days: 2},
+			}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 1)
+
+			retainUntil := time.Now().Add(48 * time.Hour)
+			metabasetest.GetObjectLock{
+				Opts:   metabase.GetObjectLock{ObjectLocation: obj.Location()},
+				Result: metabase.ObjectLock{RetainUntil: &retainUntil},
+			}.Check(ctx, t, db)
+
+			metabasetest.DeleteObjectExactVersion{
+				Opts: metabase.DeleteObjectExactVersion{
+					ObjectLocation: obj.Location(),
+					Version:        obj.Version,
+				},
+				ErrClass: &metabase.ErrObjectLocked,
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{metabase.RawObject(object)},
+				Segments: []metabase.RawSegment{
+					metabasetest.DefaultRawSegment(obj, metabase.SegmentPosition{}),
+				},
+			}.Check(ctx, t, db)
+		})
+	})
+}
+
+func TestObjectRetention(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+
+		t.Run("missing RetainUntil", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.SetObjectRetention{
+				Opts: metabase.SetObjectRetention{
+					ObjectLocation: obj.Location(),
+					Version:        obj.Version,
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "RetainUntil is missing",
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("object missing", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.SetObjectRetention{
+				Opts: metabase.SetObjectRetention{
+					ObjectLocation: obj.Location(),
+					Version:        obj.Version,
+					RetainUntil:    time.Now().Add(time.Hour),
+				},
+				ErrClass: &storj.ErrObjectNotFound,
+			}.Check(ctx, t, db)
+
+			metabasetest.SetObjectLegalHold{
+				Opts: metabase.SetObjectLegalHold{
+					ObjectLocation: obj.Location(),
+					Version:        obj.Version,
+					Enabled:        true,
+				},
+				ErrClass: &storj.ErrObjectNotFound,
+				ErrText:  "metabase: object not found",
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("extend only", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.CreateObject(ctx, t, db, obj, 0)
+
+			retainUntil := time.Now().Add(2 * time.Hour)
+			metabasetest.SetObjectRetention{
+				Opts: metabase.SetObjectRetention{
+					ObjectLocation: obj.Location(),
+					Version:        obj.Version,
+					RetainUntil:    retainUntil,
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.SetObjectRetention{
+				Opts: metabase.SetObjectRetention{
+					ObjectLocation: obj.Location(),
+					Version:        obj.Version,
+					RetainUntil:    time.Now().Add(time.Hour),
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "retention can't be shortened",
+			}.Check(ctx, t, db)
+
+			metabasetest.GetObjectLock{
+				Opts:   metabase.GetObjectLock{ObjectLocation: obj.Location(), Version: obj.Version},
+				Result: metabase.ObjectLock{RetainUntil: &retainUntil},
+			}.Check(ctx, t, db)
+
+			metabasetest.DeleteObjectLatestVersion{
+				Opts:     metabase.DeleteObjectLatestVersion{ObjectLocation: obj.Location()},
+				ErrClass: &metabase.ErrObjectLocked,
+			}.Check(ctx, t, db)
+
+			metabasetest.DeleteObjectAnyStatusAllVersions{
+				Opts:     metabase.DeleteObjectAnyStatusAllVersions{ObjectLocation: obj.Location()},
+				ErrClass: &metabase.ErrObjectLocked,
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("legal hold", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 1)
+
+			metabasetest.SetObjectLegalHold{
+				Opts: metabase.SetObjectLegalHold{
+					ObjectLocation: obj.Location(),
+					Version:        obj.Version,
+					Enabled:        true,
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.GetObjectLock{
+				Opts:   metabase.GetObjectLock{ObjectLocation: obj.Location()},
+				Result: metabase.ObjectLock{LegalHold: true},
+			}.Check(ctx, t, db)
+
+			metabasetest.DeleteObjectExactVersion{
+				Opts: metabase.DeleteObjectExactVersion{
+					ObjectLocation: obj.Location(),
+					Version:        obj.Version,
+				},
+				ErrClass: &metabase.ErrObjectLocked,
+			}.Check(ctx, t, db)
+
+			metabasetest.FinishMoveObject{
+				Opts: metabase.FinishMoveObject{
+					ObjectStream:                 obj,
+					NewBucket:                    obj.BucketName,
+					NewEncryptedObjectKey:        []byte("new key"),
+					NewEncryptedMetadataKeyNonce: testrand.Nonce().Bytes(),
+					NewEncryptedMetadataKey:      testrand.Bytes(32),
+					NewSegmentKeys: []metabase.EncryptedKeyAndNonce{{
+						EncryptedKeyNonce: testrand.Nonce().Bytes(),
+						EncryptedKey:      testrand.Bytes(32),
+					}},
+				},
+				ErrClass: &metabase.ErrObjectLocked,
+			}.Check(ctx, t, db)
+
+			// deleting multiple objects skips the locked ones.
+			metabasetest.DeleteObjectsAllVersions{
+				Opts: metabase.DeleteObjectsAllVersions{
+					Locations: []metabase.ObjectLocation{obj.Location()},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.DeleteBucketObjects{
+				Opts: metabase.DeleteBucketObjects{
+					Bucket: obj.Location().Bucket(),
+				},
+				Deleted: 0,
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{metabase.RawObject(object)},
+				Segments: []metabase.RawSegment{
+					metabasetest.DefaultRawSegment(obj, metabase.SegmentPosition{}),
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.SetObjectLegalHold{
+				Opts: metabase.SetObjectLegalHold{
+					ObjectLocation: obj.Location(),
+					Version:        obj.Version,
+					Enabled:        false,
+				},
+			}.Check(ctx, t, db)
+
+			result, err := db.DeleteObjectExactVersion(ctx, metabase.DeleteObjectExactVersion{
+				ObjectLocation: obj.Location(),
+				Version:        obj.Version,
+			})
+			require.NoError(t, err)
+			require.Len(t, result.Objects, 1)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("list locked objects", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			_, err := db.ListLockedObjects(ctx, metabase.ListLockedObjects{
+				Bucket: obj.Location().Bucket(),
+			})
+			require.True(t, metabase.ErrInvalidRequest.Has(err), err)
+
+			retained, held, unlocked := obj, obj, obj
+			retained.ObjectKey, held.ObjectKey, unlocked.ObjectKey = "a", "b", "c"
+			held.StreamID, unlocked.StreamID = testrand.UUID(), testrand.UUID()
+			for _, object := range []metabase.ObjectStream{retained, held, unlocked} {
+				metabasetest.CreateObject(ctx, t, db, object, 0)
+			}
+
+			retainUntil := time.Now().Add(time.Hour)
+			require.NoError(t, db.SetObjectRetention(ctx, metabase.SetObjectRetention{
+				ObjectLocation: retained.Location(),
+				Version:        retained.Version,
+				RetainUntil:    retainUntil,
+			}))
+			require.NoError(t, db.SetObjectLegalHold(ctx, metabase.SetObjectLegalHold{
+				ObjectLocation: held.Location(),
+				Version:        held.Version,
+				Enabled:        true,
+			}))
+
+			locked, err := db.ListLockedObjects(ctx, metabase.ListLockedObjects{
+				Bucket: obj.Location().Bucket(),
+				Limit:  10,
+			})
+			require.NoError(t, err)
+			require.Len(t, locked, 2)
+			require.Equal(t, retained.ObjectKey, locked[0].ObjectKey)
+			require.Equal(t, retained.Version, locked[0].Version)
+			require.WithinDuration(t, retainUntil, *locked[0].RetainUntil, time.Second)
+			require.Equal(t, held.ObjectKey, locked[1].ObjectKey)
+			require.True(t, locked[1].LegalHold)
+
+			locked, err = db.ListLockedObjects(ctx, metabase.ListLockedObjects{
+				Bucket: obj.Location().Bucket(),
+				Limit:  1,
+			})
+			require.NoError(t, err)
+			require.Len(t, locked, 1)
+		})
+
+		t.Run("expired object under legal hold", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			expiresAt := time.Now().Add(-time.Hour)
+			object := metabasetest.CreateExpiredObject(ctx, t, db, obj, 0, expiresAt)
+
+			metabasetest.SetObjectLegalHold{
+				Opts: metabase.SetObjectLegalHold{
+					ObjectLocation: obj.Location(),
+					Version:        obj.Version,
+					Enabled:        true,
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.DeleteExpiredObjects{
+				Opts: metabase.DeleteExpiredObjects{
+					ExpiredBefore: time.Now(),
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{metabase.RawObject(object)},
+			}.Check(ctx, t, db)
+		})
+	})
+}
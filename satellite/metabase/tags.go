@@ -0,0 +1,278 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/private/tagsql"
+)
+
+const (
+	// MaxTags is the maximum number of tags on a bucket or an object.
+	MaxTags = 10
+	// MaxTagKeyLength is the maximum length of a tag key in bytes.
+	MaxTagKeyLength = 128
+	// MaxTagValueLength is the maximum length of a tag value in bytes.
+	MaxTagValueLength = 256
+)
+
+// Tags are unencrypted key/value pairs attached to buckets and objects. Unlike
+// the encrypted metadata they are visible to the satellite, so they can be used
+// for filtering and for grouping usage.
+type Tags map[string]string
+
+// Verify verifies that tags are within the limits.
+func (tags Tags) Verify() error {
+	if len(tags) > MaxTags {
+		return ErrInvalidRequest.New("too many tags: %d, maximum %d", len(tags), MaxTags)
+	}
+	for key, value := range tags {
+		switch {
+		case key == "":
+			return ErrInvalidRequest.New("tag key missing")
+		case len(key) > MaxTagKeyLength:
+			return ErrInvalidRequest.New("tag key %q is longer than %d bytes", key, MaxTagKeyLength)
+		case len(value) > MaxTagValueLength:
+			return ErrInvalidRequest.New("value of tag %q is longer than %d bytes", key, MaxTagValueLength)
+		case strings.ContainsRune(key, '='):
+			return ErrInvalidRequest.New("tag key %q contains '='", key)
+		case !utf8.ValidString(key) || !utf8.ValidString(value):
+			return ErrInvalidRequest.New("tag %q is not valid UTF-8", key)
+		}
+	}
+	return nil
+}
+
+// pairs returns tags as sorted "key=value" strings, the way they are stored.
+func (tags Tags) pairs() []string {
+	pairs := make([]string, 0, len(tags))
+	for key, value := range tags {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return pairs
+}
+
+// splitTag splits a stored "key=value" tag.
+func splitTag(pair string) (key, value string, ok bool) {
+	i := strings.IndexByte(pair, '=')
+	if i <= 0 {
+		return "", "", false
+	}
+	return pair[:i], pair[i+1:], true
+}
+
+// SetBucketTags contains arguments necessary for replacing the tags of a bucket.
+type SetBucketTags struct {
+	BucketLocation
+	Tags Tags
+}
+
+// Verify verifies set bucket tags fields.
+func (opts *SetBucketTags) Verify() error {
+	if err := opts.BucketLocation.Verify(); err != nil {
+		return err
+	}
+	return opts.Tags.Verify()
+}
+
+// SetBucketTags replaces the tags of a bucket, empty tags remove all of them.
+func (db *DB) SetBucketTags(ctx context.Context, opts SetBucketTags) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return err
+	}
+
+	if len(opts.Tags) > 0 {
+		_, err = db.db.ExecContext(ctx, `
+			INSERT INTO bucket_tags (project_id, bucket_name, tags)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (project_id, bucket_name) DO UPDATE SET tags = $3
+		`, opts.ProjectID, []byte(opts.BucketName), opts.Tags)
+	} else {
+		_, err = db.db.ExecContext(ctx, `
+			DELETE FROM bucket_tags
+			WHERE
+				project_id  = $1 AND
+				bucket_name = $2
+		`, opts.ProjectID, []byte(opts.BucketName))
+	}
+	if err != nil {
+		return Error.New("unable to set bucket tags: %w", err)
+	}
+	return nil
+}
+
+// GetBucketTags contains arguments necessary for fetching the tags of a bucket.
+type GetBucketTags struct {
+	BucketLocation
+}
+
+// GetBucketTags returns the tags of a bucket.
+func (db *DB) GetBucketTags(ctx context.Context, opts GetBucketTags) (tags Tags, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.BucketLocation.Verify(); err != nil {
+		return nil, err
+	}
+
+	err = db.db.QueryRowContext(ctx, `
+		SELECT tags FROM bucket_tags
+		WHERE
+			project_id  = $1 AND
+			bucket_name = $2
+	`, opts.ProjectID, []byte(opts.BucketName)).Scan(&tags)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, Error.New("unable to query bucket tags: %w", err)
+	}
+	return tags, nil
+}
+
+// ListBucketTags contains arguments necessary for listing the tags of buckets.
+type ListBucketTags struct {
+	// ProjectID limits the listing to a single project, all projects are listed when zero.
+	ProjectID uuid.UUID
+}
+
+// BucketTags contains the tags of a bucket.
+type BucketTags struct {
+	BucketLocation
+	Tags Tags
+}
+
+// ListBucketTags lists the tags of all tagged buckets ordered by bucket.
+func (db *DB) ListBucketTags(ctx context.Context, opts ListBucketTags) (buckets []BucketTags, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = withRows(db.db.QueryContext(ctx, `
+		SELECT project_id, bucket_name, tags
+		FROM bucket_tags
+		WHERE
+			$1 OR project_id = $2
+		ORDER BY project_id, bucket_name
+	`, opts.ProjectID.IsZero(), opts.ProjectID))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var bucket BucketTags
+			err := rows.Scan(&bucket.ProjectID, &bucket.BucketName, &bucket.Tags)
+			if err != nil {
+				return Error.New("unable to scan bucket tags: %w", err)
+			}
+			buckets = append(buckets, bucket)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.New("unable to list bucket tags: %w", err)
+	}
+	return buckets, nil
+}
+
+// SetObjectTags contains arguments necessary for replacing the tags of an
+// object version.
+type SetObjectTags struct {
+	ObjectLocation
+	Version Version
+
+	Tags Tags
+}
+
+// Verify verifies set object tags fields.
+func (opts *SetObjectTags) Verify() error {
+	if err := opts.ObjectLocation.Verify(); err != nil {
+		return err
+	}
+	if opts.Version <= 0 {
+		return ErrInvalidRequest.New("Version invalid: %v", opts.Version)
+	}
+	return opts.Tags.Verify()
+}
+
+// SetObjectTags replaces the tags of a committed object version, empty tags
+// remove all of them.
+func (db *DB) SetObjectTags(ctx context.Context, opts SetObjectTags) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return err
+	}
+
+	result, err := db.db.ExecContext(ctx, `
+		UPDATE objects SET
+			tags = $5
+		WHERE
+			project_id   = $1 AND
+			bucket_name  = $2 AND
+			object_key   = $3 AND
+			version      = $4 AND
+			status       = `+committedStatus+`
+	`, opts.ProjectID, []byte(opts.BucketName), []byte(opts.ObjectKey), opts.Version, opts.Tags)
+	if err != nil {
+		return Error.New("unable to set object tags: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return Error.New("failed to get rows affected: %w", err)
+	}
+	if affected == 0 {
+		return storj.ErrObjectNotFound.Wrap(Error.New("object not found"))
+	}
+
+	mon.Meter("object_tags_set").Mark(1)
+
+	return nil
+}
+
+// GetObjectTags contains arguments necessary for fetching the tags of an
+// object version.
+type GetObjectTags struct {
+	ObjectLocation
+
+	// Version of the object, NextVersion means the latest committed version.
+	Version Version
+}
+
+// GetObjectTags returns the tags of a committed object version.
+func (db *DB) GetObjectTags(ctx context.Context, opts GetObjectTags) (tags Tags, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.ObjectLocation.Verify(); err != nil {
+		return nil, err
+	}
+	if opts.Version < 0 {
+		return nil, ErrInvalidRequest.New("Version invalid: %v", opts.Version)
+	}
+
+	err = db.db.QueryRowContext(ctx, `
+		SELECT tags
+		FROM objects
+		WHERE
+			project_id   = $1 AND
+			bucket_name  = $2 AND
+			object_key   = $3 AND
+			($4 = 0 OR version = $4) AND
+			status       = `+committedStatus+`
+		ORDER BY version DESC
+		LIMIT 1
+	`, opts.ProjectID, []byte(opts.BucketName), []byte(opts.ObjectKey), opts.Version).Scan(&tags)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storj.ErrObjectNotFound.Wrap(Error.Wrap(err))
+		}
+		return nil, Error.New("unable to query object tags: %w", err)
+	}
+	return tags, nil
+}
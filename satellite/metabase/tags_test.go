@@ -0,0 +1,186 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestTagsVerify(t *testing.T) {
+	tooMany := metabase.Tags{}
+	for i := 0; i <= metabase.MaxTags; i++ {
+		tooMany[string(rune('a'+i))] = "x"
+	}
+
+	for _, test := range []struct {
+		Tags    metabase.Tags
+		ErrText string
+	}{
+		{Tags: nil},
+		{Tags: metabase.Tags{"team": "storage", "empty": ""}},
+		{Tags: tooMany, ErrText: "too many tags"},
+		{Tags: metabase.Tags{"": "x"}, ErrText: "tag key missing"},
+		{Tags: metabase.Tags{"a=b": "x"}, ErrText: "contains '='"},
+		{Tags: metabase.Tags{strings.Repeat("k", metabase.MaxTagKeyLength+1): "x"}, ErrText: "longer than"},
+		{Tags: metabase.Tags{"k": strings.Repeat("v", metabase.MaxTagValueLength+1)}, ErrText: "longer than"},
+	} {
+		err := test.Tags.Verify()
+		if test.ErrText == "" {
+			require.NoError(t, err)
+			continue
+		}
+		require.True(t, metabase.ErrInvalidRequest.Has(err))
+		require.Contains(t, err.Error(), test.ErrText)
+	}
+}
+
+func TestBucketTags(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		projectID := testrand.UUID()
+		first := metabase.BucketLocation{ProjectID: projectID, BucketName: "a-bucket"}
+		second := metabase.BucketLocation{ProjectID: projectID, BucketName: "b-bucket"}
+
+		defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+		metabasetest.GetBucketTags{
+			Opts: metabase.GetBucketTags{BucketLocation: first},
+		}.Check(ctx, t, db)
+
+		tags := metabase.Tags{"team": "storage", "cost-centre": "42=x"}
+		metabasetest.SetBucketTags{
+			Opts: metabase.SetBucketTags{BucketLocation: first, Tags: tags},
+		}.Check(ctx, t, db)
+		metabasetest.SetBucketTags{
+			Opts: metabase.SetBucketTags{BucketLocation: second, Tags: metabase.Tags{"team": "billing"}},
+		}.Check(ctx, t, db)
+
+		metabasetest.GetBucketTags{
+			Opts:   metabase.GetBucketTags{BucketLocation: first},
+			Result: tags,
+		}.Check(ctx, t, db)
+
+		metabasetest.ListBucketTags{
+			Result: []metabase.BucketTags{
+				{BucketLocation: first, Tags: tags},
+				{BucketLocation: second, Tags: metabase.Tags{"team": "billing"}},
+			},
+		}.Check(ctx, t, db)
+
+		metabasetest.ListBucketTags{
+			Opts: metabase.ListBucketTags{ProjectID: testrand.UUID()},
+		}.Check(ctx, t, db)
+
+		// empty tags remove the tags of the bucket.
+		metabasetest.SetBucketTags{
+			Opts: metabase.SetBucketTags{BucketLocation: second},
+		}.Check(ctx, t, db)
+
+		metabasetest.ListBucketTags{
+			Opts: metabase.ListBucketTags{ProjectID: projectID},
+			Result: []metabase.BucketTags{
+				{BucketLocation: first, Tags: tags},
+			},
+		}.Check(ctx, t, db)
+	})
+}
+
+func TestObjectTags(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+
+		t.Run("object missing", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.SetObjectTags{
+				Opts: metabase.SetObjectTags{
+					ObjectLocation: obj.Location(),
+					Version:        obj.Version,
+					Tags:           metabase.Tags{"team": "storage"},
+				},
+				ErrClass: &storj.ErrObjectNotFound,
+				ErrText:  "metabase: object not found",
+			}.Check(ctx, t, db)
+
+			metabasetest.GetObjectTags{
+				Opts:     metabase.GetObjectTags{ObjectLocation: obj.Location()},
+				ErrClass: &storj.ErrObjectNotFound,
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("set, get and filter", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			tagged := metabasetest.CreateObject(ctx, t, db, obj, 0)
+
+			other := obj
+			other.ObjectKey = "other"
+			other.StreamID = testrand.UUID()
+			metabasetest.CreateObject(ctx, t, db, other, 0)
+
+			metabasetest.GetObjectTags{
+				Opts: metabase.GetObjectTags{ObjectLocation: obj.Location()},
+			}.Check(ctx, t, db)
+
+			tags := metabase.Tags{"team": "storage", "env": "prod"}
+			metabasetest.SetObjectTags{
+				Opts: metabase.SetObjectTags{
+					ObjectLocation: obj.Location(),
+					Version:        obj.Version,
+					Tags:           tags,
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.GetObjectTags{
+				Opts:   metabase.GetObjectTags{ObjectLocation: obj.Location(), Version: obj.Version},
+				Result: tags,
+			}.Check(ctx, t, db)
+
+			metabasetest.IterateObjectsWithStatus{
+				Opts: metabase.IterateObjectsWithStatus{
+					ProjectID:  obj.ProjectID,
+					BucketName: obj.BucketName,
+					Recursive:  true,
+					Status:     metabase.Committed,
+					Tags:       metabase.Tags{"team": "storage"},
+
+					IncludeMetadata: true,
+				},
+				Result: []metabase.ObjectEntry{
+					objectEntryFromRaw(metabase.RawObject(tagged)),
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.IterateObjectsWithStatus{
+				Opts: metabase.IterateObjectsWithStatus{
+					ProjectID:  obj.ProjectID,
+					BucketName: obj.BucketName,
+					Recursive:  true,
+					Status:     metabase.Committed,
+					Tags:       metabase.Tags{"team": "billing"},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.IterateObjectsWithStatus{
+				Opts: metabase.IterateObjectsWithStatus{
+					ProjectID:  obj.ProjectID,
+					BucketName: obj.BucketName,
+					Recursive:  true,
+					Status:     metabase.Pending,
+					Tags:       metabase.Tags{"team": "storage"},
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "Tags are only supported for committed objects",
+			}.Check(ctx, t, db)
+		})
+	})
+}
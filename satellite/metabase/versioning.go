@@ -0,0 +1,346 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/private/dbutil/txutil"
+	"storj.io/private/tagsql"
+)
+
+// SetBucketVersioning contains arguments necessary for enabling or disabling
+// versioning of a bucket.
+type SetBucketVersioning struct {
+	BucketLocation
+	Enabled bool
+}
+
+// SetBucketVersioning enables or disables versioning of a bucket. Disabling
+// versioning keeps the versions that already exist.
+func (db *DB) SetBucketVersioning(ctx context.Context, opts SetBucketVersioning) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.BucketLocation.Verify(); err != nil {
+		return err
+	}
+
+	if opts.Enabled {
+		_, err = db.db.ExecContext(ctx, `
+			INSERT INTO bucket_versioning (project_id, bucket_name)
+			VALUES ($1, $2)
+			ON CONFLICT (project_id, bucket_name) DO NOTHING
+		`, opts.ProjectID, []byte(opts.BucketName))
+	} else {
+		_, err = db.db.ExecContext(ctx, `
+			DELETE FROM bucket_versioning
+			WHERE
+				project_id  = $1 AND
+				bucket_name = $2
+		`, opts.ProjectID, []byte(opts.BucketName))
+	}
+	if err != nil {
+		return Error.New("unable to update bucket versioning: %w", err)
+	}
+	return nil
+}
+
+// GetBucketVersioning contains arguments necessary for checking whether a
+// bucket has versioning enabled.
+type GetBucketVersioning struct {
+	BucketLocation
+}
+
+// GetBucketVersioning returns whether versioning is enabled for the bucket.
+func (db *DB) GetBucketVersioning(ctx context.Context, opts GetBucketVersioning) (enabled bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.BucketLocation.Verify(); err != nil {
+		return false, err
+	}
+
+	err = db.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM bucket_versioning
+			WHERE
+				project_id  = $1 AND
+				bucket_name = $2
+		)
+	`, opts.ProjectID, []byte(opts.BucketName)).Scan(&enabled)
+	if err != nil {
+		return false, Error.New("unable to query bucket versioning: %w", err)
+	}
+	return enabled, nil
+}
+
+// CreateDeleteMarker contains arguments necessary for deleting an object in a
+// bucket with versioning enabled.
+type CreateDeleteMarker struct {
+	ObjectLocation
+}
+
+// CreateDeleteMarker hides the latest version of an object by adding a delete
+// marker as its newest version. No segments are deleted, result.Objects contains
+// the version that got hidden.
+func (db *DB) CreateDeleteMarker(ctx context.Context, opts CreateDeleteMarker) (result DeleteObjectResult, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return DeleteObjectResult{}, err
+	}
+
+	streamID, err := uuid.New()
+	if err != nil {
+		return DeleteObjectResult{}, Error.New("unable to create stream id: %w", err)
+	}
+
+	err = txutil.WithTx(ctx, db.db, nil, func(ctx context.Context, tx tagsql.Tx) error {
+		latest := Object{}
+		err := tx.QueryRowContext(ctx, `
+			SELECT
+				stream_id, version, status,
+				created_at, expires_at,
+				segment_count,
+				encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
+				total_plain_size, total_encrypted_size, fixed_segment_size,
+				encryption
+			FROM objects
+			WHERE
+				project_id   = $1 AND
+				bucket_name  = $2 AND
+				object_key   = $3 AND
+				status       IN (`+committedStatus+`, `+deleteMarkerStatus+`)
+			ORDER BY version DESC
+			LIMIT 1
+		`, opts.ProjectID, []byte(opts.BucketName), []byte(opts.ObjectKey)).
+			Scan(
+				&latest.StreamID, &latest.Version, &latest.Status,
+				&latest.CreatedAt, &latest.ExpiresAt,
+				&latest.SegmentCount,
+				&latest.EncryptedMetadataNonce, &latest.EncryptedMetadata, &latest.EncryptedMetadataEncryptedKey,
+				&latest.TotalPlainSize, &latest.TotalEncryptedSize, &latest.FixedSegmentSize,
+				encryptionParameters{&latest.Encryption},
+			)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return storj.ErrObjectNotFound.Wrap(Error.New("object not found"))
+			}
+			return Error.New("unable to query latest version: %w", err)
+		}
+		if latest.Status == DeleteMarker {
+			return storj.ErrObjectNotFound.Wrap(Error.New("object is already deleted"))
+		}
+		latest.ObjectStream.ProjectID = opts.ProjectID
+		latest.ObjectStream.BucketName = opts.BucketName
+		latest.ObjectStream.ObjectKey = opts.ObjectKey
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO objects (
+				project_id, bucket_name, object_key, version, stream_id,
+				status,
+				zombie_deletion_deadline
+			) VALUES (
+				$1, $2, $3,
+				coalesce((
+					SELECT max(version) + 1 FROM objects
+					WHERE
+						project_id  = $1 AND
+						bucket_name = $2 AND
+						object_key  = $3
+				), 1),
+				$4,
+				`+deleteMarkerStatus+`,
+				NULL
+			)
+		`, opts.ProjectID, []byte(opts.BucketName), []byte(opts.ObjectKey), streamID)
+		if err != nil {
+			return Error.New("unable to insert delete marker: %w", err)
+		}
+
+		result.Objects = []Object{latest}
+		return nil
+	})
+	if err != nil {
+		return DeleteObjectResult{}, err
+	}
+
+	mon.Meter("object_delete_marker").Mark(1)
+
+	return result, nil
+}
+
+// ListObjectVersions contains arguments necessary for listing all versions of
+// an object.
+type ListObjectVersions struct {
+	ObjectLocation
+
+	// Cursor is exclusive, only versions older than the cursor are listed.
+	// When zero the listing starts from the newest version.
+	Cursor Version
+	Limit  int
+}
+
+// Verify verifies list object versions fields.
+func (opts *ListObjectVersions) Verify() error {
+	if err := opts.ObjectLocation.Verify(); err != nil {
+		return err
+	}
+	switch {
+	case opts.Cursor < 0:
+		return ErrInvalidRequest.New("Cursor is negative")
+	case opts.Limit < 0:
+		return ErrInvalidRequest.New("Limit is negative")
+	}
+	return nil
+}
+
+// ListObjectVersionsResult contains the versions of an object.
+type ListObjectVersionsResult struct {
+	Versions []Object
+	More     bool
+}
+
+// ListObjectVersions lists committed versions and delete markers of an object,
+// newest first.
+func (db *DB) ListObjectVersions(ctx context.Context, opts ListObjectVersions) (result ListObjectVersionsResult, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return ListObjectVersionsResult{}, err
+	}
+
+	ListLimit.Ensure(&opts.Limit)
+
+	cursor := opts.Cursor
+	if cursor == 0 {
+		cursor = MaxVersion
+	}
+
+	err = withRows(db.db.QueryContext(ctx, `
+		SELECT
+			stream_id, version, status,
+			created_at, expires_at,
+			segment_count,
+			encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
+			total_plain_size, total_encrypted_size, fixed_segment_size,
+			encryption
+		FROM objects
+		WHERE
+			project_id   = $1 AND
+			bucket_name  = $2 AND
+			object_key   = $3 AND
+			version      < $4 AND
+			status       IN (`+committedStatus+`, `+deleteMarkerStatus+`)
+		ORDER BY version DESC
+		LIMIT $5
+	`, opts.ProjectID, []byte(opts.BucketName), []byte(opts.ObjectKey), cursor, opts.Limit+1))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var object Object
+			err := rows.Scan(
+				&object.StreamID, &object.Version, &object.Status,
+				&object.CreatedAt, &object.ExpiresAt,
+				&object.SegmentCount,
+				&object.EncryptedMetadataNonce, &object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey,
+				&object.TotalPlainSize, &object.TotalEncryptedSize, &object.FixedSegmentSize,
+				encryptionParameters{&object.Encryption},
+			)
+			if err != nil {
+				return Error.New("unable to scan object version: %w", err)
+			}
+			object.ProjectID = opts.ProjectID
+			object.BucketName = opts.BucketName
+			object.ObjectKey = opts.ObjectKey
+			result.Versions = append(result.Versions, object)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ListObjectVersionsResult{}, nil
+		}
+		return ListObjectVersionsResult{}, Error.New("unable to list object versions: %w", err)
+	}
+
+	if len(result.Versions) > opts.Limit {
+		result.More = true
+		result.Versions = result.Versions[:opts.Limit]
+	}
+
+	return result, nil
+}
+
+// RestoreObjectVersion contains arguments necessary for restoring an older
+// version of an object.
+type RestoreObjectVersion struct {
+	ObjectLocation
+	Version Version
+
+	NewStreamID uuid.UUID
+}
+
+// Verify verifies restore object version fields.
+func (opts *RestoreObjectVersion) Verify() error {
+	if err := opts.ObjectLocation.Verify(); err != nil {
+		return err
+	}
+	switch {
+	case opts.Version <= 0:
+		return ErrInvalidRequest.New("Version invalid: %v", opts.Version)
+	case opts.NewStreamID.IsZero():
+		return ErrInvalidRequest.New("NewStreamID is missing")
+	}
+	return nil
+}
+
+// RestoreObjectVersion makes a committed version the latest version of the object
+// by copying it on top of the existing versions. The restored version shares the
+// pieces of the original one, so nothing is uploaded to the storage nodes.
+func (db *DB) RestoreObjectVersion(ctx context.Context, opts RestoreObjectVersion) (object Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return Object{}, err
+	}
+
+	keys, err := db.objectEncryptionKeys(ctx, opts.ObjectLocation, opts.Version)
+	if err != nil {
+		return Object{}, err
+	}
+
+	// the object stays at the same location, so the keys don't need to be re-encrypted.
+	copyOpts := FinishCopyObject{
+		ObjectStream: ObjectStream{
+			ProjectID:  opts.ProjectID,
+			BucketName: opts.BucketName,
+			ObjectKey:  opts.ObjectKey,
+			Version:    opts.Version,
+			StreamID:   keys.StreamID,
+		},
+		NewStreamID:                  opts.NewStreamID,
+		NewBucket:                    opts.BucketName,
+		NewEncryptedObjectKey:        []byte(opts.ObjectKey),
+		NewEncryptedMetadataKeyNonce: keys.EncryptedMetadataKeyNonce,
+		NewEncryptedMetadataKey:      keys.EncryptedMetadataKey,
+		NewSegmentKeys:               keys.EncryptedKeysNonces,
+	}
+	if err := copyOpts.Verify(); err != nil {
+		return Object{}, err
+	}
+
+	err = txutil.WithTx(ctx, db.db, nil, func(ctx context.Context, tx tagsql.Tx) error {
+		object, err = insertObjectCopy(ctx, tx, copyOpts)
+		return err
+	})
+	if err != nil {
+		return Object{}, err
+	}
+
+	mon.Meter("restore_object_version").Mark(1)
+
+	return object, nil
+}
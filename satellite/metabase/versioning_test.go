@@ -0,0 +1,380 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestBucketVersioning(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		bucket := metabase.BucketLocation{
+			ProjectID:  testrand.UUID(),
+			BucketName: testrand.BucketName(),
+		}
+
+		t.Run("invalid location", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.SetBucketVersioning{
+				Opts: metabase.SetBucketVersioning{
+					BucketLocation: metabase.BucketLocation{BucketName: bucket.BucketName},
+					Enabled:        true,
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "ProjectID missing",
+			}.Check(ctx, t, db)
+
+			metabasetest.GetBucketVersioning{
+				Opts: metabase.GetBucketVersioning{
+					BucketLocation: metabase.BucketLocation{ProjectID: bucket.ProjectID},
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "BucketName missing",
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("enable and disable", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.GetBucketVersioning{
+				Opts:   metabase.GetBucketVersioning{BucketLocation: bucket},
+				Result: false,
+			}.Check(ctx, t, db)
+
+			for i := 0; i < 2; i++ {
+				metabasetest.SetBucketVersioning{
+					Opts: metabase.SetBucketVersioning{BucketLocation: bucket, Enabled: true},
+				}.Check(ctx, t, db)
+			}
+
+			metabasetest.GetBucketVersioning{
+				Opts:   metabase.GetBucketVersioning{BucketLocation: bucket},
+				Result: true,
+			}.Check(ctx, t, db)
+
+			metabasetest.SetBucketVersioning{
+				Opts: metabase.SetBucketVersioning{BucketLocation: bucket, Enabled: false},
+			}.Check(ctx, t, db)
+
+			metabasetest.GetBucketVersioning{
+				Opts:   metabase.GetBucketVersioning{BucketLocation: bucket},
+				Result: false,
+			}.Check(ctx, t, db)
+		})
+	})
+}
+
+func TestCreateDeleteMarker(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+
+		for _, test := range metabasetest.InvalidObjectLocations(obj.Location()) {
+			test := test
+			t.Run(test.Name, func(t *testing.T) {
+				defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+				metabasetest.CreateDeleteMarker{
+					Opts:     metabase.CreateDeleteMarker{ObjectLocation: test.ObjectLocation},
+					ErrClass: test.ErrClass,
+					ErrText:  test.ErrText,
+				}.Check(ctx, t, db)
+				metabasetest.Verify{}.Check(ctx, t, db)
+			})
+		}
+
+		t.Run("object missing", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.CreateDeleteMarker{
+				Opts:     metabase.CreateDeleteMarker{ObjectLocation: obj.Location()},
+				ErrClass: &storj.ErrObjectNotFound,
+				ErrText:  "metabase: object not found",
+			}.Check(ctx, t, db)
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("hide and unhide", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 1)
+
+			metabasetest.CreateDeleteMarker{
+				Opts: metabase.CreateDeleteMarker{ObjectLocation: obj.Location()},
+				Result: metabase.DeleteObjectResult{
+					Objects: []metabase.Object{object},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.CreateDeleteMarker{
+				Opts:     metabase.CreateDeleteMarker{ObjectLocation: obj.Location()},
+				ErrClass: &storj.ErrObjectNotFound,
+				ErrText:  "metabase: object is already deleted",
+			}.Check(ctx, t, db)
+
+			metabasetest.GetObjectLatestVersion{
+				Opts:     metabase.GetObjectLatestVersion{ObjectLocation: obj.Location()},
+				ErrClass: &storj.ErrObjectNotFound,
+				ErrText:  "metabase: object is deleted",
+			}.Check(ctx, t, db)
+
+			marker := metabase.Object{
+				ObjectStream: metabase.ObjectStream{
+					ProjectID:  obj.ProjectID,
+					BucketName: obj.BucketName,
+					ObjectKey:  obj.ObjectKey,
+					Version:    2,
+				},
+				CreatedAt: time.Now(),
+				Status:    metabase.DeleteMarker,
+			}
+
+			metabasetest.ListObjectVersions{
+				Opts: metabase.ListObjectVersions{ObjectLocation: obj.Location()},
+				Result: metabase.ListObjectVersionsResult{
+					Versions: []metabase.Object{marker, object},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.ListObjectVersions{
+				Opts: metabase.ListObjectVersions{ObjectLocation: obj.Location(), Limit: 1},
+				Result: metabase.ListObjectVersionsResult{
+					Versions: []metabase.Object{marker},
+					More:     true,
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.ListObjectVersions{
+				Opts: metabase.ListObjectVersions{ObjectLocation: obj.Location(), Cursor: 2},
+				Result: metabase.ListObjectVersionsResult{
+					Versions: []metabase.Object{object},
+				},
+			}.Check(ctx, t, db)
+
+			// removing the delete marker makes the object visible again.
+			_, err := db.DeleteObjectExactVersion(ctx, metabase.DeleteObjectExactVersion{
+				ObjectLocation: obj.Location(),
+				Version:        2,
+			})
+			require.NoError(t, err)
+
+			metabasetest.GetObjectLatestVersion{
+				Opts:   metabase.GetObjectLatestVersion{ObjectLocation: obj.Location()},
+				Result: object,
+			}.Check(ctx, t, db)
+		})
+	})
+}
+
+func TestRestoreObjectVersion(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+
+		t.Run("missing NewStreamID", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.RestoreObjectVersion{
+				Opts: metabase.RestoreObjectVersion{
+					ObjectLocation: obj.Location(),
+					Version:        1,
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "NewStreamID is missing",
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("version missing", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.RestoreObjectVersion{
+				Opts: metabase.RestoreObjectVersion{
+					ObjectLocation: obj.Location(),
+					Version:        1,
+					NewStreamID:    testrand.UUID(),
+				},
+				ErrClass: &storj.ErrObjectNotFound,
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("restore older version", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			first := metabasetest.CreateObject(ctx, t, db, obj, 1)
+
+			second := obj
+			second.Version = 2
+			second.StreamID = testrand.UUID()
+			metabasetest.CreateObject(ctx, t, db, second, 0)
+
+			newStreamID := testrand.UUID()
+			restored := metabasetest.RestoreObjectVersion{
+				Opts: metabase.RestoreObjectVersion{
+					ObjectLocation: obj.Location(),
+					Version:        1,
+					NewStreamID:    newStreamID,
+				},
+			}.Check(ctx, t, db)
+
+			expected := first
+			expected.Version = 3
+			expected.StreamID = newStreamID
+			expected.CreatedAt = restored.CreatedAt
+
+			metabasetest.GetObjectLatestVersion{
+				Opts:   metabase.GetObjectLatestVersion{ObjectLocation: obj.Location()},
+				Result: expected,
+			}.Check(ctx, t, db)
+
+			// deleting the restored version must keep the pieces of the original one.
+			result, err := db.DeleteObjectExactVersion(ctx, metabase.DeleteObjectExactVersion{
+				ObjectLocation: obj.Location(),
+				Version:        3,
+			})
+			require.NoError(t, err)
+			require.Empty(t, result.Segments)
+		})
+	})
+}
+
+func TestIterateObjectsLatestVersion(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+		projectID, bucketName := testrand.UUID(), "bucket"
+
+		stream := func(key string, version metabase.Version) metabase.ObjectStream {
+			return metabase.ObjectStream{
+				ProjectID:  projectID,
+				BucketName: bucketName,
+				ObjectKey:  metabase.ObjectKey(key),
+				Version:    version,
+				StreamID:   testrand.UUID(),
+			}
+		}
+
+		metabasetest.CreateObject(ctx, t, db, stream("a", 1), 0)
+		latest := metabasetest.CreateObject(ctx, t, db, stream("a", 2), 0)
+
+		deleted := metabasetest.CreateObject(ctx, t, db, stream("b", 1), 0)
+		metabasetest.CreateDeleteMarker{
+			Opts: metabase.CreateDeleteMarker{ObjectLocation: deleted.Location()},
+			Result: metabase.DeleteObjectResult{
+				Objects: []metabase.Object{deleted},
+			},
+		}.Check(ctx, t, db)
+
+		metabasetest.IterateObjectsLatestVersion{
+			Opts: metabase.IterateObjectsWithStatus{
+				ProjectID:       projectID,
+				BucketName:      bucketName,
+				Recursive:       true,
+				Status:          metabase.Committed,
+				IncludeMetadata: true,
+			},
+			Result: []metabase.ObjectEntry{objectEntryFromRaw(metabase.RawObject(latest))},
+		}.Check(ctx, t, db)
+	})
+}
+
+func TestIterateObjectsWithDeleteMarkers(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+		projectID, bucketName := testrand.UUID(), "bucket"
+
+		stream := func(key string, version metabase.Version) metabase.ObjectStream {
+			return metabase.ObjectStream{
+				ProjectID:  projectID,
+				BucketName: bucketName,
+				ObjectKey:  metabase.ObjectKey(key),
+				Version:    version,
+				StreamID:   testrand.UUID(),
+			}
+		}
+
+		metabasetest.IterateObjectsWithStatus{
+			Opts: metabase.IterateObjectsWithStatus{
+				ProjectID:            projectID,
+				BucketName:           bucketName,
+				Status:               metabase.Pending,
+				IncludeDeleteMarkers: true,
+			},
+			ErrClass: &metabase.ErrInvalidRequest,
+			ErrText:  "Delete markers are only supported for committed objects",
+		}.Check(ctx, t, db)
+
+		first := metabasetest.CreateObject(ctx, t, db, stream("a", 1), 0)
+		second := metabasetest.CreateObject(ctx, t, db, stream("a", 2), 0)
+
+		// the latest version of deleted/b is a delete marker.
+		deleted := metabasetest.CreateObject(ctx, t, db, stream("deleted/b", 1), 0)
+		metabasetest.CreateDeleteMarker{
+			Opts: metabase.CreateDeleteMarker{ObjectLocation: deleted.Location()},
+			Result: metabase.DeleteObjectResult{
+				Objects: []metabase.Object{deleted},
+			},
+		}.Check(ctx, t, db)
+		marker := metabase.ObjectEntry{
+			ObjectKey: "deleted/b",
+			Version:   2,
+			Status:    metabase.DeleteMarker,
+			CreatedAt: time.Now(),
+		}
+
+		metabasetest.IterateObjectsWithStatus{
+			Opts: metabase.IterateObjectsWithStatus{
+				ProjectID:            projectID,
+				BucketName:           bucketName,
+				Recursive:            true,
+				Status:               metabase.Committed,
+				IncludeMetadata:      true,
+				IncludeDeleteMarkers: true,
+			},
+			Result: []metabase.ObjectEntry{
+				objectEntryFromRaw(metabase.RawObject(first)),
+				objectEntryFromRaw(metabase.RawObject(second)),
+				objectEntryFromRaw(metabase.RawObject(deleted)),
+				marker,
+			},
+		}.Check(ctx, t, db)
+
+		// the prefix with only deleted objects is listed too.
+		metabasetest.IterateObjectsWithStatus{
+			Opts: metabase.IterateObjectsWithStatus{
+				ProjectID:            projectID,
+				BucketName:           bucketName,
+				Status:               metabase.Committed,
+				IncludeDeleteMarkers: true,
+				Cursor:               metabase.IterateCursor{Key: "a", Version: metabase.MaxVersion},
+			},
+			Result: []metabase.ObjectEntry{
+				{IsPrefix: true, ObjectKey: "deleted/", Status: metabase.Committed},
+			},
+		}.Check(ctx, t, db)
+
+		// versions hidden by the delete marker are still skipped for the latest versions.
+		metabasetest.IterateObjectsLatestVersion{
+			Opts: metabase.IterateObjectsWithStatus{
+				ProjectID:            projectID,
+				BucketName:           bucketName,
+				Recursive:            true,
+				Status:               metabase.Committed,
+				IncludeMetadata:      true,
+				IncludeDeleteMarkers: true,
+			},
+			Result: []metabase.ObjectEntry{
+				objectEntryFromRaw(metabase.RawObject(second)),
+				marker,
+			},
+		}.Check(ctx, t, db)
+	})
+}
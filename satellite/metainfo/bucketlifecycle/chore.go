@@ -0,0 +1,234 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package bucketlifecycle
+
+import (
+	"context"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+	"storj.io/storj/satellite/metabase"
+)
+
+var (
+	// Error defines the bucketlifecycle chore errors class.
+	Error = errs.Class("bucket lifecycle")
+	mon   = monkit.Package()
+)
+
+// Config contains configurable values for applying bucket lifecycle rules.
+type Config struct {
+	Interval           time.Duration `help:"the time between each attempt to apply bucket lifecycle rules" releaseDefault:"24h" devDefault:"10s" testDefault:"$TESTINTERVAL"`
+	Enabled            bool          `help:"set if bucket lifecycle rules are applied or not" releaseDefault:"true" devDefault:"true"`
+	ListLimit          int           `help:"how many objects to query in a batch" default:"2500"`
+	AsOfSystemInterval time.Duration `help:"as of system interval" releaseDefault:"-5m" devDefault:"-1us" testDefault:"-1us"`
+}
+
+// RuleStats contains what a lifecycle rule deleted during the last iteration.
+type RuleStats struct {
+	Rule metabase.LifecycleRule
+
+	DeletedObjects int64
+	DeletedBytes   int64
+	SkippedLocked  int64
+}
+
+// Chore implements the chore which applies bucket lifecycle rules.
+//
+// architecture: Chore
+type Chore struct {
+	log      *zap.Logger
+	config   Config
+	metabase *metabase.DB
+
+	nowFn func() time.Time
+	Loop  *sync2.Cycle
+}
+
+// NewChore creates a new instance of the bucketlifecycle chore.
+func NewChore(log *zap.Logger, config Config, metabase *metabase.DB) *Chore {
+	return &Chore{
+		log:      log,
+		config:   config,
+		metabase: metabase,
+
+		nowFn: time.Now,
+		Loop:  sync2.NewCycle(config.Interval),
+	}
+}
+
+// Run starts the bucketlifecycle loop service.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !chore.config.Enabled {
+		return nil
+	}
+
+	return chore.Loop.Run(ctx, func(ctx context.Context) error {
+		stats, err := chore.ApplyRules(ctx)
+		if err != nil {
+			chore.log.Error("applying bucket lifecycle rules failed", zap.Error(err))
+			return nil
+		}
+
+		for _, stat := range stats {
+			chore.log.Info("applied bucket lifecycle rule",
+				zap.Stringer("Project ID", stat.Rule.ProjectID),
+				zap.String("Bucket", stat.Rule.BucketName),
+				zap.String("Rule", stat.Rule.Name),
+				zap.Stringer("Action", stat.Rule.Action),
+				zap.Int64("Deleted Objects", stat.DeletedObjects),
+				zap.Int64("Deleted Bytes", stat.DeletedBytes),
+				zap.Int64("Skipped Locked", stat.SkippedLocked))
+		}
+		return nil
+	})
+}
+
+// Close stops the bucketlifecycle chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
+
+// SetNow allows tests to have the server act as if the current time is whatever they want.
+func (chore *Chore) SetNow(nowFn func() time.Time) {
+	chore.nowFn = nowFn
+}
+
+// match is an object which should be deleted by a rule.
+type match struct {
+	rule   int
+	object metabase.LoopObjectEntry
+}
+
+// ApplyRules deletes all objects matching a lifecycle rule and returns what
+// each rule deleted.
+func (chore *Chore) ApplyRules(ctx context.Context) (stats []RuleStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rules, err := chore.metabase.ListLifecycleRules(ctx, metabase.ListLifecycleRules{})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	stats = make([]RuleStats, len(rules))
+	bucketRules := make(map[metabase.BucketLocation][]int)
+	for i, rule := range rules {
+		stats[i].Rule = rule
+		bucketRules[rule.BucketLocation] = append(bucketRules[rule.BucketLocation], i)
+	}
+
+	now := chore.nowFn()
+
+	// matches are deleted in batches while iterating, so that the matches of
+	// a large bucket are never held in memory all at once.
+	var matches []match
+	err = chore.metabase.IterateLoopObjects(ctx, metabase.IterateLoopObjects{
+		BatchSize:          chore.config.ListLimit,
+		AsOfSystemInterval: chore.config.AsOfSystemInterval,
+	}, func(ctx context.Context, it metabase.LoopObjectsIterator) error {
+		var entry metabase.LoopObjectEntry
+		for it.Next(ctx, &entry) {
+			for _, i := range bucketRules[entry.Location().Bucket()] {
+				if rules[i].Applies(entry, now) {
+					matches = append(matches, match{rule: i, object: entry})
+					break
+				}
+			}
+
+			if len(matches) >= chore.config.ListLimit {
+				if err := chore.deleteMatches(ctx, matches, stats); err != nil {
+					return err
+				}
+				matches = matches[:0]
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if err := chore.deleteMatches(ctx, matches, stats); err != nil {
+		return nil, err
+	}
+
+	// rules are named by users, so the stats are only reported per action.
+	actions := make(map[metabase.LifecycleAction]RuleStats)
+	for _, stat := range stats {
+		total := actions[stat.Rule.Action]
+		total.DeletedObjects += stat.DeletedObjects
+		total.DeletedBytes += stat.DeletedBytes
+		total.SkippedLocked += stat.SkippedLocked
+		actions[stat.Rule.Action] = total
+	}
+	for action, total := range actions {
+		tag := monkit.NewSeriesTag("action", action.String())
+		mon.IntVal("lifecycle_rule_deleted_objects", tag).Observe(total.DeletedObjects)
+		mon.IntVal("lifecycle_rule_deleted_bytes", tag).Observe(total.DeletedBytes)
+		mon.IntVal("lifecycle_rule_skipped_locked", tag).Observe(total.SkippedLocked)
+	}
+
+	return stats, nil
+}
+
+// deleteMatches deletes the matched objects and adds them to the stats of
+// their rules.
+func (chore *Chore) deleteMatches(ctx context.Context, matches []match, stats []RuleStats) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for _, m := range matches {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		stat := &stats[m.rule]
+		err := chore.deleteObject(ctx, m.object)
+		switch {
+		case err == nil:
+			stat.DeletedObjects++
+			stat.DeletedBytes += m.object.TotalEncryptedSize
+		case metabase.ErrObjectLocked.Has(err):
+			stat.SkippedLocked++
+		case storj.ErrObjectNotFound.Has(err):
+			// the object was deleted or replaced since it was listed.
+		default:
+			chore.log.Error("deleting object failed",
+				zap.Stringer("Project ID", m.object.ProjectID),
+				zap.String("Bucket", m.object.BucketName),
+				zap.String("Rule", stat.Rule.Name),
+				zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// deleteObject deletes the listed stream, so that an object uploaded to the
+// same location after the listing is never deleted.
+func (chore *Chore) deleteObject(ctx context.Context, object metabase.LoopObjectEntry) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if object.Status == metabase.Pending {
+		_, err = chore.metabase.DeletePendingObject(ctx, metabase.DeletePendingObject{
+			ObjectStream: object.ObjectStream,
+		})
+		return err
+	}
+
+	_, err = chore.metabase.DeleteObjectExactVersion(ctx, metabase.DeleteObjectExactVersion{
+		ObjectLocation: object.Location(),
+		Version:        object.Version,
+		StreamID:       object.StreamID,
+	})
+	return err
+}
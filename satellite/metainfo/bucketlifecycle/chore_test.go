@@ -0,0 +1,125 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package bucketlifecycle_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestApplyRules(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				// matches are deleted while iterating, after every match.
+				config.BucketLifecycle.ListLimit = 1
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		upl := planet.Uplinks[0]
+		chore := satellite.Core.BucketLifecycle.Chore
+		metabaseDB := satellite.Metainfo.Metabase
+
+		chore.Loop.Pause()
+
+		err := upl.Upload(ctx, satellite, "testbucket", "logs/first", testrand.Bytes(1*memory.KiB))
+		require.NoError(t, err)
+		err = upl.Upload(ctx, satellite, "testbucket", "logs/second", testrand.Bytes(8*memory.KiB))
+		require.NoError(t, err)
+		err = upl.Upload(ctx, satellite, "testbucket", "data", testrand.Bytes(1*memory.KiB))
+		require.NoError(t, err)
+
+		objects, err := metabaseDB.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 3)
+
+		// object keys are encrypted per path component, so the encrypted prefix
+		// can be taken from one of the uploaded objects.
+		var logsPrefix metabase.ObjectKey
+		for _, object := range objects {
+			if i := strings.IndexByte(string(object.ObjectKey), '/'); i >= 0 {
+				logsPrefix = object.ObjectKey[:i+1]
+			}
+		}
+		require.NotEmpty(t, logsPrefix)
+
+		bucket := metabase.BucketLocation{
+			ProjectID:  planet.Uplinks[0].Projects[0].ID,
+			BucketName: "testbucket",
+		}
+
+		_, err = metabaseDB.BeginObjectNextVersion(ctx, metabase.BeginObjectNextVersion{
+			ObjectStream: metabase.ObjectStream{
+				ProjectID:  bucket.ProjectID,
+				BucketName: bucket.BucketName,
+				ObjectKey:  metabase.ObjectKey(testrand.Bytes(16)),
+				Version:    metabase.NextVersion,
+				StreamID:   testrand.UUID(),
+			},
+			Encryption: metabasetest.DefaultEncryption,
+		})
+		require.NoError(t, err)
+
+		logsRule := metabase.LifecycleRule{
+			BucketLocation: bucket,
+			Name:           "logs",
+			Prefix:         logsPrefix,
+			Action:         metabase.LifecycleExpireObjects,
+			AgeDays:        30,
+		}
+		uploadsRule := metabase.LifecycleRule{
+			BucketLocation: bucket,
+			Name:           "uploads",
+			Action:         metabase.LifecycleAbortUploads,
+			AgeDays:        7,
+		}
+		for _, rule := range []metabase.LifecycleRule{logsRule, uploadsRule} {
+			err = metabaseDB.SetBucketLifecycleRule(ctx, metabase.SetBucketLifecycleRule{Rule: rule})
+			require.NoError(t, err)
+		}
+
+		// nothing is old enough yet.
+		stats, err := chore.ApplyRules(ctx)
+		require.NoError(t, err)
+		require.Len(t, stats, 2)
+		for _, stat := range stats {
+			require.Zero(t, stat.DeletedObjects)
+		}
+
+		chore.SetNow(func() time.Time {
+			return time.Now().AddDate(0, 0, 31)
+		})
+
+		stats, err = chore.ApplyRules(ctx)
+		require.NoError(t, err)
+		require.Len(t, stats, 2)
+
+		require.Equal(t, "logs", stats[0].Rule.Name)
+		require.EqualValues(t, 2, stats[0].DeletedObjects)
+		require.NotZero(t, stats[0].DeletedBytes)
+
+		require.Equal(t, "uploads", stats[1].Rule.Name)
+		require.EqualValues(t, 1, stats[1].DeletedObjects)
+
+		objects, err = metabaseDB.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+		require.Equal(t, metabase.Committed, objects[0].Status)
+		require.False(t, strings.HasPrefix(string(objects[0].ObjectKey), string(logsPrefix)))
+	})
+}
@@ -0,0 +1,16 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+/*
+Package bucketlifecycle contains the chore which applies bucket lifecycle rules.
+
+A lifecycle rule deletes committed objects or aborts pending uploads under a
+prefix of a bucket once they are older than the configured number of days.
+The rules are stored in metabase.
+
+The chore iterates over all objects with IterateLoopObjects, collects the ones
+matching a rule and deletes them afterwards. Objects protected by a retention
+period or a legal hold are kept. The number of objects and bytes deleted by each
+rule is reported as metrics.
+*/
+package bucketlifecycle
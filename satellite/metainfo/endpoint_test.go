@@ -5,6 +5,7 @@ package metainfo_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -389,6 +390,53 @@ func TestDeleteBucket(t *testing.T) {
 	})
 }
 
+func TestDeleteBucket_LockedObjects(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		apiKey := planet.Uplinks[0].APIKey[planet.Satellites[0].ID()]
+		satellite := planet.Satellites[0]
+		projectID := planet.Uplinks[0].Projects[0].ID
+
+		err := planet.Uplinks[0].Upload(ctx, satellite, "bucket", "unlocked", testrand.Bytes(memory.KiB))
+		require.NoError(t, err)
+		err = planet.Uplinks[0].Upload(ctx, satellite, "bucket", "locked", testrand.Bytes(memory.KiB))
+		require.NoError(t, err)
+
+		objects, err := satellite.Metainfo.Metabase.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 2)
+
+		locked := objects[0]
+		err = satellite.Metainfo.Metabase.SetObjectLegalHold(ctx, metabase.SetObjectLegalHold{
+			ObjectLocation: locked.Location(),
+			Version:        locked.Version,
+			Enabled:        true,
+		})
+		require.NoError(t, err)
+
+		_, err = satellite.Metainfo.Endpoint.DeleteBucket(ctx, &pb.BucketDeleteRequest{
+			Header: &pb.RequestHeader{
+				ApiKey: apiKey.SerializeRaw(),
+			},
+			Name:      []byte("bucket"),
+			DeleteAll: true,
+		})
+		require.True(t, errs2.IsRPC(err, rpcstatus.PermissionDenied), err)
+		require.Contains(t, err.Error(), "locked by a retention period or a legal hold")
+		require.Contains(t, err.Error(), fmt.Sprintf("(version %d)", locked.Version))
+
+		// only the locked object is left.
+		objects, err = satellite.Metainfo.Metabase.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+		require.Equal(t, locked.ObjectKey, objects[0].ObjectKey)
+
+		_, err = satellite.DB.Buckets().GetBucket(ctx, []byte("bucket"), projectID)
+		require.NoError(t, err)
+	})
+}
+
 func TestCommitSegment_Validation(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 1,
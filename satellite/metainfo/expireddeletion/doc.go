@@ -9,6 +9,7 @@ allowing us to subscribe to the loop to get information for every segment
 in the metainfo database.
 
 The expireddeletion chore will subscribe the deleter to the metainfo loop
-and delete any expired segments from metainfo.
+and delete any expired segments from metainfo. Objects protected by a retention
+period or a legal hold are kept until the protection is lifted.
 */
 package expireddeletion
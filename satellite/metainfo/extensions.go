@@ -0,0 +1,1158 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metainfo
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/macaroon"
+	"storj.io/common/pb"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/storj/private/metainfoextpb"
+	"storj.io/storj/satellite/metabase"
+)
+
+// SetBucketVersioning enables or disables versioning of a bucket.
+func (endpoint *Endpoint) SetBucketVersioning(ctx context.Context, req *metainfoextpb.SetBucketVersioningRequest) (resp *metainfoextpb.SetBucketVersioningResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:     macaroon.ActionWrite,
+		Bucket: req.Bucket,
+		Time:   time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = endpoint.validateBucket(ctx, req.Bucket)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+
+	if err := endpoint.ensureBucketExists(ctx, keyInfo.ProjectID, req.Bucket); err != nil {
+		return nil, err
+	}
+
+	err = endpoint.metainfo.metabaseDB.SetBucketVersioning(ctx, metabase.SetBucketVersioning{
+		BucketLocation: metabase.BucketLocation{ProjectID: keyInfo.ProjectID, BucketName: string(req.Bucket)},
+		Enabled:        req.Enabled,
+	})
+	if err != nil {
+		endpoint.log.Error("internal", zap.Error(err))
+		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
+	}
+
+	return &metainfoextpb.SetBucketVersioningResponse{}, nil
+}
+
+// GetBucketVersioning returns whether versioning is enabled for a bucket.
+func (endpoint *Endpoint) GetBucketVersioning(ctx context.Context, req *metainfoextpb.GetBucketVersioningRequest) (resp *metainfoextpb.GetBucketVersioningResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:     macaroon.ActionRead,
+		Bucket: req.Bucket,
+		Time:   time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = endpoint.validateBucket(ctx, req.Bucket)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+
+	if err := endpoint.ensureBucketExists(ctx, keyInfo.ProjectID, req.Bucket); err != nil {
+		return nil, err
+	}
+
+	enabled, err := endpoint.metainfo.IsBucketVersioned(ctx, keyInfo.ProjectID, req.Bucket)
+	if err != nil {
+		endpoint.log.Error("internal", zap.Error(err))
+		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
+	}
+
+	return &metainfoextpb.GetBucketVersioningResponse{Enabled: enabled}, nil
+}
+
+// ListObjectVersions lists the committed versions and delete markers of an
+// object, newest first.
+func (endpoint *Endpoint) ListObjectVersions(ctx context.Context, req *metainfoextpb.ListObjectVersionsRequest) (resp *metainfoextpb.ListObjectVersionsResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:            macaroon.ActionList,
+		Bucket:        req.Bucket,
+		EncryptedPath: req.EncryptedObjectKey,
+		Time:          time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = endpoint.validateBucket(ctx, req.Bucket)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+
+	result, err := endpoint.metainfo.metabaseDB.ListObjectVersions(ctx, metabase.ListObjectVersions{
+		ObjectLocation: metabase.ObjectLocation{
+			ProjectID:  keyInfo.ProjectID,
+			BucketName: string(req.Bucket),
+			ObjectKey:  metabase.ObjectKey(req.EncryptedObjectKey),
+		},
+		Cursor: metabase.Version(req.Cursor),
+		Limit:  int(req.Limit),
+	})
+	if err != nil {
+		if metabase.ErrInvalidRequest.Has(err) {
+			return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+		}
+		endpoint.log.Error("internal", zap.Error(err))
+		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
+	}
+
+	resp = &metainfoextpb.ListObjectVersionsResponse{
+		Items: make([]*metainfoextpb.ObjectVersion, 0, len(result.Versions)),
+		More:  result.More,
+	}
+	for _, version := range result.Versions {
+		resp.Items = append(resp.Items, &metainfoextpb.ObjectVersion{
+			Version:      int64(version.Version),
+			DeleteMarker: version.Status == metabase.DeleteMarker,
+			CreatedAt:    version.CreatedAt,
+			PlainSize:    version.TotalPlainSize,
+		})
+	}
+
+	return resp, nil
+}
+
+// ListPrefixVersions lists the committed versions and delete markers of the
+// objects under a prefix, ordered by key and by version from oldest to newest.
+func (endpoint *Endpoint) ListPrefixVersions(ctx context.Context, req *metainfoextpb.ListPrefixVersionsRequest) (resp *metainfoextpb.ListPrefixVersionsResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:            macaroon.ActionList,
+		Bucket:        req.Bucket,
+		EncryptedPath: req.EncryptedPrefix,
+		Time:          time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = endpoint.validateBucket(ctx, req.Bucket)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+
+	if err := endpoint.ensureBucketExists(ctx, keyInfo.ProjectID, req.Bucket); err != nil {
+		return nil, err
+	}
+
+	limit := int(req.Limit)
+	if limit < 0 {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, "limit is negative")
+	}
+	metabase.ListLimit.Ensure(&limit)
+
+	var prefix metabase.ObjectKey
+	if len(req.EncryptedPrefix) != 0 {
+		prefix = metabase.ObjectKey(req.EncryptedPrefix)
+		if prefix[len(prefix)-1] != metabase.Delimiter {
+			prefix += metabase.ObjectKey(metabase.Delimiter)
+		}
+	}
+
+	cursor := metabase.IterateCursor{Version: metabase.Version(req.CursorVersion)}
+	if len(req.EncryptedCursor) != 0 {
+		cursor.Key = prefix + metabase.ObjectKey(req.EncryptedCursor)
+	}
+
+	resp = &metainfoextpb.ListPrefixVersionsResponse{}
+	err = endpoint.metainfo.metabaseDB.IterateObjectsAllVersionsWithStatus(ctx,
+		metabase.IterateObjectsWithStatus{
+			ProjectID:            keyInfo.ProjectID,
+			BucketName:           string(req.Bucket),
+			Prefix:               prefix,
+			Cursor:               cursor,
+			Recursive:            req.Recursive,
+			BatchSize:            limit + 1,
+			Status:               metabase.Committed,
+			IncludeDeleteMarkers: true,
+		}, func(ctx context.Context, it metabase.ObjectsIterator) error {
+			entry := metabase.ObjectEntry{}
+			for len(resp.Items) < limit && it.Next(ctx, &entry) {
+				item := &metainfoextpb.PrefixVersion{
+					EncryptedObjectKey: []byte(entry.ObjectKey),
+					IsPrefix:           entry.IsPrefix,
+				}
+				if !entry.IsPrefix {
+					item.Version = &metainfoextpb.ObjectVersion{
+						Version:      int64(entry.Version),
+						DeleteMarker: entry.Status == metabase.DeleteMarker,
+						CreatedAt:    entry.CreatedAt,
+						PlainSize:    entry.TotalPlainSize,
+					}
+				}
+				resp.Items = append(resp.Items, item)
+			}
+			resp.More = it.Next(ctx, &entry)
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+
+	return resp, nil
+}
+
+// DownloadObjectVersion returns the information for downloading a specific
+// version of an object.
+func (endpoint *Endpoint) DownloadObjectVersion(ctx context.Context, req *metainfoextpb.DownloadObjectVersionRequest) (resp *metainfoextpb.DownloadObjectVersionResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if req.Download == nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, "download request missing")
+	}
+	if req.Version <= 0 {
+		return nil, rpcstatus.Errorf(rpcstatus.InvalidArgument, "invalid version: %d", req.Version)
+	}
+
+	download, err := endpoint.downloadObject(ctx, req.Download, metabase.Version(req.Version))
+	if err != nil {
+		return nil, err
+	}
+
+	return &metainfoextpb.DownloadObjectVersionResponse{Download: download}, nil
+}
+
+// RestoreObjectVersion makes a committed version of an object its latest
+// version. The restored version is stored like a new object, so it counts
+// against the storage limit.
+func (endpoint *Endpoint) RestoreObjectVersion(ctx context.Context, req *metainfoextpb.RestoreObjectVersionRequest) (resp *metainfoextpb.RestoreObjectVersionResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	now := time.Now()
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:            macaroon.ActionRead,
+		Bucket:        req.Bucket,
+		EncryptedPath: req.EncryptedObjectKey,
+		Time:          now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	_, err = endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:            macaroon.ActionWrite,
+		Bucket:        req.Bucket,
+		EncryptedPath: req.EncryptedObjectKey,
+		Time:          now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = endpoint.validateBucket(ctx, req.Bucket)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+	if req.Version <= 0 {
+		return nil, rpcstatus.Errorf(rpcstatus.InvalidArgument, "invalid version: %d", req.Version)
+	}
+
+	if err := endpoint.checkExceedsStorageUsage(ctx, keyInfo.ProjectID); err != nil {
+		return nil, err
+	}
+
+	newStreamID, err := uuid.New()
+	if err != nil {
+		endpoint.log.Error("internal", zap.Error(err))
+		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
+	}
+
+	object, err := endpoint.metainfo.metabaseDB.RestoreObjectVersion(ctx, metabase.RestoreObjectVersion{
+		ObjectLocation: metabase.ObjectLocation{
+			ProjectID:  keyInfo.ProjectID,
+			BucketName: string(req.Bucket),
+			ObjectKey:  metabase.ObjectKey(req.EncryptedObjectKey),
+		},
+		Version:     metabase.Version(req.Version),
+		NewStreamID: newStreamID,
+	})
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+
+	if err := endpoint.projectUsage.AddProjectStorageUsage(ctx, keyInfo.ProjectID, object.TotalEncryptedSize); err != nil {
+		endpoint.log.Error("Could not track new project's storage usage",
+			zap.Stringer("Project ID", keyInfo.ProjectID),
+			zap.Error(err),
+		)
+	}
+
+	return &metainfoextpb.RestoreObjectVersionResponse{Version: int64(object.Version)}, nil
+}
+
+// BeginMoveObject returns the keys of the latest version of an object, which
+// the client has to re-encrypt for the new location of the object.
+func (endpoint *Endpoint) BeginMoveObject(ctx context.Context, req *metainfoextpb.BeginMoveObjectRequest) (resp *metainfoextpb.BeginMoveObjectResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	now := time.Now()
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:            macaroon.ActionRead,
+		Bucket:        req.Bucket,
+		EncryptedPath: req.EncryptedObjectKey,
+		Time:          now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	_, err = endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:            macaroon.ActionDelete,
+		Bucket:        req.Bucket,
+		EncryptedPath: req.EncryptedObjectKey,
+		Time:          now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = endpoint.validateBucket(ctx, req.Bucket)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+
+	location := metabase.ObjectLocation{
+		ProjectID:  keyInfo.ProjectID,
+		BucketName: string(req.Bucket),
+		ObjectKey:  metabase.ObjectKey(req.EncryptedObjectKey),
+	}
+	version, err := endpoint.latestObjectVersion(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := endpoint.metainfo.metabaseDB.BeginMoveObject(ctx, metabase.BeginMoveObject{
+		Version:        version,
+		ObjectLocation: location,
+	})
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+
+	return &metainfoextpb.BeginMoveObjectResponse{
+		StreamId:                  result.StreamID[:],
+		Version:                   int64(version),
+		EncryptedMetadataKeyNonce: result.EncryptedMetadataKeyNonce,
+		EncryptedMetadataKey:      result.EncryptedMetadataKey,
+		SegmentKeys:               convertSegmentKeysToPB(result.EncryptedKeysNonces),
+		EncryptionParameters:      convertEncryptionParametersToPB(result.EncryptionParameters),
+	}, nil
+}
+
+// FinishMoveObject moves an object to the new location with the keys
+// re-encrypted by the client. The segments keep pointing to the same pieces.
+func (endpoint *Endpoint) FinishMoveObject(ctx context.Context, req *metainfoextpb.FinishMoveObjectRequest) (resp *metainfoextpb.FinishMoveObjectResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	now := time.Now()
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:            macaroon.ActionDelete,
+		Bucket:        req.Bucket,
+		EncryptedPath: req.EncryptedObjectKey,
+		Time:          now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	_, err = endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:            macaroon.ActionWrite,
+		Bucket:        req.NewBucket,
+		EncryptedPath: req.NewEncryptedObjectKey,
+		Time:          now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, bucket := range [][]byte{req.Bucket, req.NewBucket} {
+		if err := endpoint.validateBucket(ctx, bucket); err != nil {
+			return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+		}
+	}
+	if err := endpoint.ensureBucketExists(ctx, keyInfo.ProjectID, req.NewBucket); err != nil {
+		return nil, err
+	}
+
+	streamID, err := uuid.FromBytes(req.StreamId)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+	segmentKeys, err := convertSegmentKeysFromPB(req.NewSegmentKeys)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+
+	err = endpoint.metainfo.metabaseDB.FinishMoveObject(ctx, metabase.FinishMoveObject{
+		ObjectStream: metabase.ObjectStream{
+			ProjectID:  keyInfo.ProjectID,
+			BucketName: string(req.Bucket),
+			ObjectKey:  metabase.ObjectKey(req.EncryptedObjectKey),
+			Version:    metabase.Version(req.Version),
+			StreamID:   streamID,
+		},
+		NewBucket:                    string(req.NewBucket),
+		NewEncryptedObjectKey:        req.NewEncryptedObjectKey,
+		NewEncryptedMetadataKeyNonce: req.NewEncryptedMetadataKeyNonce,
+		NewEncryptedMetadataKey:      req.NewEncryptedMetadataKey,
+		NewSegmentKeys:               segmentKeys,
+	})
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+
+	return &metainfoextpb.FinishMoveObjectResponse{}, nil
+}
+
+// BeginCopyObject returns the keys of the latest version of an object, which
+// the client has to re-encrypt for the location of the copy.
+func (endpoint *Endpoint) BeginCopyObject(ctx context.Context, req *metainfoextpb.BeginCopyObjectRequest) (resp *metainfoextpb.BeginCopyObjectResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:            macaroon.ActionRead,
+		Bucket:        req.Bucket,
+		EncryptedPath: req.EncryptedObjectKey,
+		Time:          time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = endpoint.validateBucket(ctx, req.Bucket)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+
+	location := metabase.ObjectLocation{
+		ProjectID:  keyInfo.ProjectID,
+		BucketName: string(req.Bucket),
+		ObjectKey:  metabase.ObjectKey(req.EncryptedObjectKey),
+	}
+	version, err := endpoint.latestObjectVersion(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := endpoint.metainfo.metabaseDB.BeginCopyObject(ctx, metabase.BeginCopyObject{
+		Version:        version,
+		ObjectLocation: location,
+	})
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+
+	return &metainfoextpb.BeginCopyObjectResponse{
+		StreamId:                  result.StreamID[:],
+		Version:                   int64(version),
+		EncryptedMetadataKeyNonce: result.EncryptedMetadataKeyNonce,
+		EncryptedMetadataKey:      result.EncryptedMetadataKey,
+		SegmentKeys:               convertSegmentKeysToPB(result.EncryptedKeysNonces),
+		EncryptionParameters:      convertEncryptionParametersToPB(result.EncryptionParameters),
+	}, nil
+}
+
+// FinishCopyObject creates a copy of an object at the new location with the
+// keys re-encrypted by the client. The segments of the copy reference the same
+// pieces as the segments of the object. An unlocked object at the new location
+// is replaced, or kept as an older version in versioned buckets.
+func (endpoint *Endpoint) FinishCopyObject(ctx context.Context, req *metainfoextpb.FinishCopyObjectRequest) (resp *metainfoextpb.FinishCopyObjectResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	now := time.Now()
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:            macaroon.ActionRead,
+		Bucket:        req.Bucket,
+		EncryptedPath: req.EncryptedObjectKey,
+		Time:          now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	_, err = endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:            macaroon.ActionWrite,
+		Bucket:        req.NewBucket,
+		EncryptedPath: req.NewEncryptedObjectKey,
+		Time:          now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, bucket := range [][]byte{req.Bucket, req.NewBucket} {
+		if err := endpoint.validateBucket(ctx, bucket); err != nil {
+			return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+		}
+	}
+	if err := endpoint.ensureBucketExists(ctx, keyInfo.ProjectID, req.NewBucket); err != nil {
+		return nil, err
+	}
+
+	// like uploads, existing objects are only overwritten when they could be
+	// deleted with the same credentials.
+	_, err = endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:            macaroon.ActionDelete,
+		Bucket:        req.NewBucket,
+		EncryptedPath: req.NewEncryptedObjectKey,
+		Time:          now,
+	})
+	canDelete := err == nil
+
+	// the copy is stored like a new object, so it counts against the limits.
+	if err := endpoint.checkExceedsStorageUsage(ctx, keyInfo.ProjectID); err != nil {
+		return nil, err
+	}
+
+	streamID, err := uuid.FromBytes(req.StreamId)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+	segmentKeys, err := convertSegmentKeysFromPB(req.NewSegmentKeys)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+
+	newStreamID, err := uuid.New()
+	if err != nil {
+		endpoint.log.Error("internal", zap.Error(err))
+		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
+	}
+
+	result, err := endpoint.metainfo.metabaseDB.FinishCopyObject(ctx, metabase.FinishCopyObject{
+		ObjectStream: metabase.ObjectStream{
+			ProjectID:  keyInfo.ProjectID,
+			BucketName: string(req.Bucket),
+			ObjectKey:  metabase.ObjectKey(req.EncryptedObjectKey),
+			Version:    metabase.Version(req.Version),
+			StreamID:   streamID,
+		},
+		NewStreamID:                  newStreamID,
+		NewBucket:                    string(req.NewBucket),
+		NewEncryptedObjectKey:        req.NewEncryptedObjectKey,
+		NewEncryptedMetadataKeyNonce: req.NewEncryptedMetadataKeyNonce,
+		NewEncryptedMetadataKey:      req.NewEncryptedMetadataKey,
+		NewSegmentKeys:               segmentKeys,
+		Overwrite:                    canDelete,
+	})
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+
+	if _, err := endpoint.deleteObjectsPieces(ctx, result.Overwritten); err != nil {
+		endpoint.log.Error("failed to delete pieces of overwritten objects", zap.Error(err))
+	}
+
+	if err := endpoint.projectUsage.AddProjectStorageUsage(ctx, keyInfo.ProjectID, result.Object.TotalEncryptedSize); err != nil {
+		// log it and continue, like commits do. Only the per-project storage
+		// limits are affected.
+		endpoint.log.Error("Could not track new project's storage usage",
+			zap.Stringer("Project ID", keyInfo.ProjectID),
+			zap.Error(err),
+		)
+	}
+
+	return &metainfoextpb.FinishCopyObjectResponse{Version: int64(result.Object.Version)}, nil
+}
+
+// SetBucketTags replaces the tags of a bucket.
+func (endpoint *Endpoint) SetBucketTags(ctx context.Context, req *metainfoextpb.SetBucketTagsRequest) (resp *metainfoextpb.SetBucketTagsResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:     macaroon.ActionWrite,
+		Bucket: req.Bucket,
+		Time:   time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = endpoint.validateBucket(ctx, req.Bucket)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+
+	if err := endpoint.ensureBucketExists(ctx, keyInfo.ProjectID, req.Bucket); err != nil {
+		return nil, err
+	}
+
+	err = endpoint.metainfo.metabaseDB.SetBucketTags(ctx, metabase.SetBucketTags{
+		BucketLocation: metabase.BucketLocation{ProjectID: keyInfo.ProjectID, BucketName: string(req.Bucket)},
+		Tags:           req.Tags,
+	})
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+
+	return &metainfoextpb.SetBucketTagsResponse{}, nil
+}
+
+// GetBucketTags returns the tags of a bucket.
+func (endpoint *Endpoint) GetBucketTags(ctx context.Context, req *metainfoextpb.GetBucketTagsRequest) (resp *metainfoextpb.GetBucketTagsResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:     macaroon.ActionRead,
+		Bucket: req.Bucket,
+		Time:   time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = endpoint.validateBucket(ctx, req.Bucket)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+
+	if err := endpoint.ensureBucketExists(ctx, keyInfo.ProjectID, req.Bucket); err != nil {
+		return nil, err
+	}
+
+	tags, err := endpoint.metainfo.metabaseDB.GetBucketTags(ctx, metabase.GetBucketTags{
+		BucketLocation: metabase.BucketLocation{ProjectID: keyInfo.ProjectID, BucketName: string(req.Bucket)},
+	})
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+
+	return &metainfoextpb.GetBucketTagsResponse{Tags: tags}, nil
+}
+
+// SetObjectTags replaces the tags of an object version, or of the latest
+// version when no version is given.
+func (endpoint *Endpoint) SetObjectTags(ctx context.Context, req *metainfoextpb.SetObjectTagsRequest) (resp *metainfoextpb.SetObjectTagsResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:            macaroon.ActionWrite,
+		Bucket:        req.Bucket,
+		EncryptedPath: req.EncryptedObjectKey,
+		Time:          time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = endpoint.validateBucket(ctx, req.Bucket)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+	if req.Version < 0 {
+		return nil, rpcstatus.Errorf(rpcstatus.InvalidArgument, "invalid version: %d", req.Version)
+	}
+
+	location := metabase.ObjectLocation{
+		ProjectID:  keyInfo.ProjectID,
+		BucketName: string(req.Bucket),
+		ObjectKey:  metabase.ObjectKey(req.EncryptedObjectKey),
+	}
+	version := metabase.Version(req.Version)
+	if version == 0 {
+		version, err = endpoint.latestObjectVersion(ctx, location)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = endpoint.metainfo.metabaseDB.SetObjectTags(ctx, metabase.SetObjectTags{
+		ObjectLocation: location,
+		Version:        version,
+		Tags:           req.Tags,
+	})
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+
+	return &metainfoextpb.SetObjectTagsResponse{}, nil
+}
+
+// GetObjectTags returns the tags of an object version, or of the latest
+// version when no version is given.
+func (endpoint *Endpoint) GetObjectTags(ctx context.Context, req *metainfoextpb.GetObjectTagsRequest) (resp *metainfoextpb.GetObjectTagsResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:            macaroon.ActionRead,
+		Bucket:        req.Bucket,
+		EncryptedPath: req.EncryptedObjectKey,
+		Time:          time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = endpoint.validateBucket(ctx, req.Bucket)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+
+	tags, err := endpoint.metainfo.metabaseDB.GetObjectTags(ctx, metabase.GetObjectTags{
+		ObjectLocation: metabase.ObjectLocation{
+			ProjectID:  keyInfo.ProjectID,
+			BucketName: string(req.Bucket),
+			ObjectKey:  metabase.ObjectKey(req.EncryptedObjectKey),
+		},
+		Version: metabase.Version(req.Version),
+	})
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+
+	return &metainfoextpb.GetObjectTagsResponse{Tags: tags}, nil
+}
+
+// ListTaggedObjects lists the objects that have all of the tags of the request.
+func (endpoint *Endpoint) ListTaggedObjects(ctx context.Context, req *metainfoextpb.ListTaggedObjectsRequest) (resp *metainfoextpb.ListTaggedObjectsResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if req.List == nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, "list request missing")
+	}
+
+	list, err := endpoint.listObjects(ctx, req.List, req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return &metainfoextpb.ListTaggedObjectsResponse{List: list}, nil
+}
+
+// SetBucketLifecycleRule adds a lifecycle rule to a bucket, replacing the rule
+// with the same name. The rule deletes objects, so it needs the permission to
+// delete objects under its prefix.
+func (endpoint *Endpoint) SetBucketLifecycleRule(ctx context.Context, req *metainfoextpb.SetBucketLifecycleRuleRequest) (resp *metainfoextpb.SetBucketLifecycleRuleResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if req.Rule == nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, "rule missing")
+	}
+
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:            macaroon.ActionDelete,
+		Bucket:        req.Bucket,
+		EncryptedPath: req.Rule.EncryptedPrefix,
+		Time:          time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = endpoint.validateBucket(ctx, req.Bucket)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+
+	if err := endpoint.ensureBucketExists(ctx, keyInfo.ProjectID, req.Bucket); err != nil {
+		return nil, err
+	}
+
+	err = endpoint.metainfo.metabaseDB.SetBucketLifecycleRule(ctx, metabase.SetBucketLifecycleRule{
+		Rule: metabase.LifecycleRule{
+			BucketLocation: metabase.BucketLocation{ProjectID: keyInfo.ProjectID, BucketName: string(req.Bucket)},
+			Name:           req.Rule.Name,
+			Prefix:         metabase.ObjectKey(req.Rule.EncryptedPrefix),
+			Action:         metabase.LifecycleAction(req.Rule.Action),
+			AgeDays:        int(req.Rule.AgeDays),
+		},
+	})
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+
+	return &metainfoextpb.SetBucketLifecycleRuleResponse{}, nil
+}
+
+// DeleteBucketLifecycleRules deletes the lifecycle rule with the name, or all
+// lifecycle rules of a bucket when no name is given.
+func (endpoint *Endpoint) DeleteBucketLifecycleRules(ctx context.Context, req *metainfoextpb.DeleteBucketLifecycleRulesRequest) (resp *metainfoextpb.DeleteBucketLifecycleRulesResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:     macaroon.ActionWrite,
+		Bucket: req.Bucket,
+		Time:   time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = endpoint.validateBucket(ctx, req.Bucket)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+
+	if err := endpoint.ensureBucketExists(ctx, keyInfo.ProjectID, req.Bucket); err != nil {
+		return nil, err
+	}
+
+	err = endpoint.metainfo.metabaseDB.DeleteBucketLifecycleRules(ctx, metabase.DeleteBucketLifecycleRules{
+		BucketLocation: metabase.BucketLocation{ProjectID: keyInfo.ProjectID, BucketName: string(req.Bucket)},
+		Name:           req.Name,
+	})
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+
+	return &metainfoextpb.DeleteBucketLifecycleRulesResponse{}, nil
+}
+
+// ListBucketLifecycleRules lists the lifecycle rules of a bucket ordered by name.
+func (endpoint *Endpoint) ListBucketLifecycleRules(ctx context.Context, req *metainfoextpb.ListBucketLifecycleRulesRequest) (resp *metainfoextpb.ListBucketLifecycleRulesResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:     macaroon.ActionRead,
+		Bucket: req.Bucket,
+		Time:   time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = endpoint.validateBucket(ctx, req.Bucket)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+
+	if err := endpoint.ensureBucketExists(ctx, keyInfo.ProjectID, req.Bucket); err != nil {
+		return nil, err
+	}
+
+	rules, err := endpoint.metainfo.metabaseDB.ListLifecycleRules(ctx, metabase.ListLifecycleRules{
+		Bucket: metabase.BucketLocation{ProjectID: keyInfo.ProjectID, BucketName: string(req.Bucket)},
+	})
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+
+	resp = &metainfoextpb.ListBucketLifecycleRulesResponse{}
+	for _, rule := range rules {
+		resp.Rules = append(resp.Rules, &metainfoextpb.LifecycleRule{
+			Name:            rule.Name,
+			EncryptedPrefix: []byte(rule.Prefix),
+			Action:          metainfoextpb.LifecycleAction(rule.Action),
+			AgeDays:         int32(rule.AgeDays),
+		})
+	}
+	return resp, nil
+}
+
+// SetBucketRetention sets the number of days that newly committed objects of a
+// bucket can't be deleted or overwritten.
+func (endpoint *Endpoint) SetBucketRetention(ctx context.Context, req *metainfoextpb.SetBucketRetentionRequest) (resp *metainfoextpb.SetBucketRetentionResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:     macaroon.ActionWrite,
+		Bucket: req.Bucket,
+		Time:   time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = endpoint.validateBucket(ctx, req.Bucket)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+
+	if err := endpoint.ensureBucketExists(ctx, keyInfo.ProjectID, req.Bucket); err != nil {
+		return nil, err
+	}
+
+	err = endpoint.metainfo.metabaseDB.SetBucketRetention(ctx, metabase.SetBucketRetention{
+		BucketLocation: metabase.BucketLocation{ProjectID: keyInfo.ProjectID, BucketName: string(req.Bucket)},
+		Days:           int(req.Days),
+	})
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+
+	return &metainfoextpb.SetBucketRetentionResponse{}, nil
+}
+
+// GetBucketRetention returns the default retention of a bucket in days.
+func (endpoint *Endpoint) GetBucketRetention(ctx context.Context, req *metainfoextpb.GetBucketRetentionRequest) (resp *metainfoextpb.GetBucketRetentionResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:     macaroon.ActionRead,
+		Bucket: req.Bucket,
+		Time:   time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = endpoint.validateBucket(ctx, req.Bucket)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+
+	if err := endpoint.ensureBucketExists(ctx, keyInfo.ProjectID, req.Bucket); err != nil {
+		return nil, err
+	}
+
+	days, err := endpoint.metainfo.metabaseDB.GetBucketRetention(ctx, metabase.GetBucketRetention{
+		BucketLocation: metabase.BucketLocation{ProjectID: keyInfo.ProjectID, BucketName: string(req.Bucket)},
+	})
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+
+	return &metainfoextpb.GetBucketRetentionResponse{Days: int32(days)}, nil
+}
+
+// SetObjectRetention protects an object version, or the latest version when no
+// version is given, from deletion until the requested time.
+func (endpoint *Endpoint) SetObjectRetention(ctx context.Context, req *metainfoextpb.SetObjectRetentionRequest) (resp *metainfoextpb.SetObjectRetentionResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	now := time.Now()
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:            macaroon.ActionWrite,
+		Bucket:        req.Bucket,
+		EncryptedPath: req.EncryptedObjectKey,
+		Time:          now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = endpoint.validateBucket(ctx, req.Bucket)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+	if req.Version < 0 {
+		return nil, rpcstatus.Errorf(rpcstatus.InvalidArgument, "invalid version: %d", req.Version)
+	}
+	if !req.RetainUntil.After(now) {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, "retain until must be in the future")
+	}
+
+	location := metabase.ObjectLocation{
+		ProjectID:  keyInfo.ProjectID,
+		BucketName: string(req.Bucket),
+		ObjectKey:  metabase.ObjectKey(req.EncryptedObjectKey),
+	}
+	version := metabase.Version(req.Version)
+	if version == 0 {
+		version, err = endpoint.latestObjectVersion(ctx, location)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = endpoint.metainfo.metabaseDB.SetObjectRetention(ctx, metabase.SetObjectRetention{
+		ObjectLocation: location,
+		Version:        version,
+		RetainUntil:    req.RetainUntil,
+	})
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+
+	return &metainfoextpb.SetObjectRetentionResponse{}, nil
+}
+
+// SetObjectLegalHold places or removes a legal hold on an object version, or on
+// the latest version when no version is given. Removing a legal hold allows
+// deleting the object, so it needs the permission to delete it.
+func (endpoint *Endpoint) SetObjectLegalHold(ctx context.Context, req *metainfoextpb.SetObjectLegalHoldRequest) (resp *metainfoextpb.SetObjectLegalHoldResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	op := macaroon.ActionDelete
+	if req.Enabled {
+		op = macaroon.ActionWrite
+	}
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:            op,
+		Bucket:        req.Bucket,
+		EncryptedPath: req.EncryptedObjectKey,
+		Time:          time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = endpoint.validateBucket(ctx, req.Bucket)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+	if req.Version < 0 {
+		return nil, rpcstatus.Errorf(rpcstatus.InvalidArgument, "invalid version: %d", req.Version)
+	}
+
+	location := metabase.ObjectLocation{
+		ProjectID:  keyInfo.ProjectID,
+		BucketName: string(req.Bucket),
+		ObjectKey:  metabase.ObjectKey(req.EncryptedObjectKey),
+	}
+	version := metabase.Version(req.Version)
+	if version == 0 {
+		version, err = endpoint.latestObjectVersion(ctx, location)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = endpoint.metainfo.metabaseDB.SetObjectLegalHold(ctx, metabase.SetObjectLegalHold{
+		ObjectLocation: location,
+		Version:        version,
+		Enabled:        req.Enabled,
+	})
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+
+	return &metainfoextpb.SetObjectLegalHoldResponse{}, nil
+}
+
+// GetObjectLock returns the retention and legal hold of an object version, or
+// of the latest version when no version is given.
+func (endpoint *Endpoint) GetObjectLock(ctx context.Context, req *metainfoextpb.GetObjectLockRequest) (resp *metainfoextpb.GetObjectLockResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:            macaroon.ActionRead,
+		Bucket:        req.Bucket,
+		EncryptedPath: req.EncryptedObjectKey,
+		Time:          time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = endpoint.validateBucket(ctx, req.Bucket)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+
+	lock, err := endpoint.metainfo.metabaseDB.GetObjectLock(ctx, metabase.GetObjectLock{
+		ObjectLocation: metabase.ObjectLocation{
+			ProjectID:  keyInfo.ProjectID,
+			BucketName: string(req.Bucket),
+			ObjectKey:  metabase.ObjectKey(req.EncryptedObjectKey),
+		},
+		Version: metabase.Version(req.Version),
+	})
+	if err != nil {
+		return nil, endpoint.convertMetabaseErr(err)
+	}
+
+	return &metainfoextpb.GetObjectLockResponse{
+		RetainUntil: lock.RetainUntil,
+		LegalHold:   lock.LegalHold,
+	}, nil
+}
+
+// latestObjectVersion returns the latest committed version of an object.
+func (endpoint *Endpoint) latestObjectVersion(ctx context.Context, location metabase.ObjectLocation) (_ metabase.Version, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	object, err := endpoint.metainfo.metabaseDB.GetObjectLatestVersion(ctx, metabase.GetObjectLatestVersion{
+		ObjectLocation: location,
+	})
+	if err != nil {
+		return 0, endpoint.convertMetabaseErr(err)
+	}
+	return object.Version, nil
+}
+
+// convertMetabaseErr converts the errors of the object operations of the
+// metabase into rpc errors.
+func (endpoint *Endpoint) convertMetabaseErr(err error) error {
+	switch {
+	case storj.ErrObjectNotFound.Has(err):
+		return rpcstatus.Error(rpcstatus.NotFound, err.Error())
+	case metabase.ErrInvalidRequest.Has(err):
+		return rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	case metabase.ErrObjectAlreadyExists.Has(err):
+		return rpcstatus.Error(rpcstatus.AlreadyExists, "object already exists")
+	case metabase.ErrObjectLocked.Has(err):
+		return rpcstatus.Error(rpcstatus.PermissionDenied, "object is locked")
+	default:
+		endpoint.log.Error("internal", zap.Error(err))
+		return rpcstatus.Error(rpcstatus.Internal, err.Error())
+	}
+}
+
+func convertEncryptionParametersToPB(params storj.EncryptionParameters) *pb.EncryptionParameters {
+	return &pb.EncryptionParameters{
+		CipherSuite: pb.CipherSuite(params.CipherSuite),
+		BlockSize:   int64(params.BlockSize),
+	}
+}
+
+func convertSegmentKeysToPB(keys []metabase.EncryptedKeyAndNonce) []*metainfoextpb.EncryptedKeyAndNonce {
+	result := make([]*metainfoextpb.EncryptedKeyAndNonce, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, &metainfoextpb.EncryptedKeyAndNonce{
+			Position: &pb.SegmentPosition{
+				PartNumber: int32(key.Position.Part),
+				Index:      int32(key.Position.Index),
+			},
+			EncryptedKeyNonce: key.EncryptedKeyNonce,
+			EncryptedKey:      key.EncryptedKey,
+		})
+	}
+	return result
+}
+
+func convertSegmentKeysFromPB(keys []*metainfoextpb.EncryptedKeyAndNonce) ([]metabase.EncryptedKeyAndNonce, error) {
+	result := make([]metabase.EncryptedKeyAndNonce, 0, len(keys))
+	for _, key := range keys {
+		if key.Position == nil {
+			return nil, errs.New("segment position missing")
+		}
+		result = append(result, metabase.EncryptedKeyAndNonce{
+			Position: metabase.SegmentPosition{
+				Part:  uint32(key.Position.PartNumber),
+				Index: uint32(key.Position.Index),
+			},
+			EncryptedKeyNonce: key.EncryptedKeyNonce,
+			EncryptedKey:      key.EncryptedKey,
+		})
+	}
+	return result, nil
+}
+
+// ensureBucketExists returns a NotFound error when the bucket does not exist.
+func (endpoint *Endpoint) ensureBucketExists(ctx context.Context, projectID uuid.UUID, bucket []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	exists, err := endpoint.metainfo.HasBucket(ctx, bucket, projectID)
+	if err != nil {
+		endpoint.log.Error("internal", zap.Error(err))
+		return rpcstatus.Error(rpcstatus.Internal, err.Error())
+	}
+	if !exists {
+		return rpcstatus.Error(rpcstatus.NotFound, storj.ErrBucketNotFound.New("%s", bucket).Error())
+	}
+	return nil
+}
@@ -0,0 +1,777 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metainfo_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/errs2"
+	"storj.io/common/macaroon"
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/metainfoextpb"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite/metabase"
+)
+
+func TestEndpoint_ObjectVersions(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		endpoint := satellite.API.Metainfo.Endpoint
+		header := &pb.RequestHeader{
+			ApiKey: planet.Uplinks[0].APIKey[satellite.ID()].SerializeRaw(),
+		}
+
+		_, err := endpoint.GetBucketVersioning(ctx, &metainfoextpb.GetBucketVersioningRequest{
+			Header: header,
+			Bucket: []byte("versioned"),
+		})
+		require.True(t, errs2.IsRPC(err, rpcstatus.NotFound))
+
+		require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, satellite, "versioned"))
+
+		_, err = endpoint.SetBucketVersioning(ctx, &metainfoextpb.SetBucketVersioningRequest{
+			Header:  header,
+			Bucket:  []byte("versioned"),
+			Enabled: true,
+		})
+		require.NoError(t, err)
+
+		versioning, err := endpoint.GetBucketVersioning(ctx, &metainfoextpb.GetBucketVersioningRequest{
+			Header: header,
+			Bucket: []byte("versioned"),
+		})
+		require.NoError(t, err)
+		require.True(t, versioning.Enabled)
+
+		// the data is small enough to be stored inline.
+		data := [][]byte{
+			testrand.Bytes(1 * memory.KiB),
+			testrand.Bytes(2 * memory.KiB),
+			testrand.Bytes(3 * memory.KiB),
+		}
+		for _, d := range data {
+			require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "versioned", "key", d))
+		}
+
+		objects, err := satellite.API.Metainfo.Metabase.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, len(data))
+		encryptedKey := []byte(objects[0].ObjectKey)
+
+		list, err := endpoint.ListObjectVersions(ctx, &metainfoextpb.ListObjectVersionsRequest{
+			Header:             header,
+			Bucket:             []byte("versioned"),
+			EncryptedObjectKey: encryptedKey,
+		})
+		require.NoError(t, err)
+		require.False(t, list.More)
+		require.Len(t, list.Items, len(data))
+		for i, item := range list.Items {
+			require.Equal(t, int64(len(data)-i), item.Version)
+			require.False(t, item.DeleteMarker)
+			require.Equal(t, int64(len(data[len(data)-i-1])), item.PlainSize)
+		}
+
+		page, err := endpoint.ListObjectVersions(ctx, &metainfoextpb.ListObjectVersionsRequest{
+			Header:             header,
+			Bucket:             []byte("versioned"),
+			EncryptedObjectKey: encryptedKey,
+			Cursor:             list.Items[0].Version,
+			Limit:              1,
+		})
+		require.NoError(t, err)
+		require.True(t, page.More)
+		require.Len(t, page.Items, 1)
+		require.Equal(t, list.Items[1].Version, page.Items[0].Version)
+
+		// the latest version is downloaded by default.
+		downloaded, err := planet.Uplinks[0].Download(ctx, satellite, "versioned", "key")
+		require.NoError(t, err)
+		require.Equal(t, data[len(data)-1], downloaded)
+
+		for i := range data {
+			version := int64(i + 1)
+			resp, err := endpoint.DownloadObjectVersion(ctx, &metainfoextpb.DownloadObjectVersionRequest{
+				Download: &pb.ObjectDownloadRequest{
+					Header:             header,
+					Bucket:             []byte("versioned"),
+					EncryptedObjectKey: encryptedKey,
+				},
+				Version: version,
+			})
+			require.NoError(t, err)
+			require.EqualValues(t, version, resp.Download.Object.Version)
+			require.Equal(t, int64(len(data[i])), resp.Download.Object.PlainSize)
+			require.Len(t, resp.Download.SegmentDownload, 1)
+		}
+
+		_, err = endpoint.DownloadObjectVersion(ctx, &metainfoextpb.DownloadObjectVersionRequest{
+			Download: &pb.ObjectDownloadRequest{
+				Header:             header,
+				Bucket:             []byte("versioned"),
+				EncryptedObjectKey: encryptedKey,
+			},
+			Version: int64(len(data) + 1),
+		})
+		require.True(t, errs2.IsRPC(err, rpcstatus.NotFound))
+
+		_, err = endpoint.DownloadObjectVersion(ctx, &metainfoextpb.DownloadObjectVersionRequest{
+			Download: &pb.ObjectDownloadRequest{
+				Header:             header,
+				Bucket:             []byte("versioned"),
+				EncryptedObjectKey: encryptedKey,
+			},
+		})
+		require.True(t, errs2.IsRPC(err, rpcstatus.InvalidArgument))
+
+		// deleting hides the object behind a delete marker, but keeps the versions.
+		require.NoError(t, planet.Uplinks[0].DeleteObject(ctx, satellite, "versioned", "key"))
+
+		_, err = planet.Uplinks[0].Download(ctx, satellite, "versioned", "key")
+		require.Error(t, err)
+
+		list, err = endpoint.ListObjectVersions(ctx, &metainfoextpb.ListObjectVersionsRequest{
+			Header:             header,
+			Bucket:             []byte("versioned"),
+			EncryptedObjectKey: encryptedKey,
+		})
+		require.NoError(t, err)
+		require.Len(t, list.Items, len(data)+1)
+		require.True(t, list.Items[0].DeleteMarker)
+
+		_, err = endpoint.DownloadObjectVersion(ctx, &metainfoextpb.DownloadObjectVersionRequest{
+			Download: &pb.ObjectDownloadRequest{
+				Header:             header,
+				Bucket:             []byte("versioned"),
+				EncryptedObjectKey: encryptedKey,
+			},
+			Version: 1,
+		})
+		require.NoError(t, err)
+
+		// the prefix listing includes the key with the delete marker, oldest version first.
+		listPrefix := func(cursor []byte, cursorVersion int64, limit int32) *metainfoextpb.ListPrefixVersionsResponse {
+			resp, err := endpoint.ListPrefixVersions(ctx, &metainfoextpb.ListPrefixVersionsRequest{
+				Header:          header,
+				Bucket:          []byte("versioned"),
+				Recursive:       true,
+				EncryptedCursor: cursor,
+				CursorVersion:   cursorVersion,
+				Limit:           limit,
+			})
+			require.NoError(t, err)
+			return resp
+		}
+		versions := listPrefix(nil, 0, 0)
+		require.False(t, versions.More)
+		require.Len(t, versions.Items, len(data)+1)
+		for i, item := range versions.Items {
+			require.Equal(t, encryptedKey, item.EncryptedObjectKey)
+			require.EqualValues(t, i+1, item.Version.Version)
+			require.Equal(t, i == len(data), item.Version.DeleteMarker)
+		}
+
+		versions = listPrefix(encryptedKey, 2, 1)
+		require.True(t, versions.More)
+		require.Len(t, versions.Items, 1)
+		require.EqualValues(t, 3, versions.Items[0].Version.Version)
+
+		restored, err := endpoint.RestoreObjectVersion(ctx, &metainfoextpb.RestoreObjectVersionRequest{
+			Header:             header,
+			Bucket:             []byte("versioned"),
+			EncryptedObjectKey: encryptedKey,
+			Version:            1,
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, len(data)+2, restored.Version)
+
+		downloaded, err = planet.Uplinks[0].Download(ctx, satellite, "versioned", "key")
+		require.NoError(t, err)
+		require.Equal(t, data[0], downloaded)
+
+		// delete markers can't be restored.
+		_, err = endpoint.RestoreObjectVersion(ctx, &metainfoextpb.RestoreObjectVersionRequest{
+			Header:             header,
+			Bucket:             []byte("versioned"),
+			EncryptedObjectKey: encryptedKey,
+			Version:            int64(len(data) + 1),
+		})
+		require.True(t, errs2.IsRPC(err, rpcstatus.NotFound), err)
+	})
+}
+
+func TestEndpoint_ObjectVersionsUnversionedBucket(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		endpoint := satellite.API.Metainfo.Endpoint
+		header := &pb.RequestHeader{
+			ApiKey: planet.Uplinks[0].APIKey[satellite.ID()].SerializeRaw(),
+		}
+
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "unversioned", "key", testrand.Bytes(memory.KiB)))
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "unversioned", "key", testrand.Bytes(memory.KiB)))
+
+		versioning, err := endpoint.GetBucketVersioning(ctx, &metainfoextpb.GetBucketVersioningRequest{
+			Header: header,
+			Bucket: []byte("unversioned"),
+		})
+		require.NoError(t, err)
+		require.False(t, versioning.Enabled)
+
+		objects, err := satellite.API.Metainfo.Metabase.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+		require.Equal(t, metabase.Version(1), objects[0].Version)
+
+		// uploads overwrite the only version.
+		list, err := endpoint.ListObjectVersions(ctx, &metainfoextpb.ListObjectVersionsRequest{
+			Header:             header,
+			Bucket:             []byte("unversioned"),
+			EncryptedObjectKey: []byte(objects[0].ObjectKey),
+		})
+		require.NoError(t, err)
+		require.Len(t, list.Items, 1)
+		require.Equal(t, int64(1), list.Items[0].Version)
+	})
+}
+
+func TestEndpoint_ListObjectsVersionedBucket(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		endpoint := satellite.API.Metainfo.Endpoint
+		header := &pb.RequestHeader{
+			ApiKey: planet.Uplinks[0].APIKey[satellite.ID()].SerializeRaw(),
+		}
+
+		require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, satellite, "versioned"))
+		_, err := endpoint.SetBucketVersioning(ctx, &metainfoextpb.SetBucketVersioningRequest{
+			Header:  header,
+			Bucket:  []byte("versioned"),
+			Enabled: true,
+		})
+		require.NoError(t, err)
+
+		// every key has several versions, so that pages end on keys whose
+		// latest version is above 1.
+		const keys = 5
+		for i := 0; i < keys; i++ {
+			for version := 0; version < 3; version++ {
+				key := "key" + strconv.Itoa(i)
+				require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "versioned", key, testrand.Bytes(memory.KiB)))
+			}
+		}
+
+		var listed [][]byte
+		var cursor []byte
+		for {
+			resp, err := endpoint.ListObjects(ctx, &pb.ObjectListRequest{
+				Header:          header,
+				Bucket:          []byte("versioned"),
+				EncryptedCursor: cursor,
+				Limit:           2,
+				Recursive:       true,
+			})
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(resp.Items), 2)
+			for _, item := range resp.Items {
+				listed = append(listed, item.EncryptedPath)
+			}
+			if !resp.More {
+				break
+			}
+			cursor = resp.Items[len(resp.Items)-1].EncryptedPath
+		}
+
+		require.Len(t, listed, keys)
+		unique := map[string]bool{}
+		for _, key := range listed {
+			require.False(t, unique[string(key)], "key listed twice")
+			unique[string(key)] = true
+		}
+	})
+}
+
+func TestEndpoint_MoveObject(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		endpoint := satellite.API.Metainfo.Endpoint
+		header := &pb.RequestHeader{
+			ApiKey: planet.Uplinks[0].APIKey[satellite.ID()].SerializeRaw(),
+		}
+
+		_, err := endpoint.BeginMoveObject(ctx, &metainfoextpb.BeginMoveObjectRequest{
+			Header:             header,
+			Bucket:             []byte("testbucket"),
+			EncryptedObjectKey: []byte("missing"),
+		})
+		require.True(t, errs2.IsRPC(err, rpcstatus.NotFound))
+
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "key", testrand.Bytes(memory.KiB)))
+		require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, satellite, "otherbucket"))
+
+		objects, err := satellite.API.Metainfo.Metabase.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+		object := objects[0]
+
+		begin, err := endpoint.BeginMoveObject(ctx, &metainfoextpb.BeginMoveObjectRequest{
+			Header:             header,
+			Bucket:             []byte("testbucket"),
+			EncryptedObjectKey: []byte(object.ObjectKey),
+		})
+		require.NoError(t, err)
+		require.Equal(t, object.StreamID[:], begin.StreamId)
+		require.EqualValues(t, object.Version, begin.Version)
+		require.Len(t, begin.SegmentKeys, 1)
+
+		finish := &metainfoextpb.FinishMoveObjectRequest{
+			Header:                       header,
+			Bucket:                       []byte("testbucket"),
+			EncryptedObjectKey:           []byte(object.ObjectKey),
+			Version:                      begin.Version,
+			StreamId:                     begin.StreamId,
+			NewBucket:                    []byte("missingbucket"),
+			NewEncryptedObjectKey:        []byte("newkey"),
+			NewEncryptedMetadataKeyNonce: begin.EncryptedMetadataKeyNonce,
+			NewEncryptedMetadataKey:      begin.EncryptedMetadataKey,
+			NewSegmentKeys:               begin.SegmentKeys,
+		}
+		_, err = endpoint.FinishMoveObject(ctx, finish)
+		require.True(t, errs2.IsRPC(err, rpcstatus.NotFound))
+
+		finish.NewBucket = []byte("otherbucket")
+		_, err = endpoint.FinishMoveObject(ctx, finish)
+		require.NoError(t, err)
+
+		objects, err = satellite.API.Metainfo.Metabase.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+		require.Equal(t, "otherbucket", objects[0].BucketName)
+		require.Equal(t, metabase.ObjectKey("newkey"), objects[0].ObjectKey)
+		require.Equal(t, object.StreamID, objects[0].StreamID)
+
+		// existing objects are not overwritten.
+		_, err = endpoint.FinishMoveObject(ctx, finish)
+		require.True(t, errs2.IsRPC(err, rpcstatus.AlreadyExists))
+
+		_, err = endpoint.BeginMoveObject(ctx, &metainfoextpb.BeginMoveObjectRequest{
+			Header:             header,
+			Bucket:             []byte("testbucket"),
+			EncryptedObjectKey: []byte(object.ObjectKey),
+		})
+		require.True(t, errs2.IsRPC(err, rpcstatus.NotFound))
+	})
+}
+
+func TestEndpoint_CopyObject(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		endpoint := satellite.API.Metainfo.Endpoint
+		header := &pb.RequestHeader{
+			ApiKey: planet.Uplinks[0].APIKey[satellite.ID()].SerializeRaw(),
+		}
+
+		_, err := endpoint.BeginCopyObject(ctx, &metainfoextpb.BeginCopyObjectRequest{
+			Header:             header,
+			Bucket:             []byte("testbucket"),
+			EncryptedObjectKey: []byte("missing"),
+		})
+		require.True(t, errs2.IsRPC(err, rpcstatus.NotFound))
+
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "key", testrand.Bytes(memory.KiB)))
+
+		objects, err := satellite.API.Metainfo.Metabase.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+		object := objects[0]
+
+		begin, err := endpoint.BeginCopyObject(ctx, &metainfoextpb.BeginCopyObjectRequest{
+			Header:             header,
+			Bucket:             []byte("testbucket"),
+			EncryptedObjectKey: []byte(object.ObjectKey),
+		})
+		require.NoError(t, err)
+		require.Equal(t, object.StreamID[:], begin.StreamId)
+		require.EqualValues(t, object.Version, begin.Version)
+		require.Len(t, begin.SegmentKeys, 1)
+
+		finish := &metainfoextpb.FinishCopyObjectRequest{
+			Header:                       header,
+			Bucket:                       []byte("testbucket"),
+			EncryptedObjectKey:           []byte(object.ObjectKey),
+			Version:                      begin.Version,
+			StreamId:                     begin.StreamId,
+			NewBucket:                    []byte("testbucket"),
+			NewEncryptedObjectKey:        []byte("copy"),
+			NewEncryptedMetadataKeyNonce: begin.EncryptedMetadataKeyNonce,
+			NewEncryptedMetadataKey:      begin.EncryptedMetadataKey,
+			NewSegmentKeys:               begin.SegmentKeys,
+		}
+		resp, err := endpoint.FinishCopyObject(ctx, finish)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), resp.Version)
+
+		objects, err = satellite.API.Metainfo.Metabase.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 2)
+
+		var copied metabase.Object
+		for _, o := range objects {
+			if o.ObjectKey == "copy" {
+				copied = o
+			}
+		}
+		require.Equal(t, "testbucket", copied.BucketName)
+		require.NotEqual(t, object.StreamID, copied.StreamID)
+		require.Equal(t, object.TotalPlainSize, copied.TotalPlainSize)
+
+		// existing objects are overwritten, like with uploads.
+		resp, err = endpoint.FinishCopyObject(ctx, finish)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), resp.Version)
+
+		objects, err = satellite.API.Metainfo.Metabase.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 2)
+		for _, o := range objects {
+			require.NotEqual(t, copied.StreamID, o.StreamID)
+		}
+
+		// copying an object onto itself would delete it.
+		finish.NewEncryptedObjectKey = []byte(object.ObjectKey)
+		_, err = endpoint.FinishCopyObject(ctx, finish)
+		require.True(t, errs2.IsRPC(err, rpcstatus.InvalidArgument))
+
+		finish.NewEncryptedObjectKey = []byte("other")
+		finish.NewSegmentKeys = nil
+		_, err = endpoint.FinishCopyObject(ctx, finish)
+		require.True(t, errs2.IsRPC(err, rpcstatus.InvalidArgument))
+	})
+}
+
+func TestEndpoint_Tags(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		endpoint := satellite.API.Metainfo.Endpoint
+		header := &pb.RequestHeader{
+			ApiKey: planet.Uplinks[0].APIKey[satellite.ID()].SerializeRaw(),
+		}
+
+		_, err := endpoint.SetBucketTags(ctx, &metainfoextpb.SetBucketTagsRequest{
+			Header: header,
+			Bucket: []byte("testbucket"),
+			Tags:   map[string]string{"team": "storage"},
+		})
+		require.True(t, errs2.IsRPC(err, rpcstatus.NotFound))
+
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "tagged", testrand.Bytes(memory.KiB)))
+
+		_, err = endpoint.SetBucketTags(ctx, &metainfoextpb.SetBucketTagsRequest{
+			Header: header,
+			Bucket: []byte("testbucket"),
+			Tags:   map[string]string{"team": "storage"},
+		})
+		require.NoError(t, err)
+
+		bucketTags, err := endpoint.GetBucketTags(ctx, &metainfoextpb.GetBucketTagsRequest{
+			Header: header,
+			Bucket: []byte("testbucket"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"team": "storage"}, bucketTags.Tags)
+
+		objects, err := satellite.API.Metainfo.Metabase.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+		object := objects[0]
+
+		_, err = endpoint.SetObjectTags(ctx, &metainfoextpb.SetObjectTagsRequest{
+			Header:             header,
+			Bucket:             []byte("testbucket"),
+			EncryptedObjectKey: []byte("missing"),
+			Tags:               map[string]string{"color": "red"},
+		})
+		require.True(t, errs2.IsRPC(err, rpcstatus.NotFound))
+
+		_, err = endpoint.SetObjectTags(ctx, &metainfoextpb.SetObjectTagsRequest{
+			Header:             header,
+			Bucket:             []byte("testbucket"),
+			EncryptedObjectKey: []byte(object.ObjectKey),
+			Version:            -1,
+			Tags:               map[string]string{"color": "red"},
+		})
+		require.True(t, errs2.IsRPC(err, rpcstatus.InvalidArgument))
+
+		_, err = endpoint.SetObjectTags(ctx, &metainfoextpb.SetObjectTagsRequest{
+			Header:             header,
+			Bucket:             []byte("testbucket"),
+			EncryptedObjectKey: []byte(object.ObjectKey),
+			Tags:               map[string]string{"color": "red"},
+		})
+		require.NoError(t, err)
+
+		objectTags, err := endpoint.GetObjectTags(ctx, &metainfoextpb.GetObjectTagsRequest{
+			Header:             header,
+			Bucket:             []byte("testbucket"),
+			EncryptedObjectKey: []byte(object.ObjectKey),
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"color": "red"}, objectTags.Tags)
+
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "untagged", testrand.Bytes(memory.KiB)))
+
+		list := func(tags map[string]string) [][]byte {
+			resp, err := endpoint.ListTaggedObjects(ctx, &metainfoextpb.ListTaggedObjectsRequest{
+				List: &pb.ObjectListRequest{
+					Header:    header,
+					Bucket:    []byte("testbucket"),
+					Recursive: true,
+					Status:    pb.Object_COMMITTED,
+				},
+				Tags: tags,
+			})
+			require.NoError(t, err)
+
+			var keys [][]byte
+			for _, item := range resp.List.Items {
+				keys = append(keys, item.EncryptedPath)
+			}
+			return keys
+		}
+		require.Equal(t, [][]byte{[]byte(object.ObjectKey)}, list(map[string]string{"color": "red"}))
+		require.Empty(t, list(map[string]string{"color": "blue"}))
+		require.Len(t, list(nil), 2)
+
+		_, err = endpoint.ListTaggedObjects(ctx, &metainfoextpb.ListTaggedObjectsRequest{
+			Tags: map[string]string{"color": "red"},
+		})
+		require.True(t, errs2.IsRPC(err, rpcstatus.InvalidArgument))
+	})
+}
+
+func TestEndpoint_BucketLifecycleRules(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		endpoint := satellite.API.Metainfo.Endpoint
+		header := &pb.RequestHeader{
+			ApiKey: planet.Uplinks[0].APIKey[satellite.ID()].SerializeRaw(),
+		}
+
+		logsRule := &metainfoextpb.LifecycleRule{
+			Name:            "logs",
+			EncryptedPrefix: []byte("logs/"),
+			Action:          metainfoextpb.LifecycleAction_EXPIRE_OBJECTS,
+			AgeDays:         30,
+		}
+		_, err := endpoint.SetBucketLifecycleRule(ctx, &metainfoextpb.SetBucketLifecycleRuleRequest{
+			Header: header,
+			Bucket: []byte("testbucket"),
+			Rule:   logsRule,
+		})
+		require.True(t, errs2.IsRPC(err, rpcstatus.NotFound))
+
+		require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, satellite, "testbucket"))
+
+		uploadsRule := &metainfoextpb.LifecycleRule{
+			Name:    "uploads",
+			Action:  metainfoextpb.LifecycleAction_ABORT_UPLOADS,
+			AgeDays: 7,
+		}
+		for _, rule := range []*metainfoextpb.LifecycleRule{uploadsRule, logsRule} {
+			_, err = endpoint.SetBucketLifecycleRule(ctx, &metainfoextpb.SetBucketLifecycleRuleRequest{
+				Header: header,
+				Bucket: []byte("testbucket"),
+				Rule:   rule,
+			})
+			require.NoError(t, err)
+		}
+
+		for _, invalid := range []*metainfoextpb.LifecycleRule{
+			nil,
+			{Name: "", Action: metainfoextpb.LifecycleAction_EXPIRE_OBJECTS, AgeDays: 1},
+			{Name: "invalid", Action: metainfoextpb.LifecycleAction_INVALID, AgeDays: 1},
+			{Name: "invalid", Action: metainfoextpb.LifecycleAction_EXPIRE_OBJECTS, AgeDays: 0},
+		} {
+			_, err = endpoint.SetBucketLifecycleRule(ctx, &metainfoextpb.SetBucketLifecycleRuleRequest{
+				Header: header,
+				Bucket: []byte("testbucket"),
+				Rule:   invalid,
+			})
+			require.True(t, errs2.IsRPC(err, rpcstatus.InvalidArgument))
+		}
+
+		list := func() []*metainfoextpb.LifecycleRule {
+			resp, err := endpoint.ListBucketLifecycleRules(ctx, &metainfoextpb.ListBucketLifecycleRulesRequest{
+				Header: header,
+				Bucket: []byte("testbucket"),
+			})
+			require.NoError(t, err)
+			return resp.Rules
+		}
+		rules := list()
+		require.Len(t, rules, 2)
+		require.Equal(t, logsRule.Name, rules[0].Name)
+		require.Equal(t, logsRule.EncryptedPrefix, rules[0].EncryptedPrefix)
+		require.Equal(t, logsRule.Action, rules[0].Action)
+		require.Equal(t, logsRule.AgeDays, rules[0].AgeDays)
+		require.Equal(t, uploadsRule.Name, rules[1].Name)
+
+		_, err = endpoint.DeleteBucketLifecycleRules(ctx, &metainfoextpb.DeleteBucketLifecycleRulesRequest{
+			Header: header,
+			Bucket: []byte("testbucket"),
+			Name:   "logs",
+		})
+		require.NoError(t, err)
+		rules = list()
+		require.Len(t, rules, 1)
+		require.Equal(t, uploadsRule.Name, rules[0].Name)
+
+		_, err = endpoint.DeleteBucketLifecycleRules(ctx, &metainfoextpb.DeleteBucketLifecycleRulesRequest{
+			Header: header,
+			Bucket: []byte("testbucket"),
+		})
+		require.NoError(t, err)
+		require.Empty(t, list())
+	})
+}
+
+func TestEndpoint_Retention(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		endpoint := satellite.API.Metainfo.Endpoint
+		apiKey := planet.Uplinks[0].APIKey[satellite.ID()]
+		header := &pb.RequestHeader{ApiKey: apiKey.SerializeRaw()}
+
+		_, err := endpoint.SetBucketRetention(ctx, &metainfoextpb.SetBucketRetentionRequest{
+			Header: header,
+			Bucket: []byte("testbucket"),
+			Days:   7,
+		})
+		require.True(t, errs2.IsRPC(err, rpcstatus.NotFound))
+
+		require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, satellite, "testbucket"))
+
+		_, err = endpoint.SetBucketRetention(ctx, &metainfoextpb.SetBucketRetentionRequest{
+			Header: header,
+			Bucket: []byte("testbucket"),
+			Days:   -1,
+		})
+		require.True(t, errs2.IsRPC(err, rpcstatus.InvalidArgument))
+
+		_, err = endpoint.SetBucketRetention(ctx, &metainfoextpb.SetBucketRetentionRequest{
+			Header: header,
+			Bucket: []byte("testbucket"),
+			Days:   7,
+		})
+		require.NoError(t, err)
+
+		retention, err := endpoint.GetBucketRetention(ctx, &metainfoextpb.GetBucketRetentionRequest{
+			Header: header,
+			Bucket: []byte("testbucket"),
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, 7, retention.Days)
+
+		// new objects get the default retention of the bucket.
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "retained", testrand.Bytes(memory.KiB)))
+		objects, err := satellite.API.Metainfo.Metabase.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+		object := objects[0]
+
+		getLock := func() *metainfoextpb.GetObjectLockResponse {
+			lock, err := endpoint.GetObjectLock(ctx, &metainfoextpb.GetObjectLockRequest{
+				Header:             header,
+				Bucket:             []byte("testbucket"),
+				EncryptedObjectKey: []byte(object.ObjectKey),
+			})
+			require.NoError(t, err)
+			return lock
+		}
+
+		lock := getLock()
+		require.NotNil(t, lock.RetainUntil)
+		require.WithinDuration(t, time.Now().Add(7*24*time.Hour), *lock.RetainUntil, time.Minute)
+		require.False(t, lock.LegalHold)
+
+		require.Error(t, planet.Uplinks[0].DeleteObject(ctx, satellite, "testbucket", "retained"))
+
+		// the retention can be extended, but not shortened or set to the past.
+		for _, retainUntil := range []time.Time{time.Now().Add(-time.Hour), time.Now().Add(24 * time.Hour)} {
+			_, err = endpoint.SetObjectRetention(ctx, &metainfoextpb.SetObjectRetentionRequest{
+				Header:             header,
+				Bucket:             []byte("testbucket"),
+				EncryptedObjectKey: []byte(object.ObjectKey),
+				RetainUntil:        retainUntil,
+			})
+			require.True(t, errs2.IsRPC(err, rpcstatus.InvalidArgument), err)
+		}
+
+		extended := time.Now().Add(30 * 24 * time.Hour)
+		_, err = endpoint.SetObjectRetention(ctx, &metainfoextpb.SetObjectRetentionRequest{
+			Header:             header,
+			Bucket:             []byte("testbucket"),
+			EncryptedObjectKey: []byte(object.ObjectKey),
+			Version:            int64(object.Version),
+			RetainUntil:        extended,
+		})
+		require.NoError(t, err)
+		require.WithinDuration(t, extended, *getLock().RetainUntil, time.Second)
+
+		_, err = endpoint.SetObjectRetention(ctx, &metainfoextpb.SetObjectRetentionRequest{
+			Header:             header,
+			Bucket:             []byte("testbucket"),
+			EncryptedObjectKey: []byte("missing"),
+			RetainUntil:        extended,
+		})
+		require.True(t, errs2.IsRPC(err, rpcstatus.NotFound))
+
+		setLegalHold := func(header *pb.RequestHeader, enabled bool) error {
+			_, err := endpoint.SetObjectLegalHold(ctx, &metainfoextpb.SetObjectLegalHoldRequest{
+				Header:             header,
+				Bucket:             []byte("testbucket"),
+				EncryptedObjectKey: []byte(object.ObjectKey),
+				Enabled:            enabled,
+			})
+			return err
+		}
+
+		require.NoError(t, setLegalHold(header, true))
+		require.True(t, getLock().LegalHold)
+
+		// removing a legal hold needs the permission to delete the object.
+		restricted, err := apiKey.Restrict(macaroon.WithNonce(macaroon.Caveat{DisallowDeletes: true}))
+		require.NoError(t, err)
+		err = setLegalHold(&pb.RequestHeader{ApiKey: restricted.SerializeRaw()}, false)
+		require.True(t, errs2.IsRPC(err, rpcstatus.PermissionDenied), err)
+		require.True(t, getLock().LegalHold)
+
+		require.NoError(t, setLegalHold(header, false))
+		require.False(t, getLock().LegalHold)
+	})
+}
@@ -6,7 +6,9 @@ package metainfo
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
@@ -385,6 +387,9 @@ func (endpoint *Endpoint) deleteBucketNotEmpty(ctx context.Context, projectID uu
 	err = endpoint.metainfo.DeleteBucket(ctx, bucketName, projectID)
 	if err != nil {
 		if ErrBucketNotEmpty.Has(err) {
+			if lockedErr := endpoint.lockedBucketError(ctx, projectID, bucketName); lockedErr != nil {
+				return nil, deletedCount, lockedErr
+			}
 			return nil, deletedCount, rpcstatus.Error(rpcstatus.FailedPrecondition, "cannot delete the bucket because it's being used by another process")
 		}
 		if storj.ErrBucketNotFound.Has(err) {
@@ -397,6 +402,48 @@ func (endpoint *Endpoint) deleteBucketNotEmpty(ctx context.Context, projectID uu
 	return bucketName, deletedCount, nil
 }
 
+// maxLockedObjectsInError is the number of locked objects that are named in
+// the error of a bucket that can't be deleted.
+const maxLockedObjectsInError = 10
+
+// lockedBucketError returns an error naming the objects that keep the bucket
+// from being deleted because of a retention period or a legal hold, or nil
+// if there are none. The keys are named as they are stored, in the encoding
+// of listings with encryption bypassed.
+func (endpoint *Endpoint) lockedBucketError(ctx context.Context, projectID uuid.UUID, bucketName []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	locked, err := endpoint.metainfo.metabaseDB.ListLockedObjects(ctx, metabase.ListLockedObjects{
+		Bucket: metabase.BucketLocation{ProjectID: projectID, BucketName: string(bucketName)},
+		Limit:  maxLockedObjectsInError + 1,
+	})
+	if err != nil {
+		endpoint.log.Error("internal", zap.Error(err))
+		return rpcstatus.Error(rpcstatus.Internal, err.Error())
+	}
+	if len(locked) == 0 {
+		return nil
+	}
+
+	var names []string
+	for i, object := range locked {
+		if i == maxLockedObjectsInError {
+			names = append(names, "...")
+			break
+		}
+
+		components := strings.Split(string(object.ObjectKey), "/")
+		for k, component := range components {
+			components[k] = base64.URLEncoding.EncodeToString([]byte(component))
+		}
+		names = append(names, fmt.Sprintf("%s (version %d)", strings.Join(components, "/"), object.Version))
+	}
+
+	mon.Meter("bucket_delete_locked").Mark(1)
+	return rpcstatus.Error(rpcstatus.PermissionDenied,
+		"cannot delete the bucket because objects are locked by a retention period or a legal hold: "+strings.Join(names, ", "))
+}
+
 // deleteBucketObjects deletes all objects in a bucket.
 func (endpoint *Endpoint) deleteBucketObjects(ctx context.Context, projectID uuid.UUID, bucketName []byte) (_ int64, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -598,6 +645,12 @@ func (endpoint *Endpoint) BeginObject(ctx context.Context, req *pb.ObjectBeginRe
 		return nil, rpcstatus.Error(rpcstatus.NotFound, "bucket not found: non-existing-bucket")
 	}
 
+	versioned, err := endpoint.metainfo.IsBucketVersioned(ctx, keyInfo.ProjectID, req.Bucket)
+	if err != nil {
+		endpoint.log.Error("unable to check bucket versioning", zap.Error(err))
+		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
+	}
+
 	_, err = endpoint.validateAuth(ctx, req.Header, macaroon.Action{
 		Op:            macaroon.ActionDelete,
 		Bucket:        req.Bucket,
@@ -606,16 +659,20 @@ func (endpoint *Endpoint) BeginObject(ctx context.Context, req *pb.ObjectBeginRe
 	})
 	canDelete := err == nil
 
-	if canDelete {
+	// versioned buckets keep the previous versions, so nothing is overwritten.
+	if !versioned && canDelete {
 		_, err = endpoint.DeleteObjectAnyStatus(ctx, metabase.ObjectLocation{
 			ProjectID:  keyInfo.ProjectID,
 			BucketName: string(req.Bucket),
 			ObjectKey:  metabase.ObjectKey(req.EncryptedPath),
 		})
+		if metabase.ErrObjectLocked.Has(err) {
+			return nil, rpcstatus.Error(rpcstatus.PermissionDenied, "object is locked and can't be overwritten")
+		}
 		if err != nil && !storj.ErrObjectNotFound.Has(err) {
 			return nil, err
 		}
-	} else {
+	} else if !versioned {
 		_, err = endpoint.metainfo.metabaseDB.GetObjectLatestVersion(ctx, metabase.GetObjectLatestVersion{
 			ObjectLocation: metabase.ObjectLocation{
 				ProjectID:  keyInfo.ProjectID,
@@ -654,17 +711,33 @@ func (endpoint *Endpoint) BeginObject(ctx context.Context, req *pb.ObjectBeginRe
 		expiresAt = &req.ExpiresAt
 	}
 
-	object, err := endpoint.metainfo.metabaseDB.BeginObjectExactVersion(ctx, metabase.BeginObjectExactVersion{
-		ObjectStream: metabase.ObjectStream{
-			ProjectID:  keyInfo.ProjectID,
-			BucketName: string(req.Bucket),
-			ObjectKey:  metabase.ObjectKey(req.EncryptedPath),
-			StreamID:   streamID,
-			Version:    metabase.Version(1),
-		},
-		ExpiresAt:  expiresAt,
-		Encryption: encryptionParameters,
-	})
+	var object metabase.Object
+	if versioned {
+		object.Version, err = endpoint.metainfo.metabaseDB.BeginObjectNextVersion(ctx, metabase.BeginObjectNextVersion{
+			ObjectStream: metabase.ObjectStream{
+				ProjectID:  keyInfo.ProjectID,
+				BucketName: string(req.Bucket),
+				ObjectKey:  metabase.ObjectKey(req.EncryptedPath),
+				StreamID:   streamID,
+				Version:    metabase.NextVersion,
+			},
+			ExpiresAt:  expiresAt,
+			Encryption: encryptionParameters,
+		})
+		object.CreatedAt = time.Now()
+	} else {
+		object, err = endpoint.metainfo.metabaseDB.BeginObjectExactVersion(ctx, metabase.BeginObjectExactVersion{
+			ObjectStream: metabase.ObjectStream{
+				ProjectID:  keyInfo.ProjectID,
+				BucketName: string(req.Bucket),
+				ObjectKey:  metabase.ObjectKey(req.EncryptedPath),
+				StreamID:   streamID,
+				Version:    metabase.Version(1),
+			},
+			ExpiresAt:  expiresAt,
+			Encryption: encryptionParameters,
+		})
+	}
 	if err != nil {
 		endpoint.log.Error("internal", zap.Error(err))
 		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
@@ -754,7 +827,7 @@ func (endpoint *Endpoint) commitObject(ctx context.Context, req *pb.ObjectCommit
 			BucketName: string(streamID.Bucket),
 			ObjectKey:  metabase.ObjectKey(streamID.EncryptedPath),
 			StreamID:   id,
-			Version:    metabase.Version(1),
+			Version:    metabase.Version(streamID.Version),
 		},
 		EncryptedMetadata:             req.EncryptedMetadata,
 		EncryptedMetadataNonce:        req.EncryptedMetadataNonce[:],
@@ -859,6 +932,14 @@ func (endpoint *Endpoint) DownloadObject(ctx context.Context, req *pb.ObjectDown
 		endpoint.log.Warn("unable to collect uplink version", zap.Error(err))
 	}
 
+	return endpoint.downloadObject(ctx, req, 0)
+}
+
+// downloadObject returns the information for downloading the given version
+// of an object. Version zero means the latest version.
+func (endpoint *Endpoint) downloadObject(ctx context.Context, req *pb.ObjectDownloadRequest, version metabase.Version) (resp *pb.ObjectDownloadResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
 	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
 		Op:            macaroon.ActionRead,
 		Bucket:        req.Bucket,
@@ -886,17 +967,30 @@ func (endpoint *Endpoint) DownloadObject(ctx context.Context, req *pb.ObjectDown
 
 	// get the object information
 
-	object, err := endpoint.metainfo.metabaseDB.GetObjectLatestVersion(ctx, metabase.GetObjectLatestVersion{
-		ObjectLocation: metabase.ObjectLocation{
-			ProjectID:  keyInfo.ProjectID,
-			BucketName: string(req.Bucket),
-			ObjectKey:  metabase.ObjectKey(req.EncryptedObjectKey),
-		},
-	})
+	location := metabase.ObjectLocation{
+		ProjectID:  keyInfo.ProjectID,
+		BucketName: string(req.Bucket),
+		ObjectKey:  metabase.ObjectKey(req.EncryptedObjectKey),
+	}
+
+	var object metabase.Object
+	if version == 0 {
+		object, err = endpoint.metainfo.metabaseDB.GetObjectLatestVersion(ctx, metabase.GetObjectLatestVersion{
+			ObjectLocation: location,
+		})
+	} else {
+		object, err = endpoint.metainfo.metabaseDB.GetObjectExactVersion(ctx, metabase.GetObjectExactVersion{
+			ObjectLocation: location,
+			Version:        version,
+		})
+	}
 	if err != nil {
 		if storj.ErrObjectNotFound.Has(err) {
 			return nil, rpcstatus.Error(rpcstatus.NotFound, err.Error())
 		}
+		if metabase.ErrInvalidRequest.Has(err) {
+			return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+		}
 		endpoint.log.Error("internal", zap.Error(err))
 		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
 	}
@@ -1198,6 +1292,13 @@ func calculateStreamRange(object metabase.Object, req *pb.Range) (*metabase.Stre
 func (endpoint *Endpoint) ListObjects(ctx context.Context, req *pb.ObjectListRequest) (resp *pb.ObjectListResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	return endpoint.listObjects(ctx, req, nil)
+}
+
+// listObjects lists the objects of the request that have all of the tags.
+func (endpoint *Endpoint) listObjects(ctx context.Context, req *pb.ObjectListRequest, tags metabase.Tags) (resp *pb.ObjectListResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
 	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
 		Op:            macaroon.ActionList,
 		Bucket:        req.Bucket,
@@ -1247,26 +1348,35 @@ func (endpoint *Endpoint) ListObjects(ctx context.Context, req *pb.ObjectListReq
 		cursor = string(prefix) + cursor
 	}
 
+	// only the latest version of committed objects is listed, so the listing
+	// continues after all versions of the cursor key. Pending objects may have
+	// several streams with the same key and the request has no version to
+	// continue from.
+	cursorVersion := metabase.Version(1)
+	if status == metabase.Committed {
+		cursorVersion = metabase.MaxVersion
+	}
+
 	includeMetadata := true
 	if req.UseObjectIncludes {
 		includeMetadata = req.ObjectIncludes.Metadata
 	}
 
 	resp = &pb.ObjectListResponse{}
-	// TODO: Replace with IterateObjectsLatestVersion when ready
-	err = endpoint.metainfo.metabaseDB.IterateObjectsAllVersionsWithStatus(ctx,
+	err = endpoint.metainfo.metabaseDB.IterateObjectsLatestVersion(ctx,
 		metabase.IterateObjectsWithStatus{
 			ProjectID:  keyInfo.ProjectID,
 			BucketName: string(req.Bucket),
 			Prefix:     prefix,
 			Cursor: metabase.IterateCursor{
 				Key:     metabase.ObjectKey(cursor),
-				Version: 1, // TODO: set to a the version from the protobuf request when it supports this
+				Version: cursorVersion,
 			},
 			Recursive:       req.Recursive,
 			BatchSize:       limit + 1,
 			Status:          status,
 			IncludeMetadata: includeMetadata,
+			Tags:            tags,
 		}, func(ctx context.Context, it metabase.ObjectsIterator) error {
 			entry := metabase.ObjectEntry{}
 			for len(resp.Items) < limit && it.Next(ctx, &entry) {
@@ -1461,6 +1571,9 @@ func (endpoint *Endpoint) BeginDeleteObject(ctx context.Context, req *pb.ObjectB
 		if storj.ErrObjectNotFound.Has(err) {
 			return nil, rpcstatus.Wrap(rpcstatus.NotFound, err)
 		}
+		if metabase.ErrObjectLocked.Has(err) {
+			return nil, rpcstatus.Wrap(rpcstatus.PermissionDenied, err)
+		}
 		endpoint.log.Error("internal", zap.Error(err))
 		return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
 	}
@@ -1706,7 +1819,7 @@ func (endpoint *Endpoint) BeginSegment(ctx context.Context, req *pb.SegmentBegin
 			BucketName: string(streamID.Bucket),
 			ObjectKey:  metabase.ObjectKey(streamID.EncryptedPath),
 			StreamID:   id,
-			Version:    1,
+			Version:    metabase.Version(streamID.Version),
 		},
 		Position: metabase.SegmentPosition{
 			Part:  uint32(req.Position.PartNumber),
@@ -1864,7 +1977,7 @@ func (endpoint *Endpoint) commitSegment(ctx context.Context, req *pb.SegmentComm
 			BucketName: string(streamID.Bucket),
 			ObjectKey:  metabase.ObjectKey(streamID.EncryptedPath),
 			StreamID:   id,
-			Version:    1,
+			Version:    metabase.Version(streamID.Version),
 		},
 		ExpiresAt:         expiresAt,
 		EncryptedKey:      req.EncryptedKey,
@@ -2006,7 +2119,7 @@ func (endpoint *Endpoint) makeInlineSegment(ctx context.Context, req *pb.Segment
 			BucketName: string(streamID.Bucket),
 			ObjectKey:  metabase.ObjectKey(streamID.EncryptedPath),
 			StreamID:   id,
-			Version:    1,
+			Version:    metabase.Version(streamID.Version),
 		},
 		ExpiresAt:         expiresAt,
 		EncryptedKey:      req.EncryptedKey,
@@ -2454,11 +2567,31 @@ func (endpoint *Endpoint) DeleteCommittedObject(
 		ObjectKey:  object,
 	}
 
-	result, err := endpoint.metainfo.metabaseDB.DeleteObjectsAllVersions(ctx, metabase.DeleteObjectsAllVersions{Locations: []metabase.ObjectLocation{req}})
+	versioned, err := endpoint.metainfo.IsBucketVersioned(ctx, projectID, []byte(bucket))
 	if err != nil {
 		return nil, err
 	}
 
+	var result metabase.DeleteObjectResult
+	if versioned {
+		// the object is only hidden, its pieces stay on the storage nodes.
+		result, err = endpoint.metainfo.metabaseDB.CreateDeleteMarker(ctx, metabase.CreateDeleteMarker{ObjectLocation: req})
+	} else {
+		result, err = endpoint.metainfo.metabaseDB.DeleteObjectsAllVersions(ctx, metabase.DeleteObjectsAllVersions{Locations: []metabase.ObjectLocation{req}})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Objects) == 0 {
+		// locked versions are skipped when deleting, report them instead of
+		// pretending that the object was deleted.
+		lock, err := endpoint.metainfo.metabaseDB.GetObjectLock(ctx, metabase.GetObjectLock{ObjectLocation: req})
+		if err == nil && lock.Locked(time.Now()) {
+			return nil, metabase.ErrObjectLocked.New("")
+		}
+	}
+
 	deletedObjects, err = endpoint.deleteObjectsPieces(ctx, result)
 	if err != nil {
 		endpoint.log.Error("failed to delete pointers",
@@ -74,7 +74,19 @@ func (s *Service) DeleteBucket(ctx context.Context, bucketName []byte, projectID
 		return ErrBucketNotEmpty.New("")
 	}
 
-	return s.bucketsDB.DeleteBucket(ctx, bucketName, projectID)
+	err = s.bucketsDB.DeleteBucket(ctx, bucketName, projectID)
+	if err != nil {
+		return err
+	}
+
+	// a new bucket with the same name must not inherit the bucket settings.
+	bucket := metabase.BucketLocation{ProjectID: projectID, BucketName: string(bucketName)}
+	return Error.Wrap(errs.Combine(
+		s.metabaseDB.SetBucketVersioning(ctx, metabase.SetBucketVersioning{BucketLocation: bucket, Enabled: false}),
+		s.metabaseDB.SetBucketRetention(ctx, metabase.SetBucketRetention{BucketLocation: bucket, Days: 0}),
+		s.metabaseDB.DeleteBucketLifecycleRules(ctx, metabase.DeleteBucketLifecycleRules{BucketLocation: bucket}),
+		s.metabaseDB.SetBucketTags(ctx, metabase.SetBucketTags{BucketLocation: bucket}),
+	))
 }
 
 // IsBucketEmpty returns whether bucket is empty.
@@ -86,6 +98,16 @@ func (s *Service) IsBucketEmpty(ctx context.Context, projectID uuid.UUID, bucket
 	return empty, Error.Wrap(err)
 }
 
+// IsBucketVersioned returns whether older versions of objects are kept in the bucket.
+func (s *Service) IsBucketVersioned(ctx context.Context, projectID uuid.UUID, bucketName []byte) (_ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	versioned, err := s.metabaseDB.GetBucketVersioning(ctx, metabase.GetBucketVersioning{
+		BucketLocation: metabase.BucketLocation{ProjectID: projectID, BucketName: string(bucketName)},
+	})
+	return versioned, Error.Wrap(err)
+}
+
 // ListBuckets returns a list of buckets for a project.
 func (s *Service) ListBuckets(ctx context.Context, projectID uuid.UUID, listOpts storj.BucketListOptions, allowedBuckets macaroon.AllowedBuckets) (bucketList storj.BucketList, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -31,6 +31,7 @@ import (
 	"storj.io/storj/satellite/gracefulexit"
 	"storj.io/storj/satellite/mailservice"
 	"storj.io/storj/satellite/metainfo"
+	"storj.io/storj/satellite/metainfo/bucketlifecycle"
 	"storj.io/storj/satellite/metainfo/expireddeletion"
 	"storj.io/storj/satellite/metrics"
 	"storj.io/storj/satellite/nodeapiversion"
@@ -128,6 +129,8 @@ type Config struct {
 
 	ExpiredDeletion expireddeletion.Config
 
+	BucketLifecycle bucketlifecycle.Config
+
 	Tally            tally.Config
 	Rollup           rollup.Config
 	RollupArchive    rolluparchive.Config
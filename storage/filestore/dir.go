@@ -157,6 +157,21 @@ func (dir *Dir) DeleteTemporary(ctx context.Context, file *os.File) (err error)
 	return errs.Combine(closeErr, os.Remove(file.Name()))
 }
 
+// ListTemporary returns the paths of the temporary files in the dir. While
+// nothing uses the dir, these are left behind by uploads that were interrupted.
+func (dir *Dir) ListTemporary() (paths []string, err error) {
+	entries, err := ioutil.ReadDir(dir.tempdir())
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.Mode().IsRegular() {
+			paths = append(paths, filepath.Join(dir.tempdir(), entry.Name()))
+		}
+	}
+	return paths, nil
+}
+
 // blobToBasePath converts a blob reference to a filepath in permanent storage. This may not be the
 // entire path; blobPathForFormatVersion() must also be used. This is a separate call because this
 // part of the filepath is constant, and blobPathForFormatVersion may need to be called multiple
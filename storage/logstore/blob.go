@@ -0,0 +1,177 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package logstore
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"io"
+	"os"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/storage"
+)
+
+// blobReader implements reading a blob from a log.
+type blobReader struct {
+	*io.SectionReader
+	store         *blobStore
+	log           *logFile
+	formatVersion storage.FormatVersion
+	closed        bool
+}
+
+func newBlobReader(store *blobStore, log *logFile, e entry) *blobReader {
+	return &blobReader{
+		SectionReader: io.NewSectionReader(log.file, e.offset, e.length),
+		store:         store,
+		log:           log,
+		formatVersion: e.format,
+	}
+}
+
+// Size returns how large is the blob.
+func (blob *blobReader) Size() (int64, error) {
+	return blob.SectionReader.Size(), nil
+}
+
+// StorageFormatVersion gets the storage format version being used by the blob.
+func (blob *blobReader) StorageFormatVersion() storage.FormatVersion {
+	return blob.formatVersion
+}
+
+// Close releases the log that the blob is read from.
+func (blob *blobReader) Close() error {
+	if blob.closed {
+		return nil
+	}
+	blob.closed = true
+	return Error.Wrap(blob.store.release(blob.log))
+}
+
+// blobWriter implements writing blobs. The blob is written to a temporary
+// file, because the piece header is written after the data, and is appended
+// to the active log when it is committed.
+type blobWriter struct {
+	ref           storage.BlobRef
+	store         *blobStore
+	closed        bool
+	formatVersion storage.FormatVersion
+	buffer        *bufio.Writer
+	fh            *os.File
+
+	created time.Time // creation time to record instead of the time of the commit
+}
+
+func newBlobWriter(ref storage.BlobRef, store *blobStore, formatVersion storage.FormatVersion, file *os.File, bufferSize int) *blobWriter {
+	return &blobWriter{
+		ref:           ref,
+		store:         store,
+		formatVersion: formatVersion,
+		buffer:        bufio.NewWriterSize(file, bufferSize),
+		fh:            file,
+	}
+}
+
+// Write adds data to the blob.
+func (blob *blobWriter) Write(p []byte) (int, error) {
+	return blob.buffer.Write(p)
+}
+
+// Cancel discards the blob.
+func (blob *blobWriter) Cancel(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if blob.closed {
+		return nil
+	}
+	blob.closed = true
+
+	return Error.Wrap(blob.store.dir.DeleteTemporary(ctx, blob.fh))
+}
+
+// Commit appends the blob to the active log.
+func (blob *blobWriter) Commit(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if blob.closed {
+		return Error.New("already closed")
+	}
+	blob.closed = true
+
+	if err := blob.buffer.Flush(); err != nil {
+		return Error.Wrap(err)
+	}
+	err = blob.store.commit(ctx, blob.ref, blob.formatVersion, blob.fh, blob.created)
+	return Error.Wrap(errs.Combine(err, blob.store.dir.DeleteTemporary(ctx, blob.fh)))
+}
+
+// Seek flushes any buffer and seeks the underlying file.
+func (blob *blobWriter) Seek(offset int64, whence int) (int64, error) {
+	if err := blob.buffer.Flush(); err != nil {
+		return 0, err
+	}
+
+	return blob.fh.Seek(offset, whence)
+}
+
+// Size returns how much has been written so far.
+func (blob *blobWriter) Size() (int64, error) {
+	pos, err := blob.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+
+	return pos, err
+}
+
+// StorageFormatVersion indicates what storage format version the blob is using.
+func (blob *blobWriter) StorageFormatVersion() storage.FormatVersion {
+	return blob.formatVersion
+}
+
+// blobInfo is the information about a blob in a log.
+type blobInfo struct {
+	ref   storage.BlobRef
+	path  string
+	entry entry
+}
+
+func (info *blobInfo) BlobRef() storage.BlobRef {
+	return info.ref
+}
+
+func (info *blobInfo) StorageFormatVersion() storage.FormatVersion {
+	return info.entry.format
+}
+
+func (info *blobInfo) Stat(ctx context.Context) (os.FileInfo, error) {
+	return &blobFileInfo{
+		name:    hex.EncodeToString(info.ref.Key),
+		size:    info.entry.length,
+		modTime: info.entry.createdAt(),
+	}, nil
+}
+
+// FullPath returns the path of the log that contains the blob.
+func (info *blobInfo) FullPath(ctx context.Context) (string, error) {
+	return info.path, nil
+}
+
+// blobFileInfo describes a blob in a log like a file.
+type blobFileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+func (fi *blobFileInfo) Name() string       { return fi.name }
+func (fi *blobFileInfo) Size() int64        { return fi.size }
+func (fi *blobFileInfo) Mode() os.FileMode  { return filePermission }
+func (fi *blobFileInfo) ModTime() time.Time { return fi.modTime }
+func (fi *blobFileInfo) IsDir() bool        { return false }
+func (fi *blobFileInfo) Sys() interface{}   { return nil }
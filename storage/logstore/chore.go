@@ -0,0 +1,50 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package logstore
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/sync2"
+)
+
+// Chore periodically compacts a blob store.
+//
+// architecture: Chore
+type Chore struct {
+	log       *zap.Logger
+	compactor Compactor
+
+	Loop *sync2.Cycle
+}
+
+// NewChore creates a new compaction chore.
+func NewChore(log *zap.Logger, compactor Compactor, interval time.Duration) *Chore {
+	return &Chore{
+		log:       log,
+		compactor: compactor,
+		Loop:      sync2.NewCycle(interval),
+	}
+}
+
+// Run runs the compaction chore.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return chore.Loop.Run(ctx, func(ctx context.Context) error {
+		if err := chore.compactor.Compact(ctx); err != nil {
+			chore.log.Error("error during compaction", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// Close stops the compaction chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
@@ -0,0 +1,244 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package logstore
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/storage"
+)
+
+// Compactor is implemented by blob stores that need to be compacted to
+// reclaim the space of deleted blobs.
+type Compactor interface {
+	Compact(ctx context.Context) error
+}
+
+// indexCompactionSlack is the number of records that the index journal may
+// have beyond twice the number of entries before it is rewritten.
+const indexCompactionSlack = 1024
+
+// Compact moves the blobs out of logs whose fraction of deleted data is at
+// least the compaction threshold, removes those logs, and rewrites the index
+// journal once it is mostly made of records that were superseded.
+func (store *blobStore) Compact(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for _, logFile := range store.compactionCandidates() {
+		if err := store.compactLog(ctx, logFile); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+	return Error.Wrap(store.compactIndex(ctx))
+}
+
+// compactionCandidates returns the logs that should be compacted.
+func (store *blobStore) compactionCandidates() (candidates []*logFile) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for _, logFile := range store.logs {
+		// logs that are still being written to are compacted later. only the
+		// active log gets new writes, so this can't change once it's false.
+		if logFile == store.active || logFile.size == 0 || logFile.writers > 0 {
+			continue
+		}
+		if float64(logFile.dead()) >= store.config.CompactionThreshold*float64(logFile.size) {
+			candidates = append(candidates, logFile)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].id < candidates[j].id })
+	return candidates
+}
+
+// located is an entry together with the blob that it belongs to.
+type located struct {
+	ref   storage.BlobRef
+	entry entry
+}
+
+// compactLog appends the blobs of the log to the active log and removes it.
+// The mutex is not held while the blobs are moved, so that the store can be
+// used while it is compacted.
+func (store *blobStore) compactLog(ctx context.Context, logFile *logFile) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	blobs := store.blobsInLog(logFile)
+
+	var moved, bytes int64
+	for _, blob := range blobs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		ok, err := store.move(blob, logFile)
+		if err != nil {
+			return err
+		}
+		if ok {
+			moved++
+			bytes += blob.entry.length
+		}
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if logFile.live != 0 {
+		return errs.New("log %d still has %d bytes of data after compaction", logFile.id, logFile.live)
+	}
+	store.log.Info("compacted piece log",
+		zap.Uint64("log", logFile.id), zap.Int64("pieces moved", moved), zap.Int64("bytes moved", bytes),
+		zap.Int64("bytes reclaimed", logFile.size-bytes))
+	delete(store.logs, logFile.id)
+	return logFile.remove()
+}
+
+// blobsInLog returns the blobs stored in the log, ordered by their offsets.
+func (store *blobStore) blobsInLog(logFile *logFile) (blobs []located) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for namespace, keys := range store.blobs {
+		for key, e := range keys {
+			if e.log != logFile.id {
+				continue
+			}
+			blobs = append(blobs, located{
+				ref:   storage.BlobRef{Namespace: []byte(namespace), Key: []byte(key)},
+				entry: e,
+			})
+		}
+	}
+	sort.Slice(blobs, func(i, j int) bool { return blobs[i].entry.offset < blobs[j].entry.offset })
+	return blobs
+}
+
+// move appends the blob to the active log unless it was moved or deleted
+// since it was found in the log.
+func (store *blobStore) move(blob located, logFile *logFile) (moved bool, err error) {
+	// current returns the entry of the blob if it is still in the log. It
+	// must be called with the mutex held.
+	current := func() (entry, bool) {
+		e, ok := store.blobs[string(blob.ref.Namespace)][string(blob.ref.Key)]
+		return e, ok && e.log == blob.entry.log && e.offset == blob.entry.offset
+	}
+
+	store.mu.Lock()
+	_, ok := current()
+	store.mu.Unlock()
+	if !ok {
+		return false, nil
+	}
+
+	// the blob may be deleted or replaced while it is copied, in which case
+	// the copy is left as dead data in the active log.
+	data := io.NewSectionReader(logFile.file, blob.entry.offset, blob.entry.length)
+	return store.appendBlob(blob.ref, blob.entry, bufio.NewReader(data), func(e *entry) bool {
+		latest, ok := current()
+		// the blob may also be trashed or restored while it is copied.
+		e.trashed = latest.trashed
+		return ok
+	})
+}
+
+// compactIndex rewrites the index journal with a record for every entry if
+// most of its records were superseded.
+func (store *blobStore) compactIndex(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	var entries int64
+	for _, keys := range store.blobs {
+		entries += int64(len(keys))
+	}
+	if store.records <= 2*entries+indexCompactionSlack {
+		return nil
+	}
+
+	path := filepath.Join(store.logsdir(), indexFileName)
+	size, err := writeIndex(path+".tmp", store.blobs)
+	if err != nil {
+		return err
+	}
+
+	store.indexMu.Lock()
+	defer store.indexMu.Unlock()
+
+	// the index is closed before it is replaced, because open files can not
+	// be replaced on every platform.
+	if err := store.index.Close(); err != nil {
+		return errs.Combine(err, os.Remove(path+".tmp"))
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		// the old index is still in place, so it's used again.
+		index, openErr := openIndex(path, store.size)
+		if openErr == nil {
+			store.index = index
+		}
+		return errs.Combine(err, openErr, os.Remove(path+".tmp"))
+	}
+	index, err := openIndex(path, size)
+	if err != nil {
+		return err
+	}
+
+	store.log.Info("compacted piece index", zap.Int64("records", store.records), zap.Int64("entries", entries))
+	store.index, store.size, store.records = index, size, entries
+	return nil
+}
+
+// openIndex opens the index journal at path for appending at offset.
+func openIndex(path string, offset int64) (*os.File, error) {
+	index, err := os.OpenFile(path, os.O_RDWR, filePermission)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := index.Seek(offset, io.SeekStart); err != nil {
+		return nil, errs.Combine(err, index.Close())
+	}
+	return index, nil
+}
+
+// writeIndex writes an index journal with a record for every entry to path.
+func writeIndex(path string, blobs map[string]map[string]entry) (size int64, err error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, filePermission)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		err = errs.Combine(err, file.Close())
+		if err != nil {
+			err = errs.Combine(err, os.Remove(path))
+		}
+	}()
+
+	buf := bufio.NewWriter(file)
+	for namespace, keys := range blobs {
+		for key, e := range keys {
+			rec := record{op: opPut, namespace: []byte(namespace), key: []byte(key), entry: e}
+			data, err := rec.marshal(nil)
+			if err != nil {
+				return 0, err
+			}
+			if _, err := buf.Write(data); err != nil {
+				return 0, err
+			}
+			size += int64(len(data))
+		}
+	}
+	if err := buf.Flush(); err != nil {
+		return 0, err
+	}
+	return size, file.Sync()
+}
@@ -0,0 +1,173 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package logstore
+
+import (
+	"bufio"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/storage"
+)
+
+// The index is a journal of records that is replayed into memory when the
+// store is opened. Every record is prefixed by the length of its payload and
+// a checksum of the payload, so that a record that was partially written when
+// the node stopped can be detected and dropped.
+const (
+	opPut             byte = 1 // sets the entry of a key
+	opDelete          byte = 2 // removes the entry of a key
+	opDeleteNamespace byte = 3 // removes every entry of a namespace
+
+	recordHeaderSize = 8
+	maxRecordSize    = 1024
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// entry is the location and state of a blob in the logs.
+type entry struct {
+	format  storage.FormatVersion
+	log     uint64
+	offset  int64
+	length  int64
+	created int64 // unix nanoseconds
+	trashed int64 // unix nanoseconds, zero if the blob is not in the trash
+}
+
+// isTrashed returns true if the blob is in the trash.
+func (e entry) isTrashed() bool { return e.trashed != 0 }
+
+// createdAt returns the time the blob was created.
+func (e entry) createdAt() time.Time { return time.Unix(0, e.created) }
+
+// record is a single change to the index.
+type record struct {
+	op        byte
+	namespace []byte
+	key       []byte
+	entry     entry
+}
+
+// marshal appends the encoded record to buf.
+func (rec *record) marshal(buf []byte) ([]byte, error) {
+	if len(rec.namespace) > 255 || len(rec.key) > 255 {
+		return nil, Error.New("namespace or key too long")
+	}
+
+	payload := []byte{rec.op, byte(len(rec.namespace))}
+	payload = append(payload, rec.namespace...)
+	payload = append(payload, byte(len(rec.key)))
+	payload = append(payload, rec.key...)
+	if rec.op == opPut {
+		var fields [41]byte
+		fields[0] = byte(rec.entry.format)
+		binary.LittleEndian.PutUint64(fields[1:], rec.entry.log)
+		binary.LittleEndian.PutUint64(fields[9:], uint64(rec.entry.offset))
+		binary.LittleEndian.PutUint64(fields[17:], uint64(rec.entry.length))
+		binary.LittleEndian.PutUint64(fields[25:], uint64(rec.entry.created))
+		binary.LittleEndian.PutUint64(fields[33:], uint64(rec.entry.trashed))
+		payload = append(payload, fields[:]...)
+	}
+
+	var header [recordHeaderSize]byte
+	binary.LittleEndian.PutUint32(header[0:], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(header[4:], crc32.Checksum(payload, castagnoli))
+	return append(append(buf, header[:]...), payload...), nil
+}
+
+// unmarshal decodes the payload of a record.
+func (rec *record) unmarshal(payload []byte) error {
+	next := func(n int) ([]byte, error) {
+		if len(payload) < n {
+			return nil, errs.New("record too short")
+		}
+		data := payload[:n]
+		payload = payload[n:]
+		return data, nil
+	}
+
+	head, err := next(2)
+	if err != nil {
+		return err
+	}
+	rec.op = head[0]
+	if rec.namespace, err = next(int(head[1])); err != nil {
+		return err
+	}
+	keyLen, err := next(1)
+	if err != nil {
+		return err
+	}
+	if rec.key, err = next(int(keyLen[0])); err != nil {
+		return err
+	}
+
+	switch rec.op {
+	case opPut:
+		fields, err := next(41)
+		if err != nil {
+			return err
+		}
+		rec.entry = entry{
+			format:  storage.FormatVersion(fields[0]),
+			log:     binary.LittleEndian.Uint64(fields[1:]),
+			offset:  int64(binary.LittleEndian.Uint64(fields[9:])),
+			length:  int64(binary.LittleEndian.Uint64(fields[17:])),
+			created: int64(binary.LittleEndian.Uint64(fields[25:])),
+			trashed: int64(binary.LittleEndian.Uint64(fields[33:])),
+		}
+	case opDelete, opDeleteNamespace:
+	default:
+		return errs.New("unknown record type %d", rec.op)
+	}
+
+	if len(payload) != 0 {
+		return errs.New("record too long")
+	}
+	return nil
+}
+
+// readRecords calls fn with every record of the journal. The namespace and
+// key of the record are only valid during the call. It returns the offset
+// after the last valid record, which is less than the size of the journal if
+// its end is damaged.
+func readRecords(r io.Reader, fn func(rec *record)) (valid int64, err error) {
+	br := bufio.NewReader(r)
+	var header [recordHeaderSize]byte
+	payload := make([]byte, maxRecordSize)
+	for {
+		if _, err := io.ReadFull(br, header[:]); err != nil {
+			if errs.Is(err, io.EOF) || errs.Is(err, io.ErrUnexpectedEOF) {
+				return valid, nil
+			}
+			return valid, err
+		}
+
+		size := binary.LittleEndian.Uint32(header[0:])
+		if size > maxRecordSize {
+			return valid, nil
+		}
+		if _, err := io.ReadFull(br, payload[:size]); err != nil {
+			if errs.Is(err, io.EOF) || errs.Is(err, io.ErrUnexpectedEOF) {
+				return valid, nil
+			}
+			return valid, err
+		}
+		if crc32.Checksum(payload[:size], castagnoli) != binary.LittleEndian.Uint32(header[4:]) {
+			return valid, nil
+		}
+
+		var rec record
+		if err := rec.unmarshal(payload[:size]); err != nil {
+			return valid, nil
+		}
+		fn(&rec)
+		valid += recordHeaderSize + int64(size)
+	}
+}
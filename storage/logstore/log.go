@@ -0,0 +1,139 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package logstore
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/zeebo/errs"
+)
+
+const logFileSuffix = ".log"
+
+// logFile is an append-only file that the data of many blobs is packed into.
+// Only the active log of the store is appended to, and every other log is
+// only read from until it is compacted and removed.
+type logFile struct {
+	id   uint64
+	path string
+	file *os.File
+
+	size int64 // bytes written to the log
+	live int64 // bytes of the log that index entries refer to
+
+	refs    int  // open readers and writes
+	writers int  // writes to reserved space that are not done yet
+	removed bool // remove the file once the last reader is closed
+}
+
+// logFileName returns the name of the log with the id.
+func logFileName(id uint64) string {
+	return fmt.Sprintf("%016x%s", id, logFileSuffix)
+}
+
+// parseLogFileName returns the id of the log with the file name.
+func parseLogFileName(name string) (id uint64, ok bool) {
+	if !strings.HasSuffix(name, logFileSuffix) {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(strings.TrimSuffix(name, logFileSuffix), 16, 64)
+	return id, err == nil
+}
+
+// openLogFile opens or creates the log with the id in dir.
+func openLogFile(dir string, id uint64) (*logFile, error) {
+	path := filepath.Join(dir, logFileName(id))
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, filePermission)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, errs.Combine(err, file.Close())
+	}
+	return &logFile{
+		id:   id,
+		path: path,
+		file: file,
+		size: stat.Size(),
+	}, nil
+}
+
+// dead returns the number of bytes in the log that no entry refers to.
+func (log *logFile) dead() int64 { return log.size - log.live }
+
+// reserve reserves length bytes at the end of the log for a blob that is
+// then written with write, and references the log until the write is done.
+// It must be called with the mutex of the store held.
+func (log *logFile) reserve(length int64) (offset int64) {
+	offset = log.size
+	log.size += length
+	log.refs++
+	log.writers++
+	return offset
+}
+
+// write writes length bytes from r at the reserved offset of the log and
+// syncs it. It is called without the mutex of the store held, so that blobs
+// are written to the log concurrently. The space of a failed write is never
+// referred to by an entry, so it is reclaimed when the log is compacted.
+func (log *logFile) write(r io.Reader, offset, length int64) error {
+	n, err := io.Copy(&offsetWriter{w: log.file, offset: offset}, io.LimitReader(r, length))
+	if err == nil && n != length {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return err
+	}
+	return log.file.Sync()
+}
+
+// finishWrite drops the reference of a write to the log. It must be called
+// with the mutex of the store held.
+func (log *logFile) finishWrite(closed bool) error {
+	log.writers--
+	return log.release(closed)
+}
+
+// offsetWriter writes to w sequentially starting at offset.
+type offsetWriter struct {
+	w      io.WriterAt
+	offset int64
+}
+
+func (o *offsetWriter) Write(p []byte) (int, error) {
+	n, err := o.w.WriteAt(p, o.offset)
+	o.offset += int64(n)
+	return n, err
+}
+
+// remove removes the log file once it is not being read anymore.
+func (log *logFile) remove() error {
+	log.removed = true
+	if log.refs > 0 {
+		return nil
+	}
+	return errs.Combine(log.file.Close(), os.Remove(log.path))
+}
+
+// release drops the reference of a reader or a write to the log. The last reference
+// closes the file if the store was closed, and removes it if the log was
+// removed.
+func (log *logFile) release(closed bool) error {
+	log.refs--
+	switch {
+	case log.refs > 0:
+		return nil
+	case log.removed:
+		return errs.Combine(log.file.Close(), os.Remove(log.path))
+	case closed:
+		return log.file.Close()
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package logstore
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+)
+
+// MigrateStats are the totals of a migration.
+type MigrateStats struct {
+	Namespaces int64
+	Blobs      int64
+	Bytes      int64
+	Trashed    int64
+}
+
+// Migrate moves every blob from one blob store to another, in either
+// direction between a filestore and a logstore. The blobs keep their storage
+// format version and creation time, and trashed blobs are trashed again in
+// the destination, which restarts their time in the trash. Every blob is
+// deleted from the source once it is committed to the destination, so an
+// interrupted migration continues where it stopped when it is run again.
+//
+// Neither store may be used by anything else during the migration.
+func Migrate(ctx context.Context, log *zap.Logger, from, to storage.Blobs) (stats MigrateStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	namespaces, err := from.ListNamespaces(ctx)
+	if err != nil {
+		return stats, Error.Wrap(err)
+	}
+
+	for _, namespace := range namespaces {
+		// there is no way to walk the trash, so the trashed blobs are
+		// restored to move them and trashed again afterwards.
+		trashed, err := from.RestoreTrash(ctx, namespace)
+		if err != nil {
+			return stats, Error.Wrap(err)
+		}
+
+		// blobs are deleted while the namespace is walked, which may make
+		// the walk skip some of them, so it is walked until it is empty.
+		var blobs, bytes int64
+		for {
+			var moved int64
+			err = from.WalkNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+				size, err := migrateBlob(ctx, from, to, info)
+				if err != nil {
+					return err
+				}
+				moved++
+				bytes += size
+				return nil
+			})
+			if err != nil {
+				return stats, Error.Wrap(err)
+			}
+			if moved == 0 {
+				break
+			}
+			blobs += moved
+		}
+
+		for _, key := range trashed {
+			if err := to.Trash(ctx, storage.BlobRef{Namespace: namespace, Key: key}); err != nil {
+				return stats, Error.Wrap(err)
+			}
+		}
+
+		if err := from.DeleteNamespace(ctx, namespace); err != nil {
+			return stats, Error.Wrap(err)
+		}
+
+		log.Info("migrated namespace", zap.Binary("namespace", namespace),
+			zap.Int64("pieces", blobs), zap.Int64("bytes", bytes), zap.Int("trashed", len(trashed)))
+		stats.Namespaces++
+		stats.Blobs += blobs
+		stats.Bytes += bytes
+		stats.Trashed += int64(len(trashed))
+	}
+
+	if compactor, ok := from.(Compactor); ok {
+		return stats, Error.Wrap(compactor.Compact(ctx))
+	}
+	return stats, nil
+}
+
+// migrateBlob copies the blob to the destination and deletes it from the source.
+func migrateBlob(ctx context.Context, from, to storage.Blobs, info storage.BlobInfo) (_ int64, err error) {
+	ref, formatVer := info.BlobRef(), info.StorageFormatVersion()
+
+	stat, err := info.Stat(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	reader, err := from.OpenWithStorageFormat(ctx, ref, formatVer)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = errs.Combine(err, reader.Close()) }()
+
+	var writer storage.BlobWriter
+	switch formatVer {
+	case filestore.MaxFormatVersionSupported:
+		writer, err = to.Create(ctx, ref, stat.Size())
+	case filestore.FormatV0:
+		creator, ok := to.(interface {
+			TestCreateV0(ctx context.Context, ref storage.BlobRef) (_ storage.BlobWriter, err error)
+		})
+		if !ok {
+			return 0, errs.New("can't write V0 blobs to this blob store (%T)", to)
+		}
+		writer, err = creator.TestCreateV0(ctx, ref)
+	default:
+		return 0, errs.New("can't migrate V%d blobs", formatVer)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if w, ok := writer.(*blobWriter); ok {
+		w.created = stat.ModTime()
+	}
+
+	size, err := io.Copy(writer, reader)
+	if err != nil {
+		return 0, errs.Combine(err, writer.Cancel(ctx))
+	}
+	if err := writer.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	if _, ok := to.(*blobStore); !ok {
+		if err := setModTime(ctx, to, ref, formatVer, stat.ModTime()); err != nil {
+			return 0, err
+		}
+	}
+
+	return size, from.DeleteWithStorageFormat(ctx, ref, formatVer)
+}
+
+// setModTime sets the modification time of the file of a blob, which is
+// used as its creation time by stores that keep every blob in a file.
+func setModTime(ctx context.Context, blobs storage.Blobs, ref storage.BlobRef, formatVer storage.FormatVersion, modTime time.Time) error {
+	info, err := blobs.StatWithStorageFormat(ctx, ref, formatVer)
+	if err != nil {
+		return err
+	}
+	path, err := info.FullPath(ctx)
+	if err != nil {
+		return err
+	}
+	return os.Chtimes(path, modTime, modTime)
+}
@@ -0,0 +1,744 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package logstore implements a blob store that packs blobs into large
+// append-only log files instead of storing every blob in a file of its own.
+package logstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+)
+
+var (
+	// Error is the default logstore error class.
+	Error = errs.Class("logstore error")
+
+	mon = monkit.Package()
+
+	_ storage.Blobs = (*blobStore)(nil)
+)
+
+const (
+	logsDirName   = "logs"
+	indexFileName = "index"
+
+	filePermission = 0600
+	dirPermission  = 0700
+
+	// anyFormat matches the blobs of every storage format version.
+	anyFormat storage.FormatVersion = -1
+)
+
+// Config is configuration for the log structured blob store.
+type Config struct {
+	WriteBufferSize     memory.Size   `help:"in-memory buffer for uploads" default:"128KiB"`
+	MaxLogSize          memory.Size   `help:"size at which a new log file is started" default:"1GiB"`
+	CompactionThreshold float64       `help:"fraction of a log file that must be deleted data before the log is compacted" default:"0.25"`
+	CompactionInterval  time.Duration `help:"how frequently log files are checked for compaction" default:"1h0m0s"`
+}
+
+// DefaultConfig is the default value for Config.
+var DefaultConfig = Config{
+	WriteBufferSize:     128 * memory.KiB,
+	MaxLogSize:          memory.GiB,
+	CompactionThreshold: 0.25,
+	CompactionInterval:  time.Hour,
+}
+
+// blobStore implements a blob store that appends blobs to log files and
+// keeps an index of where every blob is in memory.
+type blobStore struct {
+	log    *zap.Logger
+	dir    *filestore.Dir
+	config Config
+	now    func() time.Time
+
+	mu      sync.Mutex
+	closed  bool
+	index   *os.File
+	size    int64 // size of the index journal
+	records int64 // records in the index journal
+	blobs   map[string]map[string]entry
+	logs    map[uint64]*logFile
+	active  *logFile
+
+	// indexMu is held for reading while the index is synced, and for
+	// writing while the index file is closed or replaced.
+	indexMu sync.RWMutex
+}
+
+// New opens the log structured blob store in the directory, creating it if
+// it does not exist.
+func New(log *zap.Logger, dir *filestore.Dir, config Config) (storage.Blobs, error) {
+	store := &blobStore{
+		log:    log,
+		dir:    dir,
+		config: config,
+		now:    time.Now,
+		blobs:  make(map[string]map[string]entry),
+		logs:   make(map[uint64]*logFile),
+	}
+	if err := store.load(); err != nil {
+		return nil, Error.Wrap(errs.Combine(err, store.closeFiles()))
+	}
+	return store, nil
+}
+
+// NewAt opens the log structured blob store in the specified directory.
+func NewAt(log *zap.Logger, path string, config Config) (storage.Blobs, error) {
+	dir, err := filestore.NewDir(log, path)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return New(log, dir, config)
+}
+
+// InUse returns true if the directory contains a log structured blob store.
+func InUse(path string) (bool, error) {
+	_, err := os.Stat(filepath.Join(path, logsDirName, indexFileName))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// logsdir is the sub-directory containing the logs and the index.
+func (store *blobStore) logsdir() string { return filepath.Join(store.dir.Path(), logsDirName) }
+
+// load opens the logs and replays the index.
+func (store *blobStore) load() (err error) {
+	if err := os.MkdirAll(store.logsdir(), dirPermission); err != nil {
+		return err
+	}
+
+	infos, err := ioutil.ReadDir(store.logsdir())
+	if err != nil {
+		return err
+	}
+	for _, info := range infos {
+		id, ok := parseLogFileName(info.Name())
+		if !ok {
+			continue
+		}
+		logFile, err := openLogFile(store.logsdir(), id)
+		if err != nil {
+			return err
+		}
+		store.logs[id] = logFile
+		if store.active == nil || id > store.active.id {
+			store.active = logFile
+		}
+	}
+	if store.active == nil {
+		if store.active, err = openLogFile(store.logsdir(), 1); err != nil {
+			return err
+		}
+		store.logs[store.active.id] = store.active
+	}
+
+	store.index, err = os.OpenFile(filepath.Join(store.logsdir(), indexFileName), os.O_RDWR|os.O_CREATE, filePermission)
+	if err != nil {
+		return err
+	}
+	store.size, err = readRecords(store.index, store.update)
+	if err != nil {
+		return err
+	}
+	if stat, err := store.index.Stat(); err != nil {
+		return err
+	} else if stat.Size() > store.size {
+		// the end of the index was not completely written, which means that
+		// the blobs it describes were not committed either.
+		store.log.Warn("dropping damaged end of the piece index",
+			zap.Int64("offset", store.size), zap.Int64("bytes", stat.Size()-store.size))
+		if err := store.index.Truncate(store.size); err != nil {
+			return err
+		}
+	}
+	if _, err := store.index.Seek(store.size, io.SeekStart); err != nil {
+		return err
+	}
+
+	// the data of a blob is synced before its entry is written, so every
+	// entry should refer to data that exists.
+	for namespace, keys := range store.blobs {
+		for key, e := range keys {
+			if logFile := store.logs[e.log]; logFile != nil && e.offset+e.length <= logFile.size {
+				continue
+			}
+			store.log.Warn("dropping piece without data",
+				zap.Binary("namespace", []byte(namespace)), zap.Binary("key", []byte(key)), zap.Uint64("log", e.log))
+			store.unref(e)
+			delete(keys, key)
+		}
+		if len(keys) == 0 {
+			delete(store.blobs, namespace)
+		}
+	}
+
+	// logs without live data are left behind when compaction is interrupted.
+	for id, logFile := range store.logs {
+		if logFile != store.active && logFile.live == 0 {
+			delete(store.logs, id)
+			if err := logFile.remove(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Close closes the store.
+func (store *blobStore) Close() error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if store.closed {
+		return nil
+	}
+	store.closed = true
+	return Error.Wrap(store.closeFiles())
+}
+
+// closeFiles closes the index and every log that is not being read.
+func (store *blobStore) closeFiles() error {
+	var group errs.Group
+	if store.index != nil {
+		store.indexMu.Lock()
+		group.Add(store.index.Close())
+		store.indexMu.Unlock()
+	}
+	for _, logFile := range store.logs {
+		if logFile.refs == 0 {
+			group.Add(logFile.file.Close())
+		}
+	}
+	return group.Err()
+}
+
+// update applies the record to the in-memory index.
+func (store *blobStore) update(rec *record) {
+	namespace, key := string(rec.namespace), string(rec.key)
+	switch rec.op {
+	case opPut:
+		keys := store.blobs[namespace]
+		if keys == nil {
+			keys = make(map[string]entry)
+			store.blobs[namespace] = keys
+		}
+		if old, ok := keys[key]; ok {
+			store.unref(old)
+		}
+		keys[key] = rec.entry
+		if logFile := store.logs[rec.entry.log]; logFile != nil {
+			logFile.live += rec.entry.length
+		}
+	case opDelete:
+		keys := store.blobs[namespace]
+		if old, ok := keys[key]; ok {
+			store.unref(old)
+			delete(keys, key)
+		}
+		if keys != nil && len(keys) == 0 {
+			delete(store.blobs, namespace)
+		}
+	case opDeleteNamespace:
+		for _, old := range store.blobs[namespace] {
+			store.unref(old)
+		}
+		delete(store.blobs, namespace)
+	}
+	store.records++
+}
+
+// unref removes the data of the entry from the live data of its log.
+func (store *blobStore) unref(e entry) {
+	if logFile := store.logs[e.log]; logFile != nil {
+		logFile.live -= e.length
+	}
+}
+
+// write appends the records to the index journal and applies them. It must
+// be called with the mutex held, and the journal must be synced with
+// syncIndex once the mutex is released.
+func (store *blobStore) write(records ...record) (err error) {
+	if len(records) == 0 {
+		return nil
+	}
+
+	var buf []byte
+	for i := range records {
+		if buf, err = records[i].marshal(buf); err != nil {
+			return err
+		}
+	}
+
+	if _, err := store.index.Write(buf); err != nil {
+		// the partially written records are cut off so that later records
+		// are not lost when the index is replayed.
+		_, seekErr := store.index.Seek(store.size, io.SeekStart)
+		return errs.Combine(err, store.index.Truncate(store.size), seekErr)
+	}
+	store.size += int64(len(buf))
+
+	for i := range records {
+		store.update(&records[i])
+	}
+	return nil
+}
+
+// syncIndex syncs the index journal. It is called without the mutex held, so
+// that the store can be used while the journal is synced.
+func (store *blobStore) syncIndex() error {
+	store.indexMu.RLock()
+	defer store.indexMu.RUnlock()
+
+	return store.index.Sync()
+}
+
+// journal calls fn with the mutex held and syncs the records that fn wrote
+// to the index journal once the mutex is released.
+func (store *blobStore) journal(fn func() error) error {
+	store.mu.Lock()
+	size := store.size
+	err := fn()
+	written := store.size != size
+	store.mu.Unlock()
+
+	if err != nil || !written {
+		return err
+	}
+	return store.syncIndex()
+}
+
+// lookup returns the entry of the blob if it is not in the trash and has the
+// storage format version.
+func (store *blobStore) lookup(ref storage.BlobRef, formatVer storage.FormatVersion) (entry, bool) {
+	e, ok := store.blobs[string(ref.Namespace)][string(ref.Key)]
+	if !ok || e.isTrashed() || (formatVer != anyFormat && e.format != formatVer) {
+		return entry{}, false
+	}
+	return e, true
+}
+
+// notExist returns an error that os.IsNotExist recognizes for the blob.
+func notExist(op string, ref storage.BlobRef) error {
+	return &os.PathError{
+		Op:   op,
+		Path: fmt.Sprintf("%x/%x", ref.Namespace, ref.Key),
+		Err:  os.ErrNotExist,
+	}
+}
+
+// Open loads blob with the specified hash.
+func (store *blobStore) Open(ctx context.Context, ref storage.BlobRef) (_ storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.open(ref, anyFormat)
+}
+
+// OpenWithStorageFormat loads the already-located blob, avoiding the potential need to check multiple
+// storage formats to find the blob.
+func (store *blobStore) OpenWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (_ storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.open(ref, formatVer)
+}
+
+func (store *blobStore) open(ref storage.BlobRef, formatVer storage.FormatVersion) (_ storage.BlobReader, err error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if store.closed {
+		return nil, Error.New("closed")
+	}
+	e, ok := store.lookup(ref, formatVer)
+	if !ok {
+		return nil, notExist("open", ref)
+	}
+	logFile := store.logs[e.log]
+	logFile.refs++
+	return newBlobReader(store, logFile, e), nil
+}
+
+// release drops the reference of a reader to the log.
+func (store *blobStore) release(logFile *logFile) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	return logFile.release(store.closed)
+}
+
+// Stat looks up the index entry of the blob.
+func (store *blobStore) Stat(ctx context.Context, ref storage.BlobRef) (_ storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+	info, err := store.stat(ref, anyFormat)
+	return info, Error.Wrap(err)
+}
+
+// StatWithStorageFormat looks up the index entry of the blob with the given storage format version.
+func (store *blobStore) StatWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (_ storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+	info, err := store.stat(ref, formatVer)
+	return info, Error.Wrap(err)
+}
+
+func (store *blobStore) stat(ref storage.BlobRef, formatVer storage.FormatVersion) (storage.BlobInfo, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	e, ok := store.lookup(ref, formatVer)
+	if !ok {
+		return nil, notExist("stat", ref)
+	}
+	return store.blobInfo(ref, e), nil
+}
+
+func (store *blobStore) blobInfo(ref storage.BlobRef, e entry) storage.BlobInfo {
+	return &blobInfo{
+		ref:   ref,
+		path:  filepath.Join(store.logsdir(), logFileName(e.log)),
+		entry: e,
+	}
+}
+
+// Delete deletes blobs with the specified ref.
+//
+// It doesn't return an error if the blob isn't found. The space of the blob
+// is reclaimed when its log is compacted.
+func (store *blobStore) Delete(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return Error.Wrap(store.delete(ref, anyFormat))
+}
+
+// DeleteWithStorageFormat deletes blobs with the specified ref and storage format version.
+func (store *blobStore) DeleteWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return Error.Wrap(store.delete(ref, formatVer))
+}
+
+func (store *blobStore) delete(ref storage.BlobRef, formatVer storage.FormatVersion) error {
+	return store.journal(func() error {
+		if _, ok := store.lookup(ref, formatVer); !ok {
+			return nil
+		}
+		return store.write(record{op: opDelete, namespace: ref.Namespace, key: ref.Key})
+	})
+}
+
+// DeleteNamespace deletes every blob of a specific satellite, used after successful GE only.
+func (store *blobStore) DeleteNamespace(ctx context.Context, ref []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return Error.Wrap(store.journal(func() error {
+		if _, ok := store.blobs[string(ref)]; !ok {
+			return nil
+		}
+		return store.write(record{op: opDeleteNamespace, namespace: ref})
+	}))
+}
+
+// Trash marks the blob as trashed.
+func (store *blobStore) Trash(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return Error.Wrap(store.journal(func() error {
+		e, ok := store.lookup(ref, anyFormat)
+		if !ok {
+			return nil
+		}
+		e.trashed = store.now().UnixNano()
+		return store.write(record{op: opPut, namespace: ref.Namespace, key: ref.Key, entry: e})
+	}))
+}
+
+// RestoreTrash unmarks every trashed blob in the namespace.
+func (store *blobStore) RestoreTrash(ctx context.Context, namespace []byte) (keysRestored [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = store.journal(func() error {
+		var records []record
+		for key, e := range store.blobs[string(namespace)] {
+			if !e.isTrashed() {
+				continue
+			}
+			e.trashed = 0
+			records = append(records, record{op: opPut, namespace: namespace, key: []byte(key), entry: e})
+			keysRestored = append(keysRestored, []byte(key))
+		}
+		return store.write(records...)
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return keysRestored, nil
+}
+
+// EmptyTrash deletes the blobs in the namespace that were trashed before trashedBefore.
+func (store *blobStore) EmptyTrash(ctx context.Context, namespace []byte, trashedBefore time.Time) (bytesEmptied int64, keys [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = store.journal(func() error {
+		var records []record
+		for key, e := range store.blobs[string(namespace)] {
+			if !e.isTrashed() || e.trashed >= trashedBefore.UnixNano() {
+				continue
+			}
+			records = append(records, record{op: opDelete, namespace: namespace, key: []byte(key)})
+			keys = append(keys, []byte(key))
+			bytesEmptied += e.length
+		}
+		return store.write(records...)
+	})
+	if err != nil {
+		return 0, nil, Error.Wrap(err)
+	}
+	return bytesEmptied, keys, nil
+}
+
+// Create creates a new blob that can be written.
+// Optionally takes a size argument for performance improvements, -1 is unknown size.
+func (store *blobStore) Create(ctx context.Context, ref storage.BlobRef, size int64) (_ storage.BlobWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
+	file, err := store.dir.CreateTemporaryFile(ctx, size)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return newBlobWriter(ref, store, filestore.MaxFormatVersionSupported, file, store.config.WriteBufferSize.Int()), nil
+}
+
+// TestCreateV0 creates a new V0 blob that can be written. This is ONLY appropriate in test situations
+// and when migrating blobs from another store.
+func (store *blobStore) TestCreateV0(ctx context.Context, ref storage.BlobRef) (_ storage.BlobWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	file, err := store.dir.CreateTemporaryFile(ctx, -1)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return newBlobWriter(ref, store, filestore.FormatV0, file, store.config.WriteBufferSize.Int()), nil
+}
+
+// commit appends the data of the file, up to its current position, to the
+// active log and adds the blob to the index.
+func (store *blobStore) commit(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion, file *os.File, created time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	if created.IsZero() {
+		created = store.now()
+	}
+	_, err = store.appendBlob(ref, entry{
+		format:  formatVer,
+		length:  size,
+		created: created.UnixNano(),
+	}, file, nil)
+	return err
+}
+
+// appendBlob appends the data of the blob to the active log and writes the
+// entry with its location to the index. The mutex is only held while space
+// is reserved in the log and while the entry is written, so that the data of
+// blobs is written and synced concurrently.
+//
+// When valid is not nil, it is called with the mutex held once the data is
+// written, and the entry is only written if it returns true. It may update
+// the entry.
+func (store *blobStore) appendBlob(ref storage.BlobRef, e entry, data io.Reader, valid func(e *entry) bool) (appended bool, err error) {
+	store.mu.Lock()
+	logFile, offset, err := store.reserve(e.length)
+	store.mu.Unlock()
+	if err != nil {
+		return false, err
+	}
+
+	writeErr := logFile.write(data, offset, e.length)
+
+	e.log, e.offset = logFile.id, offset
+	err = store.journal(func() error {
+		if err := errs.Combine(writeErr, logFile.finishWrite(store.closed)); err != nil {
+			return err
+		}
+		if valid != nil && !valid(&e) {
+			return nil
+		}
+		appended = true
+		return store.write(record{op: opPut, namespace: ref.Namespace, key: ref.Key, entry: e})
+	})
+	return appended, err
+}
+
+// reserve reserves length bytes at the end of the active log, starting a new
+// log when the active log would grow too large. It must be called with the
+// mutex held.
+func (store *blobStore) reserve(length int64) (_ *logFile, offset int64, err error) {
+	if store.closed {
+		return nil, 0, Error.New("closed")
+	}
+
+	if store.active.size > 0 && store.active.size+length > store.config.MaxLogSize.Int64() {
+		next, err := openLogFile(store.logsdir(), store.active.id+1)
+		if err != nil {
+			return nil, 0, err
+		}
+		store.logs[next.id] = next
+		store.active = next
+	}
+
+	return store.active, store.active.reserve(length), nil
+}
+
+// SpaceUsedForBlobs adds up the space used in all namespaces for blob storage.
+func (store *blobStore) SpaceUsedForBlobs(ctx context.Context) (space int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for _, keys := range store.blobs {
+		space += spaceUsed(keys, false)
+	}
+	return space, nil
+}
+
+// SpaceUsedForBlobsInNamespace adds up how much is used in the given namespace for blob storage.
+func (store *blobStore) SpaceUsedForBlobsInNamespace(ctx context.Context, namespace []byte) (int64, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	return spaceUsed(store.blobs[string(namespace)], false), nil
+}
+
+// SpaceUsedForTrash returns the total space used by the trash.
+func (store *blobStore) SpaceUsedForTrash(ctx context.Context) (total int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for _, keys := range store.blobs {
+		total += spaceUsed(keys, true)
+	}
+	return total, nil
+}
+
+// spaceUsed adds up the size of the trashed or the not trashed blobs.
+func spaceUsed(keys map[string]entry, trashed bool) (total int64) {
+	for _, e := range keys {
+		if e.isTrashed() == trashed {
+			total += e.length
+		}
+	}
+	return total
+}
+
+// FreeSpace returns how much space left in underlying directory.
+func (store *blobStore) FreeSpace() (int64, error) {
+	info, err := store.dir.Info()
+	if err != nil {
+		return 0, err
+	}
+	return info.AvailableSpace, nil
+}
+
+// CheckWritability tests writability of the storage directory by creating and deleting a file.
+func (store *blobStore) CheckWritability() error {
+	f, err := ioutil.TempFile(store.logsdir(), "write-test")
+	if err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Remove(f.Name())
+}
+
+// ListNamespaces finds all namespace IDs that have blobs, including trashed blobs.
+func (store *blobStore) ListNamespaces(ctx context.Context) (ids [][]byte, err error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for namespace := range store.blobs {
+		ids = append(ids, []byte(namespace))
+	}
+	sort.Slice(ids, func(i, j int) bool { return bytes.Compare(ids[i], ids[j]) < 0 })
+	return ids, nil
+}
+
+// WalkNamespace executes walkFunc for each blob in the given namespace that is not trashed. The blobs
+// are visited in the order that they are stored in the logs. If walkFunc returns a non-nil error,
+// WalkNamespace will stop iterating and return the error immediately. The ctx parameter is intended
+// specifically to allow canceling iteration early.
+func (store *blobStore) WalkNamespace(ctx context.Context, namespace []byte, walkFunc func(storage.BlobInfo) error) (err error) {
+	infos := store.snapshot(namespace)
+	for _, info := range infos {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := walkFunc(info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshot returns the info of every blob in the namespace that is not
+// trashed, ordered by their location in the logs.
+func (store *blobStore) snapshot(namespace []byte) []*blobInfo {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	keys := store.blobs[string(namespace)]
+	infos := make([]*blobInfo, 0, len(keys))
+	for key, e := range keys {
+		if e.isTrashed() {
+			continue
+		}
+		infos = append(infos, store.blobInfo(storage.BlobRef{
+			Namespace: namespace,
+			Key:       []byte(key),
+		}, e).(*blobInfo))
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		a, b := infos[i].entry, infos[j].entry
+		if a.log != b.log {
+			return a.log < b.log
+		}
+		return a.offset < b.offset
+	})
+	return infos
+}
+
+// CreateVerificationFile creates a file to be used for storage directory verification.
+func (store *blobStore) CreateVerificationFile(id storj.NodeID) error {
+	return store.dir.CreateVerificationFile(id)
+}
+
+// VerifyStorageDir verifies that the storage directory is correct by checking for the existence and validity
+// of the verification file.
+func (store *blobStore) VerifyStorageDir(id storj.NodeID) error {
+	return store.dir.Verify(id)
+}
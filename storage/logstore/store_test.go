@@ -0,0 +1,488 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package logstore_test
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"golang.org/x/sync/errgroup"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/logstore"
+)
+
+func writeBlob(ctx *testcontext.Context, t *testing.T, store storage.Blobs, ref storage.BlobRef, data []byte) {
+	writer, err := store.Create(ctx, ref, -1)
+	require.NoError(t, err)
+	_, err = writer.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, writer.Commit(ctx))
+}
+
+func readBlob(ctx *testcontext.Context, t *testing.T, store storage.Blobs, ref storage.BlobRef) []byte {
+	reader, err := store.Open(ctx, ref)
+	require.NoError(t, err)
+	defer ctx.Check(reader.Close)
+	data, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	return data
+}
+
+func TestStoreLoad(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+	dir := ctx.Dir("store")
+
+	store, err := logstore.NewAt(log, dir, logstore.DefaultConfig)
+	require.NoError(t, err)
+
+	namespace := testrand.Bytes(32)
+	blobs := map[string][]byte{}
+	for i := 0; i < 16; i++ {
+		ref := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+		data := testrand.BytesInt(testrand.Intn(8<<10) + 1)
+		writeBlob(ctx, t, store, ref, data)
+		blobs[string(ref.Key)] = data
+	}
+
+	// the piece header is written at the start after the data, like the
+	// piece writer does.
+	headerRef := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	writer, err := store.Create(ctx, headerRef, 4<<10)
+	require.NoError(t, err)
+	_, err = writer.Seek(512, io.SeekStart)
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("data"))
+	require.NoError(t, err)
+	size, err := writer.Size()
+	require.NoError(t, err)
+	_, err = writer.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("header"))
+	require.NoError(t, err)
+	_, err = writer.Seek(size, io.SeekStart)
+	require.NoError(t, err)
+	require.NoError(t, writer.Commit(ctx))
+	require.Error(t, writer.Commit(ctx))
+	require.NoError(t, writer.Cancel(ctx))
+
+	expectedHeaderBlob := make([]byte, 516)
+	copy(expectedHeaderBlob, "header")
+	copy(expectedHeaderBlob[512:], "data")
+	blobs[string(headerRef.Key)] = expectedHeaderBlob
+
+	// canceled blobs are not stored.
+	canceled := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	writer, err = store.Create(ctx, canceled, -1)
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("canceled"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Cancel(ctx))
+
+	check := func(store storage.Blobs) {
+		for key, data := range blobs {
+			ref := storage.BlobRef{Namespace: namespace, Key: []byte(key)}
+			require.Equal(t, data, readBlob(ctx, t, store, ref))
+
+			info, err := store.Stat(ctx, ref)
+			require.NoError(t, err)
+			require.Equal(t, filestore.FormatV1, info.StorageFormatVersion())
+			stat, err := info.Stat(ctx)
+			require.NoError(t, err)
+			require.EqualValues(t, len(data), stat.Size())
+		}
+
+		_, err := store.Open(ctx, canceled)
+		require.True(t, os.IsNotExist(err))
+
+		var walked int
+		require.NoError(t, store.WalkNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+			walked++
+			require.Contains(t, blobs, string(info.BlobRef().Key))
+			return nil
+		}))
+		require.Equal(t, len(blobs), walked)
+
+		namespaces, err := store.ListNamespaces(ctx)
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{namespace}, namespaces)
+	}
+
+	check(store)
+	require.NoError(t, store.Close())
+
+	store, err = logstore.NewAt(log, dir, logstore.DefaultConfig)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+	check(store)
+
+	// random access works like it does for files.
+	reader, err := store.Open(ctx, headerRef)
+	require.NoError(t, err)
+	defer ctx.Check(reader.Close)
+	buf := make([]byte, 4)
+	_, err = reader.ReadAt(buf, 512)
+	require.NoError(t, err)
+	require.Equal(t, "data", string(buf))
+	readerSize, err := reader.Size()
+	require.NoError(t, err)
+	require.EqualValues(t, 516, readerSize)
+}
+
+func TestTrashAndRestore(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+	dir := ctx.Dir("store")
+
+	store, err := logstore.NewAt(log, dir, logstore.DefaultConfig)
+	require.NoError(t, err)
+
+	namespace := testrand.Bytes(32)
+	kept := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	trashed := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	writeBlob(ctx, t, store, kept, testrand.BytesInt(1000))
+	writeBlob(ctx, t, store, trashed, testrand.BytesInt(300))
+
+	require.NoError(t, store.Trash(ctx, trashed))
+	// trashing again or trashing a missing blob is not an error.
+	require.NoError(t, store.Trash(ctx, trashed))
+	require.NoError(t, store.Trash(ctx, storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}))
+
+	_, err = store.Open(ctx, trashed)
+	require.True(t, os.IsNotExist(err))
+
+	used, err := store.SpaceUsedForBlobs(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 1000, used)
+	trash, err := store.SpaceUsedForTrash(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 300, trash)
+
+	// the trash survives reopening the store.
+	require.NoError(t, store.Close())
+	store, err = logstore.NewAt(log, dir, logstore.DefaultConfig)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+
+	restored, err := store.RestoreTrash(ctx, namespace)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{trashed.Key}, restored)
+	readBlob(ctx, t, store, trashed)
+
+	require.NoError(t, store.Trash(ctx, trashed))
+
+	// blobs are only emptied once they were trashed before the given time.
+	emptied, keys, err := store.EmptyTrash(ctx, namespace, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, emptied)
+	require.Empty(t, keys)
+
+	emptied, keys, err = store.EmptyTrash(ctx, namespace, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.EqualValues(t, 300, emptied)
+	require.Equal(t, [][]byte{trashed.Key}, keys)
+
+	restored, err = store.RestoreTrash(ctx, namespace)
+	require.NoError(t, err)
+	require.Empty(t, restored)
+	_, err = store.Stat(ctx, trashed)
+	require.Error(t, err)
+}
+
+func TestCompaction(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+	dir := ctx.Dir("store")
+	config := logstore.DefaultConfig
+	config.MaxLogSize = 4 * memory.KiB
+
+	store, err := logstore.NewAt(log, dir, config)
+	require.NoError(t, err)
+
+	logFiles := func() int {
+		matches, err := filepath.Glob(filepath.Join(dir, "logs", "*.log"))
+		require.NoError(t, err)
+		return len(matches)
+	}
+
+	namespace := testrand.Bytes(32)
+	var refs []storage.BlobRef
+	blobs := map[string][]byte{}
+	for i := 0; i < 32; i++ {
+		ref := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+		data := testrand.BytesInt(1 << 10)
+		writeBlob(ctx, t, store, ref, data)
+		refs = append(refs, ref)
+		blobs[string(ref.Key)] = data
+	}
+	require.Equal(t, 8, logFiles())
+
+	// delete three out of four blobs and trash some of the rest.
+	for i, ref := range refs {
+		switch {
+		case i%4 != 0:
+			require.NoError(t, store.Delete(ctx, ref))
+			delete(blobs, string(ref.Key))
+		case i%8 == 0:
+			require.NoError(t, store.Trash(ctx, ref))
+		}
+	}
+
+	// a reader keeps its log readable while the log is compacted.
+	reader, err := store.Open(ctx, refs[4])
+	require.NoError(t, err)
+
+	require.NoError(t, store.(logstore.Compactor).Compact(ctx))
+	require.Less(t, logFiles(), 8)
+
+	data, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, blobs[string(refs[4].Key)], data)
+	require.NoError(t, reader.Close())
+
+	check := func(store storage.Blobs) {
+		used, err := store.SpaceUsedForBlobs(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 4<<10, used)
+		trash, err := store.SpaceUsedForTrash(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 4<<10, trash)
+
+		for i, ref := range refs {
+			if _, ok := blobs[string(ref.Key)]; !ok || i%8 == 0 {
+				_, err := store.Open(ctx, ref)
+				require.True(t, os.IsNotExist(err))
+				continue
+			}
+			require.Equal(t, blobs[string(ref.Key)], readBlob(ctx, t, store, ref))
+		}
+	}
+	check(store)
+
+	require.NoError(t, store.Close())
+	store, err = logstore.NewAt(log, dir, config)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+	check(store)
+
+	restored, err := store.RestoreTrash(ctx, namespace)
+	require.NoError(t, err)
+	require.Len(t, restored, 4)
+	for _, key := range restored {
+		require.Equal(t, blobs[string(key)], readBlob(ctx, t, store, storage.BlobRef{Namespace: namespace, Key: key}))
+	}
+}
+
+func TestConcurrentCompaction(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+	dir := ctx.Dir("store")
+	config := logstore.DefaultConfig
+	config.MaxLogSize = 4 * memory.KiB
+
+	store, err := logstore.NewAt(log, dir, config)
+	require.NoError(t, err)
+
+	namespace := testrand.Bytes(32)
+
+	// blobs are written, replaced and deleted concurrently while the store
+	// is compacted.
+	const writers = 8
+	blobs := make([]map[string][]byte, writers)
+	var group errgroup.Group
+	for i := range blobs {
+		written := map[string][]byte{}
+		blobs[i] = written
+		group.Go(func() error {
+			var refs []storage.BlobRef
+			for k := 0; k < 32; k++ {
+				ref := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+				if k%3 == 2 {
+					ref = refs[len(refs)-1]
+				}
+				data := testrand.BytesInt(testrand.Intn(1<<10) + 1)
+				writeBlob(ctx, t, store, ref, data)
+				refs = append(refs, ref)
+				written[string(ref.Key)] = data
+
+				if k%4 == 3 {
+					if err := store.Delete(ctx, refs[0]); err != nil {
+						return err
+					}
+					delete(written, string(refs[0].Key))
+					refs = refs[1:]
+				}
+			}
+			return nil
+		})
+	}
+	group.Go(func() error {
+		for i := 0; i < 16; i++ {
+			if err := store.(logstore.Compactor).Compact(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, group.Wait())
+	require.NoError(t, store.(logstore.Compactor).Compact(ctx))
+
+	check := func(store storage.Blobs) {
+		var total int64
+		for _, written := range blobs {
+			for key, data := range written {
+				require.Equal(t, data, readBlob(ctx, t, store, storage.BlobRef{Namespace: namespace, Key: []byte(key)}))
+				total += int64(len(data))
+			}
+		}
+		used, err := store.SpaceUsedForBlobs(ctx)
+		require.NoError(t, err)
+		require.Equal(t, total, used)
+	}
+	check(store)
+
+	require.NoError(t, store.Close())
+	store, err = logstore.NewAt(log, dir, config)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+	check(store)
+}
+
+func TestDamagedIndex(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+	dir := ctx.Dir("store")
+
+	store, err := logstore.NewAt(log, dir, logstore.DefaultConfig)
+	require.NoError(t, err)
+
+	ref := storage.BlobRef{Namespace: testrand.Bytes(32), Key: testrand.Bytes(32)}
+	data := testrand.BytesInt(100)
+	writeBlob(ctx, t, store, ref, data)
+	require.NoError(t, store.Close())
+
+	// a partially written record at the end of the index is dropped.
+	index, err := os.OpenFile(filepath.Join(dir, "logs", "index"), os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = index.Write([]byte{42, 0, 0, 0, 1, 2})
+	require.NoError(t, err)
+	require.NoError(t, index.Close())
+
+	store, err = logstore.NewAt(log, dir, logstore.DefaultConfig)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+	require.Equal(t, data, readBlob(ctx, t, store, ref))
+
+	other := storage.BlobRef{Namespace: ref.Namespace, Key: testrand.Bytes(32)}
+	writeBlob(ctx, t, store, other, data)
+	require.Equal(t, data, readBlob(ctx, t, store, other))
+}
+
+func TestMigrate(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+	dir := ctx.Dir("store")
+
+	files, err := filestore.NewAt(log, dir, filestore.DefaultConfig)
+	require.NoError(t, err)
+	defer ctx.Check(files.Close)
+
+	logs, err := logstore.NewAt(log, dir, logstore.DefaultConfig)
+	require.NoError(t, err)
+	defer ctx.Check(logs.Close)
+
+	namespace := testrand.Bytes(32)
+	blobs := map[string][]byte{}
+	for i := 0; i < 8; i++ {
+		ref := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+		data := testrand.BytesInt(testrand.Intn(4<<10) + 1)
+		writeBlob(ctx, t, files, ref, data)
+		blobs[string(ref.Key)] = data
+	}
+
+	v0 := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	writer, err := files.(interface {
+		TestCreateV0(ctx context.Context, ref storage.BlobRef) (storage.BlobWriter, error)
+	}).TestCreateV0(ctx, v0)
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("v0 piece"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Commit(ctx))
+	blobs[string(v0.Key)] = []byte("v0 piece")
+
+	trashed := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	writeBlob(ctx, t, files, trashed, []byte("trashed piece"))
+	require.NoError(t, files.Trash(ctx, trashed))
+
+	// the creation time of the pieces is kept.
+	created := time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+	info, err := files.Stat(ctx, v0)
+	require.NoError(t, err)
+	path, err := info.FullPath(ctx)
+	require.NoError(t, err)
+	require.NoError(t, os.Chtimes(path, created, created))
+
+	check := func(from, to storage.Blobs) {
+		stats, err := logstore.Migrate(ctx, log, from, to)
+		require.NoError(t, err)
+		require.Equal(t, logstore.MigrateStats{
+			Namespaces: 1,
+			Blobs:      10,
+			Bytes:      stats.Bytes,
+			Trashed:    1,
+		}, stats)
+
+		namespaces, err := from.ListNamespaces(ctx)
+		require.NoError(t, err)
+		require.Empty(t, namespaces)
+
+		for key, data := range blobs {
+			require.Equal(t, data, readBlob(ctx, t, to, storage.BlobRef{Namespace: namespace, Key: []byte(key)}))
+		}
+
+		info, err := to.Stat(ctx, v0)
+		require.NoError(t, err)
+		require.Equal(t, filestore.FormatV0, info.StorageFormatVersion())
+		stat, err := info.Stat(ctx)
+		require.NoError(t, err)
+		require.True(t, created.Equal(stat.ModTime()))
+
+		_, err = to.Open(ctx, trashed)
+		require.True(t, os.IsNotExist(err))
+		trash, err := to.SpaceUsedForTrash(ctx)
+		require.NoError(t, err)
+		require.NotZero(t, trash)
+	}
+
+	check(files, logs)
+	check(logs, files)
+
+	restored, err := files.RestoreTrash(ctx, namespace)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{trashed.Key}, restored)
+}
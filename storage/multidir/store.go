@@ -0,0 +1,630 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package multidir implements a blob store that spreads blobs over the blob
+// stores of several storage directories, usually on different disks.
+package multidir
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/logstore"
+)
+
+var (
+	// Error is the default multidir error class.
+	Error = errs.Class("multidir error")
+
+	mon = monkit.Package()
+
+	_ storage.Blobs = (*Store)(nil)
+)
+
+const (
+	// disksFileName is the file in the first storage directory that lists
+	// the other storage directories that were initialized.
+	disksFileName = "storage-dirs"
+
+	filePermission = 0600
+)
+
+// Placement decides in which storage directory new blobs are stored.
+type Placement string
+
+const (
+	// MostFree stores new blobs in the directory with the most free space.
+	MostFree Placement = "most-free"
+	// RoundRobin stores new blobs in each directory in turn.
+	RoundRobin Placement = "round-robin"
+)
+
+// Disk is a storage directory and the blob store in it.
+type Disk struct {
+	Path  string
+	Dir   *filestore.Dir
+	Blobs storage.Blobs
+	// Err is the error that the blob store could not be opened with, in which
+	// case the directory is never used.
+	Err error
+}
+
+// disk is the state of a storage directory.
+type disk struct {
+	Disk
+
+	initialized bool
+	unreadable  error
+	unwritable  error
+}
+
+func (d *disk) readable() bool { return d.Err == nil && d.initialized && d.unreadable == nil }
+func (d *disk) writable() bool { return d.readable() && d.unwritable == nil }
+
+// Store is a blob store that spans several storage directories.
+//
+// A directory that fails verification is not used until it passes it again,
+// so the blobs in it are lost to the storage node in the meantime, and a
+// directory that is not writable is only read from. The first directory
+// keeps the list of the other directories that were initialized with a
+// verification file; a directory that is not in the list is initialized the
+// first time it is verified, and a directory in the list that lost its
+// verification file, for example because its disk is no longer mounted,
+// fails verification instead of being initialized again.
+type Store struct {
+	log       *zap.Logger
+	placement Placement
+
+	mu    sync.Mutex
+	disks []*disk
+	next  int
+}
+
+// New creates a blob store that spans the disks. The first disk is the
+// primary one, which is initialized by the storage node setup.
+func New(log *zap.Logger, disks []Disk, placement Placement) (*Store, error) {
+	switch placement {
+	case MostFree, RoundRobin:
+	case "":
+		placement = MostFree
+	default:
+		return nil, Error.New("unknown placement %q", placement)
+	}
+	if len(disks) == 0 {
+		return nil, Error.New("no storage directories")
+	}
+
+	store := &Store{
+		log:       log,
+		placement: placement,
+	}
+
+	initialized, err := readDisksFile(filepath.Join(disks[0].Path, disksFileName))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	for i, d := range disks {
+		store.disks = append(store.disks, &disk{
+			Disk:        d,
+			initialized: i == 0 || initialized[cleanPath(d.Path)],
+		})
+		if d.Err != nil {
+			log.Error("unable to open storage directory; its pieces are unavailable",
+				zap.String("path", d.Path), zap.Error(d.Err))
+			mon.Meter("storage_dir_failed").Mark(1)
+		}
+	}
+	return store, nil
+}
+
+// cleanPath returns the absolute form of path, or path itself if it has none.
+func cleanPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+	return abs
+}
+
+// readDisksFile reads the set of directories that were initialized.
+func readDisksFile(path string) (map[string]bool, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]bool{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	paths := map[string]bool{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			paths[line] = true
+		}
+	}
+	return paths, scanner.Err()
+}
+
+// writeDisksFile records the directories that were initialized. It must be
+// called with the mutex held.
+func (store *Store) writeDisksFile() error {
+	var buf bytes.Buffer
+	for _, d := range store.disks[1:] {
+		if d.initialized {
+			buf.WriteString(cleanPath(d.Path))
+			buf.WriteByte('\n')
+		}
+	}
+
+	path := filepath.Join(store.disks[0].Path, disksFileName)
+	if err := ioutil.WriteFile(path+".tmp", buf.Bytes(), filePermission); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// readable returns the blob stores that can be read from.
+func (store *Store) readable() (blobs []storage.Blobs) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for _, d := range store.disks {
+		if d.readable() {
+			blobs = append(blobs, d.Blobs)
+		}
+	}
+	return blobs
+}
+
+// writable returns the disks that can be written to.
+func (store *Store) writable() (disks []*disk) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for _, d := range store.disks {
+		if d.writable() {
+			disks = append(disks, d)
+		}
+	}
+	return disks
+}
+
+// place selects the blob store that a new blob is stored in.
+func (store *Store) place() (storage.Blobs, error) {
+	disks := store.writable()
+	if len(disks) == 0 {
+		return nil, Error.New("no storage directory is writable")
+	}
+
+	if store.placement == RoundRobin {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		store.next++
+		return disks[store.next%len(disks)].Blobs, nil
+	}
+
+	var best storage.Blobs
+	var bestFree int64
+	var group errs.Group
+	for _, d := range disks {
+		free, err := d.Blobs.FreeSpace()
+		if err != nil {
+			group.Add(err)
+			continue
+		}
+		if best == nil || free > bestFree {
+			best, bestFree = d.Blobs, free
+		}
+	}
+	if best == nil {
+		return nil, Error.Wrap(group.Err())
+	}
+	return best, nil
+}
+
+// Create creates a new blob in the storage directory chosen by the placement.
+func (store *Store) Create(ctx context.Context, ref storage.BlobRef, size int64) (_ storage.BlobWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	blobs, err := store.place()
+	if err != nil {
+		return nil, err
+	}
+	return blobs.Create(ctx, ref, size)
+}
+
+// TestCreateV0 creates a new V0 blob that can be written. This is only
+// appropriate in test situations.
+func (store *Store) TestCreateV0(ctx context.Context, ref storage.BlobRef) (_ storage.BlobWriter, err error) {
+	blobs, err := store.place()
+	if err != nil {
+		return nil, err
+	}
+	creator, ok := blobs.(interface {
+		TestCreateV0(ctx context.Context, ref storage.BlobRef) (_ storage.BlobWriter, err error)
+	})
+	if !ok {
+		return nil, Error.New("can't create V0 blobs in this blob store (%T)", blobs)
+	}
+	return creator.TestCreateV0(ctx, ref)
+}
+
+// find calls fn with the readable blob stores in turn until it doesn't fail
+// with a not exist error. The not exist error is returned if no blob store
+// has the blob, unless one of them failed with another error.
+func find(blobs []storage.Blobs, fn func(storage.Blobs) error) error {
+	notExist := error(os.ErrNotExist)
+	var failure error
+	for _, b := range blobs {
+		err := fn(b)
+		switch {
+		case err == nil:
+			return nil
+		case os.IsNotExist(errs.Unwrap(err)):
+			notExist = err
+		default:
+			failure = err
+		}
+	}
+	if failure != nil {
+		return failure
+	}
+	return notExist
+}
+
+// Open opens a reader for the blob in the storage directory that has it.
+func (store *Store) Open(ctx context.Context, ref storage.BlobRef) (reader storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = find(store.readable(), func(blobs storage.Blobs) (err error) {
+		reader, err = blobs.Open(ctx, ref)
+		return err
+	})
+	return reader, err
+}
+
+// OpenWithStorageFormat opens a reader for the blob with the storage format
+// in the storage directory that has it.
+func (store *Store) OpenWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (reader storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = find(store.readable(), func(blobs storage.Blobs) (err error) {
+		reader, err = blobs.OpenWithStorageFormat(ctx, ref, formatVer)
+		return err
+	})
+	return reader, err
+}
+
+// Stat looks up the blob in the storage directory that has it.
+func (store *Store) Stat(ctx context.Context, ref storage.BlobRef) (info storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = find(store.readable(), func(blobs storage.Blobs) (err error) {
+		info, err = blobs.Stat(ctx, ref)
+		return err
+	})
+	return info, err
+}
+
+// StatWithStorageFormat looks up the blob with the storage format in the
+// storage directory that has it.
+func (store *Store) StatWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (info storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = find(store.readable(), func(blobs storage.Blobs) (err error) {
+		info, err = blobs.StatWithStorageFormat(ctx, ref, formatVer)
+		return err
+	})
+	return info, err
+}
+
+// each calls fn with every readable blob store and combines the errors.
+func (store *Store) each(fn func(storage.Blobs) error) error {
+	var group errs.Group
+	for _, blobs := range store.readable() {
+		group.Add(fn(blobs))
+	}
+	return group.Err()
+}
+
+// Delete deletes the blob from every storage directory.
+func (store *Store) Delete(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.each(func(blobs storage.Blobs) error {
+		return blobs.Delete(ctx, ref)
+	})
+}
+
+// DeleteWithStorageFormat deletes the blob with the storage format from every
+// storage directory.
+func (store *Store) DeleteWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.each(func(blobs storage.Blobs) error {
+		return blobs.DeleteWithStorageFormat(ctx, ref, formatVer)
+	})
+}
+
+// DeleteNamespace deletes the namespace from every storage directory.
+func (store *Store) DeleteNamespace(ctx context.Context, ref []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.each(func(blobs storage.Blobs) error {
+		return blobs.DeleteNamespace(ctx, ref)
+	})
+}
+
+// Trash moves the blob to the trash in every storage directory.
+func (store *Store) Trash(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.each(func(blobs storage.Blobs) error {
+		return blobs.Trash(ctx, ref)
+	})
+}
+
+// RestoreTrash restores the trash of the namespace in every storage directory.
+func (store *Store) RestoreTrash(ctx context.Context, namespace []byte) (keys [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = store.each(func(blobs storage.Blobs) error {
+		restored, err := blobs.RestoreTrash(ctx, namespace)
+		keys = append(keys, restored...)
+		return err
+	})
+	return keys, err
+}
+
+// EmptyTrash empties the trash of the namespace in every storage directory.
+func (store *Store) EmptyTrash(ctx context.Context, namespace []byte, trashedBefore time.Time) (bytesEmptied int64, keys [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = store.each(func(blobs storage.Blobs) error {
+		emptied, deleted, err := blobs.EmptyTrash(ctx, namespace, trashedBefore)
+		bytesEmptied += emptied
+		keys = append(keys, deleted...)
+		return err
+	})
+	return bytesEmptied, keys, err
+}
+
+// sum adds up fn for every readable blob store.
+func (store *Store) sum(fn func(storage.Blobs) (int64, error)) (total int64, err error) {
+	err = store.each(func(blobs storage.Blobs) error {
+		n, err := fn(blobs)
+		total += n
+		return err
+	})
+	return total, err
+}
+
+// SpaceUsedForTrash returns the space used by the trash of every readable
+// storage directory.
+func (store *Store) SpaceUsedForTrash(ctx context.Context) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.sum(func(blobs storage.Blobs) (int64, error) {
+		return blobs.SpaceUsedForTrash(ctx)
+	})
+}
+
+// SpaceUsedForBlobs returns the space used by the blobs of every readable
+// storage directory.
+func (store *Store) SpaceUsedForBlobs(ctx context.Context) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.sum(func(blobs storage.Blobs) (int64, error) {
+		return blobs.SpaceUsedForBlobs(ctx)
+	})
+}
+
+// SpaceUsedForBlobsInNamespace returns the space used by the blobs of the
+// namespace in every readable storage directory.
+func (store *Store) SpaceUsedForBlobsInNamespace(ctx context.Context, namespace []byte) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.sum(func(blobs storage.Blobs) (int64, error) {
+		return blobs.SpaceUsedForBlobsInNamespace(ctx, namespace)
+	})
+}
+
+// FreeSpace returns the free space of the writable storage directories. The
+// free space of directories on the same disk is only counted once.
+func (store *Store) FreeSpace() (total int64, err error) {
+	seen := map[string]bool{}
+	var group errs.Group
+	for _, d := range store.writable() {
+		if d.Dir != nil {
+			info, err := d.Dir.Info()
+			if err != nil {
+				group.Add(err)
+				continue
+			}
+			if seen[info.ID] {
+				continue
+			}
+			seen[info.ID] = true
+		}
+
+		free, err := d.Blobs.FreeSpace()
+		if err != nil {
+			group.Add(err)
+			continue
+		}
+		total += free
+	}
+	return total, Error.Wrap(group.Err())
+}
+
+// ListNamespaces returns the namespaces of every readable storage directory.
+func (store *Store) ListNamespaces(ctx context.Context) (namespaces [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	seen := map[string]bool{}
+	err = store.each(func(blobs storage.Blobs) error {
+		list, err := blobs.ListNamespaces(ctx)
+		for _, namespace := range list {
+			if !seen[string(namespace)] {
+				seen[string(namespace)] = true
+				namespaces = append(namespaces, namespace)
+			}
+		}
+		return err
+	})
+	return namespaces, err
+}
+
+// WalkNamespace walks the namespace in every readable storage directory in
+// turn.
+func (store *Store) WalkNamespace(ctx context.Context, namespace []byte, walkFunc func(storage.BlobInfo) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for _, blobs := range store.readable() {
+		if err := blobs.WalkNamespace(ctx, namespace, walkFunc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Compact compacts the blob stores that need to be compacted.
+func (store *Store) Compact(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.each(func(blobs storage.Blobs) error {
+		if compactor, ok := blobs.(logstore.Compactor); ok {
+			return compactor.Compact(ctx)
+		}
+		return nil
+	})
+}
+
+// CreateVerificationFile creates the verification file in every storage
+// directory and records them as initialized.
+func (store *Store) CreateVerificationFile(id storj.NodeID) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	var group errs.Group
+	for _, d := range store.disks {
+		if d.Err != nil {
+			group.Add(d.Err)
+			continue
+		}
+		if err := d.Blobs.CreateVerificationFile(id); err != nil {
+			group.Add(err)
+			continue
+		}
+		d.initialized = true
+	}
+	group.Add(store.writeDisksFile())
+	return Error.Wrap(group.Err())
+}
+
+// VerifyStorageDir verifies every storage directory, initializing the ones
+// that weren't yet. The blobs in a directory that fails verification are
+// unavailable until it passes verification again. It only fails when no
+// directory passes verification.
+func (store *Store) VerifyStorageDir(id storj.NodeID) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	var group errs.Group
+	usable := 0
+	for _, d := range store.disks {
+		if d.Err != nil {
+			group.Add(d.Err)
+			continue
+		}
+
+		if !d.initialized {
+			if err := d.Blobs.CreateVerificationFile(id); err != nil {
+				store.log.Error("unable to initialize storage directory",
+					zap.String("path", d.Path), zap.Error(err))
+				group.Add(err)
+				continue
+			}
+			d.initialized = true
+			if err := store.writeDisksFile(); err != nil {
+				store.log.Error("unable to record initialized storage directory",
+					zap.String("path", d.Path), zap.Error(err))
+			}
+			store.log.Info("initialized storage directory", zap.String("path", d.Path))
+		}
+
+		err := d.Blobs.VerifyStorageDir(id)
+		switch {
+		case err != nil && d.unreadable == nil:
+			store.log.Error("storage directory failed verification; its pieces are unavailable",
+				zap.String("path", d.Path), zap.Error(err))
+			mon.Meter("storage_dir_failed").Mark(1)
+		case err == nil && d.unreadable != nil:
+			store.log.Info("storage directory passed verification again", zap.String("path", d.Path))
+		}
+		d.unreadable = err
+		if err != nil {
+			group.Add(err)
+			continue
+		}
+		usable++
+	}
+
+	if usable == 0 {
+		return Error.New("no storage directory passed verification: %v", group.Err())
+	}
+	return nil
+}
+
+// CheckWritability checks every readable storage directory for writability.
+// New blobs are not stored in a directory that is not writable. It only fails
+// when no directory is writable.
+func (store *Store) CheckWritability() error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	var group errs.Group
+	usable := 0
+	for _, d := range store.disks {
+		if !d.readable() {
+			continue
+		}
+
+		err := d.Blobs.CheckWritability()
+		switch {
+		case err != nil && d.unwritable == nil:
+			store.log.Error("storage directory is not writable; no new pieces are stored in it",
+				zap.String("path", d.Path), zap.Error(err))
+			mon.Meter("storage_dir_unwritable").Mark(1)
+		case err == nil && d.unwritable != nil:
+			store.log.Info("storage directory is writable again", zap.String("path", d.Path))
+		}
+		d.unwritable = err
+		if err != nil {
+			group.Add(err)
+			continue
+		}
+		usable++
+	}
+
+	if usable == 0 {
+		return Error.New("no storage directory is writable: %v", group.Err())
+	}
+	return nil
+}
+
+// Close closes the blob store of every storage directory.
+func (store *Store) Close() error {
+	var group errs.Group
+	for _, d := range store.disks {
+		if d.Blobs != nil {
+			group.Add(d.Blobs.Close())
+		}
+	}
+	return group.Err()
+}
@@ -0,0 +1,171 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package multidir_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/multidir"
+)
+
+const verificationFileName = "storage-dir-verification"
+
+func openStore(ctx *testcontext.Context, t *testing.T, paths []string, placement multidir.Placement) (*multidir.Store, []storage.Blobs) {
+	log := zaptest.NewLogger(t)
+
+	var disks []multidir.Disk
+	var blobs []storage.Blobs
+	for _, path := range paths {
+		dir, err := filestore.NewDir(log, path)
+		require.NoError(t, err)
+		disk := filestore.New(log, dir, filestore.DefaultConfig)
+		disks = append(disks, multidir.Disk{Path: path, Dir: dir, Blobs: disk})
+		blobs = append(blobs, disk)
+	}
+
+	store, err := multidir.New(log, disks, placement)
+	require.NoError(t, err)
+	return store, blobs
+}
+
+func writeBlob(ctx *testcontext.Context, t *testing.T, store storage.Blobs, ref storage.BlobRef, data []byte) {
+	writer, err := store.Create(ctx, ref, -1)
+	require.NoError(t, err)
+	_, err = writer.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, writer.Commit(ctx))
+}
+
+func readBlob(ctx *testcontext.Context, t *testing.T, store storage.Blobs, ref storage.BlobRef) []byte {
+	reader, err := store.Open(ctx, ref)
+	require.NoError(t, err)
+	defer ctx.Check(reader.Close)
+	data, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	return data
+}
+
+func TestRoundRobin(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	nodeID := testrand.NodeID()
+	store, disks := openStore(ctx, t, []string{ctx.Dir("a"), ctx.Dir("b")}, multidir.RoundRobin)
+	defer ctx.Check(store.Close)
+	require.NoError(t, store.CreateVerificationFile(nodeID))
+	require.NoError(t, store.VerifyStorageDir(nodeID))
+
+	namespace := testrand.Bytes(32)
+	refs := map[string][]byte{}
+	for i := 0; i < 4; i++ {
+		ref := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+		data := testrand.BytesInt(1 << 10)
+		writeBlob(ctx, t, store, ref, data)
+		refs[string(ref.Key)] = data
+	}
+
+	for _, disk := range disks {
+		used, err := disk.SpaceUsedForBlobs(ctx)
+		require.NoError(t, err)
+		require.NotZero(t, used)
+	}
+
+	var walked int
+	require.NoError(t, store.WalkNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+		walked++
+		require.Contains(t, refs, string(info.BlobRef().Key))
+		return nil
+	}))
+	require.Equal(t, len(refs), walked)
+
+	for key, data := range refs {
+		ref := storage.BlobRef{Namespace: namespace, Key: []byte(key)}
+		require.Equal(t, data, readBlob(ctx, t, store, ref))
+		require.NoError(t, store.Delete(ctx, ref))
+		_, err := store.Stat(ctx, ref)
+		require.True(t, errs.IsFunc(err, os.IsNotExist), err)
+	}
+}
+
+func TestFailedDisk(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	nodeID := testrand.NodeID()
+	paths := []string{ctx.Dir("a"), ctx.Dir("b")}
+	store, disks := openStore(ctx, t, paths, multidir.RoundRobin)
+	defer ctx.Check(store.Close)
+	require.NoError(t, store.CreateVerificationFile(nodeID))
+
+	namespace := testrand.Bytes(32)
+	ref := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	writeBlob(ctx, t, disks[1], ref, testrand.BytesInt(1<<10))
+
+	// the second disk disappears, so its pieces are lost but the store can
+	// still be used.
+	verification := filepath.Join(paths[1], verificationFileName)
+	data, err := ioutil.ReadFile(verification)
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(verification))
+	require.NoError(t, store.VerifyStorageDir(nodeID))
+
+	_, err = store.Open(ctx, ref)
+	require.True(t, os.IsNotExist(err), err)
+
+	for i := 0; i < 4; i++ {
+		writeBlob(ctx, t, store, storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}, testrand.BytesInt(1<<10))
+	}
+	used, err := disks[1].SpaceUsedForBlobs(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 1<<10, used)
+
+	// it comes back.
+	require.NoError(t, ioutil.WriteFile(verification, data, 0600))
+	require.NoError(t, store.VerifyStorageDir(nodeID))
+	require.NotEmpty(t, readBlob(ctx, t, store, ref))
+
+	// the node only stops when no disk is left.
+	for _, path := range paths {
+		require.NoError(t, os.Remove(filepath.Join(path, verificationFileName)))
+	}
+	require.Error(t, store.VerifyStorageDir(nodeID))
+}
+
+func TestInitialization(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	nodeID := testrand.NodeID()
+	primary := ctx.Dir("primary")
+
+	store, _ := openStore(ctx, t, []string{primary}, multidir.MostFree)
+	require.NoError(t, store.CreateVerificationFile(nodeID))
+	require.NoError(t, store.Close())
+
+	// a directory that is added later is initialized when it is verified.
+	extra := ctx.Dir("extra")
+	store, _ = openStore(ctx, t, []string{primary, extra}, multidir.MostFree)
+	require.NoError(t, store.VerifyStorageDir(nodeID))
+	require.FileExists(t, filepath.Join(extra, verificationFileName))
+	require.NoError(t, store.Close())
+
+	// once it is initialized, losing the verification file fails it instead
+	// of initializing it again.
+	require.NoError(t, os.Remove(filepath.Join(extra, verificationFileName)))
+	store, _ = openStore(ctx, t, []string{primary, extra}, multidir.MostFree)
+	defer ctx.Check(store.Close)
+	require.NoError(t, store.VerifyStorageDir(nodeID))
+	require.NoFileExists(t, filepath.Join(extra, verificationFileName))
+}
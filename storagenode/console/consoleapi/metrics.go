@@ -0,0 +1,173 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/storagenode/console"
+	"storj.io/storj/storagenode/piecestore"
+)
+
+// textPlainMetrics is the content type of the Prometheus text exposition format.
+const textPlainMetrics = "text/plain; version=0.0.4; charset=utf-8"
+
+// Metrics handles requests of metrics collectors. The metrics are written in
+// the Prometheus text exposition format.
+func (dashboard *StorageNode) Metrics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	data, err := dashboard.service.GetMetricsData(ctx)
+	if err != nil {
+		dashboard.log.Error("failed to get metrics", zap.Error(ErrStorageNodeAPI.Wrap(err)))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	writeMetrics(&buf, data)
+
+	w.Header().Set(contentType, textPlainMetrics)
+	if _, err := buf.WriteTo(w); err != nil {
+		dashboard.log.Error("failed to write metrics response", zap.Error(ErrStorageNodeAPI.Wrap(err)))
+		return
+	}
+}
+
+// writeMetrics writes the metrics in the Prometheus text exposition format.
+// The names of the metrics must stay the same, since dashboards and alerts
+// are built on them.
+func writeMetrics(buf *bytes.Buffer, data *console.Metrics) {
+	gauge := func(name, help string, value int64) {
+		writeFamily(buf, name, "gauge", help)
+		writeSample(buf, name, nil, float64(value))
+	}
+	gauge("storagenode_disk_used_bytes", "Space used by pieces.", data.DiskSpace.Used)
+	gauge("storagenode_disk_trash_bytes", "Space used by trashed pieces.", data.DiskSpace.Trash)
+	gauge("storagenode_disk_allocated_bytes", "Space allocated for pieces.", data.DiskSpace.Available)
+	gauge("storagenode_disk_overused_bytes", "Space used beyond the allocated space.", data.DiskSpace.Overused)
+
+	perSatellite := func(name, typ, help string, value func(satellite console.SatelliteMetrics) float64) {
+		writeFamily(buf, name, typ, help)
+		for _, satellite := range data.Satellites {
+			writeSample(buf, name, []string{"satellite", satellite.ID.String()}, value(satellite))
+		}
+	}
+	perSatellite("storagenode_satellite_disk_used_bytes", "gauge", "Space used by the pieces of the satellite, including their headers.",
+		func(satellite console.SatelliteMetrics) float64 { return float64(satellite.DiskUsed) })
+	perSatellite("storagenode_satellite_disk_content_bytes", "gauge", "Space used by the content of the pieces of the satellite.",
+		func(satellite console.SatelliteMetrics) float64 { return float64(satellite.DiskContentSize) })
+
+	name := "storagenode_satellite_bandwidth_month_bytes"
+	writeFamily(buf, name, "gauge", "Bandwidth used for the satellite in the current month.")
+	for _, satellite := range data.Satellites {
+		usage := satellite.Bandwidth
+		for _, action := range []struct {
+			name   string
+			amount int64
+		}{
+			{"put", usage.Put},
+			{"get", usage.Get},
+			{"get_audit", usage.GetAudit},
+			{"get_repair", usage.GetRepair},
+			{"put_repair", usage.PutRepair},
+			{"delete", usage.Delete},
+		} {
+			writeSample(buf, name, []string{"satellite", satellite.ID.String(), "action", action.name}, float64(action.amount))
+		}
+	}
+
+	perSatellite("storagenode_satellite_audit_score", "gauge", "Audit score on the satellite.",
+		func(satellite console.SatelliteMetrics) float64 { return satellite.AuditScore })
+	perSatellite("storagenode_satellite_suspension_score", "gauge", "Suspension score on the satellite.",
+		func(satellite console.SatelliteMetrics) float64 { return satellite.SuspensionScore })
+	perSatellite("storagenode_satellite_online_score", "gauge", "Online score on the satellite.",
+		func(satellite console.SatelliteMetrics) float64 { return satellite.OnlineScore })
+	perSatellite("storagenode_satellite_disqualified", "gauge", "Whether the node is disqualified on the satellite.",
+		func(satellite console.SatelliteMetrics) float64 { return boolValue(satellite.Disqualified) })
+	perSatellite("storagenode_satellite_suspended", "gauge", "Whether the node is suspended on the satellite.",
+		func(satellite console.SatelliteMetrics) float64 { return boolValue(satellite.Suspended) })
+
+	transfers := func(name, help string, counts func(transfers piecestore.SatelliteTransfers) piecestore.TransferCounts) {
+		writeFamily(buf, name, "counter", help)
+		for _, satellite := range data.Satellites {
+			count := counts(satellite.Transfers)
+			for _, result := range []struct {
+				name  string
+				count int64
+			}{
+				{"success", count.Success},
+				{"failure", count.Failure},
+				{"canceled", count.Canceled},
+			} {
+				writeSample(buf, name, []string{"satellite", satellite.ID.String(), "result", result.name}, float64(result.count))
+			}
+		}
+	}
+	transfers("storagenode_satellite_uploads_total", "Piece uploads finished since the node started.",
+		func(transfers piecestore.SatelliteTransfers) piecestore.TransferCounts { return transfers.Upload })
+	transfers("storagenode_satellite_downloads_total", "Piece downloads finished since the node started.",
+		func(transfers piecestore.SatelliteTransfers) piecestore.TransferCounts { return transfers.Download })
+
+	// satellites without an estimation have no samples, so that they aren't
+	// mistaken for a payout of 0.
+	estimated := func(name, help string, value func(satellite console.SatelliteMetrics) float64) {
+		writeFamily(buf, name, "gauge", help)
+		for _, satellite := range data.Satellites {
+			if satellite.PayoutEstimated {
+				writeSample(buf, name, []string{"satellite", satellite.ID.String()}, value(satellite))
+			}
+		}
+	}
+	estimated("storagenode_satellite_estimated_payout_cents", "Estimated payout of the current month in cents.",
+		func(satellite console.SatelliteMetrics) float64 { return satellite.EstimatedPayout })
+	estimated("storagenode_satellite_estimated_held_cents", "Estimated held amount of the current month in cents.",
+		func(satellite console.SatelliteMetrics) float64 { return satellite.EstimatedHeld })
+}
+
+// writeFamily writes the help and type lines of a metric.
+func writeFamily(buf *bytes.Buffer, name, typ, help string) {
+	fmt.Fprintf(buf, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(buf, "# TYPE %s %s\n", name, typ)
+}
+
+// writeSample writes a single value of a metric. labels are pairs of label
+// names and values.
+func writeSample(buf *bytes.Buffer, name string, labels []string, value float64) {
+	buf.WriteString(name)
+	if len(labels) > 0 {
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(labels[i])
+			buf.WriteString(`="`)
+			buf.WriteString(labelEscaper.Replace(labels[i+1]))
+			buf.WriteByte('"')
+		}
+		buf.WriteByte('}')
+	}
+	buf.WriteByte(' ')
+	buf.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+	buf.WriteByte('\n')
+}
+
+// labelEscaper escapes label values as the exposition format requires.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
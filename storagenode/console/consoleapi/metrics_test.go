@@ -0,0 +1,56 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+)
+
+func TestMetrics(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+
+		err := planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "test/path", testrand.Bytes(10*memory.KiB))
+		require.NoError(t, err)
+
+		var uploads int64
+		for _, node := range planet.StorageNodes {
+			uploads += node.Storage2.Endpoint.Transfers().All()[satellite.ID()].Upload.Success
+		}
+		require.GreaterOrEqual(t, uploads, int64(3))
+
+		for _, node := range planet.StorageNodes {
+			transfers := node.Storage2.Endpoint.Transfers().All()[satellite.ID()]
+
+			res, err := http.Get(fmt.Sprintf("http://%s/metrics", node.Console.Listener.Addr()))
+			require.NoError(t, err)
+			body, err := ioutil.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			require.Equal(t, http.StatusOK, res.StatusCode)
+			require.Equal(t, "text/plain; version=0.0.4; charset=utf-8", res.Header.Get("Content-Type"))
+
+			metrics := string(body)
+			require.Contains(t, metrics, "# TYPE storagenode_disk_used_bytes gauge\n")
+			require.Contains(t, metrics, "# TYPE storagenode_satellite_uploads_total counter\n")
+			require.Contains(t, metrics, fmt.Sprintf("storagenode_satellite_uploads_total{satellite=%q,result=\"success\"} %d\n",
+				satellite.ID().String(), transfers.Upload.Success))
+			require.Contains(t, metrics, fmt.Sprintf("storagenode_satellite_audit_score{satellite=%q} ", satellite.ID().String()))
+			require.Contains(t, metrics, fmt.Sprintf("storagenode_satellite_bandwidth_month_bytes{satellite=%q,action=\"put\"} ", satellite.ID().String()))
+		}
+	})
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/private/date"
+	"storj.io/storj/storagenode/bandwidth"
+	"storj.io/storj/storagenode/piecestore"
+)
+
+// Metrics contains the values exposed to metrics collectors.
+type Metrics struct {
+	DiskSpace  DiskSpaceInfo
+	Satellites []SatelliteMetrics
+}
+
+// SatelliteMetrics contains the values of a single satellite exposed to metrics collectors.
+type SatelliteMetrics struct {
+	ID storj.NodeID
+
+	// DiskUsed is the space used by the pieces including their headers.
+	DiskUsed int64
+	// DiskContentSize is the space used by the content of the pieces.
+	DiskContentSize int64
+	// Bandwidth is the bandwidth used in the current month.
+	Bandwidth bandwidth.Usage
+
+	AuditScore      float64
+	SuspensionScore float64
+	OnlineScore     float64
+	Disqualified    bool
+	Suspended       bool
+
+	Transfers piecestore.SatelliteTransfers
+
+	// PayoutEstimated is false if there is no estimation for the satellite,
+	// then EstimatedPayout and EstimatedHeld are not set.
+	PayoutEstimated bool
+	// EstimatedPayout is the estimated payout of the current month in cents.
+	EstimatedPayout float64
+	// EstimatedHeld is the estimated held amount of the current month in cents.
+	EstimatedHeld float64
+}
+
+// GetMetricsData returns the values exposed to metrics collectors.
+func (s *Service) GetMetricsData(ctx context.Context) (_ *Metrics, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	now := time.Now().UTC()
+	data := new(Metrics)
+
+	pieceTotal, _, err := s.pieceStore.SpaceUsedForPieces(ctx)
+	if err != nil {
+		return nil, SNOServiceErr.Wrap(err)
+	}
+	trash, err := s.pieceStore.SpaceUsedForTrash(ctx)
+	if err != nil {
+		return nil, SNOServiceErr.Wrap(err)
+	}
+	data.DiskSpace = DiskSpaceInfo{
+		Used:      pieceTotal,
+		Available: s.allocatedDiskSpace.Int64(),
+		Trash:     trash,
+	}
+	if overused := s.allocatedDiskSpace.Int64() - pieceTotal - trash; overused < 0 {
+		data.DiskSpace.Overused = -overused
+	}
+
+	from, to := date.MonthBoundary(now)
+	bandwidthUsage, err := s.bandwidthDB.SummaryBySatellite(ctx, from, to)
+	if err != nil {
+		return nil, SNOServiceErr.Wrap(err)
+	}
+
+	stats, err := s.reputationDB.All(ctx)
+	if err != nil {
+		return nil, SNOServiceErr.Wrap(err)
+	}
+	transfers := s.transfers.All()
+
+	satellites := s.trust.GetSatellites(ctx)
+	sort.Slice(satellites, func(i, k int) bool {
+		return satellites[i].Less(satellites[k])
+	})
+
+	for _, satelliteID := range satellites {
+		satellite := SatelliteMetrics{
+			ID:        satelliteID,
+			Transfers: transfers[satelliteID],
+		}
+
+		satellite.DiskUsed, satellite.DiskContentSize, err = s.usageCache.SpaceUsedBySatellite(ctx, satelliteID)
+		if err != nil {
+			return nil, SNOServiceErr.Wrap(err)
+		}
+
+		if usage, ok := bandwidthUsage[satelliteID]; ok {
+			satellite.Bandwidth = *usage
+		}
+
+		for _, rep := range stats {
+			if rep.SatelliteID != satelliteID {
+				continue
+			}
+			satellite.AuditScore = rep.Audit.Score
+			satellite.SuspensionScore = rep.Audit.UnknownScore
+			satellite.OnlineScore = rep.OnlineScore
+			satellite.Disqualified = rep.DisqualifiedAt != nil
+			satellite.Suspended = rep.SuspendedAt != nil
+		}
+
+		payout, err := s.estimation.GetSatelliteEstimatedPayout(ctx, satelliteID, now)
+		if err != nil {
+			// there is no estimation for disqualified nodes or for satellites
+			// whose pricing isn't known yet, so the payout is left out.
+			s.log.Debug("unable to estimate payout", zap.Stringer("Satellite ID", satelliteID), zap.Error(err))
+		} else {
+			satellite.PayoutEstimated = true
+			satellite.EstimatedPayout = payout.CurrentMonth.Payout
+			satellite.EstimatedHeld = payout.CurrentMonth.Held
+		}
+
+		data.Satellites = append(data.Satellites, satellite)
+	}
+
+	return data, nil
+}
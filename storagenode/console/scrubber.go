@@ -0,0 +1,33 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"time"
+
+	"storj.io/common/storj"
+)
+
+// Scrubber stores the results of the piece scrubber since the node started.
+type Scrubber struct {
+	PassStarted  *time.Time `json:"passStarted"`
+	PassFinished *time.Time `json:"passFinished"`
+	Passes       int64      `json:"passes"`
+
+	Checked      int64 `json:"checked"`
+	CheckedBytes int64 `json:"checkedBytes"`
+	Corrupt      int64 `json:"corrupt"`
+	Trashed      int64 `json:"trashed"`
+
+	CorruptPieces []CorruptPiece `json:"corruptPieces"`
+}
+
+// CorruptPiece stores a piece that the scrubber found to be corrupt.
+type CorruptPiece struct {
+	Satellite storj.NodeID  `json:"satelliteID"`
+	PieceID   storj.PieceID `json:"pieceID"`
+	FoundAt   time.Time     `json:"foundAt"`
+	Reason    string        `json:"reason"`
+	Trashed   bool          `json:"trashed"`
+}
@@ -22,6 +22,7 @@ import (
 	"storj.io/storj/storagenode/operator"
 	"storj.io/storj/storagenode/payouts/estimatedpayouts"
 	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/piecestore"
 	"storj.io/storj/storagenode/pricing"
 	"storj.io/storj/storagenode/reputation"
 	"storj.io/storj/storagenode/satellites"
@@ -49,6 +50,8 @@ type Service struct {
 	pricingDB      pricing.DB
 	satelliteDB    satellites.DB
 	pieceStore     *pieces.Store
+	scrubber       *pieces.Scrubber
+	transfers      *piecestore.TransferStats
 	contact        *contact.Service
 
 	estimation *estimatedpayouts.Service
@@ -67,7 +70,7 @@ type Service struct {
 func NewService(log *zap.Logger, bandwidth bandwidth.DB, pieceStore *pieces.Store, version *checker.Service,
 	allocatedDiskSpace memory.Size, walletAddress string, versionInfo version.Info, trust *trust.Pool,
 	reputationDB reputation.DB, storageUsageDB storageusage.DB, pricingDB pricing.DB, satelliteDB satellites.DB,
-	pingStats *contact.PingStats, contact *contact.Service, estimation *estimatedpayouts.Service, usageCache *pieces.BlobsUsageCache, walletFeatures operator.WalletFeatures) (*Service, error) {
+	pingStats *contact.PingStats, contact *contact.Service, estimation *estimatedpayouts.Service, usageCache *pieces.BlobsUsageCache, scrubber *pieces.Scrubber, transfers *piecestore.TransferStats, walletFeatures operator.WalletFeatures) (*Service, error) {
 	if log == nil {
 		return nil, errs.New("log can't be nil")
 	}
@@ -100,6 +103,14 @@ func NewService(log *zap.Logger, bandwidth bandwidth.DB, pieceStore *pieces.Stor
 		return nil, errs.New("estimation service can't be nil")
 	}
 
+	if scrubber == nil {
+		return nil, errs.New("scrubber can't be nil")
+	}
+
+	if transfers == nil {
+		return nil, errs.New("transfers can't be nil")
+	}
+
 	return &Service{
 		log:                log,
 		trust:              trust,
@@ -110,6 +121,8 @@ func NewService(log *zap.Logger, bandwidth bandwidth.DB, pieceStore *pieces.Stor
 		pricingDB:          pricingDB,
 		satelliteDB:        satelliteDB,
 		pieceStore:         pieceStore,
+		scrubber:           scrubber,
+		transfers:          transfers,
 		version:            version,
 		pingStats:          pingStats,
 		allocatedDiskSpace: allocatedDiskSpace,
@@ -464,3 +477,29 @@ func (s *Service) VerifySatelliteID(ctx context.Context, satelliteID storj.NodeI
 
 	return nil
 }
+
+// GetScrubberData returns the results of the piece scrubber.
+func (s *Service) GetScrubberData(ctx context.Context) (_ *Scrubber, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	stats := s.scrubber.Stats()
+	data := &Scrubber{
+		Passes:        stats.Passes,
+		Checked:       stats.Checked,
+		CheckedBytes:  stats.CheckedBytes,
+		Corrupt:       stats.Corrupt,
+		Trashed:       stats.Trashed,
+		CorruptPieces: []CorruptPiece{},
+	}
+	if !stats.PassStarted.IsZero() {
+		data.PassStarted = &stats.PassStarted
+	}
+	if !stats.PassFinished.IsZero() {
+		data.PassFinished = &stats.PassFinished
+	}
+	for _, piece := range stats.CorruptPieces {
+		data.CorruptPieces = append(data.CorruptPieces, CorruptPiece(piece))
+	}
+
+	return data, nil
+}
@@ -17,6 +17,7 @@ import (
 	"storj.io/common/rpc"
 	"storj.io/common/storj"
 	"storj.io/common/sync2"
+	"storj.io/storj/storagenode/notifications"
 	"storj.io/storj/storagenode/trust"
 )
 
@@ -56,18 +57,25 @@ type Service struct {
 	mu   sync.Mutex
 	self NodeInfo
 
-	trust *trust.Pool
+	trust         *trust.Pool
+	notifications *notifications.Service
+
+	// offline are the satellites that reported the node as unreachable.
+	offlineMu sync.Mutex
+	offline   map[storj.NodeID]bool
 
 	initialized sync2.Fence
 }
 
 // NewService creates a new contact service.
-func NewService(log *zap.Logger, dialer rpc.Dialer, self NodeInfo, trust *trust.Pool) *Service {
+func NewService(log *zap.Logger, dialer rpc.Dialer, self NodeInfo, trust *trust.Pool, notifications *notifications.Service) *Service {
 	return &Service{
-		log:    log,
-		dialer: dialer,
-		trust:  trust,
-		self:   self,
+		log:           log,
+		dialer:        dialer,
+		trust:         trust,
+		notifications: notifications,
+		self:          self,
+		offline:       make(map[storj.NodeID]bool),
 	}
 }
 
@@ -138,14 +146,46 @@ func (service *Service) pingSatelliteOnce(ctx context.Context, id storj.NodeID)
 		return errPingSatellite.Wrap(err)
 	}
 	if resp != nil && !resp.PingNodeSuccess {
+		service.reportOffline(ctx, id, resp.PingErrorMessage)
 		return errPingSatellite.New("%s", resp.PingErrorMessage)
 	}
+	service.reportOnline(id)
 	if resp.PingErrorMessage != "" {
 		service.log.Warn("Your node is still considered to be online but encountered an error.", zap.Stringer("Satellite ID", id), zap.String("Error", resp.GetPingErrorMessage()))
 	}
 	return nil
 }
 
+// reportOffline raises a notification when the satellite starts to report
+// the node as unreachable.
+func (service *Service) reportOffline(ctx context.Context, id storj.NodeID, pingError string) {
+	service.offlineMu.Lock()
+	alreadyOffline := service.offline[id]
+	service.offline[id] = true
+	service.offlineMu.Unlock()
+
+	if alreadyOffline {
+		return
+	}
+
+	_, err := service.notifications.Receive(ctx, notifications.NewNotification{
+		SenderID: id,
+		Type:     notifications.TypeOffline,
+		Title:    "Your node is offline",
+		Message:  "Satellite " + id.String() + " could not reach your node: " + pingError,
+	})
+	if err != nil {
+		service.log.Error("failed to receive notification", zap.Error(err))
+	}
+}
+
+// reportOnline clears that the satellite reported the node as unreachable.
+func (service *Service) reportOnline(id storj.NodeID) {
+	service.offlineMu.Lock()
+	defer service.offlineMu.Unlock()
+	delete(service.offline, id)
+}
+
 // Local returns the storagenode info.
 func (service *Service) Local() NodeInfo {
 	service.mu.Lock()
@@ -5,6 +5,7 @@ package monitor
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
@@ -17,7 +18,9 @@ import (
 	"storj.io/common/sync2"
 	"storj.io/storj/storagenode/bandwidth"
 	"storj.io/storj/storagenode/contact"
+	"storj.io/storj/storagenode/notifications"
 	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/schedule"
 )
 
 var (
@@ -55,6 +58,8 @@ type Service struct {
 	store                 *pieces.Store
 	contact               *contact.Service
 	usageDB               bandwidth.DB
+	schedule              *schedule.Service
+	notifications         *notifications.Service
 	allocatedDiskSpace    int64
 	cooldown              *sync2.Cooldown
 	Loop                  *sync2.Cycle
@@ -64,12 +69,14 @@ type Service struct {
 }
 
 // NewService creates a new storage node monitoring service.
-func NewService(log *zap.Logger, store *pieces.Store, contact *contact.Service, usageDB bandwidth.DB, allocatedDiskSpace int64, interval time.Duration, reportCapacity func(context.Context), config Config) *Service {
+func NewService(log *zap.Logger, store *pieces.Store, contact *contact.Service, usageDB bandwidth.DB, schedule *schedule.Service, notifications *notifications.Service, allocatedDiskSpace int64, interval time.Duration, reportCapacity func(context.Context), config Config) *Service {
 	return &Service{
 		log:                   log,
 		store:                 store,
 		contact:               contact,
 		usageDB:               usageDB,
+		schedule:              schedule,
+		notifications:         notifications,
 		allocatedDiskSpace:    allocatedDiskSpace,
 		cooldown:              sync2.NewCooldown(config.NotifyLowDiskCooldown),
 		Loop:                  sync2.NewCycle(interval),
@@ -137,6 +144,9 @@ func (service *Service) Run(ctx context.Context) (err error) {
 		return service.VerifyDirWritableLoop.Run(ctx, func(ctx context.Context) error {
 			err := service.store.CheckWritability()
 			if err != nil {
+				service.notify(ctx, notifications.TypeWritabilityCheckFailure,
+					"Storage directory is not writable",
+					"Your node failed to write to its storage directory and is shutting down: "+err.Error())
 				return Error.New("error verifying writability of storage directory: %v", err)
 			}
 			return nil
@@ -151,6 +161,25 @@ func (service *Service) Run(ctx context.Context) (err error) {
 			return nil
 		})
 	})
+	group.Go(func() error {
+		// report the capacity immediately when the schedule changes it, since
+		// the node may be over its budget.
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-service.schedule.Changes():
+			}
+
+			if err := service.updateNodeInformation(ctx); err != nil {
+				service.log.Error("error during updating node information: ", zap.Error(err))
+				continue
+			}
+			if err := service.contact.PingSatellites(ctx, service.Config.NotifyLowDiskCooldown); err != nil {
+				service.log.Error("error notifying satellites: ", zap.Error(err))
+			}
+		}
+	})
 	service.cooldown.Start(ctx, group, func(ctx context.Context) error {
 		err := service.updateNodeInformation(ctx)
 		if err != nil {
@@ -158,6 +187,13 @@ func (service *Service) Run(ctx context.Context) (err error) {
 			return nil
 		}
 
+		freeSpace, err := service.AvailableSpace(ctx)
+		if err == nil {
+			service.notify(ctx, notifications.TypeLowDiskSpace,
+				"Your node is running out of disk space",
+				fmt.Sprintf("Your node has %s of free space left for pieces.", memory.Size(freeSpace)))
+		}
+
 		err = service.contact.PingSatellites(ctx, service.Config.NotifyLowDiskCooldown)
 		if err != nil {
 			service.log.Error("error notifying satellites: ", zap.Error(err))
@@ -173,6 +209,19 @@ func (service *Service) NotifyLowDisk() {
 	service.cooldown.Trigger()
 }
 
+// notify raises a notification about the node.
+func (service *Service) notify(ctx context.Context, notificationType notifications.Type, title, message string) {
+	_, err := service.notifications.Receive(ctx, notifications.NewNotification{
+		SenderID: service.contact.Local().ID,
+		Type:     notificationType,
+		Title:    title,
+		Message:  message,
+	})
+	if err != nil {
+		service.log.Error("failed to receive notification", zap.Error(err))
+	}
+}
+
 // Close stops the monitor service.
 func (service *Service) Close() (err error) {
 	service.Loop.Close()
@@ -188,7 +237,7 @@ func (service *Service) updateNodeInformation(ctx context.Context) (err error) {
 		return err
 	}
 	service.contact.UpdateSelf(&pb.NodeCapacity{
-		FreeDisk: freeSpace,
+		FreeDisk: service.schedule.Capacity(freeSpace),
 	})
 
 	return nil
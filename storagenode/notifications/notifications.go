@@ -35,8 +35,43 @@ const (
 	TypeDisqualification Type = 2
 	// TypeSuspension is a notification type which describes node's suspension status.
 	TypeSuspension Type = 3
+	// TypeLowDiskSpace is a notification type which describes node running out of disk space.
+	TypeLowDiskSpace Type = 4
+	// TypeWritabilityCheckFailure is a notification type which describes node's failed storage directory writability check.
+	TypeWritabilityCheckFailure Type = 5
+	// TypeOffline is a notification type which describes satellite reporting the node as unreachable.
+	TypeOffline Type = 6
 )
 
+// typeNames are the names of the notification types used in configuration and sinks.
+var typeNames = map[Type]string{
+	TypeCustom:                  "custom",
+	TypeAuditCheckFailure:       "audit-check-failure",
+	TypeDisqualification:        "disqualification",
+	TypeSuspension:              "suspension",
+	TypeLowDiskSpace:            "low-disk-space",
+	TypeWritabilityCheckFailure: "writability-check-failure",
+	TypeOffline:                 "offline",
+}
+
+// String returns the name of the notification type.
+func (t Type) String() string {
+	if name, ok := typeNames[t]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ParseType parses the name of a notification type.
+func ParseType(name string) (Type, error) {
+	for t, typeName := range typeNames {
+		if typeName == name {
+			return t, nil
+		}
+	}
+	return 0, Error.New("unknown notification type %q", name)
+}
+
 // NewNotification holds notification entity info which is being received from satellite or local client.
 type NewNotification struct {
 	SenderID storj.NodeID
@@ -5,8 +5,11 @@ package notifications
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
 	"storj.io/common/uuid"
@@ -14,6 +17,9 @@ import (
 
 var (
 	mon = monkit.Package()
+
+	// Error is the default error class for notifications package.
+	Error = errs.Class("notifications")
 )
 
 // TimesNotified is a numeric value of amount of notifications being sent to user.
@@ -35,14 +41,39 @@ const (
 type Service struct {
 	log *zap.Logger
 	db  DB
+
+	sinks   []Sink
+	types   map[Type]bool
+	timeout time.Duration
+	sending sync.WaitGroup
 }
 
 // NewService creates a new notification service.
-func NewService(log *zap.Logger, db DB) *Service {
-	return &Service{
-		log: log,
-		db:  db,
+func NewService(log *zap.Logger, db DB, config Config) (*Service, error) {
+	sinks, err := NewSinks(config)
+	if err != nil {
+		return nil, err
+	}
+
+	service := &Service{
+		log:     log,
+		db:      db,
+		sinks:   sinks,
+		timeout: config.Timeout,
 	}
+
+	if len(config.Types) > 0 {
+		service.types = make(map[Type]bool)
+		for _, name := range config.Types {
+			t, err := ParseType(name)
+			if err != nil {
+				return nil, err
+			}
+			service.types[t] = true
+		}
+	}
+
+	return service, nil
 }
 
 // Receive - receives notifications from satellite and Insert them into DB.
@@ -52,9 +83,40 @@ func (service *Service) Receive(ctx context.Context, newNotification NewNotifica
 		return Notification{}, err
 	}
 
+	service.send(notification)
+
 	return notification, nil
 }
 
+// send sends the notification to the sinks in the background.
+func (service *Service) send(notification Notification) {
+	if len(service.sinks) == 0 || (service.types != nil && !service.types[notification.Type]) {
+		return
+	}
+
+	for _, sink := range service.sinks {
+		sink := sink
+		service.sending.Add(1)
+		go func() {
+			defer service.sending.Done()
+
+			// the notification is sent even when the request that raised
+			// it is done, e.g. when the node shuts down due to a failure.
+			ctx := context.Background()
+			if service.timeout > 0 {
+				var cancel func()
+				ctx, cancel = context.WithTimeout(ctx, service.timeout)
+				defer cancel()
+			}
+
+			if err := sink.Send(ctx, notification); err != nil {
+				service.log.Error("failed to send notification",
+					zap.Stringer("Type", notification.Type), zap.String("Title", notification.Title), zap.Error(err))
+			}
+		}()
+	}
+}
+
 // Read - change notification status to Read by ID.
 func (service *Service) Read(ctx context.Context, notificationID uuid.UUID) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -106,3 +168,9 @@ func (service *Service) UnreadAmount(ctx context.Context) (_ int, err error) {
 
 	return amount, nil
 }
+
+// Close waits for the notifications that are being sent.
+func (service *Service) Close() error {
+	service.sending.Wait()
+	return nil
+}
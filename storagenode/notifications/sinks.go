@@ -0,0 +1,244 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/private/post"
+)
+
+// Config defines where notifications are sent besides the dashboard.
+type Config struct {
+	Types   []string      `help:"notification types that are sent to the sinks: custom, audit-check-failure, disqualification, suspension, low-disk-space, writability-check-failure or offline. all types are sent when empty"`
+	Timeout time.Duration `help:"how long sending a notification to a sink may take" default:"30s"`
+
+	Webhook WebhookConfig
+	Email   EmailConfig
+	Command CommandConfig
+}
+
+// WebhookConfig defines the webhook notifications are posted to.
+type WebhookConfig struct {
+	URL string `help:"url that notifications are posted to as JSON" default:""`
+}
+
+// EmailConfig defines how notifications are emailed.
+type EmailConfig struct {
+	SMTPServerAddress string   `help:"smtp server address that notifications are emailed through" default:""`
+	From              string   `help:"sender email address of notifications" default:""`
+	To                []string `help:"email addresses that notifications are sent to"`
+	AuthType          string   `help:"smtp authentication type, plain or login" default:"login"`
+	Login             string   `help:"plain/login auth user login" default:""`
+	Password          string   `help:"plain/login auth user password" default:""`
+}
+
+// CommandConfig defines the command that is run for notifications.
+type CommandConfig struct {
+	Path string `help:"command that is run for each notification with the notification as JSON on standard input" default:""`
+}
+
+// Sink sends notifications outside of the node.
+type Sink interface {
+	Send(ctx context.Context, notification Notification) error
+}
+
+// Payload is the notification as it's sent to sinks.
+type Payload struct {
+	ID        string    `json:"id"`
+	SenderID  string    `json:"senderId"`
+	Type      string    `json:"type"`
+	Title     string    `json:"title"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// NewPayload creates the payload of the notification.
+func NewPayload(notification Notification) Payload {
+	return Payload{
+		ID:        notification.ID.String(),
+		SenderID:  notification.SenderID.String(),
+		Type:      notification.Type.String(),
+		Title:     notification.Title,
+		Message:   notification.Message,
+		CreatedAt: notification.CreatedAt,
+	}
+}
+
+// NewSinks creates the sinks that are configured.
+func NewSinks(config Config) (sinks []Sink, err error) {
+	if config.Webhook.URL != "" {
+		sinks = append(sinks, &WebhookSink{
+			URL:    config.Webhook.URL,
+			Client: http.DefaultClient,
+		})
+	}
+
+	if config.Email.SMTPServerAddress != "" {
+		sink, err := newEmailSink(config.Email)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+
+	if config.Command.Path != "" {
+		sinks = append(sinks, &CommandSink{Path: config.Command.Path})
+	}
+
+	return sinks, nil
+}
+
+// WebhookSink posts notifications to a webhook as JSON.
+type WebhookSink struct {
+	URL    string
+	Client *http.Client
+}
+
+// Send posts the notification to the webhook.
+func (sink *WebhookSink) Send(ctx context.Context, notification Notification) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	data, err := json.Marshal(NewPayload(notification))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sink.URL, bytes.NewReader(data))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := sink.Client.Do(req)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(resp.Body.Close())) }()
+
+	// drain the body so the connection can be reused.
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return Error.New("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// EmailSender sends email messages.
+type EmailSender interface {
+	FromAddress() post.Address
+	SendEmail(ctx context.Context, msg *post.Message) error
+}
+
+// EmailSink emails notifications.
+type EmailSink struct {
+	Sender EmailSender
+	To     []post.Address
+}
+
+func newEmailSink(config EmailConfig) (*EmailSink, error) {
+	from, err := mail.ParseAddress(config.From)
+	if err != nil {
+		return nil, Error.New("invalid sender email address %q: %v", config.From, err)
+	}
+
+	host, _, err := net.SplitHostPort(config.SMTPServerAddress)
+	if err != nil {
+		return nil, Error.New("invalid smtp server address %q: %v", config.SMTPServerAddress, err)
+	}
+
+	if len(config.To) == 0 {
+		return nil, Error.New("no email addresses to send notifications to")
+	}
+	var to []post.Address
+	for _, address := range config.To {
+		parsed, err := mail.ParseAddress(address)
+		if err != nil {
+			return nil, Error.New("invalid recipient email address %q: %v", address, err)
+		}
+		to = append(to, *parsed)
+	}
+
+	sender := &post.SMTPSender{
+		ServerAddress: config.SMTPServerAddress,
+		From:          *from,
+	}
+	switch config.AuthType {
+	case "plain":
+		sender.Auth = smtp.PlainAuth("", config.Login, config.Password, host)
+	case "login":
+		sender.Auth = post.LoginAuth{
+			Username: config.Login,
+			Password: config.Password,
+		}
+	default:
+		return nil, Error.New("unknown smtp authentication type %q", config.AuthType)
+	}
+
+	return &EmailSink{
+		Sender: sender,
+		To:     to,
+	}, nil
+}
+
+// Send emails the notification.
+func (sink *EmailSink) Send(ctx context.Context, notification Notification) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	msg := &post.Message{
+		From:      sink.Sender.FromAddress(),
+		To:        sink.To,
+		Subject:   "Storage node: " + notification.Title,
+		Date:      notification.CreatedAt,
+		PlainText: fmt.Sprintf("%s\n\nType: %s\nSender: %s\n", notification.Message, notification.Type, notification.SenderID),
+	}
+
+	return Error.Wrap(sink.Sender.SendEmail(ctx, msg))
+}
+
+// CommandSink runs a command for notifications. The command receives the
+// notification as JSON on standard input and in environment variables.
+type CommandSink struct {
+	Path string
+}
+
+// Send runs the command for the notification.
+func (sink *CommandSink) Send(ctx context.Context, notification Notification) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	payload := NewPayload(notification)
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	cmd := exec.CommandContext(ctx, sink.Path)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Env = append(os.Environ(),
+		"STORAGENODE_NOTIFICATION_TYPE="+payload.Type,
+		"STORAGENODE_NOTIFICATION_TITLE="+payload.Title,
+		"STORAGENODE_NOTIFICATION_MESSAGE="+payload.Message,
+	)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return Error.New("command %q failed: %v: %s", sink.Path, err, bytes.TrimSpace(output))
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package notifications_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/post"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/notifications"
+	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
+)
+
+func TestParseType(t *testing.T) {
+	for _, notificationType := range []notifications.Type{
+		notifications.TypeCustom,
+		notifications.TypeAuditCheckFailure,
+		notifications.TypeDisqualification,
+		notifications.TypeSuspension,
+		notifications.TypeLowDiskSpace,
+		notifications.TypeWritabilityCheckFailure,
+		notifications.TypeOffline,
+	} {
+		parsed, err := notifications.ParseType(notificationType.String())
+		require.NoError(t, err)
+		require.Equal(t, notificationType, parsed)
+	}
+
+	_, err := notifications.ParseType("unknown")
+	require.Error(t, err)
+}
+
+func TestService_Sinks(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		var mu sync.Mutex
+		var received []notifications.Payload
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var payload notifications.Payload
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			mu.Lock()
+			received = append(received, payload)
+			mu.Unlock()
+		}))
+		defer server.Close()
+
+		service, err := notifications.NewService(zaptest.NewLogger(t), db.Notifications(), notifications.Config{
+			Types:   []string{"suspension", "offline"},
+			Timeout: time.Minute,
+			Webhook: notifications.WebhookConfig{URL: server.URL},
+		})
+		require.NoError(t, err)
+
+		satellite := testrand.NodeID()
+		_, err = service.Receive(ctx, notifications.NewNotification{
+			SenderID: satellite,
+			Type:     notifications.TypeSuspension,
+			Title:    "suspended",
+			Message:  "the node is suspended",
+		})
+		require.NoError(t, err)
+
+		// filtered out, but still shown in the dashboard.
+		_, err = service.Receive(ctx, notifications.NewNotification{
+			SenderID: satellite,
+			Type:     notifications.TypeCustom,
+			Title:    "custom",
+		})
+		require.NoError(t, err)
+
+		// waits for the notifications to be sent.
+		require.NoError(t, service.Close())
+
+		amount, err := service.UnreadAmount(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, amount)
+
+		require.Len(t, received, 1)
+		require.Equal(t, "suspension", received[0].Type)
+		require.Equal(t, "suspended", received[0].Title)
+		require.Equal(t, "the node is suspended", received[0].Message)
+		require.Equal(t, satellite.String(), received[0].SenderID)
+	})
+}
+
+func TestNewService_InvalidConfig(t *testing.T) {
+	for _, config := range []notifications.Config{
+		{Types: []string{"unknown"}},
+		{Email: notifications.EmailConfig{SMTPServerAddress: "smtp.mail.test:587", From: "invalid"}},
+		{Email: notifications.EmailConfig{SMTPServerAddress: "smtp.mail.test", From: "node@mail.test", To: []string{"operator@mail.test"}}},
+		{Email: notifications.EmailConfig{SMTPServerAddress: "smtp.mail.test:587", From: "node@mail.test"}},
+		{Email: notifications.EmailConfig{SMTPServerAddress: "smtp.mail.test:587", From: "node@mail.test", To: []string{"operator@mail.test"}, AuthType: "none"}},
+	} {
+		_, err := notifications.NewService(zaptest.NewLogger(t), nil, config)
+		require.Error(t, err)
+	}
+}
+
+func TestWebhookSink_Error(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sink := &notifications.WebhookSink{URL: server.URL, Client: http.DefaultClient}
+	err := sink.Send(ctx, notifications.Notification{Title: "title"})
+	require.Error(t, err)
+}
+
+type mockSender struct {
+	messages []*post.Message
+}
+
+func (sender *mockSender) FromAddress() post.Address {
+	return post.Address{Address: "node@mail.test"}
+}
+
+func (sender *mockSender) SendEmail(ctx context.Context, msg *post.Message) error {
+	sender.messages = append(sender.messages, msg)
+	return nil
+}
+
+func TestEmailSink(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	sender := &mockSender{}
+	sink := &notifications.EmailSink{
+		Sender: sender,
+		To:     []post.Address{{Address: "operator@mail.test"}},
+	}
+
+	err := sink.Send(ctx, notifications.Notification{
+		Type:    notifications.TypeLowDiskSpace,
+		Title:   "low disk",
+		Message: "the disk is almost full",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, sender.messages, 1)
+	msg := sender.messages[0]
+	require.Equal(t, "node@mail.test", msg.From.Address)
+	require.Equal(t, sink.To, msg.To)
+	require.Contains(t, msg.Subject, "low disk")
+	require.Contains(t, msg.PlainText, "the disk is almost full")
+	require.Contains(t, msg.PlainText, "low-disk-space")
+}
+
+func TestCommandSink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script")
+	}
+
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	output := ctx.File("output")
+	script := ctx.File("notify.sh")
+	err := ioutil.WriteFile(script, []byte("#!/bin/sh\necho \"$STORAGENODE_NOTIFICATION_TYPE\" > "+output+"\ncat >> "+output+"\n"), 0755)
+	require.NoError(t, err)
+
+	sink := &notifications.CommandSink{Path: script}
+	err = sink.Send(ctx, notifications.Notification{
+		Type:  notifications.TypeOffline,
+		Title: "offline",
+	})
+	require.NoError(t, err)
+
+	data, err := ioutil.ReadFile(output)
+	require.NoError(t, err)
+
+	lines := strings.SplitN(string(data), "\n", 2)
+	require.Equal(t, "offline", lines[0])
+
+	var payload notifications.Payload
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &payload))
+	require.Equal(t, "offline", payload.Type)
+	require.Equal(t, "offline", payload.Title)
+
+	failing := &notifications.CommandSink{Path: ctx.File("missing.sh")}
+	require.Error(t, failing.Send(ctx, notifications.Notification{}))
+}
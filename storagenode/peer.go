@@ -32,6 +32,7 @@ import (
 	"storj.io/storj/private/version/checker"
 	"storj.io/storj/storage"
 	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/logstore"
 	"storj.io/storj/storagenode/apikeys"
 	"storj.io/storj/storagenode/bandwidth"
 	"storj.io/storj/storagenode/collector"
@@ -59,6 +60,7 @@ import (
 	"storj.io/storj/storagenode/reputation"
 	"storj.io/storj/storagenode/retain"
 	"storj.io/storj/storagenode/satellites"
+	"storj.io/storj/storagenode/schedule"
 	"storj.io/storj/storagenode/storagenodedb"
 	"storj.io/storj/storagenode/storageusage"
 	"storj.io/storj/storagenode/trust"
@@ -113,6 +115,7 @@ type Config struct {
 	Collector collector.Config
 
 	Filestore filestore.Config
+	Logstore  logstore.Config
 
 	Pieces pieces.Config
 
@@ -126,6 +129,10 @@ type Config struct {
 
 	Bandwidth bandwidth.Config
 
+	Schedule schedule.Config
+
+	Notifications notifications.Config
+
 	GracefulExit gracefulexit.Config
 }
 
@@ -136,11 +143,15 @@ func (config *Config) DatabaseConfig() storagenodedb.Config {
 		dbdir = config.Storage.Path
 	}
 	return storagenodedb.Config{
-		Storage:   config.Storage.Path,
-		Info:      filepath.Join(dbdir, "piecestore.db"),
-		Info2:     filepath.Join(dbdir, "info.db"),
-		Pieces:    config.Storage.Path,
-		Filestore: config.Filestore,
+		Storage:     config.Storage.Path,
+		Info:        filepath.Join(dbdir, "piecestore.db"),
+		Info2:       filepath.Join(dbdir, "info.db"),
+		Pieces:      config.Storage.Path,
+		ExtraPieces: config.Storage.ExtraPaths,
+		Placement:   config.Storage.Placement,
+		Backend:     config.Storage2.PieceBackend,
+		Filestore:   config.Filestore,
+		Logstore:    config.Logstore,
 	}
 }
 
@@ -235,10 +246,12 @@ type Peer struct {
 		Trust         *trust.Pool
 		Store         *pieces.Store
 		TrashChore    *pieces.TrashChore
+		Scrubber      *pieces.Scrubber
 		BlobsCache    *pieces.BlobsUsageCache
 		CacheService  *pieces.CacheService
 		RetainService *retain.Service
 		PieceDeleter  *pieces.Deleter
+		Compaction    *logstore.Chore
 		Endpoint      *piecestore.Endpoint
 		Inspector     *inspector.Endpoint
 		Monitor       *monitor.Service
@@ -281,6 +294,8 @@ type Peer struct {
 
 	Bandwidth *bandwidth.Service
 
+	Schedule *schedule.Service
+
 	Reputation *reputation.Service
 
 	Multinode struct {
@@ -303,7 +318,15 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 	}
 
 	{ // setup notification service.
-		peer.Notifications.Service = notifications.NewService(peer.Log, peer.DB.Notifications())
+		var err error
+		peer.Notifications.Service, err = notifications.NewService(peer.Log, peer.DB.Notifications(), config.Notifications)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+		peer.Services.Add(lifecycle.Item{
+			Name:  "notifications:service",
+			Close: peer.Notifications.Service.Close,
+		})
 	}
 
 	{ // setup debug
@@ -410,7 +433,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			Version: *pbVersion,
 		}
 		peer.Contact.PingStats = new(contact.PingStats)
-		peer.Contact.Service = contact.NewService(peer.Log.Named("contact:service"), peer.Dialer, self, peer.Storage2.Trust)
+		peer.Contact.Service = contact.NewService(peer.Log.Named("contact:service"), peer.Dialer, self, peer.Storage2.Trust, peer.Notifications.Service)
 
 		peer.Contact.Chore = contact.NewChore(peer.Log.Named("contact:chore"), config.Contact.Interval, peer.Contact.Service)
 		peer.Services.Add(lifecycle.Item{
@@ -425,6 +448,20 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 		}
 	}
 
+	{ // setup schedule
+		peer.Schedule, err = schedule.NewService(peer.Log.Named("schedule"), peer.DB.Bandwidth(), config.Schedule)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+		peer.Services.Add(lifecycle.Item{
+			Name:  "schedule",
+			Run:   peer.Schedule.Run,
+			Close: peer.Schedule.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Schedule", peer.Schedule.Loop))
+	}
+
 	{ // setup storage
 		peer.Storage2.BlobsCache = pieces.NewBlobsUsageCache(peer.Log.Named("blobscache"), peer.DB.Pieces())
 
@@ -456,6 +493,19 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			Close: peer.Storage2.TrashChore.Close,
 		})
 
+		peer.Storage2.Scrubber = pieces.NewScrubber(
+			log.Named("pieces:scrubber"),
+			config.Pieces.Scrubber,
+			peer.Storage2.Store,
+		)
+		peer.Services.Add(lifecycle.Item{
+			Name:  "pieces:scrubber",
+			Run:   peer.Storage2.Scrubber.Run,
+			Close: peer.Storage2.Scrubber.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Piece Scrubber", peer.Storage2.Scrubber.Loop))
+
 		peer.Storage2.CacheService = pieces.NewService(
 			log.Named("piecestore:cache"),
 			peer.Storage2.BlobsCache,
@@ -475,6 +525,8 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			peer.Storage2.Store,
 			peer.Contact.Service,
 			peer.DB.Bandwidth(),
+			peer.Schedule,
+			peer.Notifications.Service,
 			config.Storage.AllocatedDiskSpace.Int64(),
 			// TODO: use config.Storage.Monitor.Interval, but for some reason is not set
 			config.Storage.KBucketRefreshInterval,
@@ -523,6 +575,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			peer.OrdersStore,
 			peer.DB.Bandwidth(),
 			peer.UsedSerials,
+			peer.Schedule,
 			config.Storage2,
 		)
 		if err != nil {
@@ -652,6 +705,8 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			peer.Contact.Service,
 			peer.Estimation.Service,
 			peer.Storage2.BlobsCache,
+			peer.Storage2.Scrubber,
+			peer.Storage2.Endpoint.Transfers(),
 			config.Operator.WalletFeatures,
 		)
 		if err != nil {
@@ -760,6 +815,17 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			debug.Cycle("Graceful Exit", peer.GracefulExit.Chore.Loop))
 	}
 
+	if compactor, ok := peer.DB.Pieces().(logstore.Compactor); ok {
+		peer.Storage2.Compaction = logstore.NewChore(peer.Log.Named("logstore:compaction"), compactor, config.Logstore.CompactionInterval)
+		peer.Services.Add(lifecycle.Item{
+			Name:  "logstore:compaction",
+			Run:   peer.Storage2.Compaction.Run,
+			Close: peer.Storage2.Compaction.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Piece Log Compaction", peer.Storage2.Compaction.Loop))
+	}
+
 	peer.Collector = collector.NewService(peer.Log.Named("collector"), peer.Storage2.Store, peer.UsedSerials, config.Collector)
 	peer.Services.Add(lifecycle.Item{
 		Name:  "collector",
@@ -54,6 +54,17 @@ func TestPieceExpirationDB(t *testing.T) {
 		err = expireDB.SetExpiration(ctx, satelliteID, pieceID, expireAt.Add(time.Hour))
 		require.Error(t, err)
 
+		// GetExpirations normal usage
+		expirations, err := expireDB.GetExpirations(ctx, satelliteID)
+		require.NoError(t, err)
+		require.Len(t, expirations, 1)
+		require.WithinDuration(t, expireAt, expirations[pieceID], time.Microsecond)
+
+		// GetExpirations of another satellite
+		expirations, err = expireDB.GetExpirations(ctx, testrand.NodeID())
+		require.NoError(t, err)
+		require.Empty(t, expirations)
+
 		// GetExpired normal usage
 		expiredPieceIDs, err = expireDB.GetExpired(ctx, expireAt.Add(time.Microsecond), 1000)
 		require.NoError(t, err)
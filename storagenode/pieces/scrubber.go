@@ -0,0 +1,291 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pieces
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"storj.io/common/memory"
+	"storj.io/common/pkcrypto"
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+)
+
+// maxCorruptPieces is the number of corrupt pieces that the scrubber keeps.
+const maxCorruptPieces = 100
+
+// scrubBufferSize is the size of the reads of the scrubber.
+const scrubBufferSize = 32 * memory.KiB
+
+// ScrubberConfig is the configuration for the piece scrubber.
+type ScrubberConfig struct {
+	Interval     time.Duration `help:"how frequently all pieces are checked for corruption. 0 disables the scrubber" default:"168h0m0s"`
+	ReadRate     memory.Size   `help:"how many bytes per second the scrubber reads at most" default:"1MiB"`
+	TrashCorrupt bool          `help:"move corrupt pieces to the trash" default:"false"`
+}
+
+// CorruptPiece is a piece whose content doesn't match its hash.
+type CorruptPiece struct {
+	Satellite storj.NodeID
+	PieceID   storj.PieceID
+	FoundAt   time.Time
+	Reason    string
+	Trashed   bool
+}
+
+// ScrubberStats are the totals of the piece scrubber since the node started.
+type ScrubberStats struct {
+	PassStarted  time.Time
+	PassFinished time.Time
+	Passes       int64
+
+	Checked      int64
+	CheckedBytes int64
+	Corrupt      int64
+	Trashed      int64
+
+	// CorruptPieces are the most recently found corrupt pieces.
+	CorruptPieces []CorruptPiece
+}
+
+// Scrubber periodically reads every piece and checks it against the hash in
+// its header, to find pieces that were corrupted on disk before an audit does.
+//
+// architecture: Chore
+type Scrubber struct {
+	log     *zap.Logger
+	config  ScrubberConfig
+	store   *Store
+	limiter *rate.Limiter
+
+	mu    sync.Mutex
+	stats ScrubberStats
+
+	Loop *sync2.Cycle
+}
+
+// NewScrubber creates a new piece scrubber.
+func NewScrubber(log *zap.Logger, config ScrubberConfig, store *Store) *Scrubber {
+	burst := scrubBufferSize.Int()
+	if config.ReadRate.Int() > burst {
+		burst = config.ReadRate.Int()
+	}
+	limit := rate.Inf
+	if config.ReadRate > 0 {
+		limit = rate.Limit(config.ReadRate)
+	}
+
+	return &Scrubber{
+		log:     log,
+		config:  config,
+		store:   store,
+		limiter: rate.NewLimiter(limit, burst),
+		Loop:    sync2.NewCycle(config.Interval),
+	}
+}
+
+// Run runs the scrubber.
+func (scrubber *Scrubber) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if scrubber.config.Interval <= 0 {
+		scrubber.log.Info("piece scrubber is disabled")
+		return nil
+	}
+
+	return scrubber.Loop.Run(ctx, func(ctx context.Context) error {
+		if err := scrubber.Scrub(ctx); err != nil {
+			scrubber.log.Error("error during piece scrubbing", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// Scrub checks every piece once.
+func (scrubber *Scrubber) Scrub(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	scrubber.mu.Lock()
+	scrubber.stats.PassStarted = time.Now()
+	scrubber.mu.Unlock()
+
+	satellites, err := scrubber.store.getAllStoringSatellites(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	var checked, corrupt int64
+	for _, satellite := range satellites {
+		err := scrubber.store.WalkSatellitePieces(ctx, satellite, func(access StoredPieceAccess) error {
+			ok, found, err := scrubber.scrubPiece(ctx, satellite, access)
+			if err != nil || !ok {
+				return err
+			}
+			checked++
+			if found {
+				corrupt++
+			}
+			return nil
+		})
+		if err != nil {
+			return Error.Wrap(err)
+		}
+	}
+
+	scrubber.mu.Lock()
+	scrubber.stats.PassFinished = time.Now()
+	scrubber.stats.Passes++
+	scrubber.mu.Unlock()
+
+	mon.IntVal("scrubber_pass_corrupt_pieces").Observe(corrupt)
+	scrubber.log.Info("finished piece scrubbing", zap.Int64("pieces checked", checked), zap.Int64("corrupt pieces", corrupt))
+	return nil
+}
+
+// scrubPiece checks a single piece and handles it if it is corrupt. Pieces
+// that were deleted or couldn't be read are skipped and not checked.
+func (scrubber *Scrubber) scrubPiece(ctx context.Context, satellite storj.NodeID, access StoredPieceAccess) (checked, corrupt bool, err error) {
+	pieceID := access.PieceID()
+
+	size, reason, err := scrubber.CheckPiece(ctx, satellite, access)
+	if err != nil {
+		if errs.IsFunc(err, os.IsNotExist) {
+			// the piece was deleted since it was found.
+			return false, false, nil
+		}
+		if ctx.Err() != nil {
+			return false, false, ctx.Err()
+		}
+		// the piece couldn't be read, which doesn't show that it's corrupt.
+		scrubber.log.Warn("unable to check piece",
+			zap.Stringer("Satellite ID", satellite), zap.Stringer("Piece ID", pieceID), zap.Error(err))
+		mon.Counter("scrubber_pieces_failed").Inc(1)
+		return false, false, nil
+	}
+
+	scrubber.mu.Lock()
+	scrubber.stats.Checked++
+	scrubber.stats.CheckedBytes += size
+	scrubber.mu.Unlock()
+	mon.Counter("scrubber_pieces_checked").Inc(1)
+	mon.Counter("scrubber_bytes_checked").Inc(size)
+
+	if reason == "" {
+		return true, false, nil
+	}
+
+	piece := CorruptPiece{
+		Satellite: satellite,
+		PieceID:   pieceID,
+		FoundAt:   time.Now(),
+		Reason:    reason,
+	}
+	scrubber.log.Warn("found corrupt piece",
+		zap.Stringer("Satellite ID", satellite), zap.Stringer("Piece ID", pieceID), zap.String("reason", reason))
+	mon.Counter("scrubber_pieces_corrupt").Inc(1)
+
+	if scrubber.config.TrashCorrupt {
+		if err := scrubber.store.Trash(ctx, satellite, pieceID); err != nil {
+			scrubber.log.Error("unable to trash corrupt piece",
+				zap.Stringer("Satellite ID", satellite), zap.Stringer("Piece ID", pieceID), zap.Error(err))
+		} else {
+			piece.Trashed = true
+			mon.Counter("scrubber_pieces_trashed").Inc(1)
+		}
+	}
+
+	scrubber.mu.Lock()
+	defer scrubber.mu.Unlock()
+	scrubber.stats.Corrupt++
+	if piece.Trashed {
+		scrubber.stats.Trashed++
+	}
+	scrubber.stats.CorruptPieces = append(scrubber.stats.CorruptPieces, piece)
+	if len(scrubber.stats.CorruptPieces) > maxCorruptPieces {
+		scrubber.stats.CorruptPieces = scrubber.stats.CorruptPieces[1:]
+	}
+	return true, true, nil
+}
+
+// CheckPiece reads the piece and compares its hash with the hash in its
+// header. It returns why the piece is corrupt, or an empty reason if it isn't.
+// A piece is only corrupt when its content was read and doesn't match the
+// size or hash, failures to open or read the piece are returned as errors.
+func (scrubber *Scrubber) CheckPiece(ctx context.Context, satellite storj.NodeID, access StoredPieceAccess) (size int64, reason string, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pieceID := access.PieceID()
+
+	reader, err := scrubber.store.ReaderWithStorageFormat(ctx, satellite, pieceID, access.StorageFormatVersion())
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() { err = errs.Combine(err, reader.Close()) }()
+
+	hash, _, err := scrubber.store.GetHashAndLimit(ctx, satellite, pieceID, reader)
+	if err != nil {
+		return 0, "", err
+	}
+	if len(hash.Hash) == 0 {
+		return 0, "", nil
+	}
+
+	// reading the header leaves the reader within the header area, where each
+	// read would seek to the start of the content again. for pieces smaller
+	// than the header area that reads the content more than once.
+	if _, err := reader.Seek(0, io.SeekStart); err != nil {
+		return 0, "", Error.Wrap(err)
+	}
+
+	h := pkcrypto.NewHash()
+	buf := make([]byte, scrubBufferSize)
+	for {
+		if err := scrubber.limiter.WaitN(ctx, len(buf)); err != nil {
+			return size, "", err
+		}
+		n, err := reader.Read(buf)
+		_, _ = h.Write(buf[:n])
+		size += int64(n)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return size, "", Error.Wrap(err)
+		}
+	}
+
+	if size != reader.Size() {
+		return size, "piece is shorter than its size", nil
+	}
+	if !bytes.Equal(h.Sum(nil), hash.Hash) {
+		return size, "piece hash mismatch", nil
+	}
+	return size, "", nil
+}
+
+// Stats returns the totals of the scrubber.
+func (scrubber *Scrubber) Stats() ScrubberStats {
+	scrubber.mu.Lock()
+	defer scrubber.mu.Unlock()
+
+	stats := scrubber.stats
+	stats.CorruptPieces = append([]CorruptPiece(nil), stats.CorruptPieces...)
+	return stats
+}
+
+// Close stops the scrubber.
+func (scrubber *Scrubber) Close() error {
+	scrubber.Loop.Close()
+	return nil
+}
@@ -0,0 +1,217 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pieces_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
+)
+
+func TestScrubber(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+
+		dir, err := filestore.NewDir(log, ctx.Dir("store"))
+		require.NoError(t, err)
+		blobs := filestore.New(log, dir, filestore.DefaultConfig)
+		defer ctx.Check(blobs.Close)
+
+		store := pieces.NewStore(log, blobs, db.V0PieceInfo(), db.PieceExpirationDB(), nil, pieces.DefaultConfig)
+
+		satellite := testrand.NodeID()
+		write := func(pieceID storj.PieceID) {
+			data := testrand.BytesInt(10 * memory.KiB.Int())
+			writer, err := store.Writer(ctx, satellite, pieceID)
+			require.NoError(t, err)
+			_, err = io.Copy(writer, bytes.NewReader(data))
+			require.NoError(t, err)
+			require.NoError(t, writer.Commit(ctx, &pb.PieceHeader{
+				Hash:         writer.Hash(),
+				CreationTime: time.Now(),
+			}))
+		}
+
+		good, bad := testrand.PieceID(), testrand.PieceID()
+		write(good)
+		write(bad)
+
+		// flip a byte of the content of one of the pieces.
+		info, err := blobs.Stat(ctx, storage.BlobRef{Namespace: satellite.Bytes(), Key: bad.Bytes()})
+		require.NoError(t, err)
+		path, err := info.FullPath(ctx)
+		require.NoError(t, err)
+		file, err := os.OpenFile(path, os.O_RDWR, 0)
+		require.NoError(t, err)
+		b := make([]byte, 1)
+		_, err = file.ReadAt(b, pieces.V1PieceHeaderReservedArea+100)
+		require.NoError(t, err)
+		b[0] ^= 0xff
+		_, err = file.WriteAt(b, pieces.V1PieceHeaderReservedArea+100)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		scrubber := pieces.NewScrubber(log, pieces.ScrubberConfig{
+			Interval:     time.Hour,
+			ReadRate:     memory.MiB,
+			TrashCorrupt: true,
+		}, store)
+		defer ctx.Check(scrubber.Close)
+		require.NoError(t, scrubber.Scrub(ctx))
+
+		stats := scrubber.Stats()
+		require.EqualValues(t, 1, stats.Passes)
+		require.EqualValues(t, 2, stats.Checked)
+		require.EqualValues(t, 20*memory.KiB, stats.CheckedBytes)
+		require.EqualValues(t, 1, stats.Corrupt)
+		require.EqualValues(t, 1, stats.Trashed)
+		require.Len(t, stats.CorruptPieces, 1)
+		require.Equal(t, bad, stats.CorruptPieces[0].PieceID)
+		require.True(t, stats.CorruptPieces[0].Trashed)
+
+		_, err = store.Reader(ctx, satellite, bad)
+		require.True(t, os.IsNotExist(err), err)
+		reader, err := store.Reader(ctx, satellite, good)
+		require.NoError(t, err)
+		require.NoError(t, reader.Close())
+	})
+}
+
+func TestScrubber_SmallPieces(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+
+		dir, err := filestore.NewDir(log, ctx.Dir("store"))
+		require.NoError(t, err)
+		blobs := filestore.New(log, dir, filestore.DefaultConfig)
+		defer ctx.Check(blobs.Close)
+
+		store := pieces.NewStore(log, blobs, db.V0PieceInfo(), db.PieceExpirationDB(), nil, pieces.DefaultConfig)
+		scrubber := pieces.NewScrubber(log, pieces.ScrubberConfig{
+			Interval: time.Hour,
+			ReadRate: memory.MiB,
+		}, store)
+		defer ctx.Check(scrubber.Close)
+
+		// pieces smaller than and exactly as large as the header area.
+		sizes := []int{1, 256, pieces.V1PieceHeaderReservedArea - 1, pieces.V1PieceHeaderReservedArea}
+
+		satellite := testrand.NodeID()
+		var total int64
+		for _, size := range sizes {
+			data := testrand.BytesInt(size)
+			writer, err := store.Writer(ctx, satellite, testrand.PieceID())
+			require.NoError(t, err)
+			_, err = io.Copy(writer, bytes.NewReader(data))
+			require.NoError(t, err)
+			require.NoError(t, writer.Commit(ctx, &pb.PieceHeader{
+				Hash:         writer.Hash(),
+				CreationTime: time.Now(),
+			}))
+			total += int64(size)
+		}
+
+		err = store.WalkSatellitePieces(ctx, satellite, func(access pieces.StoredPieceAccess) error {
+			size, reason, err := scrubber.CheckPiece(ctx, satellite, access)
+			require.NoError(t, err)
+			require.Empty(t, reason)
+
+			_, contentSize, err := access.Size(ctx)
+			require.NoError(t, err)
+			require.Equal(t, contentSize, size)
+			return nil
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, scrubber.Scrub(ctx))
+		stats := scrubber.Stats()
+		require.EqualValues(t, len(sizes), stats.Checked)
+		require.EqualValues(t, total, stats.CheckedBytes)
+		require.Zero(t, stats.Corrupt)
+	})
+}
+
+func TestScrubber_UnreadablePiece(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+
+		dir, err := filestore.NewDir(log, ctx.Dir("store"))
+		require.NoError(t, err)
+		blobs := filestore.New(log, dir, filestore.DefaultConfig)
+		defer ctx.Check(blobs.Close)
+
+		store := pieces.NewStore(log, blobs, db.V0PieceInfo(), db.PieceExpirationDB(), nil, pieces.DefaultConfig)
+
+		satellite := testrand.NodeID()
+		write := func(pieceID storj.PieceID) {
+			data := testrand.BytesInt(10 * memory.KiB.Int())
+			writer, err := store.Writer(ctx, satellite, pieceID)
+			require.NoError(t, err)
+			_, err = io.Copy(writer, bytes.NewReader(data))
+			require.NoError(t, err)
+			require.NoError(t, writer.Commit(ctx, &pb.PieceHeader{
+				Hash:         writer.Hash(),
+				CreationTime: time.Now(),
+			}))
+		}
+
+		good, unreadable := testrand.PieceID(), testrand.PieceID()
+		write(good)
+		write(unreadable)
+
+		// cut the header of one of the pieces, so that its hash can't be read.
+		info, err := blobs.Stat(ctx, storage.BlobRef{Namespace: satellite.Bytes(), Key: unreadable.Bytes()})
+		require.NoError(t, err)
+		path, err := info.FullPath(ctx)
+		require.NoError(t, err)
+		require.NoError(t, os.Truncate(path, 100))
+
+		scrubber := pieces.NewScrubber(log, pieces.ScrubberConfig{
+			Interval:     time.Hour,
+			ReadRate:     memory.MiB,
+			TrashCorrupt: true,
+		}, store)
+		defer ctx.Check(scrubber.Close)
+
+		err = store.WalkSatellitePieces(ctx, satellite, func(access pieces.StoredPieceAccess) error {
+			if access.PieceID() != unreadable {
+				return nil
+			}
+			_, reason, err := scrubber.CheckPiece(ctx, satellite, access)
+			require.Error(t, err)
+			require.Empty(t, reason)
+			return nil
+		})
+		require.NoError(t, err)
+
+		// the unreadable piece is skipped without being reported as corrupt.
+		require.NoError(t, scrubber.Scrub(ctx))
+
+		stats := scrubber.Stats()
+		require.EqualValues(t, 1, stats.Checked)
+		require.EqualValues(t, 10*memory.KiB, stats.CheckedBytes)
+		require.Zero(t, stats.Corrupt)
+		require.Zero(t, stats.Trashed)
+		require.Empty(t, stats.CorruptPieces)
+
+		_, err = blobs.Stat(ctx, storage.BlobRef{Namespace: satellite.Bytes(), Key: unreadable.Bytes()})
+		require.NoError(t, err)
+	})
+}
@@ -58,6 +58,8 @@ type PieceExpirationDB interface {
 	GetExpired(ctx context.Context, expiresBefore time.Time, limit int64) ([]ExpiredInfo, error)
 	// SetExpiration sets an expiration time for the given piece ID on the given satellite
 	SetExpiration(ctx context.Context, satellite storj.NodeID, pieceID storj.PieceID, expiresAt time.Time) error
+	// GetExpirations returns the expiration times of the pieces of the given satellite that are not in the trash
+	GetExpirations(ctx context.Context, satellite storj.NodeID) (map[storj.PieceID]time.Time, error)
 	// DeleteExpiration removes an expiration record for the given piece ID on the given satellite
 	DeleteExpiration(ctx context.Context, satellite storj.NodeID, pieceID storj.PieceID) (found bool, err error)
 	// DeleteFailed marks an expiration record as having experienced a failure in deleting the
@@ -156,6 +158,8 @@ type SatelliteUsage struct {
 type Config struct {
 	WritePreallocSize memory.Size `help:"file preallocated for uploading" default:"4MiB"`
 	DeleteToTrash     bool        `help:"move pieces to trash upon deletion. Warning: if set to false, you risk disqualification for failed audits if a satellite database is restored from backup." default:"true"`
+
+	Scrubber ScrubberConfig
 }
 
 // DefaultConfig is the default value for the Config.
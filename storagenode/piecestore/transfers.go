@@ -0,0 +1,61 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package piecestore
+
+import (
+	"sync"
+
+	"storj.io/common/storj"
+)
+
+// TransferCounts are the numbers of finished transfers by their result.
+type TransferCounts struct {
+	Success  int64
+	Failure  int64
+	Canceled int64
+}
+
+// SatelliteTransfers are the finished uploads and downloads of a satellite.
+type SatelliteTransfers struct {
+	Upload   TransferCounts
+	Download TransferCounts
+}
+
+// TransferStats counts the transfers the endpoint finished since the node started.
+type TransferStats struct {
+	mu         sync.Mutex
+	satellites map[storj.NodeID]*SatelliteTransfers
+}
+
+// NewTransferStats creates empty transfer stats.
+func NewTransferStats() *TransferStats {
+	return &TransferStats{
+		satellites: map[storj.NodeID]*SatelliteTransfers{},
+	}
+}
+
+// update calls fn with the transfers of the satellite.
+func (stats *TransferStats) update(satellite storj.NodeID, fn func(transfers *SatelliteTransfers)) {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	transfers, ok := stats.satellites[satellite]
+	if !ok {
+		transfers = &SatelliteTransfers{}
+		stats.satellites[satellite] = transfers
+	}
+	fn(transfers)
+}
+
+// All returns the transfers of every satellite.
+func (stats *TransferStats) All() map[storj.NodeID]SatelliteTransfers {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	all := make(map[storj.NodeID]SatelliteTransfers, len(stats.satellites))
+	for satellite, transfers := range stats.satellites {
+		all[satellite] = *transfers
+	}
+	return all
+}
@@ -184,7 +184,8 @@ func TestServiceStore(t *testing.T) {
 		reputationDB := db.Reputation()
 		notificationsDB := db.Notifications()
 		log := zaptest.NewLogger(t)
-		notificationService := notifications.NewService(log, notificationsDB)
+		notificationService, err := notifications.NewService(log, notificationsDB, notifications.Config{})
+		require.NoError(t, err)
 		reputationService := reputation.NewService(log, reputationDB, storj.NodeID{}, notificationService)
 
 		id := testrand.NodeID()
@@ -195,7 +196,7 @@ func TestServiceStore(t *testing.T) {
 			SatelliteID: id,
 		}
 
-		err := reputationDB.Store(ctx, stats)
+		err = reputationDB.Store(ctx, stats)
 		require.NoError(t, err)
 
 		statsNew := reputation.Stats{
@@ -230,7 +231,7 @@ func TestServiceStore(t *testing.T) {
 		require.NoError(t, err)
 		amount, err = notificationsDB.UnreadAmount(ctx)
 		require.NoError(t, err)
-		require.Equal(t, amount, 2)
+		require.Equal(t, amount, 3)
 
 		statsNew = reputation.Stats{
 			SatelliteID:        id,
@@ -242,7 +243,7 @@ func TestServiceStore(t *testing.T) {
 		require.NoError(t, err)
 		amount, err = notificationsDB.UnreadAmount(ctx)
 		require.NoError(t, err)
-		require.Equal(t, amount, 2)
+		require.Equal(t, amount, 3)
 
 		statsNew = reputation.Stats{
 			SatelliteID:        id,
@@ -254,7 +255,7 @@ func TestServiceStore(t *testing.T) {
 		require.NoError(t, err)
 		amount, err = notificationsDB.UnreadAmount(ctx)
 		require.NoError(t, err)
-		require.Equal(t, amount, 3)
+		require.Equal(t, amount, 4)
 
 		later = later.AddDate(0, 1, 0)
 
@@ -267,7 +268,7 @@ func TestServiceStore(t *testing.T) {
 		require.NoError(t, err)
 		amount, err = notificationsDB.UnreadAmount(ctx)
 		require.NoError(t, err)
-		require.Equal(t, amount, 4)
+		require.Equal(t, amount, 5)
 
 		statsNew = reputation.Stats{
 			SatelliteID:        id,
@@ -278,7 +279,7 @@ func TestServiceStore(t *testing.T) {
 		require.NoError(t, err)
 		amount, err = notificationsDB.UnreadAmount(ctx)
 		require.NoError(t, err)
-		require.Equal(t, amount, 4)
+		require.Equal(t, amount, 5)
 
 		id2 := testrand.NodeID()
 
@@ -291,6 +292,27 @@ func TestServiceStore(t *testing.T) {
 		require.NoError(t, err)
 		amount, err = notificationsDB.UnreadAmount(ctx)
 		require.NoError(t, err)
-		require.Equal(t, amount, 5)
+		require.Equal(t, amount, 6)
+
+		statsNew = reputation.Stats{
+			SatelliteID: id2,
+			SuspendedAt: &later,
+		}
+
+		err = reputationService.Store(ctx, statsNew, id2)
+		require.NoError(t, err)
+		amount, err = notificationsDB.UnreadAmount(ctx)
+		require.NoError(t, err)
+		require.Equal(t, amount, 8)
+
+		statsNew = reputation.Stats{
+			SatelliteID: id2,
+		}
+
+		err = reputationService.Store(ctx, statsNew, id2)
+		require.NoError(t, err)
+		amount, err = notificationsDB.UnreadAmount(ctx)
+		require.NoError(t, err)
+		require.Equal(t, amount, 9)
 	})
 }
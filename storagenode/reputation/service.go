@@ -34,7 +34,7 @@ func NewService(log *zap.Logger, db DB, nodeID storj.NodeID, notifications *noti
 	}
 }
 
-// Store stores reputation stats into db, and notify's in case of offline suspension.
+// Store stores reputation stats into db, and notify's in case of disqualification or suspension changes.
 func (s *Service) Store(ctx context.Context, stats Stats, satelliteID storj.NodeID) error {
 	rep, err := s.db.Get(ctx, satelliteID)
 	if err != nil {
@@ -46,9 +46,28 @@ func (s *Service) Store(ctx context.Context, stats Stats, satelliteID storj.Node
 		return err
 	}
 
-	if stats.DisqualifiedAt == nil && isSuspended(stats, *rep) {
-		notification := newSuspensionNotification(satelliteID, s.nodeID, *stats.OfflineSuspendedAt)
+	var raised []notifications.NewNotification
+	switch {
+	case stats.DisqualifiedAt != nil:
+		if rep.DisqualifiedAt == nil {
+			raised = append(raised, newDisqualificationNotification(satelliteID, s.nodeID, *stats.DisqualifiedAt))
+		}
+	default:
+		if isSuspended(stats, *rep) {
+			raised = append(raised, newSuspensionNotification(satelliteID, s.nodeID, *stats.OfflineSuspendedAt))
+		}
+		if rep.OfflineSuspendedAt != nil && stats.OfflineSuspendedAt == nil {
+			raised = append(raised, newSuspensionLiftedNotification(satelliteID, s.nodeID, "offline"))
+		}
+		if rep.SuspendedAt == nil && stats.SuspendedAt != nil {
+			raised = append(raised, newUnknownAuditSuspensionNotification(satelliteID, s.nodeID, *stats.SuspendedAt))
+		}
+		if rep.SuspendedAt != nil && stats.SuspendedAt == nil {
+			raised = append(raised, newSuspensionLiftedNotification(satelliteID, s.nodeID, "unknown audit"))
+		}
+	}
 
+	for _, notification := range raised {
 		_, err = s.notifications.Receive(ctx, notification)
 		if err != nil {
 			s.log.Sugar().Errorf("Failed to receive notification", err.Error())
@@ -84,3 +103,33 @@ func newSuspensionNotification(satelliteID storj.NodeID, senderID storj.NodeID,
 		Message:  "This is a reminder that your StorageNode on " + satelliteID.String() + "Satellite is suspended",
 	}
 }
+
+// newUnknownAuditSuspensionNotification - returns unknown audit suspension notification.
+func newUnknownAuditSuspensionNotification(satelliteID storj.NodeID, senderID storj.NodeID, time time.Time) (_ notifications.NewNotification) {
+	return notifications.NewNotification{
+		SenderID: senderID,
+		Type:     notifications.TypeSuspension,
+		Title:    "Your Node was suspended for unknown audit errors " + time.String(),
+		Message:  "This is a reminder that your StorageNode on " + satelliteID.String() + " Satellite is suspended for unknown audit errors",
+	}
+}
+
+// newSuspensionLiftedNotification - returns notification about suspension being lifted.
+func newSuspensionLiftedNotification(satelliteID storj.NodeID, senderID storj.NodeID, kind string) (_ notifications.NewNotification) {
+	return notifications.NewNotification{
+		SenderID: senderID,
+		Type:     notifications.TypeSuspension,
+		Title:    "Your Node is no longer suspended",
+		Message:  "Your StorageNode on " + satelliteID.String() + " Satellite is no longer under " + kind + " suspension",
+	}
+}
+
+// newDisqualificationNotification - returns disqualification notification.
+func newDisqualificationNotification(satelliteID storj.NodeID, senderID storj.NodeID, time time.Time) (_ notifications.NewNotification) {
+	return notifications.NewNotification{
+		SenderID: senderID,
+		Type:     notifications.TypeDisqualification,
+		Title:    "Your Node was disqualified " + time.String(),
+		Message:  "Your StorageNode on " + satelliteID.String() + " Satellite is disqualified",
+	}
+}
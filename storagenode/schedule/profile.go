@@ -0,0 +1,176 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"storj.io/common/memory"
+)
+
+// weekdays are the names of the days in profiles.
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// Profile limits the node during a time of the days of the week.
+type Profile struct {
+	// Source is the profile as it was configured.
+	Source string
+
+	Days [7]bool
+	// Start and End are the time since midnight when the profile starts and
+	// ends. When End isn't after Start, the profile continues into the next day.
+	Start time.Duration
+	End   time.Duration
+
+	// Ingress and Egress are the rates in bytes per second the node transfers
+	// pieces at most. 0 means unlimited.
+	Ingress memory.Size
+	Egress  memory.Size
+
+	// Capacity is the most free space the node reports to satellites, when
+	// LimitCapacity is set.
+	Capacity      memory.Size
+	LimitCapacity bool
+}
+
+// ParseProfile parses a profile in the form of
+// "<days> <hh:mm>-<hh:mm> [ingress=<size>] [egress=<size>] [capacity=<size>]".
+//
+// Days are "*" for every day or a comma-separated list of days and day
+// ranges, such as "mon-fri" or "sat,sun".
+func ParseProfile(s string) (_ Profile, err error) {
+	profile := Profile{Source: s}
+
+	fields := strings.Fields(s)
+	if len(fields) < 2 {
+		return Profile{}, Error.New("invalid profile %q: expected days and time range", s)
+	}
+
+	if err := profile.parseDays(strings.ToLower(fields[0])); err != nil {
+		return Profile{}, Error.New("invalid profile %q: %v", s, err)
+	}
+
+	times := strings.SplitN(fields[1], "-", 2)
+	if len(times) != 2 {
+		return Profile{}, Error.New("invalid profile %q: invalid time range %q", s, fields[1])
+	}
+	if profile.Start, err = parseTimeOfDay(times[0]); err != nil {
+		return Profile{}, Error.New("invalid profile %q: %v", s, err)
+	}
+	if profile.End, err = parseTimeOfDay(times[1]); err != nil {
+		return Profile{}, Error.New("invalid profile %q: %v", s, err)
+	}
+
+	for _, field := range fields[2:] {
+		split := strings.SplitN(field, "=", 2)
+		if len(split) != 2 {
+			return Profile{}, Error.New("invalid profile %q: invalid limit %q", s, field)
+		}
+
+		// memory.Size doesn't handle sizes without any digits.
+		if !strings.ContainsAny(split[1], "0123456789") {
+			return Profile{}, Error.New("invalid profile %q: invalid size %q", s, split[1])
+		}
+		var size memory.Size
+		if err := size.Set(split[1]); err != nil {
+			return Profile{}, Error.New("invalid profile %q: invalid size %q: %v", s, split[1], err)
+		}
+		if size < 0 {
+			return Profile{}, Error.New("invalid profile %q: negative size %q", s, split[1])
+		}
+
+		switch strings.ToLower(split[0]) {
+		case "ingress":
+			profile.Ingress = size
+		case "egress":
+			profile.Egress = size
+		case "capacity":
+			profile.Capacity = size
+			profile.LimitCapacity = true
+		default:
+			return Profile{}, Error.New("invalid profile %q: unknown limit %q", s, split[0])
+		}
+	}
+
+	return profile, nil
+}
+
+func (profile *Profile) parseDays(s string) error {
+	if s == "*" {
+		for day := range profile.Days {
+			profile.Days[day] = true
+		}
+		return nil
+	}
+
+	for _, days := range strings.Split(s, ",") {
+		bounds := strings.SplitN(days, "-", 2)
+
+		first, ok := weekdays[bounds[0]]
+		if !ok {
+			return fmt.Errorf("unknown day %q", bounds[0])
+		}
+		last := first
+		if len(bounds) == 2 {
+			last, ok = weekdays[bounds[1]]
+			if !ok {
+				return fmt.Errorf("unknown day %q", bounds[1])
+			}
+		}
+
+		// ranges such as fri-mon wrap around the end of the week.
+		for day := first; ; day = (day + 1) % 7 {
+			profile.Days[day] = true
+			if day == last {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// parseTimeOfDay parses "hh:mm" into the time since midnight.
+func parseTimeOfDay(s string) (time.Duration, error) {
+	split := strings.SplitN(s, ":", 2)
+	if len(split) != 2 {
+		return 0, fmt.Errorf("invalid time %q, expected hh:mm", s)
+	}
+	hours, err := strconv.Atoi(split[0])
+	if err != nil || hours < 0 || hours > 24 {
+		return 0, fmt.Errorf("invalid hour in %q", s)
+	}
+	minutes, err := strconv.Atoi(split[1])
+	if err != nil || minutes < 0 || minutes > 59 || (hours == 24 && minutes != 0) {
+		return 0, fmt.Errorf("invalid minute in %q", s)
+	}
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute, nil
+}
+
+// Active returns whether the profile applies at the time.
+func (profile *Profile) Active(now time.Time) bool {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	offset := now.Sub(midnight)
+	day := now.Weekday()
+
+	if profile.Start < profile.End {
+		return profile.Days[day] && profile.Start <= offset && offset < profile.End
+	}
+
+	// the profile continues into the next day.
+	if offset >= profile.Start {
+		return profile.Days[day]
+	}
+	return offset < profile.End && profile.Days[(day+6)%7]
+}
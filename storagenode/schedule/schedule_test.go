@@ -0,0 +1,166 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package schedule_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/schedule"
+	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
+)
+
+func TestParseProfile(t *testing.T) {
+	profile, err := schedule.ParseProfile("mon-fri 08:00-18:30 ingress=1MB egress=2MiB capacity=1TB")
+	require.NoError(t, err)
+	require.Equal(t, [7]bool{false, true, true, true, true, true, false}, profile.Days)
+	require.Equal(t, 8*time.Hour, profile.Start)
+	require.Equal(t, 18*time.Hour+30*time.Minute, profile.End)
+	require.Equal(t, memory.MB, profile.Ingress)
+	require.Equal(t, 2*memory.MiB, profile.Egress)
+	require.Equal(t, memory.TB, profile.Capacity)
+	require.True(t, profile.LimitCapacity)
+
+	profile, err = schedule.ParseProfile("* 22:00-06:00")
+	require.NoError(t, err)
+	require.Equal(t, [7]bool{true, true, true, true, true, true, true}, profile.Days)
+	require.False(t, profile.LimitCapacity)
+
+	profile, err = schedule.ParseProfile("fri-mon,wed 00:00-24:00 capacity=0B")
+	require.NoError(t, err)
+	require.Equal(t, [7]bool{true, true, false, true, false, true, true}, profile.Days)
+	require.True(t, profile.LimitCapacity)
+	require.Zero(t, profile.Capacity)
+
+	for _, invalid := range []string{
+		"",
+		"mon",
+		"mon 08:00",
+		"xyz 08:00-09:00",
+		"mon-xyz 08:00-09:00",
+		"mon 8-9",
+		"mon 25:00-26:00",
+		"mon 08:60-09:00",
+		"mon 24:30-09:00",
+		"mon 08:00-09:00 ingress",
+		"mon 08:00-09:00 ingress=fast",
+		"mon 08:00-09:00 speed=1MB",
+	} {
+		_, err := schedule.ParseProfile(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestProfile_Active(t *testing.T) {
+	// 2021-03-05 is a Friday.
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2021, 3, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	daytime, err := schedule.ParseProfile("mon-fri 08:00-18:00")
+	require.NoError(t, err)
+	require.True(t, daytime.Active(at(5, 8, 0)))
+	require.True(t, daytime.Active(at(5, 17, 59)))
+	require.False(t, daytime.Active(at(5, 18, 0)))
+	require.False(t, daytime.Active(at(5, 7, 59)))
+	require.False(t, daytime.Active(at(6, 12, 0)))
+
+	// the night of friday continues into saturday, but the night of
+	// saturday doesn't start.
+	night, err := schedule.ParseProfile("fri 22:00-06:00")
+	require.NoError(t, err)
+	require.False(t, night.Active(at(5, 21, 59)))
+	require.True(t, night.Active(at(5, 22, 0)))
+	require.True(t, night.Active(at(6, 5, 59)))
+	require.False(t, night.Active(at(6, 6, 0)))
+	require.False(t, night.Active(at(6, 22, 0)))
+	require.False(t, night.Active(at(5, 3, 0)))
+
+	weekend, err := schedule.ParseProfile("sat-sun 00:00-24:00")
+	require.NoError(t, err)
+	require.False(t, weekend.Active(at(5, 23, 59)))
+	require.True(t, weekend.Active(at(6, 0, 0)))
+	require.True(t, weekend.Active(at(7, 23, 59)))
+	require.False(t, weekend.Active(at(8, 0, 0)))
+}
+
+func TestService(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		now := time.Now()
+		weekday := []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}[now.Weekday()]
+
+		service, err := schedule.NewService(zaptest.NewLogger(t), db.Bandwidth(), schedule.Config{
+			Profiles:         []string{weekday + " 00:00-24:00 ingress=1MB capacity=5GB"},
+			MonthlyBandwidth: 10 * memory.KB,
+			Interval:         time.Hour,
+		})
+		require.NoError(t, err)
+		defer ctx.Check(service.Close)
+
+		require.NoError(t, service.Update(ctx, now))
+		state := service.State()
+		require.NotNil(t, state.Profile)
+		require.Equal(t, memory.MB, state.Profile.Ingress)
+		require.False(t, state.OverQuota)
+		require.Equal(t, (5 * memory.GB).Int64(), service.Capacity(memory.TB.Int64()))
+		require.Equal(t, memory.GB.Int64(), service.Capacity(memory.GB.Int64()))
+		requireChanged(t, service)
+
+		// nothing changed.
+		require.NoError(t, service.Update(ctx, now))
+		requireNotChanged(t, service)
+
+		err = db.Bandwidth().Add(ctx, testrand.NodeID(), pb.PieceAction_GET, (20 * memory.KB).Int64(), now)
+		require.NoError(t, err)
+
+		require.NoError(t, service.Update(ctx, now))
+		state = service.State()
+		require.True(t, state.OverQuota)
+		require.Equal(t, (20 * memory.KB).Int64(), state.UsedBandwidth)
+		require.Zero(t, service.Capacity(memory.TB.Int64()))
+		requireChanged(t, service)
+
+		// the node is unlimited outside of the profile in the next month.
+		require.NoError(t, service.Update(ctx, now.AddDate(0, 1, 1)))
+		state = service.State()
+		require.Nil(t, state.Profile)
+		require.False(t, state.OverQuota)
+		require.Equal(t, memory.TB.Int64(), service.Capacity(memory.TB.Int64()))
+		requireChanged(t, service)
+
+		require.NoError(t, service.WaitIngress(ctx, memory.MB.Int64()))
+		require.NoError(t, service.WaitEgress(ctx, memory.MB.Int64()))
+	})
+}
+
+func TestService_InvalidProfile(t *testing.T) {
+	_, err := schedule.NewService(zaptest.NewLogger(t), nil, schedule.Config{
+		Profiles: []string{"someday 08:00-09:00"},
+	})
+	require.Error(t, err)
+}
+
+func requireChanged(t *testing.T, service *schedule.Service) {
+	select {
+	case <-service.Changes():
+	default:
+		t.Fatal("expected capacity change")
+	}
+}
+
+func requireNotChanged(t *testing.T, service *schedule.Service) {
+	select {
+	case <-service.Changes():
+		t.Fatal("unexpected capacity change")
+	default:
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package schedule limits the bandwidth and the advertised capacity of the
+// storage node according to the time of the week and the bandwidth used in
+// the month.
+package schedule
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"storj.io/common/memory"
+	"storj.io/common/sync2"
+	"storj.io/storj/storagenode/bandwidth"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the default error class for schedule errors.
+	Error = errs.Class("schedule")
+)
+
+// minBurst is the least amount of bytes a rate limited transfer can send at once.
+const minBurst = 64 * memory.KiB
+
+// Config defines the bandwidth and capacity schedule of the node.
+type Config struct {
+	Profiles         []string      `help:"profiles that limit the node at times of the week, as \"<days> <hh:mm>-<hh:mm> [ingress=<size>] [egress=<size>] [capacity=<size>]\" in local time, e.g. \"mon-fri 08:00-18:00 ingress=1MB egress=2MB\"; rates are per second and the first active profile applies"`
+	MonthlyBandwidth memory.Size   `help:"bandwidth the node may use in a month, after which it reports no free capacity to satellites. 0 means unlimited" default:"0B"`
+	Interval         time.Duration `help:"how often the active profile and the used bandwidth are checked" default:"1m0s"`
+}
+
+// State is the schedule that is in effect.
+type State struct {
+	// Profile is the active profile, nil when none is active.
+	Profile *Profile
+
+	// UsedBandwidth is the bandwidth used in the current month, when there is
+	// a monthly bandwidth quota.
+	UsedBandwidth int64
+	// OverQuota is set when the monthly bandwidth quota is used up.
+	OverQuota bool
+}
+
+// Service tracks which profile is active and whether the monthly bandwidth
+// is used up, and limits the node accordingly.
+//
+// architecture: Service
+type Service struct {
+	log      *zap.Logger
+	config   Config
+	usageDB  bandwidth.DB
+	profiles []Profile
+
+	ingress *rate.Limiter
+	egress  *rate.Limiter
+
+	mu    sync.Mutex
+	state State
+
+	changes chan struct{}
+
+	Loop *sync2.Cycle
+}
+
+// NewService creates a new schedule service.
+func NewService(log *zap.Logger, usageDB bandwidth.DB, config Config) (*Service, error) {
+	service := &Service{
+		log:     log,
+		config:  config,
+		usageDB: usageDB,
+		ingress: rate.NewLimiter(rate.Inf, minBurst.Int()),
+		egress:  rate.NewLimiter(rate.Inf, minBurst.Int()),
+		changes: make(chan struct{}, 1),
+		Loop:    sync2.NewCycle(config.Interval),
+	}
+
+	for _, source := range config.Profiles {
+		profile, err := ParseProfile(source)
+		if err != nil {
+			return nil, err
+		}
+		service.profiles = append(service.profiles, profile)
+	}
+
+	return service, nil
+}
+
+// Run periodically updates which limits apply.
+func (service *Service) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(service.profiles) == 0 && service.config.MonthlyBandwidth <= 0 {
+		return nil
+	}
+
+	return service.Loop.Run(ctx, func(ctx context.Context) error {
+		if err := service.Update(ctx, time.Now()); err != nil {
+			service.log.Error("unable to update schedule", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// Update applies the limits that are in effect at the time.
+func (service *Service) Update(ctx context.Context, now time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var state State
+	for i := range service.profiles {
+		if service.profiles[i].Active(now) {
+			state.Profile = &service.profiles[i]
+			break
+		}
+	}
+
+	if service.config.MonthlyBandwidth > 0 {
+		state.UsedBandwidth, err = service.usageDB.MonthSummary(ctx, now)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		state.OverQuota = state.UsedBandwidth >= service.config.MonthlyBandwidth.Int64()
+	}
+
+	var ingress, egress memory.Size
+	if state.Profile != nil {
+		ingress, egress = state.Profile.Ingress, state.Profile.Egress
+	}
+	setRate(service.ingress, ingress)
+	setRate(service.egress, egress)
+
+	service.mu.Lock()
+	previous := service.state
+	service.state = state
+	service.mu.Unlock()
+
+	if previous.Profile != state.Profile {
+		if state.Profile != nil {
+			service.log.Info("schedule profile active", zap.String("Profile", state.Profile.Source))
+		} else {
+			service.log.Info("no schedule profile active")
+		}
+	}
+	if previous.OverQuota != state.OverQuota {
+		if state.OverQuota {
+			service.log.Warn("monthly bandwidth is used up, reporting no free capacity",
+				zap.Int64("Used", state.UsedBandwidth), zap.Int64("Monthly Bandwidth", service.config.MonthlyBandwidth.Int64()))
+		} else {
+			service.log.Info("monthly bandwidth is available again")
+		}
+	}
+
+	if capacityLimit(previous) != capacityLimit(state) {
+		select {
+		case service.changes <- struct{}{}:
+		default:
+		}
+	}
+
+	mon.IntVal("schedule_ingress_limit").Observe(ingress.Int64())
+	mon.IntVal("schedule_egress_limit").Observe(egress.Int64())
+	return nil
+}
+
+// setRate changes the rate of the limiter, where 0 means unlimited.
+func setRate(limiter *rate.Limiter, size memory.Size) {
+	if size <= 0 {
+		limiter.SetLimit(rate.Inf)
+		return
+	}
+	burst := size
+	if burst < minBurst {
+		burst = minBurst
+	}
+	limiter.SetBurst(burst.Int())
+	limiter.SetLimit(rate.Limit(size))
+}
+
+// capacityLimit returns the most free space that the node reports in the
+// state, or -1 when it isn't limited.
+func capacityLimit(state State) int64 {
+	switch {
+	case state.OverQuota:
+		return 0
+	case state.Profile != nil && state.Profile.LimitCapacity:
+		return state.Profile.Capacity.Int64()
+	default:
+		return -1
+	}
+}
+
+// State returns the schedule that is in effect.
+func (service *Service) State() State {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	return service.state
+}
+
+// Capacity returns the free space the node reports to satellites, when it
+// has free space available.
+func (service *Service) Capacity(free int64) int64 {
+	limit := capacityLimit(service.State())
+	if limit >= 0 && limit < free {
+		return limit
+	}
+	return free
+}
+
+// Changes returns a channel that receives when the reported capacity changes.
+func (service *Service) Changes() <-chan struct{} {
+	return service.changes
+}
+
+// WaitIngress waits until n bytes may be received.
+func (service *Service) WaitIngress(ctx context.Context, n int64) error {
+	return wait(ctx, service.ingress, n)
+}
+
+// WaitEgress waits until n bytes may be sent.
+func (service *Service) WaitEgress(ctx context.Context, n int64) error {
+	return wait(ctx, service.egress, n)
+}
+
+// wait waits for n bytes in parts that fit the burst of the limiter.
+func wait(ctx context.Context, limiter *rate.Limiter, n int64) error {
+	for n > 0 {
+		if limiter.Limit() == rate.Inf {
+			return nil
+		}
+		part := int64(limiter.Burst())
+		if part > n {
+			part = n
+		}
+		if err := limiter.WaitN(ctx, int(part)); err != nil {
+			return err
+		}
+		n -= part
+	}
+	return nil
+}
+
+// Close stops the schedule service.
+func (service *Service) Close() error {
+	service.Loop.Close()
+	return nil
+}
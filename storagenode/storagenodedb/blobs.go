@@ -0,0 +1,128 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storagenodedb
+
+import (
+	"context"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/logstore"
+	"storj.io/storj/storage/multidir"
+)
+
+// Backends that pieces can be stored with.
+const (
+	FilestoreBackend = "filestore"
+	LogstoreBackend  = "logstore"
+)
+
+// OtherBackend returns the backend that is not the given one.
+func OtherBackend(backend string) string {
+	if backend == LogstoreBackend {
+		return FilestoreBackend
+	}
+	return LogstoreBackend
+}
+
+// OpenBlobs opens the blob store of the backend in the pieces directory,
+// without checking whether pieces are stored with another backend.
+func OpenBlobs(log *zap.Logger, config Config, backend string) (storage.Blobs, error) {
+	dir, err := filestore.NewDir(log, config.Pieces)
+	if err != nil {
+		return nil, err
+	}
+	return openBlobs(log, config, dir, backend)
+}
+
+func openBlobs(log *zap.Logger, config Config, dir *filestore.Dir, backend string) (storage.Blobs, error) {
+	switch backend {
+	case FilestoreBackend, "":
+		return filestore.New(log, dir, config.Filestore), nil
+	case LogstoreBackend:
+		return logstore.New(log, dir, config.Logstore)
+	default:
+		return nil, errs.New("unknown piece backend %q", backend)
+	}
+}
+
+// openPieces opens the blob store of the configured backend. Starting with
+// another backend than the pieces are stored with would lose every piece, so
+// it fails if the other backend has pieces.
+func openPieces(ctx context.Context, log *zap.Logger, config Config, dir *filestore.Dir) (_ storage.Blobs, err error) {
+	backend := config.Backend
+	if backend == "" {
+		backend = FilestoreBackend
+	}
+
+	pieces, err := openBlobs(log, config, dir, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	inUse := true
+	if backend == FilestoreBackend {
+		inUse, err = logstore.InUse(dir.Path())
+		if err != nil {
+			return nil, errs.Combine(err, pieces.Close())
+		}
+	}
+	if !inUse {
+		return pieces, nil
+	}
+
+	other, err := openBlobs(log, config, dir, OtherBackend(backend))
+	if err != nil {
+		return nil, errs.Combine(err, pieces.Close())
+	}
+	namespaces, err := other.ListNamespaces(ctx)
+	if err = errs.Combine(err, other.Close()); err != nil {
+		return nil, errs.Combine(err, pieces.Close())
+	}
+	if len(namespaces) > 0 {
+		return nil, errs.Combine(
+			errs.New("pieces are stored with the %s backend; run the migrate-pieces command to move them to the %s backend",
+				OtherBackend(backend), backend),
+			pieces.Close())
+	}
+	return pieces, nil
+}
+
+// openStorage opens the blob store of the pieces directory, or one that
+// spreads the pieces over it and the extra piece directories if there are
+// any. An extra directory that can't be opened is only logged, so that a
+// failed disk doesn't keep the storage node from starting.
+func openStorage(ctx context.Context, log *zap.Logger, config Config, dir *filestore.Dir) (_ storage.Blobs, err error) {
+	pieces, err := openPieces(ctx, log, config, dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(config.ExtraPieces) == 0 {
+		return pieces, nil
+	}
+
+	disks := []multidir.Disk{{Path: config.Pieces, Dir: dir, Blobs: pieces}}
+	for _, path := range config.ExtraPieces {
+		disk := multidir.Disk{Path: path}
+		disk.Dir, disk.Err = filestore.NewDir(log, path)
+		if disk.Err == nil {
+			disk.Blobs, disk.Err = openPieces(ctx, log, config, disk.Dir)
+		}
+		disks = append(disks, disk)
+	}
+
+	store, err := multidir.New(log.Named("multidir"), disks, multidir.Placement(config.Placement))
+	if err != nil {
+		for _, disk := range disks {
+			if disk.Blobs != nil {
+				err = errs.Combine(err, disk.Blobs.Close())
+			}
+		}
+		return nil, err
+	}
+	return store, nil
+}
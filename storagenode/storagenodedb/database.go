@@ -25,6 +25,7 @@ import (
 	"storj.io/storj/private/migrate"
 	"storj.io/storj/storage"
 	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/logstore"
 	"storj.io/storj/storagenode/apikeys"
 	"storj.io/storj/storagenode/bandwidth"
 	"storj.io/storj/storagenode/notifications"
@@ -77,12 +78,21 @@ func withTx(ctx context.Context, db tagsql.DB, cb func(tx tagsql.Tx) error) erro
 // Config configures storage node database.
 type Config struct {
 	// TODO: figure out better names
-	Storage   string
-	Info      string
-	Info2     string
-	Driver    string // if unset, uses sqlite3
-	Pieces    string
-	Filestore filestore.Config
+	Storage     string
+	Info        string
+	Info2       string
+	Driver      string // if unset, uses sqlite3
+	Pieces      string
+	ExtraPieces []string
+	Placement   string // if unset, uses most free space
+	Backend     string // if unset, uses filestore
+	Filestore   filestore.Config
+	Logstore    logstore.Config
+}
+
+// PieceDirs returns every directory that pieces are stored in.
+func (config Config) PieceDirs() []string {
+	return append([]string{config.Pieces}, config.ExtraPieces...)
 }
 
 // DB contains access to different database tables.
@@ -119,7 +129,10 @@ func OpenNew(ctx context.Context, log *zap.Logger, config Config) (*DB, error) {
 		return nil, err
 	}
 
-	pieces := filestore.New(log, piecesDir, config.Filestore)
+	pieces, err := openStorage(ctx, log, config, piecesDir)
+	if err != nil {
+		return nil, err
+	}
 
 	deprecatedInfoDB := &deprecatedInfoDB{}
 	v0PieceInfoDB := &v0PieceInfoDB{}
@@ -187,7 +200,10 @@ func OpenExisting(ctx context.Context, log *zap.Logger, config Config) (*DB, err
 		return nil, err
 	}
 
-	pieces := filestore.New(log, piecesDir, config.Filestore)
+	pieces, err := openStorage(ctx, log, config, piecesDir)
+	if err != nil {
+		return nil, err
+	}
 
 	deprecatedInfoDB := &deprecatedInfoDB{}
 	v0PieceInfoDB := &v0PieceInfoDB{}
@@ -456,7 +472,7 @@ func (db *DB) preflight(ctx context.Context, dbName string, dbContainer DBContai
 
 // Close closes any resources.
 func (db *DB) Close() error {
-	return db.closeDatabases()
+	return errs.Combine(db.closeDatabases(), db.pieces.Close())
 }
 
 // closeDatabases closes all the SQLite database connections and removes them from the associated maps.
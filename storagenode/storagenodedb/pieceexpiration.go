@@ -67,6 +67,33 @@ func (db *pieceExpirationDB) SetExpiration(ctx context.Context, satellite storj.
 	return ErrPieceExpiration.Wrap(err)
 }
 
+// GetExpirations returns the expiration times of the pieces of the given satellite that are not in the trash.
+func (db *pieceExpirationDB) GetExpirations(ctx context.Context, satellite storj.NodeID) (_ map[storj.PieceID]time.Time, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT piece_id, piece_expiration
+			FROM piece_expirations
+			WHERE satellite_id = ?
+				AND trash = 0
+	`, satellite)
+	if err != nil {
+		return nil, ErrPieceExpiration.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	expirations := map[storj.PieceID]time.Time{}
+	for rows.Next() {
+		var pieceID storj.PieceID
+		var expiresAt time.Time
+		if err := rows.Scan(&pieceID, &expiresAt); err != nil {
+			return nil, ErrPieceExpiration.Wrap(err)
+		}
+		expirations[pieceID] = expiresAt
+	}
+	return expirations, ErrPieceExpiration.Wrap(rows.Err())
+}
+
 // DeleteExpiration removes an expiration record for the given piece ID on the given satellite.
 func (db *pieceExpirationDB) DeleteExpiration(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID) (found bool, err error) {
 	defer mon.Task()(&ctx)(&err)
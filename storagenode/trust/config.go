@@ -4,6 +4,7 @@
 package trust
 
 import (
+	"crypto/ed25519"
 	"strings"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 type Config struct {
 	Sources         Sources       `help:"list of trust sources" devDefault:"" releaseDefault:"https://www.storj.io/dcs-satellites"`
 	Exclusions      Exclusions    `help:"list of trust exclusions" devDefault:"" releaseDefault:""`
+	ListKeys        []string      `help:"hex encoded ed25519 public keys that signed+http(s):// and signed+file:// trust lists are verified with"`
 	RefreshInterval time.Duration `help:"how often the trust pool should be refreshed" default:"6h"`
 	CachePath       string        `help:"file path where trust lists should be cached" default:"${CONFDIR}/trust-cache.json"`
 }
@@ -55,6 +57,23 @@ func (sources Sources) Type() string {
 	return "trust-sources"
 }
 
+// WithKeys returns the sources where signed sources verify lists with the keys.
+// It fails when there are signed sources but no keys, since their lists could
+// never be verified.
+func (sources Sources) WithKeys(keys []ed25519.PublicKey) (Sources, error) {
+	withKeys := make(Sources, 0, len(sources))
+	for _, source := range sources {
+		if signed, ok := source.(*SignedSource); ok {
+			if len(keys) == 0 {
+				return nil, Error.New("signed source %q requires list keys", signed.String())
+			}
+			source = signed.WithKeys(keys)
+		}
+		withKeys = append(withKeys, source)
+	}
+	return withKeys, nil
+}
+
 // Exclusions is a list of excluding rules that implements pflag.Value.
 type Exclusions struct {
 	Rules Rules
@@ -5,6 +5,7 @@ package trust
 
 import (
 	"context"
+	"io/ioutil"
 	"os"
 
 	"github.com/zeebo/errs"
@@ -54,6 +55,24 @@ func (source *FileSource) FetchEntries(ctx context.Context) (_ []Entry, err erro
 	return entries, nil
 }
 
+// fetchSignedList returns the signed list in the file and its detached
+// signature, which is in the file at the same path with a ".sig" suffix.
+func (source *FileSource) fetchSignedList(ctx context.Context) (list, signature []byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if list, err = ioutil.ReadFile(source.path); err != nil {
+		return nil, nil, ErrFileSource.Wrap(err)
+	}
+	if signature, err = ioutil.ReadFile(source.path + signatureSuffix); err != nil {
+		return nil, nil, ErrFileSource.Wrap(err)
+	}
+	return list, signature, nil
+}
+
+// isAuthoritative implements signedListSource. Entries from files are
+// authoritative.
+func (source *FileSource) isAuthoritative(SatelliteURL) bool { return true }
+
 // LoadSatelliteURLList loads a list of Satellite URLs from a path on disk.
 func LoadSatelliteURLList(ctx context.Context, path string) (_ []SatelliteURL, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -7,6 +7,7 @@ import (
 	"bufio"
 	"context"
 	"io"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/url"
@@ -58,41 +59,91 @@ func (source *HTTPSource) Static() bool { return false }
 func (source *HTTPSource) FetchEntries(ctx context.Context) (_ []Entry, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", source.url.String(), nil)
+	body, err := source.get(ctx, source.url)
 	if err != nil {
-		return nil, ErrHTTPSource.Wrap(err)
-	}
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, ErrHTTPSource.Wrap(err)
+		return nil, err
 	}
 	defer func() {
 		// Errors closing the response body can be ignored since they don't
 		// impact the correctness of the function.
-		_ = resp.Body.Close()
+		_ = body.Close()
 	}()
-	if resp.StatusCode != http.StatusOK {
-		return nil, ErrHTTPSource.New("%q: unexpected status code %d: %q", source.url, resp.StatusCode, tryReadLine(resp.Body))
-	}
 
-	urls, err := ParseSatelliteURLList(ctx, resp.Body)
+	urls, err := ParseSatelliteURLList(ctx, body)
 	if err != nil {
 		return nil, ErrHTTPSource.New("cannot parse list at %q: %w", source.url, err)
 	}
 
 	var entries []Entry
 	for _, url := range urls {
-		authoritative := URLMatchesHTTPSourceHost(url.Host, source.url.Hostname())
-
 		entries = append(entries, Entry{
 			SatelliteURL:  url,
-			Authoritative: authoritative,
+			Authoritative: source.isAuthoritative(url),
 		})
 	}
 	return entries, nil
 }
 
+// fetchSignedList returns the signed list at the source URL and its detached
+// signature, which is at the same URL with a ".sig" suffix on the path.
+func (source *HTTPSource) fetchSignedList(ctx context.Context) (list, signature []byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	signatureURL := *source.url
+	signatureURL.Path += signatureSuffix
+	signatureURL.RawPath = ""
+
+	if list, err = source.read(ctx, source.url); err != nil {
+		return nil, nil, err
+	}
+	if signature, err = source.read(ctx, &signatureURL); err != nil {
+		return nil, nil, err
+	}
+	return list, signature, nil
+}
+
+// isAuthoritative returns whether the entry URL has a host that matches or
+// is a subdomain of the source URL.
+func (source *HTTPSource) isAuthoritative(url SatelliteURL) bool {
+	return URLMatchesHTTPSourceHost(url.Host, source.url.Hostname())
+}
+
+// read returns the contents at the URL, up to maxSignedListSize bytes.
+func (source *HTTPSource) read(ctx context.Context, u *url.URL) (_ []byte, err error) {
+	body, err := source.get(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = body.Close() }()
+
+	data, err := ioutil.ReadAll(io.LimitReader(body, maxSignedListSize+1))
+	if err != nil {
+		return nil, ErrHTTPSource.Wrap(err)
+	}
+	if len(data) > maxSignedListSize {
+		return nil, ErrHTTPSource.New("%q: larger than %d bytes", u, maxSignedListSize)
+	}
+	return data, nil
+}
+
+// get returns the body of a successful GET request to the URL.
+func (source *HTTPSource) get(ctx context.Context, u *url.URL) (_ io.ReadCloser, err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, ErrHTTPSource.Wrap(err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, ErrHTTPSource.Wrap(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer func() { _ = resp.Body.Close() }()
+		return nil, ErrHTTPSource.New("%q: unexpected status code %d: %q", u, resp.StatusCode, tryReadLine(resp.Body))
+	}
+	return resp.Body, nil
+}
+
 // URLMatchesHTTPSourceHost takes the Satellite URL host and the host of the
 // HTTPSource URL and determines if the SatelliteURL matches or is in the
 // same domain as the HTTPSource URL.
@@ -95,7 +95,12 @@ func (list *List) fetchEntries(ctx context.Context) (_ []Entry, err error) {
 				sourceLog.Error("Failed to fetch URLs from source", zap.Error(err))
 				return nil, Error.New("failed to fetch from source %q: %w", source.String(), err)
 			}
-			sourceLog.Warn("Failed to fetch URLs from source; used cache", zap.Error(err))
+			if ErrSignature.Has(err) {
+				// keep trusting the last list with a valid signature.
+				sourceLog.Error("Fetched list has an invalid signature; used cache", zap.Error(err))
+			} else {
+				sourceLog.Warn("Failed to fetch URLs from source; used cache", zap.Error(err))
+			}
 		} else {
 			sourceLog.Debug("Fetched URLs from source; updating cache", zap.Int("count", len(entries)))
 			list.updateCache(source, entries)
@@ -91,7 +91,17 @@ func NewPool(log *zap.Logger, resolver IdentityResolver, config Config, satellit
 		return nil, err
 	}
 
-	list, err := NewList(log, config.Sources, config.Exclusions.Rules, cache)
+	keys, err := ParseListKeys(config.ListKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	sources, err := config.Sources.WithKeys(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := NewList(log, sources, config.Exclusions.Rules, cache)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,160 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package trust
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/zeebo/errs"
+)
+
+const (
+	// signatureSuffix is appended to the location of a signed list to find
+	// its detached signature.
+	signatureSuffix = ".sig"
+
+	// maxSignedListSize is the largest signed list or signature that is read.
+	maxSignedListSize = 1 << 20
+)
+
+var (
+	// ErrSignedSource is an error class for signed source errors.
+	ErrSignedSource = errs.Class("signed source")
+
+	// ErrSignature is an error class for signed lists that fail verification.
+	ErrSignature = errs.Class("trust list signature")
+)
+
+// SignedList is the format of signed trust lists.
+type SignedList struct {
+	Satellites []string `json:"satellites"`
+}
+
+// signedListSource is a source that can fetch a signed list and its detached
+// signature.
+type signedListSource interface {
+	Source
+
+	// fetchSignedList returns the signed list and its detached signature.
+	fetchSignedList(ctx context.Context) (list, signature []byte, err error)
+
+	// isAuthoritative returns whether the entry from the list is authoritative.
+	isAuthoritative(url SatelliteURL) bool
+}
+
+// SignedSource represents a trust source with a signed JSON list that is
+// fetched from an HTTP(S) URL or a file. The list has a detached hex encoded
+// ed25519 signature at the same location with a ".sig" suffix, which has to
+// be valid for one of the configured keys.
+type SignedSource struct {
+	source signedListSource
+	keys   []ed25519.PublicKey
+}
+
+// NewSignedSource creates a new SignedSource for the list at the HTTPSource
+// or FileSource. The source cannot verify lists until it has keys.
+func NewSignedSource(source signedListSource) *SignedSource {
+	return &SignedSource{
+		source: source,
+	}
+}
+
+// WithKeys returns a copy of the source that verifies lists with the keys.
+func (source *SignedSource) WithKeys(keys []ed25519.PublicKey) *SignedSource {
+	return &SignedSource{
+		source: source.source,
+		keys:   keys,
+	}
+}
+
+// String implements the Source interface and returns the signed source URL.
+func (source *SignedSource) String() string {
+	if file, ok := source.source.(*FileSource); ok {
+		return "signed+file://" + file.String()
+	}
+	return "signed+" + source.source.String()
+}
+
+// Static implements the Source interface. It returns false for this source,
+// so that the last list with a valid signature is cached.
+func (source *SignedSource) Static() bool { return false }
+
+// FetchEntries implements the Source interface and returns the entries of the
+// signed list, after verifying its signature. Each entry records the key
+// that validated the list.
+func (source *SignedSource) FetchEntries(ctx context.Context) (_ []Entry, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(source.keys) == 0 {
+		return nil, ErrSignedSource.New("%q: no list keys configured", source.String())
+	}
+
+	list, signature, err := source.source.fetchSignedList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := VerifyList(source.keys, list, signature)
+	if err != nil {
+		return nil, ErrSignature.New("%q: %w", source.String(), err)
+	}
+
+	var signed SignedList
+	if err := json.Unmarshal(list, &signed); err != nil {
+		return nil, ErrSignedSource.New("cannot parse list at %q: %w", source.String(), err)
+	}
+
+	signedBy := hex.EncodeToString(key)
+
+	var entries []Entry
+	for _, s := range signed.Satellites {
+		url, err := ParseSatelliteURL(s)
+		if err != nil {
+			return nil, ErrSignedSource.New("cannot parse list at %q: %w", source.String(), err)
+		}
+		entries = append(entries, Entry{
+			SatelliteURL:  url,
+			Authoritative: source.source.isAuthoritative(url),
+			SignedBy:      signedBy,
+		})
+	}
+	return entries, nil
+}
+
+// SignList returns the detached hex encoded signature of the list.
+func SignList(list []byte, key ed25519.PrivateKey) []byte {
+	return []byte(hex.EncodeToString(ed25519.Sign(key, list)) + "\n")
+}
+
+// VerifyList verifies the detached hex encoded signature of the list and
+// returns the key the signature is valid for.
+func VerifyList(keys []ed25519.PublicKey, list, signature []byte) (ed25519.PublicKey, error) {
+	decoded, err := hex.DecodeString(string(bytes.TrimSpace(signature)))
+	if err != nil {
+		return nil, errs.New("malformed signature: %w", err)
+	}
+	for _, key := range keys {
+		if ed25519.Verify(key, list, decoded) {
+			return key, nil
+		}
+	}
+	return nil, errs.New("signature is not valid for any of the list keys")
+}
+
+// ParseListKeys parses hex encoded ed25519 public keys.
+func ParseListKeys(keys []string) ([]ed25519.PublicKey, error) {
+	var parsed []ed25519.PublicKey
+	for _, key := range keys {
+		decoded, err := hex.DecodeString(key)
+		if err != nil || len(decoded) != ed25519.PublicKeySize {
+			return nil, Error.New("invalid list key %q: expected %d hex encoded bytes", key, ed25519.PublicKeySize)
+		}
+		parsed = append(parsed, ed25519.PublicKey(decoded))
+	}
+	return parsed, nil
+}
@@ -0,0 +1,212 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package trust_test
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/trust"
+)
+
+func makeSignedList(t *testing.T, urls ...trust.SatelliteURL) []byte {
+	var list trust.SignedList
+	for _, url := range urls {
+		list.Satellites = append(list.Satellites, url.String())
+	}
+	data, err := json.Marshal(list)
+	require.NoError(t, err)
+	return data
+}
+
+func generateListKey(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	publicKey, privateKey, err := ed25519.GenerateKey(testrand.Reader())
+	require.NoError(t, err)
+	return publicKey, privateKey
+}
+
+func TestSignedSourceFile(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	publicKey, privateKey := generateListKey(t)
+	otherKey, otherPrivateKey := generateListKey(t)
+
+	url1 := makeSatelliteURL("domain1.test")
+	list := makeSignedList(t, url1)
+
+	path := ctx.File("list.json")
+	require.NoError(t, ioutil.WriteFile(path, list, 0644))
+	require.NoError(t, ioutil.WriteFile(path+".sig", trust.SignList(list, privateKey), 0644))
+
+	source, err := trust.NewSource("signed+file://" + path)
+	require.NoError(t, err)
+	require.Equal(t, "signed+file://"+path, source.String())
+	require.False(t, source.Static())
+
+	// lists can't be verified without keys.
+	_, err = source.FetchEntries(ctx)
+	require.Error(t, err)
+
+	signed := source.(*trust.SignedSource).WithKeys([]ed25519.PublicKey{otherKey, publicKey})
+	entries, err := signed.FetchEntries(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []trust.Entry{{
+		SatelliteURL:  url1,
+		Authoritative: true,
+		SignedBy:      hex.EncodeToString(publicKey),
+	}}, entries)
+
+	// a list signed by an unknown key isn't trusted.
+	require.NoError(t, ioutil.WriteFile(path+".sig", trust.SignList(list, otherPrivateKey), 0644))
+	_, err = source.(*trust.SignedSource).WithKeys([]ed25519.PublicKey{publicKey}).FetchEntries(ctx)
+	require.True(t, trust.ErrSignature.Has(err), "expected signature error, got %v", err)
+}
+
+func TestSignedSourceHTTP(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	publicKey, privateKey := generateListKey(t)
+
+	url1 := makeSatelliteURL("127.0.0.1")
+	url2 := makeSatelliteURL("domain.test")
+	list := makeSignedList(t, url1, url2)
+	signature := trust.SignList(list, privateKey)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/list.json":
+			_, _ = w.Write(list)
+		case "/list.json.sig":
+			_, _ = w.Write(signature)
+		case "/unsigned.json":
+			_, _ = w.Write(list)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	source, err := trust.NewSource("signed+" + server.URL + "/list.json")
+	require.NoError(t, err)
+	require.Equal(t, "signed+"+server.URL+"/list.json", source.String())
+	signed := source.(*trust.SignedSource).WithKeys([]ed25519.PublicKey{publicKey})
+
+	entries, err := signed.FetchEntries(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []trust.Entry{
+		{SatelliteURL: url1, Authoritative: true, SignedBy: hex.EncodeToString(publicKey)},
+		{SatelliteURL: url2, Authoritative: false, SignedBy: hex.EncodeToString(publicKey)},
+	}, entries)
+
+	// the signature is required.
+	source, err = trust.NewSource("signed+" + server.URL + "/unsigned.json")
+	require.NoError(t, err)
+	_, err = source.(*trust.SignedSource).WithKeys([]ed25519.PublicKey{publicKey}).FetchEntries(ctx)
+	require.Error(t, err)
+}
+
+func TestSignedSourceKeepsLastGoodList(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	publicKey, privateKey := generateListKey(t)
+
+	url1 := makeSatelliteURL("domain1.test")
+	url2 := makeSatelliteURL("domain2.test")
+
+	path := ctx.File("list.json")
+	writeList := func(list, signature []byte) {
+		require.NoError(t, ioutil.WriteFile(path, list, 0644))
+		require.NoError(t, ioutil.WriteFile(path+".sig", signature, 0644))
+	}
+
+	source, err := trust.NewSource("signed+file://" + path)
+	require.NoError(t, err)
+	sources, err := trust.Sources{source}.WithKeys([]ed25519.PublicKey{publicKey})
+	require.NoError(t, err)
+
+	cache, err := trust.LoadCache(ctx.File("cache.json"))
+	require.NoError(t, err)
+	list, err := trust.NewList(zaptest.NewLogger(t), sources, nil, cache)
+	require.NoError(t, err)
+
+	good := makeSignedList(t, url1)
+	writeList(good, trust.SignList(good, privateKey))
+
+	urls, err := list.FetchURLs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []storj.NodeURL{url1.NodeURL()}, urls)
+
+	// the cache records which key validated the entries.
+	cached, err := trust.LoadCacheData(cache.Path())
+	require.NoError(t, err)
+	require.Equal(t, []trust.Entry{{
+		SatelliteURL:  url1,
+		Authoritative: true,
+		SignedBy:      hex.EncodeToString(publicKey),
+	}}, cached.Entries[source.String()])
+
+	// a tampered list keeps the last good list.
+	tampered := makeSignedList(t, url2)
+	writeList(tampered, trust.SignList(good, privateKey))
+
+	urls, err = list.FetchURLs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []storj.NodeURL{url1.NodeURL()}, urls)
+
+	cachedAfter, err := trust.LoadCacheData(cache.Path())
+	require.NoError(t, err)
+	require.Equal(t, cached, cachedAfter)
+
+	// a new list with a valid signature replaces it.
+	writeList(tampered, trust.SignList(tampered, privateKey))
+
+	urls, err = list.FetchURLs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []storj.NodeURL{url2.NodeURL()}, urls)
+}
+
+func TestSignedSourceRequiresKeys(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	source, err := trust.NewSource("signed+file://" + ctx.File("list.json"))
+	require.NoError(t, err)
+
+	_, err = trust.Sources{source}.WithKeys(nil)
+	require.Error(t, err)
+
+	_, err = trust.NewPool(zaptest.NewLogger(t), nil, trust.Config{
+		Sources:   trust.Sources{source},
+		CachePath: ctx.File("cache.json"),
+	}, nil)
+	require.Error(t, err)
+}
+
+func TestParseListKeys(t *testing.T) {
+	publicKey, _ := generateListKey(t)
+
+	keys, err := trust.ParseListKeys([]string{hex.EncodeToString(publicKey)})
+	require.NoError(t, err)
+	require.Equal(t, []ed25519.PublicKey{publicKey}, keys)
+
+	_, err = trust.ParseListKeys([]string{"not hex"})
+	require.Error(t, err)
+
+	_, err = trust.ParseListKeys([]string{hex.EncodeToString(publicKey[:16])})
+	require.Error(t, err)
+}
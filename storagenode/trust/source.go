@@ -6,6 +6,7 @@ package trust
 import (
 	"context"
 	"regexp"
+	"strings"
 
 	"github.com/zeebo/errs"
 )
@@ -18,6 +19,10 @@ type Entry struct {
 	// Authoritative indicates whether this entry came from an authoritative
 	// source. This impacts how URLS are aggregated.
 	Authoritative bool `json:"authoritative"`
+
+	// SignedBy is the hex encoded public key that validated the signed list
+	// the entry came from. It is empty for entries from unsigned sources.
+	SignedBy string `json:"signedBy,omitempty"`
 }
 
 // Source is a trust source for trusted Satellites.
@@ -42,6 +47,14 @@ func NewSource(config string) (Source, error) {
 			return NewHTTPSource(config)
 		case "storj":
 			return NewStaticURLSource(config)
+		case "signed+http", "signed+https":
+			source, err := NewHTTPSource(strings.TrimPrefix(config, "signed+"))
+			if err != nil {
+				return nil, err
+			}
+			return NewSignedSource(source), nil
+		case "signed+file":
+			return NewSignedSource(NewFileSource(strings.TrimPrefix(config, "signed+file://"))), nil
 		default:
 			return nil, errs.New("unsupported schema %q", schema)
 		}
@@ -54,7 +67,7 @@ func NewSource(config string) (Source, error) {
 	return NewFileSource(config), nil
 }
 
-var reReserved = regexp.MustCompile(`^([a-zA-Z]{2,})://`)
+var reReserved = regexp.MustCompile(`^([a-zA-Z]{2,}(?:\+[a-zA-Z]{2,})?)://`)
 
 // isReserved returns the true if the string is within the reserved namespace
 // for trust sources, i.e. things that look like a URI scheme. Single letter
@@ -34,6 +34,26 @@ func TestNewSource(t *testing.T) {
 			config: "https://domain.test",
 			typ:    new(trust.HTTPSource),
 		},
+		{
+			name:   "signed HTTP source",
+			config: "signed+https://domain.test/list.json",
+			typ:    new(trust.SignedSource),
+		},
+		{
+			name:   "signed HTTP source with bad URL",
+			config: "signed+http:///list.json",
+			err:    `HTTP source: "http:///list.json": host is missing`,
+		},
+		{
+			name:   "signed file source",
+			config: "signed+file:///some/list.json",
+			typ:    new(trust.SignedSource),
+		},
+		{
+			name:   "unrecognized signed schema",
+			config: "signed+ftp://domain.test",
+			err:    `unsupported schema "signed+ftp"`,
+		},
 		{
 			name:   "relative file path",
 			config: "path.txt",
@@ -0,0 +1,103 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package versioncontrol
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/private/version/release"
+)
+
+// HashErr defines the binary hashes config error class.
+var HashErr = errs.Class("binary hashes config")
+
+// processManifestHandle handles the signed release manifest of a process binary.
+func (peer *Peer) processManifestHandle(w http.ResponseWriter, r *http.Request) {
+	if peer.signingKey == nil {
+		http.Error(w, "release manifests are not signed", http.StatusNotFound)
+		return
+	}
+
+	binary, ok := peer.resolveBinary(w, r)
+	if !ok {
+		return
+	}
+
+	hash, ok := binary.Version.Hash(binary.OS, binary.Arch)
+	if !ok {
+		http.Error(w, fmt.Sprintf("binary %s_%s_%s has no hash", binary.Service, binary.OS, binary.Arch), http.StatusNotFound)
+		return
+	}
+
+	signed, err := release.Sign(release.Manifest{
+		Service: binary.Service,
+		Version: binary.Version.Version,
+		OS:      binary.OS,
+		Arch:    binary.Arch,
+		URL:     binary.URL,
+		SHA256:  hash,
+	}, peer.signingKey)
+	if err != nil {
+		peer.Log.Error("Error signing release manifest.", zap.Error(err))
+		http.Error(w, "unable to sign release manifest", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(signed); err != nil {
+		peer.Log.Error("Error writing response to client.", zap.Error(err))
+	}
+}
+
+// Hash returns the hash of the binary archive of the platform.
+func (versionConfig VersionConfig) Hash(os, arch string) (hash string, ok bool) {
+	platform := os + "_" + arch + ":"
+	for _, entry := range versionConfig.Hashes {
+		if strings.HasPrefix(entry, platform) {
+			return entry[len(platform):], true
+		}
+	}
+	return "", false
+}
+
+// ValidateHashes validates the binary hashes of each field in the Versions struct.
+func (versions ProcessesConfig) ValidateHashes() error {
+	value := reflect.ValueOf(versions)
+	validationErrs := errs.Group{}
+	for i := 0; i < value.NumField(); i++ {
+		binary, ok := value.Field(i).Interface().(ProcessConfig)
+		if !ok {
+			continue
+		}
+		for _, versionConfig := range []VersionConfig{binary.Minimum, binary.Suggested} {
+			for _, entry := range versionConfig.Hashes {
+				if err := validateHash(entry); err != nil {
+					validationErrs.Add(HashErr.New("%s: %v", value.Type().Field(i).Name, err))
+				}
+			}
+		}
+	}
+	return validationErrs.Err()
+}
+
+func validateHash(entry string) error {
+	split := strings.SplitN(entry, ":", 2)
+	if len(split) != 2 || strings.Count(split[0], "_") != 1 {
+		return errs.New("invalid hash %q, should be <os>_<arch>:<hash>", entry)
+	}
+	hash, err := hex.DecodeString(split[1])
+	if err != nil || len(hash) != sha256.Size {
+		return errs.New("invalid sha256 hash %q", split[1])
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ package versioncontrol
 
 import (
 	"context"
+	"crypto/ed25519"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -21,6 +22,7 @@ import (
 
 	"storj.io/common/errs2"
 	"storj.io/private/version"
+	"storj.io/storj/private/version/release"
 )
 
 // seedLength is the number of bytes in a rollout seed.
@@ -39,6 +41,8 @@ type Config struct {
 	Versions OldVersionConfig
 
 	Binary ProcessesConfig
+
+	SigningKey string `user:"true" help:"path to the hex-encoded ed25519 key that signs the release manifests; manifests aren't served without it" default:""`
 }
 
 // OldVersionConfig provides a list of allowed Versions per process.
@@ -73,6 +77,9 @@ type ProcessConfig struct {
 type VersionConfig struct {
 	Version string `user:"true" help:"peer version" default:"v0.0.1"`
 	URL     string `user:"true" help:"URL for specific binary" default:""`
+	// Hashes are the hashes of the binary archives of each platform, which are
+	// published in the release manifests.
+	Hashes []string `user:"true" help:"hex-encoded sha256 hashes of the binary archives as <os>_<arch>:<hash>"`
 }
 
 // RolloutConfig represents the state of a version rollout configuration of a process.
@@ -96,6 +103,9 @@ type Peer struct {
 
 	Versions version.AllowedVersions
 
+	binaries   ProcessesConfig
+	signingKey ed25519.PrivateKey
+
 	// response contains the byte version of current allowed versions
 	response []byte
 }
@@ -105,9 +115,20 @@ func New(log *zap.Logger, config *Config) (peer *Peer, err error) {
 	if err := config.Binary.ValidateRollouts(log); err != nil {
 		return nil, RolloutErr.Wrap(err)
 	}
+	if err := config.Binary.ValidateHashes(); err != nil {
+		return nil, err
+	}
 
 	peer = &Peer{
-		Log: log,
+		Log:      log,
+		binaries: config.Binary,
+	}
+
+	if config.SigningKey != "" {
+		peer.signingKey, err = release.LoadPrivateKey(config.SigningKey)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Convert each Service's VersionConfig String to SemVer
@@ -178,6 +199,7 @@ func New(log *zap.Logger, config *Config) (peer *Peer, err error) {
 		router := mux.NewRouter()
 		router.HandleFunc("/", peer.versionHandle).Methods(http.MethodGet)
 		router.HandleFunc("/processes/{service}/{version}/url", peer.processURLHandle).Methods(http.MethodGet)
+		router.HandleFunc("/processes/{service}/{version}/manifest", peer.processManifestHandle).Methods(http.MethodGet)
 
 		peer.Server.Endpoint = http.Server{
 			Handler: router,
@@ -204,38 +226,62 @@ func (peer *Peer) versionHandle(w http.ResponseWriter, r *http.Request) {
 
 // processURLHandle handles process binary url resolving.
 func (peer *Peer) processURLHandle(w http.ResponseWriter, r *http.Request) {
+	binary, ok := peer.resolveBinary(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	_, err := w.Write([]byte(binary.URL))
+	if err != nil {
+		peer.Log.Error("Error writing response to client.", zap.Error(err))
+	}
+}
+
+// binary is a process binary requested by a client.
+type binary struct {
+	Service string
+	OS      string
+	Arch    string
+	Version VersionConfig
+	URL     string
+}
+
+// resolveBinary finds the process binary of the request. When it can't be
+// found, the error is written to the response.
+func (peer *Peer) resolveBinary(w http.ResponseWriter, r *http.Request) (_ binary, ok bool) {
 	params := mux.Vars(r)
 	service := params["service"]
 	versionType := params["version"]
 
-	var process version.Process
+	var process ProcessConfig
 	switch service {
 	case "satellite":
-		process = peer.Versions.Processes.Satellite
+		process = peer.binaries.Satellite
 	case "storagenode":
-		process = peer.Versions.Processes.Storagenode
+		process = peer.binaries.Storagenode
 	case "storagenode-updater":
-		process = peer.Versions.Processes.StoragenodeUpdater
+		process = peer.binaries.StoragenodeUpdater
 	case "uplink":
-		process = peer.Versions.Processes.Uplink
+		process = peer.binaries.Uplink
 	case "gateway":
-		process = peer.Versions.Processes.Gateway
+		process = peer.binaries.Gateway
 	case "identity":
-		process = peer.Versions.Processes.Identity
+		process = peer.binaries.Identity
 	default:
 		http.Error(w, "service does not exists", http.StatusNotFound)
-		return
+		return binary{}, false
 	}
 
-	var url string
+	var versionConfig VersionConfig
 	switch versionType {
 	case "minimum":
-		url = process.Minimum.URL
+		versionConfig = process.Minimum
 	case "suggested":
-		url = process.Suggested.URL
+		versionConfig = process.Suggested
 	default:
 		http.Error(w, "invalid version, should be minimum or suggested", http.StatusBadRequest)
-		return
+		return binary{}, false
 	}
 
 	query := r.URL.Query()
@@ -243,28 +289,30 @@ func (peer *Peer) processURLHandle(w http.ResponseWriter, r *http.Request) {
 	os := query.Get("os")
 	if os == "" {
 		http.Error(w, "goos is not specified", http.StatusBadRequest)
-		return
+		return binary{}, false
 	}
 
 	arch := query.Get("arch")
 	if arch == "" {
 		http.Error(w, "goarch is not specified", http.StatusBadRequest)
-		return
+		return binary{}, false
 	}
 
 	if scheme, ok := isBinarySupported(service, os, arch); !ok {
 		http.Error(w, fmt.Sprintf("binary scheme %s is not supported", scheme), http.StatusNotFound)
-		return
+		return binary{}, false
 	}
 
-	url = strings.Replace(url, "{os}", os, 1)
+	url := strings.Replace(versionConfig.URL, "{os}", os, 1)
 	url = strings.Replace(url, "{arch}", arch, 1)
 
-	w.Header().Set("Content-Type", "text/plain")
-	_, err := w.Write([]byte(url))
-	if err != nil {
-		peer.Log.Error("Error writing response to client.", zap.Error(err))
-	}
+	return binary{
+		Service: service,
+		OS:      os,
+		Arch:    arch,
+		Version: versionConfig,
+		URL:     url,
+	}, true
 }
 
 // Run runs versioncontrol server until it's either closed or it errors.
@@ -312,6 +312,24 @@ func TestVersions_ValidateRollouts(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestVersions_ValidateHashes(t *testing.T) {
+	versions := validRandVersions(t)
+	versions.Storagenode.Suggested.Hashes = []string{"linux_amd64:" + randSeedString(t)}
+	require.NoError(t, versions.ValidateHashes())
+
+	for _, hash := range []string{
+		randSeedString(t),
+		"linux:" + randSeedString(t),
+		"linux_amd64:" + randSeedString(t)[2:],
+		"linux_amd64:not a hash",
+	} {
+		versions.Storagenode.Suggested.Hashes = []string{hash}
+		err := versions.ValidateHashes()
+		require.Error(t, err, hash)
+		require.True(t, versioncontrol.HashErr.Has(err))
+	}
+}
+
 func TestRollout_Validate(t *testing.T) {
 	for i := 0; i < 100; i++ {
 		rollout := versioncontrol.RolloutConfig{
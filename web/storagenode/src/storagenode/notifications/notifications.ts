@@ -47,6 +47,9 @@ export enum NotificationTypes {
     AuditCheckFailure = 1,
     Disqualification = 2,
     Suspension = 3,
+    LowDiskSpace = 4,
+    WritabilityCheckFailure = 5,
+    Offline = 6,
 }
 
 /**